@@ -0,0 +1,341 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/nginxinc/kubernetes-ingress/internal/configs"
+	"github.com/nginxinc/kubernetes-ingress/internal/configs/version1"
+	"github.com/nginxinc/kubernetes-ingress/internal/configs/version2"
+	"github.com/nginxinc/kubernetes-ingress/internal/k8s"
+	cr_validation "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/validation"
+	conf_scheme "github.com/nginxinc/kubernetes-ingress/pkg/client/clientset/versioned/scheme"
+	api_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+const warningsFilename = "warnings.txt"
+
+var (
+	manifests = flag.String("manifests", "",
+		`A comma-separated list of YAML or JSON files, or directories with such files, with the resources to render the configuration for.
+	Supported resources: Ingress, VirtualServer, VirtualServerRoute, TransportServer, GlobalConfiguration, Policy, Secret, Service,
+	Endpoints, EndpointSlice, Pod and ConfigMap. Resources without a namespace are put into the "default" namespace`)
+
+	outputDir = flag.String("output-dir", "",
+		`The directory to write the generated NGINX configuration files and the warnings (warnings.txt) to`)
+
+	templatesDir = flag.String("templates-dir", "internal/configs",
+		`The directory with the version1 and version2 directories of the NGINX configuration templates`)
+
+	nginxPlus = flag.Bool("nginx-plus", false, "Render the configuration for NGINX Plus")
+
+	ingressClass = flag.String("ingress-class", "nginx",
+		`The class of the resources to render the configuration for`)
+
+	nginxConfigMaps = flag.String("nginx-configmaps", "",
+		`A ConfigMap from the manifests with customization of the NGINX configuration. Format: <namespace>/<name>`)
+
+	enableTLSPassthrough = flag.Bool("enable-tls-passthrough", false,
+		"Enable TLS Passthrough on port 443")
+
+	enableSnippets = flag.Bool("enable-snippets", false,
+		"Enable custom NGINX configuration snippets in Ingress, VirtualServer, VirtualServerRoute and TransportServer resources")
+
+	enablePreviewPolicies = flag.Bool("enable-preview-policies", false,
+		"Enable preview policies")
+
+	enableFaultInjection = flag.Bool("enable-fault-injection", false,
+		"Enable the fault action option in VirtualServer and VirtualServerRoute resources")
+)
+
+func main() {
+	flag.Parse()
+
+	err := flag.Lookup("logtostderr").Value.Set("true")
+	if err != nil {
+		glog.Fatalf("Error setting logtostderr to true: %v", err)
+	}
+
+	if *manifests == "" {
+		glog.Fatal("manifests flag is required")
+	}
+	if *outputDir == "" {
+		glog.Fatal("output-dir flag is required")
+	}
+
+	err = conf_scheme.AddToScheme(scheme.Scheme)
+	if err != nil {
+		glog.Fatalf("Failed to add configuration types to the scheme: %v", err)
+	}
+
+	objects, err := readManifests(strings.Split(*manifests, ","))
+	if err != nil {
+		glog.Fatalf("Error reading the manifests: %v", err)
+	}
+
+	warnings, err := render(objects)
+	if err != nil {
+		glog.Fatalf("Error rendering the configuration: %v", err)
+	}
+
+	err = os.WriteFile(filepath.Join(*outputDir, warningsFilename), []byte(strings.Join(append(warnings, ""), "\n")), 0o644)
+	if err != nil {
+		glog.Fatalf("Error writing the warnings: %v", err)
+	}
+
+	for _, w := range warnings {
+		glog.Warning(w)
+	}
+
+	glog.Infof("The configuration was written to %v with %v warning(s)", *outputDir, len(warnings))
+}
+
+func render(objects []runtime.Object) ([]string, error) {
+	nginxConfTemplatePath := "nginx.tmpl"
+	nginxIngressTemplatePath := "nginx.ingress.tmpl"
+	nginxVirtualServerTemplatePath := "nginx.virtualserver.tmpl"
+	nginxTransportServerTemplatePath := "nginx.transportserver.tmpl"
+	if *nginxPlus {
+		nginxConfTemplatePath = "nginx-plus.tmpl"
+		nginxIngressTemplatePath = "nginx-plus.ingress.tmpl"
+		nginxVirtualServerTemplatePath = "nginx-plus.virtualserver.tmpl"
+		nginxTransportServerTemplatePath = "nginx-plus.transportserver.tmpl"
+	}
+
+	templateExecutor, err := version1.NewTemplateExecutor(
+		filepath.Join(*templatesDir, "version1", nginxConfTemplatePath),
+		filepath.Join(*templatesDir, "version1", nginxIngressTemplatePath))
+	if err != nil {
+		return nil, fmt.Errorf("error creating TemplateExecutor: %w", err)
+	}
+
+	templateExecutorV2, err := version2.NewTemplateExecutor(
+		filepath.Join(*templatesDir, "version2", nginxVirtualServerTemplatePath),
+		filepath.Join(*templatesDir, "version2", nginxTransportServerTemplatePath))
+	if err != nil {
+		return nil, fmt.Errorf("error creating TemplateExecutorV2: %w", err)
+	}
+
+	nginxManager := newFileManager(*outputDir)
+
+	cfgParams := configs.NewDefaultConfigParams(*nginxPlus)
+
+	if *nginxConfigMaps != "" {
+		cfm, err := findConfigMap(objects, *nginxConfigMaps)
+		if err != nil {
+			return nil, err
+		}
+
+		cfgParams = configs.ParseConfigMap(cfm, *nginxPlus, false, false)
+		if cfgParams.MainTemplate != nil {
+			err = templateExecutor.UpdateMainTemplate(cfgParams.MainTemplate)
+			if err != nil {
+				return nil, fmt.Errorf("error updating NGINX main template: %w", err)
+			}
+		}
+		if cfgParams.IngressTemplate != nil {
+			err = templateExecutor.UpdateIngressTemplate(cfgParams.IngressTemplate)
+			if err != nil {
+				return nil, fmt.Errorf("error updating ingress template: %w", err)
+			}
+		}
+		if cfgParams.VirtualServerTemplate != nil {
+			err = templateExecutorV2.UpdateVirtualServerTemplate(cfgParams.VirtualServerTemplate)
+			if err != nil {
+				return nil, fmt.Errorf("error updating VirtualServer template: %w", err)
+			}
+		}
+	}
+
+	staticCfgParams := &configs.StaticConfigParams{
+		NginxStatus:           true,
+		NginxStatusAllowCIDRs: []string{"127.0.0.1"},
+		NginxStatusPort:       8080,
+		TLSPassthrough:        *enableTLSPassthrough,
+		EnableSnippets:        *enableSnippets,
+		EnablePreviewPolicies: *enablePreviewPolicies,
+	}
+
+	ngxConfig := configs.GenerateNginxMainConfig(staticCfgParams, cfgParams)
+	content, err := templateExecutor.ExecuteMainConfigTemplate(ngxConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error generating NGINX main config: %w", err)
+	}
+	nginxManager.CreateMainConfig(content)
+
+	if *enableTLSPassthrough {
+		var emptyFile []byte
+		nginxManager.CreateTLSPassthroughHostsConfig(emptyFile)
+	}
+
+	cnf := configs.NewConfigurator(nginxManager, staticCfgParams, cfgParams, templateExecutor, templateExecutorV2, *nginxPlus, false, nil, false, nil, false)
+
+	forbiddenListenerPorts := map[int]bool{
+		80:  true,
+		443: true,
+	}
+
+	renderer := k8s.NewRenderer(k8s.RendererInput{
+		NginxConfigurator:            cnf,
+		IsNginxPlus:                  *nginxPlus,
+		IngressClass:                 *ingressClass,
+		EnablePreviewPolicies:        *enablePreviewPolicies,
+		IsTLSPassthroughEnabled:      *enableTLSPassthrough,
+		SnippetsEnabled:              *enableSnippets,
+		GlobalConfigurationValidator: cr_validation.NewGlobalConfigurationValidator(forbiddenListenerPorts),
+		TransportServerValidator:     cr_validation.NewTransportServerValidator(*enableTLSPassthrough, *enableSnippets, *nginxPlus),
+		VirtualServerValidator:       cr_validation.NewVirtualServerValidator(*nginxPlus, false, *enableFaultInjection),
+	})
+
+	var warnings []string
+
+	for _, obj := range objects {
+		if _, ok := obj.(*api_v1.ConfigMap); ok {
+			continue
+		}
+
+		err := renderer.AddObject(obj)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("Skipping %v: %v", getObjectName(obj), err))
+		}
+	}
+
+	renderWarnings, err := renderer.Render()
+	if err != nil {
+		return nil, err
+	}
+
+	return append(warnings, renderWarnings...), nil
+}
+
+func findConfigMap(objects []runtime.Object, nsName string) (*api_v1.ConfigMap, error) {
+	ns, name, err := k8s.ParseNamespaceName(nsName)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing the nginx-configmaps argument: %w", err)
+	}
+
+	for _, obj := range objects {
+		cfm, ok := obj.(*api_v1.ConfigMap)
+		if ok && cfm.Namespace == ns && cfm.Name == name {
+			return cfm, nil
+		}
+	}
+
+	return nil, fmt.Errorf("ConfigMap %v not found in the manifests", nsName)
+}
+
+// readManifests reads the resources from the files. The files of the directories are read in lexical order.
+func readManifests(paths []string) ([]runtime.Object, error) {
+	var objects []runtime.Object
+
+	for _, p := range paths {
+		files, err := getManifestFiles(p)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, f := range files {
+			objs, err := readManifestFile(f)
+			if err != nil {
+				return nil, fmt.Errorf("error reading %v: %w", f, err)
+			}
+			objects = append(objects, objs...)
+		}
+	}
+
+	return objects, nil
+}
+
+func getManifestFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	var files []string
+	err = filepath.Walk(path, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		switch filepath.Ext(p) {
+		case ".yaml", ".yml", ".json":
+			if !info.IsDir() {
+				files = append(files, p)
+			}
+		}
+		return nil
+	})
+
+	return files, err
+}
+
+func readManifestFile(path string) ([]runtime.Object, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var objects []runtime.Object
+
+	decoder := scheme.Codecs.UniversalDeserializer()
+	reader := yaml.NewYAMLReader(bufio.NewReader(f))
+
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+
+		obj, _, err := decoder.Decode(doc, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		accessor, err := meta.Accessor(obj)
+		if err != nil {
+			return nil, err
+		}
+		if accessor.GetNamespace() == "" {
+			accessor.SetNamespace(api_v1.NamespaceDefault)
+		}
+
+		objects = append(objects, obj)
+	}
+
+	return objects, nil
+}
+
+func getObjectName(obj runtime.Object) string {
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return kind
+	}
+
+	return fmt.Sprintf("%v %v/%v", kind, accessor.GetNamespace(), accessor.GetName())
+}
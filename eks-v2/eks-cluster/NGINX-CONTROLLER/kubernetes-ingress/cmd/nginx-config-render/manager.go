@@ -0,0 +1,57 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/golang/glog"
+	"github.com/nginxinc/kubernetes-ingress/internal/nginx"
+)
+
+// fileManager is a nginx.Manager that writes the NGINX configuration files to a directory
+// with the same layout as /etc/nginx. Other operations, like reloading NGINX, are faked.
+type fileManager struct {
+	*nginx.FakeManager
+	outputDir string
+}
+
+func newFileManager(outputDir string) *fileManager {
+	return &fileManager{
+		FakeManager: nginx.NewFakeManager(outputDir),
+		outputDir:   outputDir,
+	}
+}
+
+// CreateMainConfig writes nginx.conf.
+func (fm *fileManager) CreateMainConfig(content []byte) {
+	fm.writeFile(filepath.Join(fm.outputDir, "nginx.conf"), content)
+}
+
+// CreateConfig writes the config of an Ingress or a VirtualServer to the conf.d directory.
+func (fm *fileManager) CreateConfig(name string, content []byte) {
+	fm.writeFile(filepath.Join(fm.outputDir, "conf.d", name+".conf"), content)
+}
+
+// CreateStreamConfig writes the config of a TransportServer to the stream-conf.d directory.
+func (fm *fileManager) CreateStreamConfig(name string, content []byte) {
+	fm.writeFile(filepath.Join(fm.outputDir, "stream-conf.d", name+".conf"), content)
+}
+
+// CreateTLSPassthroughHostsConfig writes tls-passthrough-hosts.conf.
+func (fm *fileManager) CreateTLSPassthroughHostsConfig(content []byte) {
+	fm.writeFile(filepath.Join(fm.outputDir, "tls-passthrough-hosts.conf"), content)
+}
+
+func (*fileManager) writeFile(name string, content []byte) {
+	glog.V(3).Infof("Writing %v", name)
+
+	err := os.MkdirAll(filepath.Dir(name), 0o755)
+	if err != nil {
+		glog.Fatalf("Failed to create the directory for %v: %v", name, err)
+	}
+
+	err = os.WriteFile(name, content, 0o644)
+	if err != nil {
+		glog.Fatalf("Failed to write %v: %v", name, err)
+	}
+}
@@ -2,6 +2,9 @@ package main
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
 	"flag"
 	"fmt"
 	"net"
@@ -10,6 +13,7 @@ import (
 	"os/signal"
 	"regexp"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -30,6 +34,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	api_v1 "k8s.io/api/core/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/validation"
 	util_version "k8s.io/apimachinery/pkg/util/version"
 	"k8s.io/client-go/dynamic"
@@ -59,7 +64,16 @@ var (
 	The Ingress controller does not start NGINX and does not write any generated NGINX configuration files to disk`)
 
 	watchNamespace = flag.String("watch-namespace", api_v1.NamespaceAll,
-		`Namespace to watch for Ingress resources. By default the Ingress controller watches all namespaces`)
+		`Comma separated list of namespaces to watch for Ingress resources. By default the Ingress controller watches all namespaces`)
+
+	watchNamespaceLabel = flag.String("watch-namespace-label", "",
+		`Configures the Ingress Controller to watch only the namespaces that match the label selector, for example "team=frontend".
+	The Ingress Controller starts or stops watching a namespace when its labels change. Cannot be used together with -watch-namespace`)
+
+	resourceSelector = flag.String("resource-selector", "",
+		`Configures the Ingress Controller to handle only the Ingress, VirtualServer, VirtualServerRoute, TransportServer and Policy resources
+	that match the label selector, for example "shard=a". Several Ingress Controllers of the same class can share the resources this way.
+	The name of the leader election lock gets a suffix derived from the selector, so that every shard reports the status of its resources`)
 
 	nginxConfigMaps = flag.String("nginx-configmaps", "",
 		`A ConfigMap resource for customizing NGINX configuration. If a ConfigMap is set,
@@ -161,6 +175,9 @@ var (
 	enablePreviewPolicies = flag.Bool("enable-preview-policies", false,
 		"Enable preview policies")
 
+	enableFaultInjection = flag.Bool("enable-fault-injection", false,
+		"Enable the fault action option in VirtualServer and VirtualServerRoute resources")
+
 	enableSnippets = flag.Bool("enable-snippets", false,
 		"Enable custom NGINX configuration snippets in Ingress, VirtualServer, VirtualServerRoute and TransportServer resources.")
 
@@ -170,6 +187,14 @@ var (
 	enableTLSPassthrough = flag.Bool("enable-tls-passthrough", false,
 		"Enable TLS Passthrough on port 443. Requires -enable-custom-resources")
 
+	enableGatewayAPI = flag.Bool("enable-gateway-api", false,
+		`Enable support for the Gateway API (v1alpha2) GatewayClass, Gateway and HTTPRoute resources.
+	The Ingress Controller handles the Gateways of the GatewayClasses with the controllerName "nginx.org/gateway-controller".`)
+
+	enableGatewayAPIL4Routes = flag.Bool("enable-gateway-api-l4-routes", false,
+		`Enable support for the Gateway API (v1alpha2) TCPRoute, UDPRoute and TLSRoute resources. Requires -enable-gateway-api.
+	TLSRoutes require -enable-tls-passthrough.`)
+
 	spireAgentAddress = flag.String("spire-agent-address", "",
 		`Specifies the address of the running Spire agent. Requires -nginx-plus and is for use with NGINX Service Mesh only. If the flag is set,
 			but the Ingress Controller is not able to connect with the Spire Agent, the Ingress Controller will fail to start.`)
@@ -184,6 +209,29 @@ var (
 	enableLatencyMetrics = flag.Bool("enable-latency-metrics", false,
 		"Enable collection of latency metrics for upstreams. Requires -enable-prometheus-metrics")
 
+	enableAdmissionWebhook = flag.Bool("enable-admission-webhook", false,
+		`Enable the validating admission webhook server for Ingress, VirtualServer, VirtualServerRoute, TransportServer and Policy resources.
+	The server also serves the conversion webhook for TransportServer and GlobalConfiguration resources.
+	Requires -admission-webhook-tls-secret.`)
+
+	admissionWebhookTLSSecretName = flag.String("admission-webhook-tls-secret", "",
+		`A Secret with a TLS certificate and key for TLS termination of the admission webhook server. Format: <namespace>/<name>.`)
+
+	admissionWebhookListenPort = flag.Int("admission-webhook-listen-port", 8443,
+		"Set the port where the admission webhook server listens. [1024 - 65535]")
+
+	enableEndpointSlices = flag.Bool("enable-endpoint-slices", true,
+		`Use EndpointSlices (discovery.k8s.io/v1) to discover the endpoints of services. Requires Kubernetes 1.21+.
+	Set to false to use the legacy Endpoints resources instead.`)
+
+	nginxReloadInterval = flag.Int("nginx-reload-interval", 0,
+		`The minimum interval in milliseconds between NGINX reloads. The configuration changes that come during the interval are batched and applied by a single reload.
+	Set to 0 to reload NGINX on every change. (default 0)`)
+
+	nginxReloadMaxDelay = flag.Int("nginx-reload-max-delay", 0,
+		`The maximum delay in milliseconds of a batched NGINX reload after the first of the batched configuration changes. Must not be less than nginx-reload-interval.
+	If not set, the value of nginx-reload-interval is used.`)
+
 	startupCheckFn func() error
 )
 
@@ -234,6 +282,36 @@ func main() {
 		glog.Fatalf("Invalid value for ready-status-port: %v", readyStatusPortValidationError)
 	}
 
+	admissionWebhookPortValidationError := validatePort(*admissionWebhookListenPort)
+	if admissionWebhookPortValidationError != nil {
+		glog.Fatalf("Invalid value for admission-webhook-listen-port: %v", admissionWebhookPortValidationError)
+	}
+
+	watchNamespaces, err := parseWatchNamespaces(*watchNamespace)
+	if err != nil {
+		glog.Fatalf("Invalid value for watch-namespace: %v", err)
+	}
+
+	var namespaceSelector labels.Selector
+	if *watchNamespaceLabel != "" {
+		if len(watchNamespaces) > 0 {
+			glog.Fatal("watch-namespace-label and watch-namespace cannot both be set")
+		}
+
+		namespaceSelector, err = labels.Parse(*watchNamespaceLabel)
+		if err != nil {
+			glog.Fatalf("Invalid value for watch-namespace-label: %v", err)
+		}
+	}
+
+	var resourceLabelSelector labels.Selector
+	if *resourceSelector != "" {
+		resourceLabelSelector, err = labels.Parse(*resourceSelector)
+		if err != nil {
+			glog.Fatalf("Invalid value for resource-selector: %v", err)
+		}
+	}
+
 	allowedCIDRs, err := parseNginxStatusAllowCIDRs(*nginxStatusAllowCIDRs)
 	if err != nil {
 		glog.Fatalf(`Invalid value for nginx-status-allow-cidrs: %v`, err)
@@ -243,6 +321,14 @@ func main() {
 		glog.Fatal("enable-tls-passthrough flag requires -enable-custom-resources")
 	}
 
+	if *enableGatewayAPIL4Routes && !*enableGatewayAPI {
+		glog.Fatal("enable-gateway-api-l4-routes flag requires -enable-gateway-api")
+	}
+
+	if *enableAdmissionWebhook && *admissionWebhookTLSSecretName == "" {
+		glog.Fatal("enable-admission-webhook flag requires -admission-webhook-tls-secret")
+	}
+
 	if *appProtect && !*nginxPlus {
 		glog.Fatal("NGINX App Protect support is for NGINX Plus only")
 	}
@@ -280,6 +366,14 @@ func main() {
 		*enableLatencyMetrics = false
 	}
 
+	if *nginxReloadInterval < 0 {
+		glog.Fatalf("Invalid value for nginx-reload-interval: %v, must not be negative", *nginxReloadInterval)
+	}
+
+	if *nginxReloadMaxDelay != 0 && *nginxReloadMaxDelay < *nginxReloadInterval {
+		glog.Fatalf("Invalid value for nginx-reload-max-delay: %v, must not be less than nginx-reload-interval", *nginxReloadMaxDelay)
+	}
+
 	if *ingressLink != "" && *externalService != "" {
 		glog.Fatal("ingresslink and external-service cannot both be set")
 	}
@@ -331,7 +425,7 @@ func main() {
 	}
 
 	var dynClient dynamic.Interface
-	if *appProtectDos || *appProtect || *ingressLink != "" {
+	if *appProtectDos || *appProtect || *ingressLink != "" || *enableGatewayAPI {
 		dynClient, err = dynamic.NewForConfig(config)
 		if err != nil {
 			glog.Fatalf("Failed to create dynamic client: %v.", err)
@@ -498,6 +592,14 @@ func main() {
 		}
 	}
 
+	var admissionWebhookSecret *api_v1.Secret
+	if *enableAdmissionWebhook {
+		admissionWebhookSecret, err = getAndValidateSecret(kubeClient, *admissionWebhookTLSSecretName)
+		if err != nil {
+			glog.Fatalf("Error trying to get the admission webhook TLS secret %v: %v", *admissionWebhookTLSSecretName, err)
+		}
+	}
+
 	globalConfigurationValidator := createGlobalConfigurationValidator()
 
 	if *globalConfiguration != "" {
@@ -641,14 +743,16 @@ func main() {
 	controllerNamespace := os.Getenv("POD_NAMESPACE")
 
 	transportServerValidator := cr_validation.NewTransportServerValidator(*enableTLSPassthrough, *enableSnippets, *nginxPlus)
-	virtualServerValidator := cr_validation.NewVirtualServerValidator(*nginxPlus, *appProtectDos)
+	virtualServerValidator := cr_validation.NewVirtualServerValidator(*nginxPlus, *appProtectDos, *enableFaultInjection)
 
 	lbcInput := k8s.NewLoadBalancerControllerInput{
 		KubeClient:                   kubeClient,
 		ConfClient:                   confClient,
 		DynClient:                    dynClient,
 		ResyncPeriod:                 30 * time.Second,
-		Namespace:                    *watchNamespace,
+		Namespaces:                   watchNamespaces,
+		NamespaceSelector:            namespaceSelector,
+		ResourceSelector:             resourceLabelSelector,
 		NginxConfigurator:            cnf,
 		DefaultServerSecret:          *defaultServerSecret,
 		AppProtectEnabled:            *appProtect,
@@ -660,7 +764,7 @@ func main() {
 		ControllerNamespace:          controllerNamespace,
 		ReportIngressStatus:          *reportIngressStatus,
 		IsLeaderElectionEnabled:      *leaderElectionEnabled,
-		LeaderElectionLockName:       *leaderElectionLockName,
+		LeaderElectionLockName:       getLeaderElectionLockName(*leaderElectionLockName, *resourceSelector),
 		WildcardTLSSecret:            *wildcardTLSSecret,
 		ConfigMaps:                   *nginxConfigMaps,
 		GlobalConfiguration:          *globalConfiguration,
@@ -674,8 +778,15 @@ func main() {
 		InternalRoutesEnabled:        *enableInternalRoutes,
 		IsPrometheusEnabled:          *enablePrometheusMetrics,
 		IsLatencyMetricsEnabled:      *enableLatencyMetrics,
+		LatencyCollector:             latencyCollector,
 		IsTLSPassthroughEnabled:      *enableTLSPassthrough,
 		SnippetsEnabled:              *enableSnippets,
+		GatewayAPIEnabled:            *enableGatewayAPI,
+		GatewayAPIL4RoutesEnabled:    *enableGatewayAPIL4Routes,
+		EndpointSlicesEnabled:        *enableEndpointSlices,
+		ReloadInterval:               time.Duration(*nginxReloadInterval) * time.Millisecond,
+		ReloadMaxDelay:               time.Duration(*nginxReloadMaxDelay) * time.Millisecond,
+		ManagerMetricsCollector:      managerCollector,
 	}
 
 	lbc := k8s.NewLoadBalancerController(lbcInput)
@@ -689,6 +800,10 @@ func main() {
 		}()
 	}
 
+	if *enableAdmissionWebhook {
+		go runAdmissionWebhookServer(lbc, kubeClient, *admissionWebhookListenPort, admissionWebhookSecret)
+	}
+
 	if *appProtect || *appProtectDos {
 		go handleTerminationWithAppProtect(lbc, nginxManager, syslogListener, nginxDone, aPAgentDone, aPPluginDone, aPPDosAgentDone, *appProtect, *appProtectDos)
 	} else {
@@ -781,6 +896,36 @@ func validatePort(port int) error {
 	return nil
 }
 
+// parseWatchNamespaces converts a comma separated list of namespaces into an array of namespaces.
+// An empty list means all namespaces. It returns an error if any of the namespaces is invalid.
+func parseWatchNamespaces(input string) ([]string, error) {
+	if input == api_v1.NamespaceAll {
+		return nil, nil
+	}
+
+	var namespaces []string
+	for _, ns := range strings.Split(input, ",") {
+		trimmedNs := strings.TrimSpace(ns)
+		allErrs := validation.IsDNS1123Label(trimmedNs)
+		if len(allErrs) > 0 {
+			return nil, fmt.Errorf("invalid namespace %q: %v", trimmedNs, allErrs)
+		}
+		namespaces = append(namespaces, trimmedNs)
+	}
+	return namespaces, nil
+}
+
+// getLeaderElectionLockName returns the name of the leader election lock. If a resource selector is set, the name gets
+// the first 8 characters of the hex encoded SHA-256 hash of the selector as a suffix, so that every shard has its own leader.
+func getLeaderElectionLockName(lockName string, resourceSelector string) string {
+	if resourceSelector == "" {
+		return lockName
+	}
+
+	hash := sha256.Sum256([]byte(resourceSelector))
+	return fmt.Sprintf("%s-%s", lockName, hex.EncodeToString(hash[:4]))
+}
+
 // parseNginxStatusAllowCIDRs converts a comma separated CIDR/IP address string into an array of CIDR/IP addresses.
 // It returns an array of the valid CIDR/IP addresses or an error if given an invalid address.
 func parseNginxStatusAllowCIDRs(input string) (cidrs []string, err error) {
@@ -882,6 +1027,114 @@ func handleTerminationWithAppProtect(lbc *k8s.LoadBalancerController, nginxManag
 	os.Exit(0)
 }
 
+// admissionWebhookCertRefreshInterval is the interval of fetching the admission webhook TLS secret,
+// so that the webhook server picks up a rotated certificate without a restart.
+const admissionWebhookCertRefreshInterval = time.Minute
+
+// admissionWebhookCertificate holds the latest valid certificate of the admission webhook TLS secret.
+type admissionWebhookCertificate struct {
+	kubeClient   *kubernetes.Clientset
+	secretNsName string
+
+	mu              sync.RWMutex
+	cert            *tls.Certificate
+	resourceVersion string
+}
+
+func newAdmissionWebhookCertificate(kubeClient *kubernetes.Clientset, secretNsName string, secret *api_v1.Secret) (*admissionWebhookCertificate, error) {
+	c := &admissionWebhookCertificate{
+		kubeClient:   kubeClient,
+		secretNsName: secretNsName,
+	}
+
+	err := c.update(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (c *admissionWebhookCertificate) update(secret *api_v1.Secret) error {
+	cert, err := tls.X509KeyPair(secret.Data[api_v1.TLSCertKey], secret.Data[api_v1.TLSPrivateKeyKey])
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.cert = &cert
+	c.resourceVersion = secret.ResourceVersion
+
+	return nil
+}
+
+// refresh fetches the secret and updates the certificate if the secret has changed.
+// If the secret can't be fetched or is invalid, the previous certificate is kept.
+func (c *admissionWebhookCertificate) refresh() {
+	secret, err := getAndValidateSecret(c.kubeClient, c.secretNsName)
+	if err != nil {
+		glog.Errorf("Error trying to get the admission webhook TLS secret %v: %v", c.secretNsName, err)
+		return
+	}
+
+	c.mu.RLock()
+	unchanged := secret.ResourceVersion == c.resourceVersion
+	c.mu.RUnlock()
+
+	if unchanged {
+		return
+	}
+
+	err = c.update(secret)
+	if err != nil {
+		glog.Errorf("Error loading the admission webhook TLS certificate and key from %v: %v", c.secretNsName, err)
+		return
+	}
+
+	glog.V(3).Infof("Updated the admission webhook TLS certificate from %v", c.secretNsName)
+}
+
+func (c *admissionWebhookCertificate) run() {
+	for range time.Tick(admissionWebhookCertRefreshInterval) {
+		c.refresh()
+	}
+}
+
+// GetCertificate returns the latest valid certificate for the TLS handshakes of the admission webhook server.
+func (c *admissionWebhookCertificate) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.cert, nil
+}
+
+func runAdmissionWebhookServer(lbc *k8s.LoadBalancerController, kubeClient *kubernetes.Clientset, port int, secret *api_v1.Secret) {
+	cert, err := newAdmissionWebhookCertificate(kubeClient, *admissionWebhookTLSSecretName, secret)
+	if err != nil {
+		glog.Fatalf("Error loading the admission webhook TLS certificate and key: %v", err)
+	}
+
+	go cert.run()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/validate", lbc.ServeAdmissionReview)
+	mux.HandleFunc("/convert", k8s.ServeConversionReview)
+
+	s := &http.Server{
+		Addr:              fmt.Sprintf(":%v", port),
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		TLSConfig: &tls.Config{
+			GetCertificate: cert.GetCertificate,
+			MinVersion:     tls.VersionTLS12,
+		},
+	}
+
+	glog.Fatal("Error in admission webhook server: ", s.ListenAndServeTLS("", ""))
+}
+
 func ready(lbc *k8s.LoadBalancerController) http.HandlerFunc {
 	return func(w http.ResponseWriter, _ *http.Request) {
 		if !lbc.IsNginxReady() {
@@ -24,6 +24,74 @@ func TestValidatePort(t *testing.T) {
 	}
 }
 
+func TestParseWatchNamespaces(t *testing.T) {
+	goodInputs := []struct {
+		input    string
+		expected []string
+	}{
+		{
+			"",
+			nil,
+		},
+		{
+			"default",
+			[]string{"default"},
+		},
+		{
+			"ns-1, ns-2",
+			[]string{"ns-1", "ns-2"},
+		},
+	}
+	for _, test := range goodInputs {
+		result, err := parseWatchNamespaces(test.input)
+		if err != nil {
+			t.Errorf("parseWatchNamespaces(%q) returned an error when it should have returned no error: %q", test.input, err)
+		}
+
+		if !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("parseWatchNamespaces(%q) returned %v expected %v", test.input, result, test.expected)
+		}
+	}
+
+	badInputs := []string{
+		"ns-1,,ns-2",
+		"Default",
+		"ns/name",
+	}
+	for _, input := range badInputs {
+		_, err := parseWatchNamespaces(input)
+		if err == nil {
+			t.Errorf("parseWatchNamespaces(%q) returned no error when it should have returned an error", input)
+		}
+	}
+}
+
+func TestGetLeaderElectionLockName(t *testing.T) {
+	tests := []struct {
+		lockName         string
+		resourceSelector string
+		expected         string
+	}{
+		{
+			lockName:         "nginx-ingress-leader-election",
+			resourceSelector: "",
+			expected:         "nginx-ingress-leader-election",
+		},
+		{
+			lockName:         "nginx-ingress-leader-election",
+			resourceSelector: "shard=a",
+			expected:         "nginx-ingress-leader-election-3d8dfb26",
+		},
+	}
+
+	for _, test := range tests {
+		result := getLeaderElectionLockName(test.lockName, test.resourceSelector)
+		if result != test.expected {
+			t.Errorf("getLeaderElectionLockName(%q, %q) returned %q but expected %q", test.lockName, test.resourceSelector, result, test.expected)
+		}
+	}
+}
+
 func TestParseNginxStatusAllowCIDRs(t *testing.T) {
 	badCIDRs := []struct {
 		input         string
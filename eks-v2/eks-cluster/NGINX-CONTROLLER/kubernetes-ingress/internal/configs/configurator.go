@@ -6,9 +6,13 @@ import (
 	"crypto/x509"
 	"encoding/json"
 	"encoding/pem"
+	"errors"
 	"fmt"
 	"os"
+	"reflect"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/nginxinc/kubernetes-ingress/pkg/apis/dos/v1beta1"
 
@@ -17,12 +21,12 @@ import (
 	"github.com/spiffe/go-spiffe/workload"
 
 	"github.com/nginxinc/kubernetes-ingress/internal/configs/version2"
-	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
 
 	"github.com/golang/glog"
 	api_v1 "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/nginxinc/kubernetes-ingress/internal/configs/version1"
 	"github.com/nginxinc/kubernetes-ingress/internal/nginx"
@@ -30,12 +34,13 @@ import (
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
-	latCollector "github.com/nginxinc/kubernetes-ingress/internal/metrics/collectors"
+	"github.com/nginxinc/kubernetes-ingress/internal/metrics/collectors"
 )
 
 const (
 	pemFileNameForWildcardTLSSecret = "/etc/nginx/secrets/wildcard" // #nosec G101
 	appProtectPolicyFolder          = "/etc/nginx/waf/nac-policies/"
+	policyCacheZonesConfigName      = "policy_cache_zones"
 	appProtectLogConfFolder         = "/etc/nginx/waf/nac-logconfs/"
 	appProtectUserSigFolder         = "/etc/nginx/waf/nac-usersigs/"
 	appProtectUserSigIndex          = "/etc/nginx/waf/nac-usersigs/index.conf"
@@ -55,6 +60,9 @@ const WildcardSecretName = "wildcard"
 // JWTKeyKey is the key of the data field of a Secret where the JWK must be stored.
 const JWTKeyKey = "jwk"
 
+// HtpasswdFileKey is the key of the data field of a Secret where the htpasswd file must be stored.
+const HtpasswdFileKey = "htpasswd"
+
 // CAKey is the key of the data field of a Secret where the cert must be stored.
 const CAKey = "ca.crt"
 
@@ -78,9 +86,34 @@ type ExtendedResources struct {
 	TransportServerExes []*TransportServerEx
 }
 
+// InvalidResourcesError is returned when the configuration of some resources failed the NGINX configuration test
+// during a reload. The new configuration of those resources was excluded: their last valid configuration is kept,
+// or, if they don't have one, they are removed from the NGINX configuration. NGINX was reloaded
+// with the configuration of the other resources.
+type InvalidResourcesError struct {
+	// Errors maps the excluded resources to the errors that NGINX reported for their configuration.
+	Errors map[runtime.Object][]string
+}
+
+func (e *InvalidResourcesError) Error() string {
+	return fmt.Sprintf("the configuration of %d resource(s) was excluded because it failed the NGINX configuration test", len(e.Errors))
+}
+
+// ErrorForResource returns the error for a resource if the resource was excluded, otherwise nil.
+func (e *InvalidResourcesError) ErrorForResource(obj runtime.Object) error {
+	errs, exists := e.Errors[obj]
+	if !exists {
+		return nil
+	}
+
+	return fmt.Errorf("the new configuration failed the NGINX configuration test and was excluded: %v", strings.Join(errs, "; "))
+}
+
 type tlsPassthroughPair struct {
-	Host       string
-	UnixSocket string
+	Host         string
+	UnixSocket   string
+	Listener     string
+	ListenerPort int
 }
 
 // metricLabelsIndex keeps the relations between Ingress Controller resources and NGINX configuration.
@@ -111,21 +144,25 @@ type Configurator struct {
 	ingresses               map[string]*IngressEx
 	minions                 map[string]map[string]bool
 	virtualServers          map[string]*VirtualServerEx
+	transportServers        map[string]*TransportServerEx
 	tlsPassthroughPairs     map[string]tlsPassthroughPair
+	tlsPassthroughListeners map[string]bool
+	policyCacheZones        map[string][]version2.CacheZone
 	isWildcardEnabled       bool
 	isPlus                  bool
 	labelUpdater            collector.LabelUpdater
 	metricLabelsIndex       *metricLabelsIndex
 	isPrometheusEnabled     bool
-	latencyCollector        latCollector.LatencyCollector
+	latencyCollector        collectors.LatencyCollector
 	isLatencyMetricsEnabled bool
 	isReloadsEnabled        bool
+	reloadScheduler         *reloadScheduler
 }
 
 // NewConfigurator creates a new Configurator.
 func NewConfigurator(nginxManager nginx.Manager, staticCfgParams *StaticConfigParams, config *ConfigParams,
 	templateExecutor *version1.TemplateExecutor, templateExecutorV2 *version2.TemplateExecutor, isPlus bool, isWildcardEnabled bool,
-	labelUpdater collector.LabelUpdater, isPrometheusEnabled bool, latencyCollector latCollector.LatencyCollector, isLatencyMetricsEnabled bool) *Configurator {
+	labelUpdater collector.LabelUpdater, isPrometheusEnabled bool, latencyCollector collectors.LatencyCollector, isLatencyMetricsEnabled bool) *Configurator {
 	metricLabelsIndex := &metricLabelsIndex{
 		ingressUpstreams:             make(map[string][]string),
 		virtualServerUpstreams:       make(map[string][]string),
@@ -144,10 +181,13 @@ func NewConfigurator(nginxManager nginx.Manager, staticCfgParams *StaticConfigPa
 		cfgParams:               config,
 		ingresses:               make(map[string]*IngressEx),
 		virtualServers:          make(map[string]*VirtualServerEx),
+		transportServers:        make(map[string]*TransportServerEx),
 		templateExecutor:        templateExecutor,
 		templateExecutorV2:      templateExecutorV2,
 		minions:                 make(map[string]map[string]bool),
 		tlsPassthroughPairs:     make(map[string]tlsPassthroughPair),
+		tlsPassthroughListeners: make(map[string]bool),
+		policyCacheZones:        make(map[string][]version2.CacheZone),
 		isPlus:                  isPlus,
 		isWildcardEnabled:       isWildcardEnabled,
 		labelUpdater:            labelUpdater,
@@ -468,6 +508,11 @@ func (cnf *Configurator) addOrUpdateVirtualServer(virtualServerEx *VirtualServer
 	}
 	cnf.nginxManager.CreateConfig(name, content)
 
+	err = cnf.updatePolicyCacheZones(name, vsCfg.PolicyCacheZones)
+	if err != nil {
+		return warnings, fmt.Errorf("Error updating cache zones for VirtualServer config: %v: %w", name, err)
+	}
+
 	cnf.virtualServers[name] = virtualServerEx
 
 	if (cnf.isPlus && cnf.isPrometheusEnabled) || cnf.isLatencyMetricsEnabled {
@@ -476,6 +521,44 @@ func (cnf *Configurator) addOrUpdateVirtualServer(virtualServerEx *VirtualServer
 	return warnings, nil
 }
 
+// updatePolicyCacheZones updates the cache zones of the cache policies of a VirtualServer. The zones of all VirtualServers
+// are configured in one file, so that the VirtualServers that reference the same policy share its zone.
+func (cnf *Configurator) updatePolicyCacheZones(name string, zones []version2.CacheZone) error {
+	if reflect.DeepEqual(cnf.policyCacheZones[name], zones) {
+		return nil
+	}
+
+	if len(zones) > 0 {
+		cnf.policyCacheZones[name] = zones
+	} else {
+		delete(cnf.policyCacheZones, name)
+	}
+
+	if len(cnf.policyCacheZones) == 0 {
+		cnf.nginxManager.DeleteConfig(policyCacheZonesConfigName)
+		return nil
+	}
+
+	var allZones []version2.CacheZone
+	for _, z := range cnf.policyCacheZones {
+		allZones = append(allZones, z...)
+	}
+
+	allZones = removeDuplicateCacheZones(allZones)
+	sort.Slice(allZones, func(i, j int) bool {
+		return allZones[i].Name < allZones[j].Name
+	})
+
+	content, err := cnf.templateExecutorV2.ExecutePolicyCacheZonesTemplate(allZones)
+	if err != nil {
+		return fmt.Errorf("Error generating config for the cache zones of the cache policies: %w", err)
+	}
+
+	cnf.nginxManager.CreateConfig(policyCacheZonesConfigName, content)
+
+	return nil
+}
+
 // AddOrUpdateVirtualServers adds or updates NGINX configuration for multiple VirtualServer resources.
 func (cnf *Configurator) AddOrUpdateVirtualServers(virtualServerExes []*VirtualServerEx) (Warnings, error) {
 	allWarnings := newWarnings()
@@ -564,27 +647,27 @@ func (cnf *Configurator) deleteTransportServerMetricsLabels(key string) {
 
 // AddOrUpdateTransportServer adds or updates NGINX configuration for the TransportServer resource.
 // It is a responsibility of the caller to check that the TransportServer references an existing listener.
-func (cnf *Configurator) AddOrUpdateTransportServer(transportServerEx *TransportServerEx) error {
-	err := cnf.addOrUpdateTransportServer(transportServerEx)
+func (cnf *Configurator) AddOrUpdateTransportServer(transportServerEx *TransportServerEx) (Warnings, error) {
+	warnings, err := cnf.addOrUpdateTransportServer(transportServerEx)
 	if err != nil {
-		return fmt.Errorf("Error adding or updating TransportServer %v/%v: %w", transportServerEx.TransportServer.Namespace, transportServerEx.TransportServer.Name, err)
+		return warnings, fmt.Errorf("Error adding or updating TransportServer %v/%v: %w", transportServerEx.TransportServer.Namespace, transportServerEx.TransportServer.Name, err)
 	}
 
 	if err := cnf.reload(nginx.ReloadForOtherUpdate); err != nil {
-		return fmt.Errorf("Error reloading NGINX for TransportServer %v/%v: %w", transportServerEx.TransportServer.Namespace, transportServerEx.TransportServer.Name, err)
+		return warnings, fmt.Errorf("Error reloading NGINX for TransportServer %v/%v: %w", transportServerEx.TransportServer.Namespace, transportServerEx.TransportServer.Name, err)
 	}
 
-	return nil
+	return warnings, nil
 }
 
-func (cnf *Configurator) addOrUpdateTransportServer(transportServerEx *TransportServerEx) error {
+func (cnf *Configurator) addOrUpdateTransportServer(transportServerEx *TransportServerEx) (Warnings, error) {
 	name := getFileNameForTransportServer(transportServerEx.TransportServer)
 
-	tsCfg := generateTransportServerConfig(transportServerEx, transportServerEx.ListenerPort, cnf.isPlus)
+	tsCfg, warnings := generateTransportServerConfig(transportServerEx, transportServerEx.ListenerPort, cnf.isPlus)
 
 	content, err := cnf.templateExecutorV2.ExecuteTransportServerTemplate(tsCfg)
 	if err != nil {
-		return fmt.Errorf("Error generating TransportServer config %v: %w", name, err)
+		return warnings, fmt.Errorf("Error generating TransportServer config %v: %w", name, err)
 	}
 
 	if cnf.isPlus && cnf.isPrometheusEnabled {
@@ -593,19 +676,23 @@ func (cnf *Configurator) addOrUpdateTransportServer(transportServerEx *Transport
 
 	cnf.nginxManager.CreateStreamConfig(name, content)
 
+	cnf.transportServers[name] = transportServerEx
+
 	// update TLS Passthrough Hosts config in case we have a TLS Passthrough TransportServer
 	// only TLS Passthrough TransportServers have non-empty hosts
 	if transportServerEx.TransportServer.Spec.Host != "" {
 		key := generateNamespaceNameKey(&transportServerEx.TransportServer.ObjectMeta)
 		cnf.tlsPassthroughPairs[key] = tlsPassthroughPair{
-			Host:       transportServerEx.TransportServer.Spec.Host,
-			UnixSocket: generateUnixSocket(transportServerEx),
+			Host:         transportServerEx.TransportServer.Spec.Host,
+			UnixSocket:   generateUnixSocket(transportServerEx),
+			Listener:     transportServerEx.TransportServer.Spec.Listener.Name,
+			ListenerPort: transportServerEx.ListenerPort,
 		}
 
-		return cnf.updateTLSPassthroughHostsConfig()
+		return warnings, cnf.updateTLSPassthroughHostsConfig()
 	}
 
-	return nil
+	return warnings, nil
 }
 
 // GetVirtualServerRoutesForVirtualServer returns the virtualServerRoutes that a virtualServer
@@ -628,19 +715,80 @@ func (cnf *Configurator) updateTLSPassthroughHostsConfig() error {
 
 	cnf.nginxManager.CreateTLSPassthroughHostsConfig(content)
 
+	return cnf.updateTLSPassthroughListenersConfig()
+}
+
+// updateTLSPassthroughListenersConfig updates the configuration of the custom TLS Passthrough listeners.
+// The configuration of a listener exists only while at least one TransportServer references the listener.
+func (cnf *Configurator) updateTLSPassthroughListenersConfig() error {
+	cfgs := generateTLSPassthroughListenerConfigs(cnf.tlsPassthroughPairs, cnf.cfgParams)
+
+	for name := range cnf.tlsPassthroughListeners {
+		if _, exists := cfgs[name]; !exists {
+			cnf.nginxManager.DeleteStreamConfig(getFileNameForTLSPassthroughListener(name))
+			delete(cnf.tlsPassthroughListeners, name)
+		}
+	}
+
+	for name, cfg := range cfgs {
+		content, err := cnf.templateExecutorV2.ExecuteTLSPassthroughListenerTemplate(cfg)
+		if err != nil {
+			return fmt.Errorf("Error generating config for TLS Passthrough listener %v: %w", name, err)
+		}
+
+		cnf.nginxManager.CreateStreamConfig(getFileNameForTLSPassthroughListener(name), content)
+		cnf.tlsPassthroughListeners[name] = true
+	}
+
 	return nil
 }
 
+// generateTLSPassthroughHostsConfig generates the mapping between the hosts and the unix sockets for the built-in
+// TLS Passthrough listener.
 func generateTLSPassthroughHostsConfig(tlsPassthroughPairs map[string]tlsPassthroughPair) *version2.TLSPassthroughHostsConfig {
 	cfg := version2.TLSPassthroughHostsConfig{}
 
 	for _, pair := range tlsPassthroughPairs {
+		if pair.Listener != conf_v1.TLSPassthroughListenerName {
+			continue
+		}
+
 		cfg[pair.Host] = pair.UnixSocket
 	}
 
 	return &cfg
 }
 
+// generateTLSPassthroughListenerConfigs generates the configuration of the custom TLS Passthrough listeners.
+// A connection with a host that doesn't belong to any TransportServer of a listener is closed.
+func generateTLSPassthroughListenerConfigs(tlsPassthroughPairs map[string]tlsPassthroughPair, cfgParams *ConfigParams) map[string]*version2.TLSPassthroughListenerConfig {
+	cfgs := make(map[string]*version2.TLSPassthroughListenerConfig)
+
+	for _, pair := range tlsPassthroughPairs {
+		if pair.Listener == conf_v1.TLSPassthroughListenerName {
+			continue
+		}
+
+		cfg, exists := cfgs[pair.Listener]
+		if !exists {
+			cfg = &version2.TLSPassthroughListenerConfig{
+				Name:              pair.Listener,
+				Port:              pair.ListenerPort,
+				Variable:          fmt.Sprintf("$dest_passthrough_%s", strings.ReplaceAll(pair.Listener, "-", "_")),
+				DefaultUnixSocket: nginxNonExistingUnixSocket,
+				Hosts:             version2.TLSPassthroughHostsConfig{},
+				ProxyProtocol:     cfgParams.ProxyProtocol,
+				SetRealIPFrom:     cfgParams.SetRealIPFrom,
+			}
+			cfgs[pair.Listener] = cfg
+		}
+
+		cfg.Hosts[pair.Host] = pair.UnixSocket
+	}
+
+	return cfgs
+}
+
 func (cnf *Configurator) addOrUpdateCASecret(secret *api_v1.Secret) string {
 	name := objectMetaToFileName(&secret.ObjectMeta)
 	data := GenerateCAFileContent(secret)
@@ -653,6 +801,12 @@ func (cnf *Configurator) addOrUpdateJWKSecret(secret *api_v1.Secret) string {
 	return cnf.nginxManager.CreateSecret(name, data, nginx.JWKSecretFileMode)
 }
 
+func (cnf *Configurator) addOrUpdateHtpasswdSecret(secret *api_v1.Secret) string {
+	name := objectMetaToFileName(&secret.ObjectMeta)
+	data := secret.Data[HtpasswdFileKey]
+	return cnf.nginxManager.CreateSecret(name, data, nginx.HtpasswdSecretFileMode)
+}
+
 // AddOrUpdateResources adds or updates configuration for resources.
 func (cnf *Configurator) AddOrUpdateResources(resources ExtendedResources) (Warnings, error) {
 	allWarnings := newWarnings()
@@ -682,10 +836,11 @@ func (cnf *Configurator) AddOrUpdateResources(resources ExtendedResources) (Warn
 	}
 
 	for _, tsEx := range resources.TransportServerExes {
-		err := cnf.addOrUpdateTransportServer(tsEx)
+		warnings, err := cnf.addOrUpdateTransportServer(tsEx)
 		if err != nil {
 			return allWarnings, fmt.Errorf("Error adding or updating TransportServer %v/%v: %w", tsEx.TransportServer.Namespace, tsEx.TransportServer.Name, err)
 		}
+		allWarnings.Add(warnings)
 	}
 
 	if err := cnf.reload(nginx.ReloadForOtherUpdate); err != nil {
@@ -738,6 +893,16 @@ func GenerateCAFileContent(secret *api_v1.Secret) []byte {
 
 // DeleteIngress deletes NGINX configuration for the Ingress resource.
 func (cnf *Configurator) DeleteIngress(key string) error {
+	cnf.deleteIngress(key)
+
+	if err := cnf.reload(nginx.ReloadForOtherUpdate); err != nil {
+		return fmt.Errorf("Error when removing ingress %v: %w", key, err)
+	}
+
+	return nil
+}
+
+func (cnf *Configurator) deleteIngress(key string) {
 	name := keyToFileName(key)
 	cnf.nginxManager.DeleteConfig(name)
 
@@ -747,29 +912,32 @@ func (cnf *Configurator) DeleteIngress(key string) error {
 	if (cnf.isPlus && cnf.isPrometheusEnabled) || cnf.isLatencyMetricsEnabled {
 		cnf.deleteIngressMetricsLabels(key)
 	}
+}
+
+// DeleteVirtualServer deletes NGINX configuration for the VirtualServer resource.
+func (cnf *Configurator) DeleteVirtualServer(key string) error {
+	cnf.deleteVirtualServer(key)
 
 	if err := cnf.reload(nginx.ReloadForOtherUpdate); err != nil {
-		return fmt.Errorf("Error when removing ingress %v: %w", key, err)
+		return fmt.Errorf("Error when removing VirtualServer %v: %w", key, err)
 	}
 
 	return nil
 }
 
-// DeleteVirtualServer deletes NGINX configuration for the VirtualServer resource.
-func (cnf *Configurator) DeleteVirtualServer(key string) error {
+func (cnf *Configurator) deleteVirtualServer(key string) {
 	name := getFileNameForVirtualServerFromKey(key)
 	cnf.nginxManager.DeleteConfig(name)
 
+	err := cnf.updatePolicyCacheZones(name, nil)
+	if err != nil {
+		glog.Errorf("Error when removing the cache zones of VirtualServer %v: %v", key, err)
+	}
+
 	delete(cnf.virtualServers, name)
 	if (cnf.isPlus && cnf.isPrometheusEnabled) || cnf.isLatencyMetricsEnabled {
 		cnf.deleteVirtualServerMetricsLabels(key)
 	}
-
-	if err := cnf.reload(nginx.ReloadForOtherUpdate); err != nil {
-		return fmt.Errorf("Error when removing VirtualServer %v: %w", key, err)
-	}
-
-	return nil
 }
 
 // DeleteTransportServer deletes NGINX configuration for the TransportServer resource.
@@ -795,6 +963,8 @@ func (cnf *Configurator) deleteTransportServer(key string) error {
 	name := getFileNameForTransportServerFromKey(key)
 	cnf.nginxManager.DeleteStreamConfig(name)
 
+	delete(cnf.transportServers, name)
+
 	// update TLS Passthrough Hosts config in case we have a TLS Passthrough TransportServer
 	if _, exists := cnf.tlsPassthroughPairs[key]; exists {
 		delete(cnf.tlsPassthroughPairs, key)
@@ -924,7 +1094,7 @@ func (cnf *Configurator) UpdateEndpointsForTransportServers(transportServerExes
 	reloadPlus := false
 
 	for _, tsEx := range transportServerExes {
-		err := cnf.addOrUpdateTransportServer(tsEx)
+		_, err := cnf.addOrUpdateTransportServer(tsEx)
 		if err != nil {
 			return fmt.Errorf("Error adding or updating TransportServer %v/%v: %w", tsEx.TransportServer.Namespace, tsEx.TransportServer.Name, err)
 		}
@@ -1024,12 +1194,130 @@ func (cnf *Configurator) EnableReloads() {
 	cnf.isReloadsEnabled = true
 }
 
+// EnableReloadBatching enables batching of the reloads: NGINX is reloaded at most once per interval, and
+// the configuration changes that come during the interval are applied by a single reload, which happens no later
+// than maxDelay after the first of the changes. For such a pending reload, onReloadDue is called (from another goroutine)
+// when the reload must be performed via ReloadPending.
+func (cnf *Configurator) EnableReloadBatching(interval time.Duration, maxDelay time.Duration, collector collectors.ManagerCollector, onReloadDue func()) {
+	cnf.reloadScheduler = newReloadScheduler(interval, maxDelay, collector, onReloadDue)
+}
+
+// IsReloadPending returns true if the configuration changes are batched into a pending reload that is not yet performed.
+func (cnf *Configurator) IsReloadPending() bool {
+	return cnf.reloadScheduler != nil && cnf.reloadScheduler.pending
+}
+
+// ReloadPending performs the pending reload, if any.
+func (cnf *Configurator) ReloadPending() error {
+	if !cnf.IsReloadPending() {
+		return nil
+	}
+
+	glog.V(3).Infof("Performing the pending NGINX reload")
+
+	return cnf.reloadNow(cnf.reloadScheduler.isEndpointsUpdate)
+}
+
+// reload reloads NGINX. If reload batching is enabled, the reload can become pending, in which case no error is returned.
 func (cnf *Configurator) reload(isEndpointsUpdate bool) error {
 	if !cnf.isReloadsEnabled {
 		return nil
 	}
 
-	return cnf.nginxManager.Reload(isEndpointsUpdate)
+	if cnf.reloadScheduler != nil && !cnf.reloadScheduler.schedule(isEndpointsUpdate) {
+		return nil
+	}
+
+	return cnf.reloadNow(isEndpointsUpdate)
+}
+
+// reloadNow reloads NGINX. If the new configuration fails the NGINX configuration test because of the configuration
+// of some resources, the new configuration of those resources is excluded and NGINX is reloaded without it,
+// so that a resource with a broken config (for example, because of a snippet) doesn't block the updates of
+// the other resources. In that case, an *InvalidResourcesError is returned.
+func (cnf *Configurator) reloadNow(isEndpointsUpdate bool) error {
+	if cnf.reloadScheduler != nil {
+		defer cnf.reloadScheduler.reloaded()
+	}
+
+	invalidResourcesErr := &InvalidResourcesError{
+		Errors: make(map[runtime.Object][]string),
+	}
+
+	err := cnf.nginxManager.Reload(isEndpointsUpdate)
+
+	var testErr *nginx.ConfigTestError
+	for errors.As(err, &testErr) {
+		if !cnf.excludeInvalidResources(testErr, invalidResourcesErr) {
+			cnf.nginxManager.DiscardStagedConfig()
+			return err
+		}
+
+		err = cnf.nginxManager.Reload(isEndpointsUpdate)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if len(invalidResourcesErr.Errors) > 0 {
+		return invalidResourcesErr
+	}
+
+	return nil
+}
+
+// excludeInvalidResources excludes the new configuration of the resources whose configs failed the NGINX configuration test.
+// The last valid config of such a resource is kept. If the resource doesn't have one, the resource is removed.
+// It returns false if none of the failed configs belongs to a resource.
+func (cnf *Configurator) excludeInvalidResources(testErr *nginx.ConfigTestError, invalidResourcesErr *InvalidResourcesError) bool {
+	excluded := false
+
+	for name, errs := range testErr.ConfigErrors {
+		if ingEx, exists := cnf.ingresses[name]; exists {
+			glog.Warningf("Excluding the new configuration of Ingress %v/%v: %v", ingEx.Ingress.Namespace, ingEx.Ingress.Name, errs)
+
+			if !cnf.nginxManager.RestoreConfig(name) {
+				key := generateNamespaceNameKey(&ingEx.Ingress.ObjectMeta)
+				cnf.deleteIngress(key)
+			}
+
+			invalidResourcesErr.Errors[ingEx.Ingress] = errs
+			excluded = true
+		} else if vsEx, exists := cnf.virtualServers[name]; exists {
+			glog.Warningf("Excluding the new configuration of VirtualServer %v/%v: %v", vsEx.VirtualServer.Namespace, vsEx.VirtualServer.Name, errs)
+
+			if !cnf.nginxManager.RestoreConfig(name) {
+				key := generateNamespaceNameKey(&vsEx.VirtualServer.ObjectMeta)
+				cnf.deleteVirtualServer(key)
+			}
+
+			invalidResourcesErr.Errors[vsEx.VirtualServer] = errs
+			excluded = true
+		}
+	}
+
+	for name, errs := range testErr.StreamConfigErrors {
+		if tsEx, exists := cnf.transportServers[name]; exists {
+			glog.Warningf("Excluding the new configuration of TransportServer %v/%v: %v", tsEx.TransportServer.Namespace, tsEx.TransportServer.Name, errs)
+
+			if !cnf.nginxManager.RestoreStreamConfig(name) {
+				key := generateNamespaceNameKey(&tsEx.TransportServer.ObjectMeta)
+				if cnf.isPlus && cnf.isPrometheusEnabled {
+					cnf.deleteTransportServerMetricsLabels(key)
+				}
+				err := cnf.deleteTransportServer(key)
+				if err != nil {
+					glog.Errorf("Error when excluding the configuration of TransportServer %v: %v", key, err)
+				}
+			}
+
+			invalidResourcesErr.Errors[tsEx.TransportServer] = errs
+			excluded = true
+		}
+	}
+
+	return excluded
 }
 
 func (cnf *Configurator) updateServersInPlus(upstream string, servers []string, config nginx.ServerConfig) error {
@@ -1112,6 +1400,11 @@ func (cnf *Configurator) UpdateConfig(cfgParams *ConfigParams, resources Extende
 		allWarnings.Add(warnings)
 	}
 
+	// the custom TLS Passthrough listeners depend on the PROXY protocol settings of the ConfigMap
+	if err := cnf.updateTLSPassthroughListenersConfig(); err != nil {
+		return allWarnings, err
+	}
+
 	// we don't need to regenerate config for TransportServers, because:
 	// (1) Changes to the ConfigMap don't affect TransportServer configs directly
 	// (2) addOrUpdateTransportServer doesn't return any warnings that we need to propagate to the caller.
@@ -1134,7 +1427,7 @@ func (cnf *Configurator) UpdateConfig(cfgParams *ConfigParams, resources Extende
 // UpdateTransportServers updates TransportServers.
 func (cnf *Configurator) UpdateTransportServers(updatedTSExes []*TransportServerEx, deletedKeys []string) error {
 	for _, tsEx := range updatedTSExes {
-		err := cnf.addOrUpdateTransportServer(tsEx)
+		_, err := cnf.addOrUpdateTransportServer(tsEx)
 		if err != nil {
 			return fmt.Errorf("Error adding or updating TransportServer %v/%v: %w", tsEx.TransportServer.Namespace, tsEx.TransportServer.Name, err)
 		}
@@ -1170,10 +1463,14 @@ func getFileNameForVirtualServer(virtualServer *conf_v1.VirtualServer) string {
 	return fmt.Sprintf("vs_%s_%s", virtualServer.Namespace, virtualServer.Name)
 }
 
-func getFileNameForTransportServer(transportServer *conf_v1alpha1.TransportServer) string {
+func getFileNameForTransportServer(transportServer *conf_v1.TransportServer) string {
 	return fmt.Sprintf("ts_%s_%s", transportServer.Namespace, transportServer.Name)
 }
 
+func getFileNameForTLSPassthroughListener(listener string) string {
+	return fmt.Sprintf("tls-passthrough_%s", listener)
+}
+
 func getFileNameForVirtualServerFromKey(key string) string {
 	replaced := strings.Replace(key, "/", "_", -1)
 	return fmt.Sprintf("vs_%s", replaced)
@@ -1464,7 +1761,10 @@ func (cnf *Configurator) RefreshAppProtectUserSigs(
 		fmt.Fprintf(&builder, "app_protect_user_defined_signatures %s;\n", fName)
 	}
 	cnf.nginxManager.CreateAppProtectResourceFile(appProtectUserSigIndex, []byte(builder.String()))
-	return allWarnings, cnf.reload(nginx.ReloadForOtherUpdate)
+
+	err = cnf.reload(nginx.ReloadForOtherUpdate)
+
+	return allWarnings, err
 }
 
 func appProtectDosPolicyFileName(namespace string, name string) string {
@@ -1508,6 +1808,8 @@ func (cnf *Configurator) AddOrUpdateSecret(secret *api_v1.Secret) string {
 		return cnf.addOrUpdateCASecret(secret)
 	case secrets.SecretTypeJWK:
 		return cnf.addOrUpdateJWKSecret(secret)
+	case secrets.SecretTypeHtpasswd:
+		return cnf.addOrUpdateHtpasswdSecret(secret)
 	case secrets.SecretTypeOIDC:
 		// OIDC ClientSecret is not required on the filesystem, it is written directly to the config file.
 		return ""
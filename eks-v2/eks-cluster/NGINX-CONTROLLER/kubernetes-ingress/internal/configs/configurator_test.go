@@ -1,9 +1,13 @@
 package configs
 
 import (
+	"errors"
 	"os"
 	"reflect"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/prometheus/client_golang/prometheus"
@@ -13,9 +17,9 @@ import (
 
 	"github.com/nginxinc/kubernetes-ingress/internal/configs/version1"
 	"github.com/nginxinc/kubernetes-ingress/internal/configs/version2"
+	"github.com/nginxinc/kubernetes-ingress/internal/metrics/collectors"
 	"github.com/nginxinc/kubernetes-ingress/internal/nginx"
 	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
-	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
 )
 
 func createTestStaticConfigParams() *StaticConfigParams {
@@ -251,7 +255,7 @@ func TestGetFileNameForVirtualServerFromKey(t *testing.T) {
 }
 
 func TestGetFileNameForTransportServer(t *testing.T) {
-	transportServer := &conf_v1alpha1.TransportServer{
+	transportServer := &conf_v1.TransportServer{
 		ObjectMeta: meta_v1.ObjectMeta{
 			Namespace: "default",
 			Name:      "test-server",
@@ -296,10 +300,18 @@ func TestGenerateTLSPassthroughHostsConfig(t *testing.T) {
 		"default/ts-1": {
 			Host:       "one.example.com",
 			UnixSocket: "socket1.sock",
+			Listener:   "tls-passthrough",
 		},
 		"default/ts-2": {
 			Host:       "two.example.com",
 			UnixSocket: "socket2.sock",
+			Listener:   "tls-passthrough",
+		},
+		"default/ts-3": {
+			Host:         "three.example.com",
+			UnixSocket:   "socket3.sock",
+			Listener:     "tls-passthrough-8443",
+			ListenerPort: 8443,
 		},
 	}
 
@@ -314,6 +326,70 @@ func TestGenerateTLSPassthroughHostsConfig(t *testing.T) {
 	}
 }
 
+func TestGenerateTLSPassthroughListenerConfigs(t *testing.T) {
+	tlsPassthroughPairs := map[string]tlsPassthroughPair{
+		"default/ts-1": {
+			Host:       "one.example.com",
+			UnixSocket: "socket1.sock",
+			Listener:   "tls-passthrough",
+		},
+		"default/ts-2": {
+			Host:         "two.example.com",
+			UnixSocket:   "socket2.sock",
+			Listener:     "tls-passthrough-8443",
+			ListenerPort: 8443,
+		},
+		"default/ts-3": {
+			Host:         "three.example.com",
+			UnixSocket:   "socket3.sock",
+			Listener:     "tls-passthrough-8443",
+			ListenerPort: 8443,
+		},
+		"default/ts-4": {
+			Host:         "four.example.com",
+			UnixSocket:   "socket4.sock",
+			Listener:     "tls-passthrough-9443",
+			ListenerPort: 9443,
+		},
+	}
+
+	expectedCfgs := map[string]*version2.TLSPassthroughListenerConfig{
+		"tls-passthrough-8443": {
+			Name:              "tls-passthrough-8443",
+			Port:              8443,
+			Variable:          "$dest_passthrough_tls_passthrough_8443",
+			DefaultUnixSocket: "unix:/var/lib/nginx/non-existing-unix-socket.sock",
+			Hosts: version2.TLSPassthroughHostsConfig{
+				"two.example.com":   "socket2.sock",
+				"three.example.com": "socket3.sock",
+			},
+			ProxyProtocol: true,
+			SetRealIPFrom: []string{"10.0.0.0/8"},
+		},
+		"tls-passthrough-9443": {
+			Name:              "tls-passthrough-9443",
+			Port:              9443,
+			Variable:          "$dest_passthrough_tls_passthrough_9443",
+			DefaultUnixSocket: "unix:/var/lib/nginx/non-existing-unix-socket.sock",
+			Hosts: version2.TLSPassthroughHostsConfig{
+				"four.example.com": "socket4.sock",
+			},
+			ProxyProtocol: true,
+			SetRealIPFrom: []string{"10.0.0.0/8"},
+		},
+	}
+
+	cfgParams := &ConfigParams{
+		ProxyProtocol: true,
+		SetRealIPFrom: []string{"10.0.0.0/8"},
+	}
+
+	resultCfgs := generateTLSPassthroughListenerConfigs(tlsPassthroughPairs, cfgParams)
+	if !reflect.DeepEqual(resultCfgs, expectedCfgs) {
+		t.Errorf("generateTLSPassthroughListenerConfigs() returned %v but expected %v", resultCfgs, expectedCfgs)
+	}
+}
+
 func TestAddInternalRouteConfig(t *testing.T) {
 	cnf, err := createTestConfigurator()
 	if err != nil {
@@ -528,6 +604,11 @@ func (u *mockLatencyCollector) RecordLatency(string) {}
 // Register implements a fake Register method
 func (u *mockLatencyCollector) Register(*prometheus.Registry) error { return nil }
 
+// GetUpstreamStats implements a fake GetUpstreamStats method
+func (u *mockLatencyCollector) GetUpstreamStats(string) collectors.UpstreamStats {
+	return collectors.UpstreamStats{}
+}
+
 func TestUpdateIngressMetricsLabels(t *testing.T) {
 	cnf, err := createTestConfigurator()
 	if err != nil {
@@ -880,13 +961,13 @@ func TestUpdateTransportServerMetricsLabels(t *testing.T) {
 	cnf.labelUpdater = newFakeLabelUpdater()
 
 	tsEx := &TransportServerEx{
-		TransportServer: &conf_v1alpha1.TransportServer{
+		TransportServer: &conf_v1.TransportServer{
 			ObjectMeta: meta_v1.ObjectMeta{
 				Name:      "test-transportserver",
 				Namespace: "default",
 			},
-			Spec: conf_v1alpha1.TransportServerSpec{
-				Listener: conf_v1alpha1.TransportServerListener{
+			Spec: conf_v1.TransportServerSpec{
+				Listener: conf_v1.TransportServerListener{
 					Name:     "dns-tcp",
 					Protocol: "TCP",
 				},
@@ -1011,13 +1092,13 @@ func TestUpdateTransportServerMetricsLabels(t *testing.T) {
 	}
 
 	tsExTLS := &TransportServerEx{
-		TransportServer: &conf_v1alpha1.TransportServer{
+		TransportServer: &conf_v1.TransportServer{
 			ObjectMeta: meta_v1.ObjectMeta{
 				Name:      "test-transportserver-tls",
 				Namespace: "default",
 			},
-			Spec: conf_v1alpha1.TransportServerSpec{
-				Listener: conf_v1alpha1.TransportServerListener{
+			Spec: conf_v1.TransportServerSpec{
+				Listener: conf_v1.TransportServerListener{
 					Name:     "tls-passthrough",
 					Protocol: "TLS_PASSTHROUGH",
 				},
@@ -1302,3 +1383,242 @@ func TestUpdateApResourcesForVs(t *testing.T) {
 		}
 	}
 }
+
+type reloadTestManager struct {
+	*nginx.FakeManager
+	reloadErrs      []error
+	liveConfigs     []string
+	createdConfigs  map[string]string
+	deletedConfigs  []string
+	restoredConfigs []string
+	discarded       bool
+	reloads         int
+}
+
+func (m *reloadTestManager) Reload(_ bool) error {
+	m.reloads++
+
+	if len(m.reloadErrs) == 0 {
+		return nil
+	}
+
+	err := m.reloadErrs[0]
+	m.reloadErrs = m.reloadErrs[1:]
+
+	return err
+}
+
+func (m *reloadTestManager) CreateConfig(name string, content []byte) {
+	if m.createdConfigs == nil {
+		m.createdConfigs = make(map[string]string)
+	}
+	m.createdConfigs[name] = string(content)
+}
+
+func (m *reloadTestManager) DeleteConfig(name string) {
+	m.deletedConfigs = append(m.deletedConfigs, name)
+}
+
+func (m *reloadTestManager) RestoreConfig(name string) bool {
+	m.restoredConfigs = append(m.restoredConfigs, name)
+
+	for _, live := range m.liveConfigs {
+		if live == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *reloadTestManager) DiscardStagedConfig() {
+	m.discarded = true
+}
+
+func TestReloadExcludesInvalidResources(t *testing.T) {
+	cafe := &conf_v1.VirtualServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "cafe",
+			Namespace: "default",
+		},
+	}
+	tea := &conf_v1.VirtualServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "tea",
+			Namespace: "default",
+		},
+	}
+	coffee := &conf_v1.VirtualServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "coffee",
+			Namespace: "default",
+		},
+	}
+
+	manager := &reloadTestManager{
+		FakeManager: nginx.NewFakeManager("/etc/nginx"),
+		reloadErrs: []error{
+			&nginx.ConfigTestError{
+				ConfigErrors: map[string][]string{
+					"vs_default_cafe":   {"[emerg] unknown directive"},
+					"vs_default_coffee": {"[emerg] unknown directive"},
+				},
+			},
+		},
+		liveConfigs: []string{"vs_default_cafe", "vs_default_tea"},
+	}
+
+	cnf := NewConfigurator(manager, createTestStaticConfigParams(), NewDefaultConfigParams(false), nil, nil, false, false, nil, false, nil, false)
+	cnf.EnableReloads()
+	cnf.virtualServers["vs_default_cafe"] = &VirtualServerEx{VirtualServer: cafe}
+	cnf.virtualServers["vs_default_tea"] = &VirtualServerEx{VirtualServer: tea}
+	cnf.virtualServers["vs_default_coffee"] = &VirtualServerEx{VirtualServer: coffee}
+
+	err := cnf.reload(nginx.ReloadForOtherUpdate)
+
+	var invalidResourcesErr *InvalidResourcesError
+	if !errors.As(err, &invalidResourcesErr) {
+		t.Fatalf("reload() returned %v but expected an InvalidResourcesError", err)
+	}
+	if invalidResourcesErr.ErrorForResource(cafe) == nil {
+		t.Errorf("reload() returned no error for the invalid VirtualServer")
+	}
+	if invalidResourcesErr.ErrorForResource(coffee) == nil {
+		t.Errorf("reload() returned no error for the invalid VirtualServer without a valid config")
+	}
+	if err := invalidResourcesErr.ErrorForResource(tea); err != nil {
+		t.Errorf("reload() returned error %v for the valid VirtualServer", err)
+	}
+
+	if _, exists := cnf.virtualServers["vs_default_cafe"]; !exists {
+		t.Errorf("reload() removed the invalid VirtualServer with a valid config")
+	}
+	if _, exists := cnf.virtualServers["vs_default_coffee"]; exists {
+		t.Errorf("reload() didn't remove the invalid VirtualServer without a valid config")
+	}
+	if _, exists := cnf.virtualServers["vs_default_tea"]; !exists {
+		t.Errorf("reload() removed the valid VirtualServer")
+	}
+	sort.Strings(manager.restoredConfigs)
+	if !reflect.DeepEqual(manager.restoredConfigs, []string{"vs_default_cafe", "vs_default_coffee"}) {
+		t.Errorf("reload() restored configs %v but expected [vs_default_cafe vs_default_coffee]", manager.restoredConfigs)
+	}
+	if !reflect.DeepEqual(manager.deletedConfigs, []string{"vs_default_coffee"}) {
+		t.Errorf("reload() deleted configs %v but expected [vs_default_coffee]", manager.deletedConfigs)
+	}
+	if manager.discarded {
+		t.Errorf("reload() discarded the staged config")
+	}
+}
+
+func TestReloadDiscardsStagedConfig(t *testing.T) {
+	testErr := &nginx.ConfigTestError{
+		Output: "[emerg] unknown directive in /etc/nginx/nginx.conf:12",
+	}
+
+	manager := &reloadTestManager{
+		FakeManager: nginx.NewFakeManager("/etc/nginx"),
+		reloadErrs:  []error{testErr},
+	}
+
+	cnf := NewConfigurator(manager, createTestStaticConfigParams(), NewDefaultConfigParams(false), nil, nil, false, false, nil, false, nil, false)
+	cnf.EnableReloads()
+
+	err := cnf.reload(nginx.ReloadForOtherUpdate)
+	if !errors.Is(err, testErr) {
+		t.Errorf("reload() returned %v but expected %v", err, testErr)
+	}
+	if !manager.discarded {
+		t.Errorf("reload() didn't discard the staged config")
+	}
+}
+
+func TestReloadBatching(t *testing.T) {
+	manager := &reloadTestManager{
+		FakeManager: nginx.NewFakeManager("/etc/nginx"),
+	}
+
+	cnf := NewConfigurator(manager, createTestStaticConfigParams(), NewDefaultConfigParams(false), nil, nil, false, false, nil, false, nil, false)
+	cnf.EnableReloads()
+	cnf.EnableReloadBatching(time.Minute, time.Minute, collectors.NewManagerFakeCollector(), func() {})
+
+	err := cnf.reload(nginx.ReloadForOtherUpdate)
+	if err != nil {
+		t.Fatalf("reload() returned an unexpected error: %v", err)
+	}
+	if manager.reloads != 1 {
+		t.Fatalf("reload() performed %v reloads but expected 1", manager.reloads)
+	}
+
+	for i := 0; i < 3; i++ {
+		err = cnf.reload(nginx.ReloadForOtherUpdate)
+		if err != nil {
+			t.Fatalf("reload() returned an unexpected error: %v", err)
+		}
+	}
+	if manager.reloads != 1 {
+		t.Errorf("reload() within the interval performed %v reloads but expected none", manager.reloads-1)
+	}
+	if !cnf.IsReloadPending() {
+		t.Fatalf("IsReloadPending() returned false after reload() within the interval")
+	}
+
+	err = cnf.ReloadPending()
+	if err != nil {
+		t.Fatalf("ReloadPending() returned an unexpected error: %v", err)
+	}
+	if manager.reloads != 2 {
+		t.Errorf("ReloadPending() performed %v reloads but expected 1", manager.reloads-1)
+	}
+	if cnf.IsReloadPending() {
+		t.Errorf("IsReloadPending() returned true after ReloadPending()")
+	}
+}
+
+func TestUpdatePolicyCacheZones(t *testing.T) {
+	templateExecutorV2, err := version2.NewTemplateExecutor("version2/nginx-plus.virtualserver.tmpl", "version2/nginx-plus.transportserver.tmpl")
+	if err != nil {
+		t.Fatalf("Failed to create a template executor: %v", err)
+	}
+
+	manager := &reloadTestManager{
+		FakeManager: nginx.NewFakeManager("/etc/nginx"),
+	}
+
+	cnf := NewConfigurator(manager, createTestStaticConfigParams(), NewDefaultConfigParams(false), nil, templateExecutorV2, false, false, nil, false, nil, false)
+
+	zone := version2.CacheZone{
+		Name: "pol_cache_default_cache-policy",
+		Path: "/var/cache/nginx/pol_cache_default_cache-policy",
+		Size: "10m",
+	}
+	expectedContent := "proxy_cache_path /var/cache/nginx/pol_cache_default_cache-policy levels=1:2 keys_zone=pol_cache_default_cache-policy:10m;"
+
+	for _, name := range []string{"vs_default_cafe", "vs_default_tea"} {
+		err := cnf.updatePolicyCacheZones(name, []version2.CacheZone{zone})
+		if err != nil {
+			t.Fatalf("updatePolicyCacheZones() returned an unexpected error: %v", err)
+		}
+	}
+
+	content := manager.createdConfigs[policyCacheZonesConfigName]
+	if strings.Count(content, "proxy_cache_path") != 1 || !strings.Contains(content, expectedContent) {
+		t.Errorf("updatePolicyCacheZones() created config %q but expected one zone %q", content, expectedContent)
+	}
+
+	err = cnf.updatePolicyCacheZones("vs_default_cafe", nil)
+	if err != nil {
+		t.Fatalf("updatePolicyCacheZones() returned an unexpected error: %v", err)
+	}
+	if len(manager.deletedConfigs) != 0 {
+		t.Errorf("updatePolicyCacheZones() deleted configs %v while a VirtualServer still references the zone", manager.deletedConfigs)
+	}
+
+	err = cnf.updatePolicyCacheZones("vs_default_tea", nil)
+	if err != nil {
+		t.Fatalf("updatePolicyCacheZones() returned an unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(manager.deletedConfigs, []string{policyCacheZonesConfigName}) {
+		t.Errorf("updatePolicyCacheZones() deleted configs %v but expected [%v]", manager.deletedConfigs, policyCacheZonesConfigName)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -205,6 +206,44 @@ func ParseTime(s string) (string, error) {
 	return fmt.Sprintf("%s%s%s%s%s%s%s%s", years, months, weeks, days, hours, mins, secs, millis), nil
 }
 
+// nginxTimeUnits maps the units of the NGINX time syntax to their duration, following http://nginx.org/en/docs/syntax.html
+var nginxTimeUnits = map[string]time.Duration{
+	"y":  365 * 24 * time.Hour,
+	"M":  30 * 24 * time.Hour,
+	"w":  7 * 24 * time.Hour,
+	"d":  24 * time.Hour,
+	"h":  time.Hour,
+	"m":  time.Minute,
+	"s":  time.Second,
+	"ms": time.Millisecond,
+}
+
+// ParseTimeDuration parses a time in the NGINX syntax (see ParseTime) into a time.Duration.
+func ParseTimeDuration(s string) (time.Duration, error) {
+	if s == "" || strings.TrimSpace(s) == "" || !timeRegexp.MatchString(s) {
+		return 0, errors.New("invalid time string")
+	}
+
+	var d time.Duration
+	for _, part := range timeRegexp.FindStringSubmatch(s)[1:] {
+		if part == "" {
+			continue
+		}
+		value := strings.TrimRight(part, "yMwdhms")
+		unit := part[len(value):]
+		if unit == "" {
+			unit = "s"
+		}
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid time string: %w", err)
+		}
+		d += time.Duration(n) * nginxTimeUnits[unit]
+	}
+
+	return d, nil
+}
+
 // OffsetFmt http://nginx.org/en/docs/syntax.html
 const OffsetFmt = `\d+[kKmMgG]?`
 
@@ -3,6 +3,7 @@ package configs
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1"
@@ -402,6 +403,41 @@ func TestParseTime(t *testing.T) {
 	}
 }
 
+func TestParseTimeDuration(t *testing.T) {
+	testsWithValidInput := []struct {
+		input    string
+		expected time.Duration
+	}{
+		{"1h30m 5 100ms", time.Hour + 30*time.Minute + 5*time.Second + 100*time.Millisecond},
+		{"10ms", 10 * time.Millisecond},
+		{"1", time.Second},
+		{"5m 30s", 5*time.Minute + 30*time.Second},
+		{"2w", 14 * 24 * time.Hour},
+		{"1d", 24 * time.Hour},
+		{"1M", 30 * 24 * time.Hour},
+		{"1y", 365 * 24 * time.Hour},
+	}
+	invalidInput := []string{"5s 5s", "ss", "-5s", "", " ", "1L"}
+
+	for _, test := range testsWithValidInput {
+		result, err := ParseTimeDuration(test.input)
+		if err != nil {
+			t.Errorf("ParseTimeDuration(%q) returned an error for valid input", test.input)
+		}
+
+		if result != test.expected {
+			t.Errorf("ParseTimeDuration(%q) returned %v expected %v", test.input, result, test.expected)
+		}
+	}
+
+	for _, test := range invalidInput {
+		result, err := ParseTimeDuration(test)
+		if err == nil {
+			t.Errorf("ParseTimeDuration(%q) didn't return error. Returned: %v", test, result)
+		}
+	}
+}
+
 func TestParseOffset(t *testing.T) {
 	testsWithValidInput := []string{"1", "2k", "2K", "3m", "3M", "4g", "4G"}
 	invalidInput := []string{"-1", "", "blah"}
@@ -0,0 +1,98 @@
+package configs
+
+import (
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/nginxinc/kubernetes-ingress/internal/metrics/collectors"
+)
+
+// reloadScheduler batches the reloads of NGINX.
+// If no reload is pending and the last reload happened at least the interval ago, a reload is performed right away.
+// Otherwise, the reload becomes pending and the configuration changes that come before it is performed are batched into it.
+// The pending reload is performed after the interval since the latest change, but no later than the max delay
+// after the first change of the batch. Thus, NGINX is reloaded at most once per interval, and a burst of changes
+// results in a single reload.
+type reloadScheduler struct {
+	interval     time.Duration
+	maxDelay     time.Duration
+	collector    collectors.ManagerCollector
+	onReloadDue  func()
+	now          func() time.Time
+	afterFunc    func(d time.Duration, f func()) *time.Timer
+	timer        *time.Timer
+	lastReload   time.Time
+	firstPending time.Time
+	pending      bool
+	// isEndpointsUpdate is true if all the batched changes are endpoints updates.
+	isEndpointsUpdate bool
+}
+
+func newReloadScheduler(interval time.Duration, maxDelay time.Duration, collector collectors.ManagerCollector, onReloadDue func()) *reloadScheduler {
+	if maxDelay < interval {
+		maxDelay = interval
+	}
+
+	return &reloadScheduler{
+		interval:    interval,
+		maxDelay:    maxDelay,
+		collector:   collector,
+		onReloadDue: onReloadDue,
+		now:         time.Now,
+		afterFunc:   time.AfterFunc,
+	}
+}
+
+// schedule requests a reload. It returns true if the reload must be performed right away. Otherwise, the reload is
+// pending, and onReloadDue is called when it must be performed.
+func (rs *reloadScheduler) schedule(isEndpointsUpdate bool) bool {
+	now := rs.now()
+
+	if !rs.pending && now.Sub(rs.lastReload) >= rs.interval {
+		return true
+	}
+
+	if !rs.pending {
+		rs.pending = true
+		rs.firstPending = now
+		rs.isEndpointsUpdate = isEndpointsUpdate
+	} else {
+		rs.isEndpointsUpdate = rs.isEndpointsUpdate && isEndpointsUpdate
+	}
+
+	rs.collector.IncNginxSkippedReloadCount()
+
+	due := now.Add(rs.interval)
+	if maxDue := rs.firstPending.Add(rs.maxDelay); due.After(maxDue) {
+		due = maxDue
+	}
+
+	if rs.timer != nil {
+		rs.timer.Stop()
+	}
+	rs.timer = rs.afterFunc(due.Sub(now), rs.onReloadDue)
+
+	glog.V(3).Infof("NGINX reload is pending until %v", due)
+
+	return false
+}
+
+// reloaded records a reload. The pending reload, if any, is no longer pending after it.
+// It returns true if the reload applied batched changes.
+func (rs *reloadScheduler) reloaded() bool {
+	batched := rs.pending
+
+	rs.lastReload = rs.now()
+	rs.pending = false
+
+	if rs.timer != nil {
+		rs.timer.Stop()
+		rs.timer = nil
+	}
+
+	if batched {
+		rs.collector.IncNginxBatchedReloadCount()
+	}
+
+	return batched
+}
@@ -0,0 +1,112 @@
+package configs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nginxinc/kubernetes-ingress/internal/metrics/collectors"
+)
+
+type countingManagerCollector struct {
+	*collectors.ManagerFakeCollector
+	batched int
+	skipped int
+}
+
+func (c *countingManagerCollector) IncNginxBatchedReloadCount() {
+	c.batched++
+}
+
+func (c *countingManagerCollector) IncNginxSkippedReloadCount() {
+	c.skipped++
+}
+
+func createTestReloadScheduler(interval time.Duration, maxDelay time.Duration) (*reloadScheduler, *countingManagerCollector, *time.Time, *[]time.Duration) {
+	collector := &countingManagerCollector{
+		ManagerFakeCollector: collectors.NewManagerFakeCollector(),
+	}
+
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	var delays []time.Duration
+
+	rs := newReloadScheduler(interval, maxDelay, collector, func() {})
+	rs.now = func() time.Time {
+		return now
+	}
+	rs.afterFunc = func(d time.Duration, f func()) *time.Timer {
+		delays = append(delays, d)
+		// the timer never fires during the test
+		return time.AfterFunc(time.Hour, f)
+	}
+
+	return rs, collector, &now, &delays
+}
+
+func TestReloadSchedulerSchedule(t *testing.T) {
+	rs, collector, now, delays := createTestReloadScheduler(10*time.Second, 25*time.Second)
+
+	if !rs.schedule(false) {
+		t.Fatalf("schedule() returned false for the first reload")
+	}
+	rs.reloaded()
+
+	*now = now.Add(4 * time.Second)
+	if rs.schedule(true) {
+		t.Fatalf("schedule() returned true within the interval after the last reload")
+	}
+
+	*now = now.Add(8 * time.Second)
+	if rs.schedule(true) {
+		t.Fatalf("schedule() returned true while a reload is pending")
+	}
+
+	*now = now.Add(8 * time.Second)
+	if rs.schedule(false) {
+		t.Fatalf("schedule() returned true while a reload is pending")
+	}
+
+	// the last delay is limited by the max delay after the first pending change
+	expectedDelays := []time.Duration{10 * time.Second, 10 * time.Second, 9 * time.Second}
+	if len(*delays) != len(expectedDelays) {
+		t.Fatalf("schedule() set timers with delays %v but expected %v", *delays, expectedDelays)
+	}
+	for i := range expectedDelays {
+		if (*delays)[i] != expectedDelays[i] {
+			t.Errorf("schedule() set timers with delays %v but expected %v", *delays, expectedDelays)
+			break
+		}
+	}
+
+	if !rs.pending {
+		t.Errorf("schedule() didn't make the reload pending")
+	}
+	if rs.isEndpointsUpdate {
+		t.Errorf("schedule() batched a non-endpoints change into an endpoints update")
+	}
+	if collector.skipped != 3 {
+		t.Errorf("schedule() counted %v skipped reloads but expected 3", collector.skipped)
+	}
+
+	if !rs.reloaded() {
+		t.Errorf("reloaded() returned false for the pending reload")
+	}
+	if rs.pending {
+		t.Errorf("reloaded() didn't clear the pending reload")
+	}
+	if collector.batched != 1 {
+		t.Errorf("reloaded() counted %v batched reloads but expected 1", collector.batched)
+	}
+
+	*now = now.Add(10 * time.Second)
+	if !rs.schedule(false) {
+		t.Errorf("schedule() returned false after the interval since the last reload")
+	}
+}
+
+func TestNewReloadSchedulerMaxDelay(t *testing.T) {
+	rs := newReloadScheduler(10*time.Second, time.Second, collectors.NewManagerFakeCollector(), func() {})
+
+	if rs.maxDelay != 10*time.Second {
+		t.Errorf("newReloadScheduler() set the max delay to %v but expected the interval %v", rs.maxDelay, 10*time.Second)
+	}
+}
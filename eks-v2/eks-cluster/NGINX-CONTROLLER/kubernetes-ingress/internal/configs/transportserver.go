@@ -5,7 +5,9 @@ import (
 	"strings"
 
 	"github.com/nginxinc/kubernetes-ingress/internal/configs/version2"
-	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
+	"github.com/nginxinc/kubernetes-ingress/internal/k8s/secrets"
+	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
+	api_v1 "k8s.io/api/core/v1"
 )
 
 const nginxNonExistingUnixSocket = "unix:/var/lib/nginx/non-existing-unix-socket.sock"
@@ -13,9 +15,11 @@ const nginxNonExistingUnixSocket = "unix:/var/lib/nginx/non-existing-unix-socket
 // TransportServerEx holds a TransportServer along with the resources referenced by it.
 type TransportServerEx struct {
 	ListenerPort    int
-	TransportServer *conf_v1alpha1.TransportServer
+	TransportServer *conf_v1.TransportServer
 	Endpoints       map[string][]string
 	PodsByIP        map[string]string
+	Policies        map[string]*conf_v1.Policy
+	SecretRefs      map[string]*secrets.SecretReference
 }
 
 func (tsEx *TransportServerEx) String() string {
@@ -31,7 +35,7 @@ func (tsEx *TransportServerEx) String() string {
 }
 
 // generateTransportServerConfig generates a full configuration for a TransportServer.
-func generateTransportServerConfig(transportServerEx *TransportServerEx, listenerPort int, isPlus bool) *version2.TransportServerConfig {
+func generateTransportServerConfig(transportServerEx *TransportServerEx, listenerPort int, isPlus bool) (*version2.TransportServerConfig, Warnings) {
 	upstreamNamer := newUpstreamNamerForTransportServer(transportServerEx.TransportServer)
 
 	upstreams := generateStreamUpstreams(transportServerEx, upstreamNamer, isPlus)
@@ -42,7 +46,7 @@ func generateTransportServerConfig(transportServerEx *TransportServerEx, listene
 
 	var proxyRequests, proxyResponses *int
 	var connectTimeout, nextUpstreamTimeout string
-	var nextUpstream bool
+	var nextUpstream, proxyProtocol bool
 	var nextUpstreamTries int
 	if transportServerEx.TransportServer.Spec.UpstreamParameters != nil {
 		proxyRequests = transportServerEx.TransportServer.Spec.UpstreamParameters.UDPRequests
@@ -55,6 +59,7 @@ func generateTransportServerConfig(transportServerEx *TransportServerEx, listene
 		}
 
 		connectTimeout = transportServerEx.TransportServer.Spec.UpstreamParameters.ConnectTimeout
+		proxyProtocol = transportServerEx.TransportServer.Spec.UpstreamParameters.ProxyProtocol
 	}
 
 	var proxyTimeout string
@@ -66,21 +71,40 @@ func generateTransportServerConfig(transportServerEx *TransportServerEx, listene
 
 	streamSnippets := generateSnippets(true, transportServerEx.TransportServer.Spec.StreamSnippets, []string{})
 
+	warnings := newWarnings()
+
+	ssl, sslValid := generateTransportServerSSL(transportServerEx, warnings)
+	proxySSL, proxySSLValid := generateTransportServerProxySSL(transportServerEx, warnings)
+
+	proxyPass, splitClients, maps, sslPreread := generateTransportServerProxyPass(transportServerEx.TransportServer, upstreamNamer, ssl != nil)
+
+	policiesConfig, policiesWarnings := generateTransportServerPolicies(transportServerEx, ssl != nil)
+	warnings.Add(policiesWarnings)
+
+	// Connections must not be proxied without the TLS configuration the user expects, so we deny them all.
+	if !sslValid || !proxySSLValid {
+		policiesConfig = policiesCfg{Deny: []string{"all"}}
+	}
+
+	isTLSPassthrough := transportServerEx.TransportServer.Spec.Listener.Protocol == conf_v1.TLSPassthroughListenerProtocol
+
 	statusZone := transportServerEx.TransportServer.Spec.Listener.Name
-	if transportServerEx.TransportServer.Spec.Listener.Name == conf_v1alpha1.TLSPassthroughListenerName {
+	if isTLSPassthrough {
 		statusZone = transportServerEx.TransportServer.Spec.Host
 	}
 
 	tsConfig := &version2.TransportServerConfig{
 		Server: version2.StreamServer{
-			TLSPassthrough:           transportServerEx.TransportServer.Spec.Listener.Name == conf_v1alpha1.TLSPassthroughListenerName,
+			TLSPassthrough:           isTLSPassthrough,
 			UnixSocket:               generateUnixSocket(transportServerEx),
 			Port:                     listenerPort,
 			UDP:                      transportServerEx.TransportServer.Spec.Listener.Protocol == "UDP",
 			StatusZone:               statusZone,
 			ProxyRequests:            proxyRequests,
 			ProxyResponses:           proxyResponses,
-			ProxyPass:                upstreamNamer.GetNameForUpstream(transportServerEx.TransportServer.Spec.Action.Pass),
+			ProxyPass:                proxyPass,
+			ProxyProtocol:            proxyProtocol,
+			SSLPreread:               sslPreread,
 			Name:                     transportServerEx.TransportServer.Name,
 			Namespace:                transportServerEx.TransportServer.Namespace,
 			ProxyConnectTimeout:      generateTimeWithDefault(connectTimeout, "60s"),
@@ -90,17 +114,297 @@ func generateTransportServerConfig(transportServerEx *TransportServerEx, listene
 			ProxyNextUpstreamTries:   nextUpstreamTries,
 			HealthCheck:              healthCheck,
 			ServerSnippets:           serverSnippets,
+			Allow:                    policiesConfig.Allow,
+			Deny:                     policiesConfig.Deny,
+			LimitConnOptions:         policiesConfig.LimitConnOptions,
+			LimitConns:               policiesConfig.LimitConns,
+			IngressMTLS:              policiesConfig.IngressMTLS,
+			SSL:                      ssl,
+			ProxySSL:                 proxySSL,
 		},
 		Match:          match,
 		Upstreams:      upstreams,
 		StreamSnippets: streamSnippets,
+		LimitConnZones: policiesConfig.LimitConnZones,
+		SplitClients:   splitClients,
+		Maps:           maps,
+	}
+
+	return tsConfig, warnings
+}
+
+// generateTransportServerProxyPass generates the proxy_pass of a TransportServer along with the split_clients and maps
+// for the splits and matches of its action. It returns true if the SNI server name must be read by the ssl_preread module.
+func generateTransportServerProxyPass(
+	transportServer *conf_v1.TransportServer,
+	upstreamNamer *upstreamNamer,
+	tls bool,
+) (string, []version2.SplitClient, []version2.Map, bool) {
+	action := transportServer.Spec.Action
+	safeNsName := strings.ReplaceAll(fmt.Sprintf("%s_%s", transportServer.Namespace, transportServer.Name), "-", "_")
+
+	var splitClients []version2.SplitClient
+	proxyPass := upstreamNamer.GetNameForUpstream(action.Pass)
+
+	if len(action.Splits) > 0 {
+		var distributions []version2.Distribution
+		for _, s := range action.Splits {
+			distributions = append(distributions, version2.Distribution{
+				Weight: fmt.Sprintf("%d%%", s.Weight),
+				Value:  upstreamNamer.GetNameForUpstream(s.Pass),
+			})
+		}
+
+		proxyPass = fmt.Sprintf("$ts_%s_splits", safeNsName)
+		splitClients = append(splitClients, version2.SplitClient{
+			Source:        `"${remote_addr}${remote_port}${connection}"`,
+			Variable:      proxyPass,
+			Distributions: distributions,
+		})
+	}
+
+	if len(action.Matches) == 0 {
+		return proxyPass, splitClients, nil, false
+	}
+
+	var params []version2.Parameter
+	for _, m := range action.Matches {
+		value, _ := generateValueForMatchesRouteMap(m.SNI)
+		params = append(params, version2.Parameter{
+			Value:  value,
+			Result: upstreamNamer.GetNameForUpstream(m.Pass),
+		})
+	}
+	params = append(params, version2.Parameter{
+		Value:  "default",
+		Result: proxyPass,
+	})
+
+	// When NGINX terminates TLS, the handshake happens before the preread phase, so the SNI server name
+	// must be taken from the established TLS connection.
+	source := "$ssl_preread_server_name"
+	if tls {
+		source = "$ssl_server_name"
+	}
+
+	variable := fmt.Sprintf("$ts_%s_matches", safeNsName)
+	maps := []version2.Map{
+		{
+			Source:     source,
+			Variable:   variable,
+			Parameters: params,
+		},
+	}
+
+	return variable, splitClients, maps, !tls
+}
+
+// getUpstreamNamesForTransportServerAction returns the names of all upstreams referenced by an action.
+func getUpstreamNamesForTransportServerAction(action *conf_v1.TransportServerAction) []string {
+	var names []string
+
+	if action.Pass != "" {
+		names = append(names, action.Pass)
+	}
+	for _, s := range action.Splits {
+		names = append(names, s.Pass)
+	}
+	for _, m := range action.Matches {
+		names = append(names, m.Pass)
+	}
+
+	return names
+}
+
+// generateTransportServerPolicies generates the configuration of the policies referenced by a TransportServer.
+// Only accessControl, connectionLimit and ingressMTLS policies are supported in the stream context.
+// If a policy is missing or can't be applied, all connections to the TransportServer are denied.
+func generateTransportServerPolicies(transportServerEx *TransportServerEx, tls bool) (policiesCfg, Warnings) {
+	warnings := newWarnings()
+	ts := transportServerEx.TransportServer
+	config := newPoliciesConfig()
+
+	for _, p := range ts.Spec.Policies {
+		polNamespace := p.Namespace
+		if polNamespace == "" {
+			polNamespace = ts.Namespace
+		}
+
+		key := fmt.Sprintf("%s/%s", polNamespace, p.Name)
+
+		pol, exists := transportServerEx.Policies[key]
+		if !exists {
+			warnings.AddWarningf(ts, "Policy %s is missing or invalid", key)
+			return policiesCfg{Deny: []string{"all"}}, warnings
+		}
+
+		var res *validationResults
+		switch {
+		case pol.Spec.AccessControl != nil:
+			res = config.addAccessControlConfig(pol.Spec.AccessControl)
+		case pol.Spec.ConnectionLimit != nil:
+			res = config.addTransportServerConnectionLimitConfig(pol.Spec.ConnectionLimit, key, polNamespace, p.Name, ts.Namespace, ts.Name)
+		case pol.Spec.IngressMTLS != nil:
+			res = config.addTransportServerIngressMTLSConfig(pol.Spec.IngressMTLS, key, polNamespace, tls, transportServerEx.SecretRefs)
+		default:
+			res = newValidationResults()
+			res.addWarningf("Policy %s is not supported in TransportServer", key)
+			res.isError = true
+		}
+
+		for _, w := range res.warnings {
+			warnings.AddWarning(ts, w)
+		}
+
+		if res.isError {
+			return policiesCfg{Deny: []string{"all"}}, warnings
+		}
+	}
+
+	return *config, warnings
+}
+
+// generateTransportServerSSL generates the TLS termination configuration of a TransportServer.
+// It returns false if the TLS secret is invalid.
+func generateTransportServerSSL(transportServerEx *TransportServerEx, warnings Warnings) (*version2.StreamSSL, bool) {
+	ts := transportServerEx.TransportServer
+	tls := ts.Spec.TLS
+	if tls == nil {
+		return nil, true
+	}
+
+	secretRef, exists := transportServerEx.SecretRefs[fmt.Sprintf("%s/%s", ts.Namespace, tls.Secret)]
+	if !exists {
+		warnings.AddWarningf(ts, "TLS secret %s is missing", tls.Secret)
+		return nil, false
+	}
+
+	var secretType api_v1.SecretType
+	if secretRef.Secret != nil {
+		secretType = secretRef.Secret.Type
+	}
+	if secretType != "" && secretType != api_v1.SecretTypeTLS {
+		warnings.AddWarningf(ts, "TLS secret %s is of a wrong type '%s', must be '%s'", tls.Secret, secretType, api_v1.SecretTypeTLS)
+		return nil, false
+	} else if secretRef.Error != nil {
+		warnings.AddWarningf(ts, "TLS secret %s is invalid: %v", tls.Secret, secretRef.Error)
+		return nil, false
+	}
+
+	return &version2.StreamSSL{
+		Certificate:    secretRef.Path,
+		CertificateKey: secretRef.Path,
+		Protocols:      tls.Protocols,
+		Ciphers:        tls.Ciphers,
+	}, true
+}
+
+// generateTransportServerProxySSL generates the TLS configuration of the connections to the upstreams of the action.
+// The validation ensures that all upstreams of the action have the same TLS configuration.
+// It returns false if the trusted certificate secret is invalid.
+func generateTransportServerProxySSL(transportServerEx *TransportServerEx, warnings Warnings) (*version2.StreamProxySSL, bool) {
+	ts := transportServerEx.TransportServer
+
+	upstreamNames := getUpstreamNamesForTransportServerAction(ts.Spec.Action)
+	if len(upstreamNames) == 0 {
+		return nil, true
+	}
+	upstreamName := upstreamNames[0]
+
+	var tls *conf_v1.TransportServerUpstreamTLS
+	for _, u := range ts.Spec.Upstreams {
+		if u.Name == upstreamName {
+			tls = u.TLS
+			break
+		}
+	}
+
+	if tls == nil || !tls.Enable {
+		return nil, true
+	}
+
+	var trustedCert string
+	if tls.TrustedCertSecret != "" {
+		secretRef, exists := transportServerEx.SecretRefs[fmt.Sprintf("%s/%s", ts.Namespace, tls.TrustedCertSecret)]
+		if !exists {
+			warnings.AddWarningf(ts, "Upstream %s references a missing secret %s", upstreamName, tls.TrustedCertSecret)
+			return nil, false
+		}
+
+		var secretType api_v1.SecretType
+		if secretRef.Secret != nil {
+			secretType = secretRef.Secret.Type
+		}
+		if secretType != "" && secretType != secrets.SecretTypeCA {
+			warnings.AddWarningf(ts, "Upstream %s references a secret %s of a wrong type '%s', must be '%s'", upstreamName, tls.TrustedCertSecret, secretType, secrets.SecretTypeCA)
+			return nil, false
+		} else if secretRef.Error != nil {
+			warnings.AddWarningf(ts, "Upstream %s references an invalid secret %s: %v", upstreamName, tls.TrustedCertSecret, secretRef.Error)
+			return nil, false
+		}
+
+		trustedCert = secretRef.Path
+	}
+
+	return &version2.StreamProxySSL{
+		TrustedCert:  trustedCert,
+		VerifyServer: tls.VerifyServer,
+		VerifyDepth:  generateIntFromPointer(tls.VerifyDepth, 1),
+		Protocols:    tls.Protocols,
+		Ciphers:      tls.Ciphers,
+		ServerName:   tls.ServerName,
+		SSLName:      tls.SSLName,
+	}, true
+}
+
+func (p *policiesCfg) addTransportServerConnectionLimitConfig(
+	connectionLimit *conf_v1.ConnectionLimit,
+	polKey string,
+	polNamespace string,
+	polName string,
+	tsNamespace string,
+	tsName string,
+) *validationResults {
+	// $binary_remote_addr is the only variable of the connectionLimit key available in the stream context.
+	if strings.Contains(strings.ReplaceAll(connectionLimit.Key, "${binary_remote_addr}", ""), "$") {
+		res := newValidationResults()
+		res.addWarningf("ConnectionLimit policy %s with key '%s' is not supported in TransportServer: only ${binary_remote_addr} variable is allowed", polKey, connectionLimit.Key)
+		res.isError = true
+		return res
+	}
+
+	// The zone names must not clash with the zones of a VirtualServer with the same namespace and name.
+	clZoneName := fmt.Sprintf("ts_pol_cl_%v_%v_%v_%v", polNamespace, polName, tsNamespace, tsName)
+	res := p.addLimitConnConfig(connectionLimit, polKey, clZoneName)
+
+	if connectionLimit.RejectCode != nil {
+		res.addWarningf("ConnectionLimit policy %s option rejectCode is ignored in TransportServer: excessive connections are closed", polKey)
+	}
+
+	return res
+}
+
+func (p *policiesCfg) addTransportServerIngressMTLSConfig(
+	ingressMTLS *conf_v1.IngressMTLS,
+	polKey string,
+	polNamespace string,
+	tls bool,
+	secretRefs map[string]*secrets.SecretReference,
+) *validationResults {
+	if !tls {
+		res := newValidationResults()
+		res.addWarningf("TLS termination must be configured in TransportServer for IngressMTLS policy %s", polKey)
+		res.isError = true
+		return res
 	}
 
-	return tsConfig
+	return p.addIngressMTLSConfig(ingressMTLS, polKey, polNamespace, specContext, tls, secretRefs)
 }
 
+// generateUnixSocket generates the unix socket of a TLS Passthrough TransportServer.
+// The socket is unique per TransportServer, so TransportServers of different TLS Passthrough listeners don't clash.
 func generateUnixSocket(transportServerEx *TransportServerEx) string {
-	if transportServerEx.TransportServer.Spec.Listener.Name == conf_v1alpha1.TLSPassthroughListenerName {
+	if transportServerEx.TransportServer.Spec.Listener.Protocol == conf_v1.TLSPassthroughListenerProtocol {
 		return fmt.Sprintf("unix:/var/lib/nginx/passthrough-%s_%s.sock", transportServerEx.TransportServer.Namespace, transportServerEx.TransportServer.Name)
 	}
 
@@ -129,7 +433,7 @@ func generateStreamUpstreams(transportServerEx *TransportServerEx, upstreamNamer
 	return upstreams
 }
 
-func generateTransportServerHealthCheck(upstreamName string, generatedUpstreamName string, upstreams []conf_v1alpha1.Upstream) (*version2.StreamHealthCheck, *version2.Match) {
+func generateTransportServerHealthCheck(upstreamName string, generatedUpstreamName string, upstreams []conf_v1.TransportServerUpstream) (*version2.StreamHealthCheck, *version2.Match) {
 	var hc *version2.StreamHealthCheck
 	var match *version2.Match
 
@@ -170,7 +474,7 @@ func generateTransportServerHealthCheck(upstreamName string, generatedUpstreamNa
 	return hc, match
 }
 
-func generateTransportServerHealthCheckWithDefaults(up conf_v1alpha1.Upstream) *version2.StreamHealthCheck {
+func generateTransportServerHealthCheckWithDefaults(up conf_v1.TransportServerUpstream) *version2.StreamHealthCheck {
 	return &version2.StreamHealthCheck{
 		Enabled:  false,
 		Timeout:  "5s",
@@ -183,7 +487,7 @@ func generateTransportServerHealthCheckWithDefaults(up conf_v1alpha1.Upstream) *
 	}
 }
 
-func generateHealthCheckMatch(match *conf_v1alpha1.Match, name string) *version2.Match {
+func generateHealthCheckMatch(match *conf_v1.TransportServerMatch, name string) *version2.Match {
 	var modifier string
 	var expect string
 
@@ -205,7 +509,7 @@ func generateHealthCheckMatch(match *conf_v1alpha1.Match, name string) *version2
 	}
 }
 
-func generateStreamUpstream(upstream conf_v1alpha1.Upstream, upstreamNamer *upstreamNamer, endpoints []string, isPlus bool) version2.StreamUpstream {
+func generateStreamUpstream(upstream conf_v1.TransportServerUpstream, upstreamNamer *upstreamNamer, endpoints []string, isPlus bool) version2.StreamUpstream {
 	var upsServers []version2.StreamUpstreamServer
 
 	name := upstreamNamer.GetNameForUpstream(upstream.Name)
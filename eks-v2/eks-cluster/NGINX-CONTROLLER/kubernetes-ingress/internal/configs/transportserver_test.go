@@ -1,16 +1,19 @@
 package configs
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/nginxinc/kubernetes-ingress/internal/configs/version2"
-	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
+	"github.com/nginxinc/kubernetes-ingress/internal/k8s/secrets"
+	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
+	api_v1 "k8s.io/api/core/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestUpstreamNamerForTransportServer(t *testing.T) {
-	transportServer := conf_v1alpha1.TransportServer{
+	transportServer := conf_v1.TransportServer{
 		ObjectMeta: meta_v1.ObjectMeta{
 			Name:      "tcp-app",
 			Namespace: "default",
@@ -34,7 +37,7 @@ func TestTransportServerExString(t *testing.T) {
 	}{
 		{
 			input: &TransportServerEx{
-				TransportServer: &conf_v1alpha1.TransportServer{
+				TransportServer: &conf_v1.TransportServer{
 					ObjectMeta: meta_v1.ObjectMeta{
 						Name:      "test-server",
 						Namespace: "default",
@@ -63,24 +66,24 @@ func TestTransportServerExString(t *testing.T) {
 
 func TestGenerateTransportServerConfigForTCPSnippets(t *testing.T) {
 	transportServerEx := TransportServerEx{
-		TransportServer: &conf_v1alpha1.TransportServer{
+		TransportServer: &conf_v1.TransportServer{
 			ObjectMeta: meta_v1.ObjectMeta{
 				Name:      "tcp-server",
 				Namespace: "default",
 			},
-			Spec: conf_v1alpha1.TransportServerSpec{
-				Listener: conf_v1alpha1.TransportServerListener{
+			Spec: conf_v1.TransportServerSpec{
+				Listener: conf_v1.TransportServerListener{
 					Name:     "tcp-listener",
 					Protocol: "TCP",
 				},
-				Upstreams: []conf_v1alpha1.Upstream{
+				Upstreams: []conf_v1.TransportServerUpstream{
 					{
 						Name:    "tcp-app",
 						Service: "tcp-app-svc",
 						Port:    5001,
 					},
 				},
-				Action: &conf_v1alpha1.Action{
+				Action: &conf_v1.TransportServerAction{
 					Pass: "tcp-app",
 				},
 				ServerSnippets: "deny  192.168.1.1;\nallow 192.168.1.0/24;",
@@ -134,25 +137,28 @@ func TestGenerateTransportServerConfigForTCPSnippets(t *testing.T) {
 		StreamSnippets: []string{"limit_conn_zone $binary_remote_addr zone=addr:10m;"},
 	}
 
-	result := generateTransportServerConfig(&transportServerEx, listenerPort, true)
+	result, warnings := generateTransportServerConfig(&transportServerEx, listenerPort, true)
 	if diff := cmp.Diff(expected, result); diff != "" {
 		t.Errorf("generateTransportServerConfig() mismatch (-want +got):\n%s", diff)
 	}
+	if len(warnings) != 0 {
+		t.Errorf("generateTransportServerConfig() returned unexpected warnings: %v", warnings)
+	}
 }
 
 func TestGenerateTransportServerConfigForTCP(t *testing.T) {
 	transportServerEx := TransportServerEx{
-		TransportServer: &conf_v1alpha1.TransportServer{
+		TransportServer: &conf_v1.TransportServer{
 			ObjectMeta: meta_v1.ObjectMeta{
 				Name:      "tcp-server",
 				Namespace: "default",
 			},
-			Spec: conf_v1alpha1.TransportServerSpec{
-				Listener: conf_v1alpha1.TransportServerListener{
+			Spec: conf_v1.TransportServerSpec{
+				Listener: conf_v1.TransportServerListener{
 					Name:     "tcp-listener",
 					Protocol: "TCP",
 				},
-				Upstreams: []conf_v1alpha1.Upstream{
+				Upstreams: []conf_v1.TransportServerUpstream{
 					{
 						Name:        "tcp-app",
 						Service:     "tcp-app-svc",
@@ -161,14 +167,14 @@ func TestGenerateTransportServerConfigForTCP(t *testing.T) {
 						FailTimeout: "40s",
 					},
 				},
-				UpstreamParameters: &conf_v1alpha1.UpstreamParameters{
+				UpstreamParameters: &conf_v1.UpstreamParameters{
 					ConnectTimeout: "30s",
 					NextUpstream:   false,
 				},
-				SessionParameters: &conf_v1alpha1.SessionParameters{
+				SessionParameters: &conf_v1.SessionParameters{
 					Timeout: "50s",
 				},
-				Action: &conf_v1alpha1.Action{
+				Action: &conf_v1.TransportServerAction{
 					Pass: "tcp-app",
 				},
 			},
@@ -220,25 +226,28 @@ func TestGenerateTransportServerConfigForTCP(t *testing.T) {
 		StreamSnippets: []string{},
 	}
 
-	result := generateTransportServerConfig(&transportServerEx, listenerPort, true)
+	result, warnings := generateTransportServerConfig(&transportServerEx, listenerPort, true)
 	if diff := cmp.Diff(expected, result); diff != "" {
 		t.Errorf("generateTransportServerConfig() mismatch (-want +got):\n%s", diff)
 	}
+	if len(warnings) != 0 {
+		t.Errorf("generateTransportServerConfig() returned unexpected warnings: %v", warnings)
+	}
 }
 
 func TestGenerateTransportServerConfigForTCPMaxConnections(t *testing.T) {
 	transportServerEx := TransportServerEx{
-		TransportServer: &conf_v1alpha1.TransportServer{
+		TransportServer: &conf_v1.TransportServer{
 			ObjectMeta: meta_v1.ObjectMeta{
 				Name:      "tcp-server",
 				Namespace: "default",
 			},
-			Spec: conf_v1alpha1.TransportServerSpec{
-				Listener: conf_v1alpha1.TransportServerListener{
+			Spec: conf_v1.TransportServerSpec{
+				Listener: conf_v1.TransportServerListener{
 					Name:     "tcp-listener",
 					Protocol: "TCP",
 				},
-				Upstreams: []conf_v1alpha1.Upstream{
+				Upstreams: []conf_v1.TransportServerUpstream{
 					{
 						Name:        "tcp-app",
 						Service:     "tcp-app-svc",
@@ -248,14 +257,14 @@ func TestGenerateTransportServerConfigForTCPMaxConnections(t *testing.T) {
 						FailTimeout: "40s",
 					},
 				},
-				UpstreamParameters: &conf_v1alpha1.UpstreamParameters{
+				UpstreamParameters: &conf_v1.UpstreamParameters{
 					ConnectTimeout: "30s",
 					NextUpstream:   false,
 				},
-				SessionParameters: &conf_v1alpha1.SessionParameters{
+				SessionParameters: &conf_v1.SessionParameters{
 					Timeout: "50s",
 				},
-				Action: &conf_v1alpha1.Action{
+				Action: &conf_v1.TransportServerAction{
 					Pass: "tcp-app",
 				},
 			},
@@ -308,39 +317,42 @@ func TestGenerateTransportServerConfigForTCPMaxConnections(t *testing.T) {
 		StreamSnippets: []string{},
 	}
 
-	result := generateTransportServerConfig(&transportServerEx, listenerPort, true)
+	result, warnings := generateTransportServerConfig(&transportServerEx, listenerPort, true)
 	if diff := cmp.Diff(expected, result); diff != "" {
 		t.Errorf("generateTransportServerConfig() mismatch (-want +got):\n%s", diff)
 	}
+	if len(warnings) != 0 {
+		t.Errorf("generateTransportServerConfig() returned unexpected warnings: %v", warnings)
+	}
 }
 
 func TestGenerateTransportServerConfigForTLSPasstrhough(t *testing.T) {
 	transportServerEx := TransportServerEx{
-		TransportServer: &conf_v1alpha1.TransportServer{
+		TransportServer: &conf_v1.TransportServer{
 			ObjectMeta: meta_v1.ObjectMeta{
 				Name:      "tcp-server",
 				Namespace: "default",
 			},
-			Spec: conf_v1alpha1.TransportServerSpec{
-				Listener: conf_v1alpha1.TransportServerListener{
+			Spec: conf_v1.TransportServerSpec{
+				Listener: conf_v1.TransportServerListener{
 					Name:     "tls-passthrough",
 					Protocol: "TLS_PASSTHROUGH",
 				},
 				Host: "example.com",
-				Upstreams: []conf_v1alpha1.Upstream{
+				Upstreams: []conf_v1.TransportServerUpstream{
 					{
 						Name:    "tcp-app",
 						Service: "tcp-app-svc",
 						Port:    5001,
 					},
 				},
-				UpstreamParameters: &conf_v1alpha1.UpstreamParameters{
+				UpstreamParameters: &conf_v1.UpstreamParameters{
 					ConnectTimeout:      "30s",
 					NextUpstream:        false,
 					NextUpstreamTries:   0,
 					NextUpstreamTimeout: "",
 				},
-				Action: &conf_v1alpha1.Action{
+				Action: &conf_v1.TransportServerAction{
 					Pass: "tcp-app",
 				},
 			},
@@ -394,10 +406,67 @@ func TestGenerateTransportServerConfigForTLSPasstrhough(t *testing.T) {
 		StreamSnippets: []string{},
 	}
 
-	result := generateTransportServerConfig(&transportServerEx, listenerPort, true)
+	result, warnings := generateTransportServerConfig(&transportServerEx, listenerPort, true)
 	if diff := cmp.Diff(expected, result); diff != "" {
 		t.Errorf("generateTransportServerConfig() mismatch (-want +got):\n%s", diff)
 	}
+	if len(warnings) != 0 {
+		t.Errorf("generateTransportServerConfig() returned unexpected warnings: %v", warnings)
+	}
+}
+
+func TestGenerateTransportServerConfigForCustomTLSPassthroughListener(t *testing.T) {
+	transportServerEx := TransportServerEx{
+		TransportServer: &conf_v1.TransportServer{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "tcp-server",
+				Namespace: "default",
+			},
+			Spec: conf_v1.TransportServerSpec{
+				Listener: conf_v1.TransportServerListener{
+					Name:     "tls-passthrough-8443",
+					Protocol: "TLS_PASSTHROUGH",
+				},
+				Host: "example.com",
+				Upstreams: []conf_v1.TransportServerUpstream{
+					{
+						Name:    "tcp-app",
+						Service: "tcp-app-svc",
+						Port:    5001,
+					},
+				},
+				UpstreamParameters: &conf_v1.UpstreamParameters{
+					ProxyProtocol: true,
+				},
+				Action: &conf_v1.TransportServerAction{
+					Pass: "tcp-app",
+				},
+			},
+		},
+	}
+
+	expected := version2.StreamServer{
+		TLSPassthrough:           true,
+		UnixSocket:               "unix:/var/lib/nginx/passthrough-default_tcp-server.sock",
+		Port:                     8443,
+		StatusZone:               "example.com",
+		ProxyPass:                "ts_default_tcp-server_tcp-app",
+		ProxyProtocol:            true,
+		Name:                     "tcp-server",
+		Namespace:                "default",
+		ProxyConnectTimeout:      "60s",
+		ProxyNextUpstreamTimeout: "0s",
+		ProxyTimeout:             "10m",
+		ServerSnippets:           []string{},
+	}
+
+	result, warnings := generateTransportServerConfig(&transportServerEx, 8443, true)
+	if diff := cmp.Diff(expected, result.Server); diff != "" {
+		t.Errorf("generateTransportServerConfig() mismatch (-want +got):\n%s", diff)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("generateTransportServerConfig() returned unexpected warnings: %v", warnings)
+	}
 }
 
 func TestGenerateTransportServerConfigForUDP(t *testing.T) {
@@ -405,25 +474,25 @@ func TestGenerateTransportServerConfigForUDP(t *testing.T) {
 	udpResponses := 5
 
 	transportServerEx := TransportServerEx{
-		TransportServer: &conf_v1alpha1.TransportServer{
+		TransportServer: &conf_v1.TransportServer{
 			ObjectMeta: meta_v1.ObjectMeta{
 				Name:      "udp-server",
 				Namespace: "default",
 			},
-			Spec: conf_v1alpha1.TransportServerSpec{
-				Listener: conf_v1alpha1.TransportServerListener{
+			Spec: conf_v1.TransportServerSpec{
+				Listener: conf_v1.TransportServerListener{
 					Name:     "udp-listener",
 					Protocol: "UDP",
 				},
-				Upstreams: []conf_v1alpha1.Upstream{
+				Upstreams: []conf_v1.TransportServerUpstream{
 					{
 						Name:        "udp-app",
 						Service:     "udp-app-svc",
 						Port:        5001,
-						HealthCheck: &conf_v1alpha1.HealthCheck{},
+						HealthCheck: &conf_v1.TransportServerHealthCheck{},
 					},
 				},
-				UpstreamParameters: &conf_v1alpha1.UpstreamParameters{
+				UpstreamParameters: &conf_v1.UpstreamParameters{
 					UDPRequests:         &udpRequests,
 					UDPResponses:        &udpResponses,
 					ConnectTimeout:      "30s",
@@ -431,7 +500,7 @@ func TestGenerateTransportServerConfigForUDP(t *testing.T) {
 					NextUpstreamTimeout: "",
 					NextUpstreamTries:   0,
 				},
-				Action: &conf_v1alpha1.Action{
+				Action: &conf_v1.TransportServerAction{
 					Pass: "udp-app",
 				},
 			},
@@ -485,22 +554,637 @@ func TestGenerateTransportServerConfigForUDP(t *testing.T) {
 		StreamSnippets: []string{},
 	}
 
-	result := generateTransportServerConfig(&transportServerEx, listenerPort, true)
+	result, warnings := generateTransportServerConfig(&transportServerEx, listenerPort, true)
 	if diff := cmp.Diff(expected, result); diff != "" {
 		t.Errorf("generateTransportServerConfig() mismatch (-want +got):\n%s", diff)
 	}
+	if len(warnings) != 0 {
+		t.Errorf("generateTransportServerConfig() returned unexpected warnings: %v", warnings)
+	}
+}
+
+func createTransportServerExWithPolicies(policyRefs []conf_v1.PolicyReference, policies map[string]*conf_v1.Policy) *TransportServerEx {
+	return &TransportServerEx{
+		TransportServer: &conf_v1.TransportServer{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "tcp-server",
+				Namespace: "default",
+			},
+			Spec: conf_v1.TransportServerSpec{
+				Policies: policyRefs,
+			},
+		},
+		Policies: policies,
+		SecretRefs: map[string]*secrets.SecretReference{
+			"default/ingress-mtls-secret": {
+				Secret: &api_v1.Secret{
+					Type: secrets.SecretTypeCA,
+				},
+				Path: "/etc/nginx/secrets/default-ingress-mtls-secret",
+			},
+		},
+	}
+}
+
+func TestGenerateTransportServerPolicies(t *testing.T) {
+	accessControlPolicy := &conf_v1.Policy{
+		Spec: conf_v1.PolicySpec{
+			AccessControl: &conf_v1.AccessControl{
+				Allow: []string{"10.0.0.0/8"},
+			},
+		},
+	}
+	connectionLimitPolicy := &conf_v1.Policy{
+		Spec: conf_v1.PolicySpec{
+			ConnectionLimit: &conf_v1.ConnectionLimit{
+				Key:            "${binary_remote_addr}",
+				ZoneSize:       "10M",
+				MaxConnections: 10,
+			},
+		},
+	}
+	ingressMTLSPolicy := &conf_v1.Policy{
+		Spec: conf_v1.PolicySpec{
+			IngressMTLS: &conf_v1.IngressMTLS{
+				ClientCertSecret: "ingress-mtls-secret",
+			},
+		},
+	}
+
+	tests := []struct {
+		tsEx             *TransportServerEx
+		tls              bool
+		expected         policiesCfg
+		expectedWarnings []string
+		msg              string
+	}{
+		{
+			tsEx: createTransportServerExWithPolicies(
+				[]conf_v1.PolicyReference{
+					{
+						Name: "allow-list",
+					},
+					{
+						Name:      "connection-limit",
+						Namespace: "default",
+					},
+				},
+				map[string]*conf_v1.Policy{
+					"default/allow-list":       accessControlPolicy,
+					"default/connection-limit": connectionLimitPolicy,
+				},
+			),
+			expected: policiesCfg{
+				Allow: []string{"10.0.0.0/8"},
+				LimitConnZones: []version2.LimitConnZone{
+					{
+						Key:      "${binary_remote_addr}",
+						ZoneName: "ts_pol_cl_default_connection-limit_default_tcp-server",
+						ZoneSize: "10M",
+					},
+				},
+				LimitConns: []version2.LimitConn{
+					{
+						ZoneName:    "ts_pol_cl_default_connection-limit_default_tcp-server",
+						Connections: 10,
+					},
+				},
+				LimitConnOptions: version2.LimitConnOptions{
+					LogLevel:   "error",
+					RejectCode: 503,
+				},
+			},
+			msg: "access control and connection limit policies",
+		},
+		{
+			tsEx: createTransportServerExWithPolicies(
+				[]conf_v1.PolicyReference{
+					{
+						Name: "ingress-mtls",
+					},
+				},
+				map[string]*conf_v1.Policy{
+					"default/ingress-mtls": ingressMTLSPolicy,
+				},
+			),
+			tls: true,
+			expected: policiesCfg{
+				IngressMTLS: &version2.IngressMTLS{
+					ClientCert:   "/etc/nginx/secrets/default-ingress-mtls-secret",
+					VerifyClient: "on",
+					VerifyDepth:  1,
+				},
+			},
+			msg: "ingress mtls policy",
+		},
+		{
+			tsEx: createTransportServerExWithPolicies(
+				[]conf_v1.PolicyReference{
+					{
+						Name: "ingress-mtls",
+					},
+				},
+				map[string]*conf_v1.Policy{
+					"default/ingress-mtls": ingressMTLSPolicy,
+				},
+			),
+			tls: false,
+			expected: policiesCfg{
+				Deny: []string{"all"},
+			},
+			expectedWarnings: []string{
+				"TLS termination must be configured in TransportServer for IngressMTLS policy default/ingress-mtls",
+			},
+			msg: "ingress mtls policy without tls",
+		},
+		{
+			tsEx: createTransportServerExWithPolicies(
+				[]conf_v1.PolicyReference{
+					{
+						Name: "allow-list",
+					},
+					{
+						Name: "missing",
+					},
+				},
+				map[string]*conf_v1.Policy{
+					"default/allow-list": accessControlPolicy,
+				},
+			),
+			expected: policiesCfg{
+				Deny: []string{"all"},
+			},
+			expectedWarnings: []string{
+				"Policy default/missing is missing or invalid",
+			},
+			msg: "missing policy",
+		},
+		{
+			tsEx: createTransportServerExWithPolicies(
+				[]conf_v1.PolicyReference{
+					{
+						Name: "rate-limit",
+					},
+				},
+				map[string]*conf_v1.Policy{
+					"default/rate-limit": {
+						Spec: conf_v1.PolicySpec{
+							RateLimit: &conf_v1.RateLimit{
+								Key:      "${binary_remote_addr}",
+								ZoneSize: "10M",
+								Rate:     "10r/s",
+							},
+						},
+					},
+				},
+			),
+			expected: policiesCfg{
+				Deny: []string{"all"},
+			},
+			expectedWarnings: []string{
+				"Policy default/rate-limit is not supported in TransportServer",
+			},
+			msg: "unsupported policy",
+		},
+		{
+			tsEx: createTransportServerExWithPolicies(
+				[]conf_v1.PolicyReference{
+					{
+						Name: "connection-limit",
+					},
+				},
+				map[string]*conf_v1.Policy{
+					"default/connection-limit": {
+						Spec: conf_v1.PolicySpec{
+							ConnectionLimit: &conf_v1.ConnectionLimit{
+								Key:            "${request_uri}",
+								ZoneSize:       "10M",
+								MaxConnections: 10,
+							},
+						},
+					},
+				},
+			),
+			expected: policiesCfg{
+				Deny: []string{"all"},
+			},
+			expectedWarnings: []string{
+				"ConnectionLimit policy default/connection-limit with key '${request_uri}' is not supported in TransportServer: only ${binary_remote_addr} variable is allowed",
+			},
+			msg: "connection limit policy with http variable in key",
+		},
+	}
+
+	for _, test := range tests {
+		result, warnings := generateTransportServerPolicies(test.tsEx, test.tls)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("generateTransportServerPolicies() '%s' mismatch (-want +got):\n%s", test.msg, diff)
+		}
+		if diff := cmp.Diff(test.expectedWarnings, warnings[test.tsEx.TransportServer]); diff != "" {
+			t.Errorf("generateTransportServerPolicies() '%s' warnings mismatch (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func createTransportServerExWithTLS(tls *conf_v1.TransportServerTLS, upstreamTLS *conf_v1.TransportServerUpstreamTLS) *TransportServerEx {
+	return &TransportServerEx{
+		TransportServer: &conf_v1.TransportServer{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "tcp-server",
+				Namespace: "default",
+			},
+			Spec: conf_v1.TransportServerSpec{
+				Listener: conf_v1.TransportServerListener{
+					Name:     "tcp-listener",
+					Protocol: "TCP",
+				},
+				TLS: tls,
+				Upstreams: []conf_v1.TransportServerUpstream{
+					{
+						Name:    "tcp-app",
+						Service: "tcp-app-svc",
+						Port:    5001,
+						TLS:     upstreamTLS,
+					},
+				},
+				Action: &conf_v1.TransportServerAction{
+					Pass: "tcp-app",
+				},
+			},
+		},
+		SecretRefs: map[string]*secrets.SecretReference{
+			"default/tls-secret": {
+				Secret: &api_v1.Secret{
+					Type: api_v1.SecretTypeTLS,
+				},
+				Path: "/etc/nginx/secrets/default-tls-secret",
+			},
+			"default/ca-secret": {
+				Secret: &api_v1.Secret{
+					Type: secrets.SecretTypeCA,
+				},
+				Path: "/etc/nginx/secrets/default-ca-secret",
+			},
+			"default/invalid-secret": {
+				Error: errors.New("secret is invalid"),
+			},
+		},
+	}
+}
+
+func TestGenerateTransportServerSSL(t *testing.T) {
+	tests := []struct {
+		tls              *conf_v1.TransportServerTLS
+		expected         *version2.StreamSSL
+		expectedValid    bool
+		expectedWarnings []string
+		msg              string
+	}{
+		{
+			tls:           nil,
+			expected:      nil,
+			expectedValid: true,
+			msg:           "no tls",
+		},
+		{
+			tls: &conf_v1.TransportServerTLS{
+				Secret:    "tls-secret",
+				Protocols: "TLSv1.2 TLSv1.3",
+				Ciphers:   "HIGH:!aNULL:!MD5",
+			},
+			expected: &version2.StreamSSL{
+				Certificate:    "/etc/nginx/secrets/default-tls-secret",
+				CertificateKey: "/etc/nginx/secrets/default-tls-secret",
+				Protocols:      "TLSv1.2 TLSv1.3",
+				Ciphers:        "HIGH:!aNULL:!MD5",
+			},
+			expectedValid: true,
+			msg:           "valid tls secret",
+		},
+		{
+			tls: &conf_v1.TransportServerTLS{
+				Secret: "ca-secret",
+			},
+			expected:      nil,
+			expectedValid: false,
+			expectedWarnings: []string{
+				"TLS secret ca-secret is of a wrong type 'nginx.org/ca', must be 'kubernetes.io/tls'",
+			},
+			msg: "tls secret of a wrong type",
+		},
+		{
+			tls: &conf_v1.TransportServerTLS{
+				Secret: "invalid-secret",
+			},
+			expected:      nil,
+			expectedValid: false,
+			expectedWarnings: []string{
+				"TLS secret invalid-secret is invalid: secret is invalid",
+			},
+			msg: "invalid tls secret",
+		},
+	}
+
+	for _, test := range tests {
+		tsEx := createTransportServerExWithTLS(test.tls, nil)
+		warnings := newWarnings()
+
+		result, valid := generateTransportServerSSL(tsEx, warnings)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("generateTransportServerSSL() '%s' mismatch (-want +got):\n%s", test.msg, diff)
+		}
+		if valid != test.expectedValid {
+			t.Errorf("generateTransportServerSSL() returned %v but expected %v for the case of %s", valid, test.expectedValid, test.msg)
+		}
+		if diff := cmp.Diff(test.expectedWarnings, warnings[tsEx.TransportServer]); diff != "" {
+			t.Errorf("generateTransportServerSSL() '%s' warnings mismatch (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestGenerateTransportServerProxySSL(t *testing.T) {
+	tests := []struct {
+		tls              *conf_v1.TransportServerUpstreamTLS
+		expected         *version2.StreamProxySSL
+		expectedValid    bool
+		expectedWarnings []string
+		msg              string
+	}{
+		{
+			tls: &conf_v1.TransportServerUpstreamTLS{
+				Enable: false,
+			},
+			expected:      nil,
+			expectedValid: true,
+			msg:           "upstream tls is disabled",
+		},
+		{
+			tls: &conf_v1.TransportServerUpstreamTLS{
+				Enable: true,
+			},
+			expected: &version2.StreamProxySSL{
+				VerifyDepth: 1,
+			},
+			expectedValid: true,
+			msg:           "upstream tls with defaults",
+		},
+		{
+			tls: &conf_v1.TransportServerUpstreamTLS{
+				Enable:            true,
+				VerifyServer:      true,
+				TrustedCertSecret: "ca-secret",
+				VerifyDepth:       intPointer(2),
+				Protocols:         "TLSv1.3",
+				Ciphers:           "HIGH",
+				ServerName:        true,
+				SSLName:           "postgres.example.com",
+			},
+			expected: &version2.StreamProxySSL{
+				TrustedCert:  "/etc/nginx/secrets/default-ca-secret",
+				VerifyServer: true,
+				VerifyDepth:  2,
+				Protocols:    "TLSv1.3",
+				Ciphers:      "HIGH",
+				ServerName:   true,
+				SSLName:      "postgres.example.com",
+			},
+			expectedValid: true,
+			msg:           "upstream tls with server verification",
+		},
+		{
+			tls: &conf_v1.TransportServerUpstreamTLS{
+				Enable:            true,
+				VerifyServer:      true,
+				TrustedCertSecret: "tls-secret",
+			},
+			expected:      nil,
+			expectedValid: false,
+			expectedWarnings: []string{
+				"Upstream tcp-app references a secret tls-secret of a wrong type 'kubernetes.io/tls', must be 'nginx.org/ca'",
+			},
+			msg: "trusted cert secret of a wrong type",
+		},
+		{
+			tls: &conf_v1.TransportServerUpstreamTLS{
+				Enable:            true,
+				VerifyServer:      true,
+				TrustedCertSecret: "invalid-secret",
+			},
+			expected:      nil,
+			expectedValid: false,
+			expectedWarnings: []string{
+				"Upstream tcp-app references an invalid secret invalid-secret: secret is invalid",
+			},
+			msg: "invalid trusted cert secret",
+		},
+	}
+
+	for _, test := range tests {
+		tsEx := createTransportServerExWithTLS(nil, test.tls)
+		warnings := newWarnings()
+
+		result, valid := generateTransportServerProxySSL(tsEx, warnings)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("generateTransportServerProxySSL() '%s' mismatch (-want +got):\n%s", test.msg, diff)
+		}
+		if valid != test.expectedValid {
+			t.Errorf("generateTransportServerProxySSL() returned %v but expected %v for the case of %s", valid, test.expectedValid, test.msg)
+		}
+		if diff := cmp.Diff(test.expectedWarnings, warnings[tsEx.TransportServer]); diff != "" {
+			t.Errorf("generateTransportServerProxySSL() '%s' warnings mismatch (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestGenerateTransportServerProxyPass(t *testing.T) {
+	tests := []struct {
+		action               *conf_v1.TransportServerAction
+		tls                  bool
+		expectedProxyPass    string
+		expectedSplitClients []version2.SplitClient
+		expectedMaps         []version2.Map
+		expectedSSLPreread   bool
+		msg                  string
+	}{
+		{
+			action: &conf_v1.TransportServerAction{
+				Pass: "tcp-app",
+			},
+			expectedProxyPass: "ts_default_tcp-server_tcp-app",
+			msg:               "pass",
+		},
+		{
+			action: &conf_v1.TransportServerAction{
+				Splits: []conf_v1.TransportServerSplit{
+					{
+						Weight: 90,
+						Pass:   "tcp-app",
+					},
+					{
+						Weight: 10,
+						Pass:   "tcp-app-v2",
+					},
+				},
+			},
+			expectedProxyPass: "$ts_default_tcp_server_splits",
+			expectedSplitClients: []version2.SplitClient{
+				{
+					Source:   `"${remote_addr}${remote_port}${connection}"`,
+					Variable: "$ts_default_tcp_server_splits",
+					Distributions: []version2.Distribution{
+						{
+							Weight: "90%",
+							Value:  "ts_default_tcp-server_tcp-app",
+						},
+						{
+							Weight: "10%",
+							Value:  "ts_default_tcp-server_tcp-app-v2",
+						},
+					},
+				},
+			},
+			msg: "splits",
+		},
+		{
+			action: &conf_v1.TransportServerAction{
+				Pass: "tcp-app",
+				Matches: []conf_v1.SNIMatch{
+					{
+						SNI:  "v2.example.com",
+						Pass: "tcp-app-v2",
+					},
+				},
+			},
+			expectedProxyPass: "$ts_default_tcp_server_matches",
+			expectedMaps: []version2.Map{
+				{
+					Source:   "$ssl_preread_server_name",
+					Variable: "$ts_default_tcp_server_matches",
+					Parameters: []version2.Parameter{
+						{
+							Value:  `"v2.example.com"`,
+							Result: "ts_default_tcp-server_tcp-app-v2",
+						},
+						{
+							Value:  "default",
+							Result: "ts_default_tcp-server_tcp-app",
+						},
+					},
+				},
+			},
+			expectedSSLPreread: true,
+			msg:                "matches",
+		},
+		{
+			action: &conf_v1.TransportServerAction{
+				Splits: []conf_v1.TransportServerSplit{
+					{
+						Weight: 50,
+						Pass:   "tcp-app",
+					},
+					{
+						Weight: 50,
+						Pass:   "tcp-app-v2",
+					},
+				},
+				Matches: []conf_v1.SNIMatch{
+					{
+						SNI:  "v3.example.com",
+						Pass: "tcp-app-v3",
+					},
+				},
+			},
+			tls:               true,
+			expectedProxyPass: "$ts_default_tcp_server_matches",
+			expectedSplitClients: []version2.SplitClient{
+				{
+					Source:   `"${remote_addr}${remote_port}${connection}"`,
+					Variable: "$ts_default_tcp_server_splits",
+					Distributions: []version2.Distribution{
+						{
+							Weight: "50%",
+							Value:  "ts_default_tcp-server_tcp-app",
+						},
+						{
+							Weight: "50%",
+							Value:  "ts_default_tcp-server_tcp-app-v2",
+						},
+					},
+				},
+			},
+			expectedMaps: []version2.Map{
+				{
+					Source:   "$ssl_server_name",
+					Variable: "$ts_default_tcp_server_matches",
+					Parameters: []version2.Parameter{
+						{
+							Value:  `"v3.example.com"`,
+							Result: "ts_default_tcp-server_tcp-app-v3",
+						},
+						{
+							Value:  "default",
+							Result: "$ts_default_tcp_server_splits",
+						},
+					},
+				},
+			},
+			msg: "matches with splits and TLS termination",
+		},
+	}
+
+	for _, test := range tests {
+		ts := &conf_v1.TransportServer{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "tcp-server",
+				Namespace: "default",
+			},
+			Spec: conf_v1.TransportServerSpec{
+				Action: test.action,
+			},
+		}
+
+		proxyPass, splitClients, maps, sslPreread := generateTransportServerProxyPass(ts, newUpstreamNamerForTransportServer(ts), test.tls)
+		if proxyPass != test.expectedProxyPass {
+			t.Errorf("generateTransportServerProxyPass() returned proxy pass %q but expected %q for the case of %s", proxyPass, test.expectedProxyPass, test.msg)
+		}
+		if diff := cmp.Diff(test.expectedSplitClients, splitClients); diff != "" {
+			t.Errorf("generateTransportServerProxyPass() '%s' split clients mismatch (-want +got):\n%s", test.msg, diff)
+		}
+		if diff := cmp.Diff(test.expectedMaps, maps); diff != "" {
+			t.Errorf("generateTransportServerProxyPass() '%s' maps mismatch (-want +got):\n%s", test.msg, diff)
+		}
+		if sslPreread != test.expectedSSLPreread {
+			t.Errorf("generateTransportServerProxyPass() returned ssl preread %v but expected %v for the case of %s", sslPreread, test.expectedSSLPreread, test.msg)
+		}
+	}
+}
+
+func TestGenerateTransportServerConfigForTCPWithInvalidTLSSecret(t *testing.T) {
+	tsEx := createTransportServerExWithTLS(&conf_v1.TransportServerTLS{Secret: "invalid-secret"}, nil)
+
+	result, warnings := generateTransportServerConfig(tsEx, 2020, false)
+	if result.Server.SSL != nil {
+		t.Errorf("generateTransportServerConfig() returned SSL %+v but expected nil", result.Server.SSL)
+	}
+	if diff := cmp.Diff([]string{"all"}, result.Server.Deny); diff != "" {
+		t.Errorf("generateTransportServerConfig() Deny mismatch (-want +got):\n%s", diff)
+	}
+	if len(warnings[tsEx.TransportServer]) != 1 {
+		t.Errorf("generateTransportServerConfig() returned warnings %v but expected 1 warning", warnings)
+	}
 }
 
 func TestGenerateUnixSocket(t *testing.T) {
 	transportServerEx := &TransportServerEx{
-		TransportServer: &conf_v1alpha1.TransportServer{
+		TransportServer: &conf_v1.TransportServer{
 			ObjectMeta: meta_v1.ObjectMeta{
 				Name:      "tcp-server",
 				Namespace: "default",
 			},
-			Spec: conf_v1alpha1.TransportServerSpec{
-				Listener: conf_v1alpha1.TransportServerListener{
-					Name: "tls-passthrough",
+			Spec: conf_v1.TransportServerSpec{
+				Listener: conf_v1.TransportServerListener{
+					Name:     "tls-passthrough",
+					Protocol: "TLS_PASSTHROUGH",
 				},
 			},
 		},
@@ -513,7 +1197,15 @@ func TestGenerateUnixSocket(t *testing.T) {
 		t.Errorf("generateUnixSocket() returned %q but expected %q", result, expected)
 	}
 
+	transportServerEx.TransportServer.Spec.Listener.Name = "tls-passthrough-8443"
+
+	result = generateUnixSocket(transportServerEx)
+	if result != expected {
+		t.Errorf("generateUnixSocket() returned %q but expected %q", result, expected)
+	}
+
 	transportServerEx.TransportServer.Spec.Listener.Name = "some-listener"
+	transportServerEx.TransportServer.Spec.Listener.Protocol = "TCP"
 	expected = ""
 
 	result = generateUnixSocket(transportServerEx)
@@ -527,16 +1219,16 @@ func TestGenerateTransportServerHealthChecks(t *testing.T) {
 	generatedUpsteamName := "ts_namespace_name_dns-tcp"
 
 	tests := []struct {
-		upstreams     []conf_v1alpha1.Upstream
+		upstreams     []conf_v1.TransportServerUpstream
 		expectedHC    *version2.StreamHealthCheck
 		expectedMatch *version2.Match
 		msg           string
 	}{
 		{
-			upstreams: []conf_v1alpha1.Upstream{
+			upstreams: []conf_v1.TransportServerUpstream{
 				{
 					Name: "dns-tcp",
-					HealthCheck: &conf_v1alpha1.HealthCheck{
+					HealthCheck: &conf_v1.TransportServerHealthCheck{
 						Enabled:  false,
 						Timeout:  "30s",
 						Jitter:   "30s",
@@ -552,10 +1244,10 @@ func TestGenerateTransportServerHealthChecks(t *testing.T) {
 			msg:           "health checks disabled",
 		},
 		{
-			upstreams: []conf_v1alpha1.Upstream{
+			upstreams: []conf_v1.TransportServerUpstream{
 				{
 					Name:        "dns-tcp",
-					HealthCheck: &conf_v1alpha1.HealthCheck{},
+					HealthCheck: &conf_v1.TransportServerHealthCheck{},
 				},
 			},
 			expectedHC:    nil,
@@ -563,10 +1255,10 @@ func TestGenerateTransportServerHealthChecks(t *testing.T) {
 			msg:           "empty health check",
 		},
 		{
-			upstreams: []conf_v1alpha1.Upstream{
+			upstreams: []conf_v1.TransportServerUpstream{
 				{
 					Name: "dns-tcp",
-					HealthCheck: &conf_v1alpha1.HealthCheck{
+					HealthCheck: &conf_v1.TransportServerHealthCheck{
 						Enabled:  true,
 						Timeout:  "40s",
 						Jitter:   "30s",
@@ -590,10 +1282,10 @@ func TestGenerateTransportServerHealthChecks(t *testing.T) {
 			msg:           "valid health checks",
 		},
 		{
-			upstreams: []conf_v1alpha1.Upstream{
+			upstreams: []conf_v1.TransportServerUpstream{
 				{
 					Name: "dns-tcp",
-					HealthCheck: &conf_v1alpha1.HealthCheck{
+					HealthCheck: &conf_v1.TransportServerHealthCheck{
 						Enabled:  true,
 						Timeout:  "40s",
 						Jitter:   "30s",
@@ -605,7 +1297,7 @@ func TestGenerateTransportServerHealthChecks(t *testing.T) {
 				},
 				{
 					Name: "dns-tcp-2",
-					HealthCheck: &conf_v1alpha1.HealthCheck{
+					HealthCheck: &conf_v1.TransportServerHealthCheck{
 						Enabled:  false,
 						Timeout:  "50s",
 						Jitter:   "60s",
@@ -629,11 +1321,11 @@ func TestGenerateTransportServerHealthChecks(t *testing.T) {
 			msg:           "valid 2 health checks",
 		},
 		{
-			upstreams: []conf_v1alpha1.Upstream{
+			upstreams: []conf_v1.TransportServerUpstream{
 				{
 					Name: "dns-tcp",
 					Port: 90,
-					HealthCheck: &conf_v1alpha1.HealthCheck{
+					HealthCheck: &conf_v1.TransportServerHealthCheck{
 						Enabled: true,
 					},
 				},
@@ -651,13 +1343,13 @@ func TestGenerateTransportServerHealthChecks(t *testing.T) {
 			msg:           "return default values for health check",
 		},
 		{
-			upstreams: []conf_v1alpha1.Upstream{
+			upstreams: []conf_v1.TransportServerUpstream{
 				{
 					Name: "dns-tcp",
 					Port: 90,
-					HealthCheck: &conf_v1alpha1.HealthCheck{
+					HealthCheck: &conf_v1.TransportServerHealthCheck{
 						Enabled: true,
-						Match: &conf_v1alpha1.Match{
+						Match: &conf_v1.TransportServerMatch{
 							Send:   `GET / HTTP/1.0\r\nHost: localhost\r\n\r\n`,
 							Expect: "~*200 OK",
 						},
@@ -697,12 +1389,12 @@ func TestGenerateTransportServerHealthChecks(t *testing.T) {
 
 func TestGenerateHealthCheckMatch(t *testing.T) {
 	tests := []struct {
-		match    *conf_v1alpha1.Match
+		match    *conf_v1.TransportServerMatch
 		expected *version2.Match
 		msg      string
 	}{
 		{
-			match: &conf_v1alpha1.Match{
+			match: &conf_v1.TransportServerMatch{
 				Send:   "",
 				Expect: "",
 			},
@@ -715,7 +1407,7 @@ func TestGenerateHealthCheckMatch(t *testing.T) {
 			msg: "match with empty fields",
 		},
 		{
-			match: &conf_v1alpha1.Match{
+			match: &conf_v1.TransportServerMatch{
 				Send:   "xxx",
 				Expect: "yyy",
 			},
@@ -728,7 +1420,7 @@ func TestGenerateHealthCheckMatch(t *testing.T) {
 			msg: "match with all fields and no regexp",
 		},
 		{
-			match: &conf_v1alpha1.Match{
+			match: &conf_v1.TransportServerMatch{
 				Send:   "xxx",
 				Expect: "~yyy",
 			},
@@ -741,7 +1433,7 @@ func TestGenerateHealthCheckMatch(t *testing.T) {
 			msg: "match with all fields and case sensitive regexp",
 		},
 		{
-			match: &conf_v1alpha1.Match{
+			match: &conf_v1.TransportServerMatch{
 				Send:   "xxx",
 				Expect: "~*yyy",
 			},
@@ -12,14 +12,19 @@ type UpstreamLabels struct {
 
 // VirtualServerConfig holds NGINX configuration for a VirtualServer.
 type VirtualServerConfig struct {
-	HTTPSnippets  []string
-	LimitReqZones []LimitReqZone
-	Maps          []Map
-	Server        Server
-	SpiffeCerts   bool
-	SplitClients  []SplitClient
-	StatusMatches []StatusMatch
-	Upstreams     []Upstream
+	CacheZones     []CacheZone
+	HTTPSnippets   []string
+	LimitReqZones  []LimitReqZone
+	LimitConnZones []LimitConnZone
+	Maps           []Map
+	// PolicyCacheZones are the cache zones of the cache policies. They are shared by all VirtualServers that
+	// reference a policy, so they are configured separately from the VirtualServers.
+	PolicyCacheZones []CacheZone
+	Server           Server
+	SpiffeCerts      bool
+	SplitClients     []SplitClient
+	StatusMatches    []StatusMatch
+	Upstreams        []Upstream
 }
 
 // Upstream defines an upstream.
@@ -59,7 +64,10 @@ type Server struct {
 	InternalRedirectLocations []InternalRedirectLocation
 	Locations                 []Location
 	ErrorPageLocations        []ErrorPageLocation
+	ExternalAuthLocations     []ExternalAuthLocation
 	ReturnLocations           []ReturnLocation
+	MirrorLocations           []MirrorLocation
+	FaultLocations            []FaultLocation
 	HealthChecks              []HealthCheck
 	TLSRedirect               *TLSRedirect
 	TLSPassthrough            bool
@@ -67,7 +75,13 @@ type Server struct {
 	Deny                      []string
 	LimitReqOptions           LimitReqOptions
 	LimitReqs                 []LimitReq
+	LimitConnOptions          LimitConnOptions
+	LimitConns                []LimitConn
 	JWTAuth                   *JWTAuth
+	BasicAuth                 *BasicAuth
+	ExternalAuth              *ExternalAuth
+	CORS                      *CORS
+	Cache                     *Cache
 	IngressMTLS               *IngressMTLS
 	EgressMTLS                *EgressMTLS
 	OIDC                      *OIDC
@@ -173,7 +187,13 @@ type Location struct {
 	Deny                     []string
 	LimitReqOptions          LimitReqOptions
 	LimitReqs                []LimitReq
+	LimitConnOptions         LimitConnOptions
+	LimitConns               []LimitConn
 	JWTAuth                  *JWTAuth
+	BasicAuth                *BasicAuth
+	ExternalAuth             *ExternalAuth
+	CORS                     *CORS
+	Cache                    *Cache
 	EgressMTLS               *EgressMTLS
 	OIDC                     bool
 	WAF                      *WAF
@@ -184,6 +204,27 @@ type Location struct {
 	VSRName                  string
 	VSRNamespace             string
 	GRPCPass                 string
+	Mirror                   *Mirror
+	RetryEligibility         *RetryEligibility
+	FaultLocation            string
+}
+
+// FaultLocation defines a named location that injects a fault into the requests.
+// The location delays the requests by Delay and then passes them to DelayedLocation. Without a delay,
+// the location returns AbortCode right away. If both are set, DelayedLocation returns AbortCode.
+// The requests get to the location from a Location with FaultLocation set, after the policies of that Location.
+type FaultLocation struct {
+	Path            string
+	Delay           string
+	DelayedLocation string
+	AbortCode       int
+}
+
+// RetryEligibility restricts the retries of a location to the requests for which Variable is not empty.
+// The location rewrites the other requests to FallbackLocation, which doesn't retry them.
+type RetryEligibility struct {
+	Variable         string
+	FallbackLocation string
 }
 
 // ReturnLocation defines a location for returning a fixed response.
@@ -193,6 +234,26 @@ type ReturnLocation struct {
 	Return      Return
 }
 
+// Mirror defines the mirroring of the requests of a location to a MirrorLocation.
+type Mirror struct {
+	Location MirrorLocation
+}
+
+// MirrorLocation defines an internal location that sends the mirrored requests to an upstream.
+// If SampleVariable is set, only the requests for which the variable is not empty are sent.
+// EgressMTLS holds the SSL settings of the mirrored location, which the internal location doesn't inherit.
+// If RequestBody is false, the location doesn't wait for the request body, which is not mirrored.
+type MirrorLocation struct {
+	Path           string
+	ProxyPass      string
+	ProxySSLName   string
+	HasKeepalive   bool
+	SampleVariable string
+	Percentage     int
+	RequestBody    bool
+	EgressMTLS     *EgressMTLS
+}
+
 // SplitClient defines a split_clients.
 type SplitClient struct {
 	Source        string
@@ -321,6 +382,32 @@ func (rlz LimitReqZone) String() string {
 	return fmt.Sprintf("{Key %q, ZoneName %q, ZoneSize %v, Rate %q}", rlz.Key, rlz.ZoneName, rlz.ZoneSize, rlz.Rate)
 }
 
+// CacheZone defines a proxy cache with its shared memory zone.
+type CacheZone struct {
+	Name     string
+	Path     string
+	Size     string
+	MaxSize  string
+	Inactive string
+}
+
+// Cache holds response caching configuration of a location.
+type Cache struct {
+	ZoneName             string
+	Key                  string
+	Valid                []CacheValid
+	Methods              string
+	Bypass               []string
+	Lock                 bool
+	StaleWhileRevalidate bool
+}
+
+// CacheValid defines the caching time for responses with the status codes.
+type CacheValid struct {
+	Codes []int
+	Time  string
+}
+
 // LimitReq defines a rate limit.
 type LimitReq struct {
 	ZoneName string
@@ -344,9 +431,82 @@ func (rl LimitReqOptions) String() string {
 	return fmt.Sprintf("{DryRun %v, LogLevel %q, RejectCode %q}", rl.DryRun, rl.LogLevel, rl.RejectCode)
 }
 
+// LimitConnZone defines a connection limit shared memory zone.
+type LimitConnZone struct {
+	Key      string
+	ZoneName string
+	ZoneSize string
+}
+
+// LimitConn defines a connection limit.
+type LimitConn struct {
+	ZoneName    string
+	Connections int
+}
+
+// LimitConnOptions defines connection limit options.
+type LimitConnOptions struct {
+	DryRun     bool
+	LogLevel   string
+	RejectCode int
+}
+
 // JWTAuth holds JWT authentication configuration.
 type JWTAuth struct {
 	Secret string
 	Realm  string
 	Token  string
 }
+
+// ExternalAuth holds external authorization configuration of a location.
+type ExternalAuth struct {
+	Location        string
+	ResponseHeaders []ExternalAuthResponseHeader
+	SigninLocation  string
+}
+
+// ExternalAuthResponseHeader defines a header of the response of the authorization service,
+// which is passed to the upstream in the Variable.
+type ExternalAuthResponseHeader struct {
+	Name             string
+	Variable         string
+	UpstreamVariable string
+}
+
+// ExternalAuthLocation defines an internal location that sends the authorization subrequests to the authorization service
+// and the optional location that redirects unauthorized clients to the sign-in URL.
+type ExternalAuthLocation struct {
+	Path           string
+	ProxyPass      string
+	ForwardHeaders []ExternalAuthForwardHeader
+	CacheZone      string
+	CacheKey       string
+	CacheValid     string
+	SigninLocation string
+	SigninURL      string
+}
+
+// ExternalAuthForwardHeader defines a header of the client request which is passed to the authorization service.
+type ExternalAuthForwardHeader struct {
+	Name     string
+	Variable string
+}
+
+// CORS holds Cross-Origin Resource Sharing configuration of a location.
+// OriginVariable holds the origin of the request if the origin is allowed and is empty otherwise.
+// PreflightVariable is 1 for preflight requests from allowed origins and 0 otherwise.
+type CORS struct {
+	OriginVariable    string
+	PreflightVariable string
+	AllowMethods      string
+	AllowHeaders      string
+	ExposeHeaders     string
+	AllowCredentials  bool
+	MaxAge            string
+}
+
+// BasicAuth holds HTTP Basic authentication configuration.
+type BasicAuth struct {
+	Secret string
+	Realm  string
+}
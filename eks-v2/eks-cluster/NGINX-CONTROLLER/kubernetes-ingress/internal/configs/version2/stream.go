@@ -6,6 +6,9 @@ type TransportServerConfig struct {
 	Upstreams      []StreamUpstream
 	StreamSnippets []string
 	Match          *Match
+	LimitConnZones []LimitConnZone
+	SplitClients   []SplitClient
+	Maps           []Map
 }
 
 // StreamUpstream defines a stream upstream.
@@ -34,6 +37,8 @@ type StreamServer struct {
 	ProxyRequests            *int
 	ProxyResponses           *int
 	ProxyPass                string
+	ProxyProtocol            bool
+	SSLPreread               bool
 	Name                     string
 	Namespace                string
 	ProxyTimeout             string
@@ -43,6 +48,32 @@ type StreamServer struct {
 	ProxyNextUpstreamTries   int
 	HealthCheck              *StreamHealthCheck
 	ServerSnippets           []string
+	Allow                    []string
+	Deny                     []string
+	LimitConnOptions         LimitConnOptions
+	LimitConns               []LimitConn
+	IngressMTLS              *IngressMTLS
+	SSL                      *StreamSSL
+	ProxySSL                 *StreamProxySSL
+}
+
+// StreamSSL defines TLS termination for a StreamServer.
+type StreamSSL struct {
+	Certificate    string
+	CertificateKey string
+	Protocols      string
+	Ciphers        string
+}
+
+// StreamProxySSL defines TLS configuration for connections from a StreamServer to its upstream.
+type StreamProxySSL struct {
+	TrustedCert  string
+	VerifyServer bool
+	VerifyDepth  int
+	Protocols    string
+	Ciphers      string
+	ServerName   bool
+	SSLName      string
 }
 
 // StreamHealthCheck defines a health check for a StreamUpstream in a StreamServer.
@@ -67,3 +98,17 @@ type Match struct {
 
 // TLSPassthroughHostsConfig defines a mapping between TLS Passthrough hosts and the corresponding unix sockets.
 type TLSPassthroughHostsConfig map[string]string
+
+// TLSPassthroughListenerConfig defines a custom TLS Passthrough listener defined in the GlobalConfiguration.
+// Variable is the map variable which holds the unix socket for the host of a connection.
+// ProxyProtocol and SetRealIPFrom come from the ConfigMap, so that the listener accepts the PROXY protocol
+// the same way as the HTTPS listener.
+type TLSPassthroughListenerConfig struct {
+	Name              string
+	Port              int
+	Variable          string
+	DefaultUnixSocket string
+	Hosts             TLSPassthroughHostsConfig
+	ProxyProtocol     bool
+	SetRealIPFrom     []string
+}
@@ -13,11 +13,45 @@ const tlsPassthroughHostsTemplateString = `# mapping between TLS Passthrough hos
 {{ end }}
 `
 
+// #nosec G101
+const tlsPassthroughListenerTemplateString = `# configuration for TLS Passthrough listener {{ .Name }}
+map $ssl_preread_server_name {{ .Variable }} {
+    default {{ .DefaultUnixSocket }};
+    {{ range $h, $u := .Hosts }}
+    {{ $h }} {{ $u }};
+    {{ end }}
+}
+
+server {
+    listen {{ .Port }}{{ if .ProxyProtocol }} proxy_protocol{{ end }};
+    listen [::]:{{ .Port }}{{ if .ProxyProtocol }} proxy_protocol{{ end }};
+
+    {{ if .ProxyProtocol }}
+    {{ range $ip := .SetRealIPFrom }}
+    set_real_ip_from {{ $ip }};
+    {{ end }}
+    {{ end }}
+
+    ssl_preread on;
+
+    proxy_protocol on;
+    proxy_pass {{ .Variable }};
+}
+`
+
+const policyCacheZonesTemplateString = `# cache zones of the cache policies
+{{ range $z := . }}
+proxy_cache_path {{ $z.Path }} levels=1:2 keys_zone={{ $z.Name }}:{{ $z.Size }}{{ if $z.MaxSize }} max_size={{ $z.MaxSize }}{{ end }}{{ if $z.Inactive }} inactive={{ $z.Inactive }}{{ end }};
+{{ end }}
+`
+
 // TemplateExecutor executes NGINX configuration templates.
 type TemplateExecutor struct {
 	virtualServerTemplate       *template.Template
 	transportServerTemplate     *template.Template
-	tlsPassthroughHostsTemplate *template.Template
+	tlsPassthroughHostsTemplate    *template.Template
+	tlsPassthroughListenerTemplate *template.Template
+	policyCacheZonesTemplate       *template.Template
 }
 
 // NewTemplateExecutor creates a TemplateExecutor.
@@ -39,10 +73,22 @@ func NewTemplateExecutor(virtualServerTemplatePath string, transportServerTempla
 		return nil, err
 	}
 
+	tlsPassthroughListenerTemplate, err := template.New("tlsPassthroughListener").Parse(tlsPassthroughListenerTemplateString)
+	if err != nil {
+		return nil, err
+	}
+
+	policyCacheZonesTemplate, err := template.New("policyCacheZones").Parse(policyCacheZonesTemplateString)
+	if err != nil {
+		return nil, err
+	}
+
 	return &TemplateExecutor{
-		virtualServerTemplate:       vsTemplate,
-		transportServerTemplate:     tsTemplate,
-		tlsPassthroughHostsTemplate: tlsPassthroughHostsTemplate,
+		virtualServerTemplate:          vsTemplate,
+		transportServerTemplate:        tsTemplate,
+		tlsPassthroughHostsTemplate:    tlsPassthroughHostsTemplate,
+		tlsPassthroughListenerTemplate: tlsPassthroughListenerTemplate,
+		policyCacheZonesTemplate:       policyCacheZonesTemplate,
 	}, nil
 }
 
@@ -81,3 +127,21 @@ func (te *TemplateExecutor) ExecuteTLSPassthroughHostsTemplate(cfg *TLSPassthrou
 
 	return configBuffer.Bytes(), err
 }
+
+// ExecuteTLSPassthroughListenerTemplate generates the content of an NGINX configuration file for a custom
+// TLS Passthrough listener.
+func (te *TemplateExecutor) ExecuteTLSPassthroughListenerTemplate(cfg *TLSPassthroughListenerConfig) ([]byte, error) {
+	var configBuffer bytes.Buffer
+	err := te.tlsPassthroughListenerTemplate.Execute(&configBuffer, cfg)
+
+	return configBuffer.Bytes(), err
+}
+
+// ExecutePolicyCacheZonesTemplate generates the content of an NGINX configuration file for the cache zones
+// of the cache policies.
+func (te *TemplateExecutor) ExecutePolicyCacheZonesTemplate(zones []CacheZone) ([]byte, error) {
+	var configBuffer bytes.Buffer
+	err := te.policyCacheZonesTemplate.Execute(&configBuffer, zones)
+
+	return configBuffer.Bytes(), err
+}
@@ -1,6 +1,7 @@
 package version2
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -17,6 +18,19 @@ var virtualServerCfg = VirtualServerConfig{
 			ZoneName: "pol_rl_test_test_test", Rate: "10r/s", ZoneSize: "10m", Key: "$url",
 		},
 	},
+	LimitConnZones: []LimitConnZone{
+		{
+			ZoneName: "pol_cl_test_test_test", ZoneSize: "10m", Key: "$binary_remote_addr",
+		},
+	},
+	CacheZones: []CacheZone{
+		{
+			Name: "ext_auth_test_test_test_test", Path: "/var/cache/nginx/ext_auth_test_test_test_test", Size: "1m", Inactive: "30s",
+		},
+		{
+			Name: "pol_cache_test_test_test_test", Path: "/var/cache/nginx/pol_cache_test_test_test_test", Size: "10m", MaxSize: "1g",
+		},
+	},
 	Upstreams: []Upstream{
 		{
 			Name: "test-upstream",
@@ -76,8 +90,50 @@ var virtualServerCfg = VirtualServerConfig{
 				},
 			},
 		},
+		{
+			Source:   "$request_id",
+			Variable: "$vs_default_cafe_mirror_10",
+			Distributions: []Distribution{
+				{
+					Weight: "10%",
+					Value:  "1",
+				},
+				{
+					Weight: "*",
+					Value:  `""`,
+				},
+			},
+		},
 	},
 	Maps: []Map{
+		{
+			Source:   "$http_origin",
+			Variable: "$cors_origin_default_cors_test_test",
+			Parameters: []Parameter{
+				{
+					Value:  `"https://app.example.com"`,
+					Result: "$http_origin",
+				},
+				{
+					Value:  "default",
+					Result: `""`,
+				},
+			},
+		},
+		{
+			Source:   "$request_method:$http_access_control_request_method:$cors_origin_default_cors_test_test",
+			Variable: "$cors_preflight_default_cors_test_test",
+			Parameters: []Parameter{
+				{
+					Value:  `"~^OPTIONS:[^:]+:."`,
+					Result: "1",
+				},
+				{
+					Value:  "default",
+					Result: "0",
+				},
+			},
+		},
 		{
 			Source:   "$match_0_0",
 			Variable: "$match",
@@ -138,6 +194,16 @@ var virtualServerCfg = VirtualServerConfig{
 			LogLevel:   "error",
 			RejectCode: 503,
 		},
+		LimitConns: []LimitConn{
+			{
+				ZoneName:    "pol_cl_test_test_test",
+				Connections: 10,
+			},
+		},
+		LimitConnOptions: LimitConnOptions{
+			LogLevel:   "error",
+			RejectCode: 503,
+		},
 		JWTAuth: &JWTAuth{
 			Realm:  "My Api",
 			Secret: "jwk-secret",
@@ -152,6 +218,61 @@ var virtualServerCfg = VirtualServerConfig{
 			ApSecurityLogEnable: true,
 			ApLogConf:           "/etc/nginx/waf/nac-logconfs/default-logconf",
 		},
+		ExternalAuth: &ExternalAuth{
+			Location: "/_ext_auth_test_test",
+			ResponseHeaders: []ExternalAuthResponseHeader{
+				{
+					Name:             "X-User",
+					Variable:         "$ext_auth_x_user",
+					UpstreamVariable: "$upstream_http_x_user",
+				},
+			},
+			SigninLocation: "@ext_auth_signin_test_test",
+		},
+		ExternalAuthLocations: []ExternalAuthLocation{
+			{
+				Path:      "/_ext_auth_test_test",
+				ProxyPass: "http://authz.test.svc:8080/check",
+				ForwardHeaders: []ExternalAuthForwardHeader{
+					{
+						Name:     "Authorization",
+						Variable: "$http_authorization",
+					},
+				},
+				CacheZone:      "ext_auth_test_test_test_test",
+				CacheKey:       "$request_method$host$request_uri$http_authorization",
+				CacheValid:     "30s",
+				SigninLocation: "@ext_auth_signin_test_test",
+				SigninURL:      "https://login.example.com/signin?rd=$scheme://$host$request_uri",
+			},
+		},
+		MirrorLocations: []MirrorLocation{
+			{
+				Path:           "/internal_location_mirror_vs_default_cafe_tea-v2_10",
+				ProxyPass:      "http://vs_default_cafe_tea-v2$request_uri",
+				HasKeepalive:   true,
+				SampleVariable: "$vs_default_cafe_mirror_10",
+				Percentage:     10,
+				RequestBody:    true,
+			},
+		},
+		FaultLocations: []FaultLocation{
+			{
+				Path:            "/internal_location_fault_0",
+				Delay:           "5s",
+				DelayedLocation: "@fault_0_delayed",
+			},
+			{
+				Path:            "/internal_location_fault_1",
+				Delay:           "1s",
+				DelayedLocation: "@fault_1_abort",
+				AbortCode:       503,
+			},
+			{
+				Path:      "/internal_location_fault_2",
+				AbortCode: 500,
+			},
+		},
 		Snippets: []string{"# server snippet"},
 		InternalRedirectLocations: []InternalRedirectLocation{
 			{
@@ -195,11 +316,62 @@ var virtualServerCfg = VirtualServerConfig{
 				Snippets: []string{"# location snippet"},
 				Allow:    []string{"127.0.0.1"},
 				Deny:     []string{"127.0.0.1"},
+				Mirror: &Mirror{
+					Location: MirrorLocation{
+						Path:           "/internal_location_mirror_vs_default_cafe_tea-v2_10",
+						ProxyPass:      "http://vs_default_cafe_tea-v2$request_uri",
+						HasKeepalive:   true,
+						SampleVariable: "$vs_default_cafe_mirror_10",
+						Percentage:     10,
+						RequestBody:    true,
+					},
+				},
 				LimitReqs: []LimitReq{
 					{
 						ZoneName: "loc_pol_rl_test_test_test",
 					},
 				},
+				LimitConns: []LimitConn{
+					{
+						ZoneName:    "loc_pol_cl_test_test_test",
+						Connections: 5,
+					},
+				},
+				LimitConnOptions: LimitConnOptions{
+					DryRun:     true,
+					LogLevel:   "info",
+					RejectCode: 429,
+				},
+				BasicAuth: &BasicAuth{
+					Realm:  "My Dashboard",
+					Secret: "htpasswd-secret",
+				},
+				Cache: &Cache{
+					ZoneName: "pol_cache_test_test_test_test",
+					Key:      "${scheme}${host}${request_uri}",
+					Valid: []CacheValid{
+						{
+							Codes: []int{200, 301},
+							Time:  "10m",
+						},
+						{
+							Time: "1m",
+						},
+					},
+					Methods:              "GET HEAD POST",
+					Bypass:               []string{"${cookie_nocache}", "${http_pragma}"},
+					Lock:                 true,
+					StaleWhileRevalidate: true,
+				},
+				CORS: &CORS{
+					OriginVariable:    "$cors_origin_default_cors_test_test",
+					PreflightVariable: "$cors_preflight_default_cors_test_test",
+					AllowMethods:      "GET, POST",
+					AllowHeaders:      "Authorization, Content-Type",
+					ExposeHeaders:     "X-Request-ID",
+					AllowCredentials:  true,
+					MaxAge:            "600",
+				},
 				ProxyConnectTimeout:      "30s",
 				ProxyReadTimeout:         "31s",
 				ProxySendTimeout:         "32s",
@@ -249,6 +421,10 @@ var virtualServerCfg = VirtualServerConfig{
 				ProxyNextUpstream:        "error timeout",
 				ProxyNextUpstreamTimeout: "5s",
 				ProxyInterceptErrors:     true,
+				RetryEligibility: &RetryEligibility{
+					Variable:         "$retry_methods_default_retry_default_cafe",
+					FallbackLocation: "/internal_location_retry_fallback_0",
+				},
 				ErrorPages: []ErrorPage{
 					{
 						Name:         "@error_page_1",
@@ -373,6 +549,43 @@ var transportServerCfg = TransportServerConfig{
 		ExpectRegexModifier: "~*",
 		Expect:              "200 OK",
 	},
+	LimitConnZones: []LimitConnZone{
+		{
+			ZoneName: "ts_pol_cl_test_test_test_test", ZoneSize: "10m", Key: "${binary_remote_addr}",
+		},
+	},
+	SplitClients: []SplitClient{
+		{
+			Source:   `"${remote_addr}${remote_port}${connection}"`,
+			Variable: "$ts_default_tcp_app_splits",
+			Distributions: []Distribution{
+				{
+					Weight: "90%",
+					Value:  "ts_default_tcp-app_tcp-app",
+				},
+				{
+					Weight: "10%",
+					Value:  "ts_default_tcp-app_tcp-app-v2",
+				},
+			},
+		},
+	},
+	Maps: []Map{
+		{
+			Source:   "$ssl_preread_server_name",
+			Variable: "$ts_default_tcp_app_matches",
+			Parameters: []Parameter{
+				{
+					Value:  `"v3.example.com"`,
+					Result: "ts_default_tcp-app_tcp-app-v3",
+				},
+				{
+					Value:  "default",
+					Result: "$ts_default_tcp_app_splits",
+				},
+			},
+		},
+	},
 	Server: StreamServer{
 		Port:                     1234,
 		UDP:                      true,
@@ -380,6 +593,8 @@ var transportServerCfg = TransportServerConfig{
 		ProxyRequests:            createPointerFromInt(1),
 		ProxyResponses:           createPointerFromInt(2),
 		ProxyPass:                "udp-upstream",
+		ProxyProtocol:            true,
+		SSLPreread:               true,
 		ProxyTimeout:             "10s",
 		ProxyConnectTimeout:      "10s",
 		ProxyNextUpstream:        true,
@@ -395,6 +610,37 @@ var transportServerCfg = TransportServerConfig{
 			Fails:    1,
 			Match:    "match_udp-upstream",
 		},
+		Allow: []string{"10.0.0.0/8"},
+		Deny:  []string{"10.0.0.1"},
+		LimitConnOptions: LimitConnOptions{
+			DryRun:   true,
+			LogLevel: "warn",
+		},
+		LimitConns: []LimitConn{
+			{
+				ZoneName:    "ts_pol_cl_test_test_test_test",
+				Connections: 10,
+			},
+		},
+		IngressMTLS: &IngressMTLS{
+			ClientCert:   "ingress-mtls-secret",
+			VerifyClient: "on",
+			VerifyDepth:  1,
+		},
+		SSL: &StreamSSL{
+			Certificate:    "/etc/nginx/secrets/default-tls-secret",
+			CertificateKey: "/etc/nginx/secrets/default-tls-secret",
+			Protocols:      "TLSv1.2 TLSv1.3",
+			Ciphers:        "HIGH:!aNULL:!MD5",
+		},
+		ProxySSL: &StreamProxySSL{
+			TrustedCert:  "/etc/nginx/secrets/default-ca-secret",
+			VerifyServer: true,
+			VerifyDepth:  2,
+			Protocols:    "TLSv1.3",
+			ServerName:   true,
+			SSLName:      "postgres.example.com",
+		},
 	},
 }
 
@@ -430,6 +676,52 @@ func TestVirtualServerForNginx(t *testing.T) {
 	t.Log(string(data))
 }
 
+func TestVirtualServerMirrorWithoutRequestBody(t *testing.T) {
+	mirror := MirrorLocation{
+		Path:        "/internal_location_mirror_vs_default_cafe_tea-v2_no_body",
+		ProxyPass:   "http://vs_default_cafe_tea-v2$request_uri",
+		RequestBody: false,
+	}
+	cfg := VirtualServerConfig{
+		Server: Server{
+			ServerName: "cafe.example.com",
+			Locations: []Location{
+				{
+					Path:      "/upload",
+					ProxyPass: "http://vs_default_cafe_tea",
+					Mirror:    &Mirror{Location: mirror},
+				},
+			},
+			MirrorLocations: []MirrorLocation{mirror},
+		},
+	}
+
+	expectedDirectives := []string{
+		"mirror_request_body off;",
+		"proxy_pass_request_body off;",
+		`proxy_set_header Content-Length "";`,
+	}
+
+	for _, tmpl := range []string{nginxVirtualServerTmpl, nginxPlusVirtualServerTmpl} {
+		executor, err := NewTemplateExecutor(tmpl, nginxTransportServerTmpl)
+		if err != nil {
+			t.Fatalf("Failed to create template executor: %v", err)
+		}
+
+		data, err := executor.ExecuteVirtualServerTemplate(&cfg)
+		if err != nil {
+			t.Fatalf("Failed to execute template %s: %v", tmpl, err)
+		}
+
+		// POST and PUT requests hang in the mirror location unless it drops the body and its Content-Length
+		for _, d := range expectedDirectives {
+			if !strings.Contains(string(data), d) {
+				t.Errorf("Template %s didn't generate the directive %q for a mirror without the request body", tmpl, d)
+			}
+		}
+	}
+}
+
 func TestTransportServerForNginxPlus(t *testing.T) {
 	executor, err := NewTemplateExecutor(nginxPlusVirtualServerTmpl, nginxPlusTransportServerTmpl)
 	if err != nil {
@@ -475,3 +767,52 @@ func TestTLSPassthroughHosts(t *testing.T) {
 
 	t.Log(string(data))
 }
+
+func TestTLSPassthroughListener(t *testing.T) {
+	executor, err := NewTemplateExecutor(nginxVirtualServerTmpl, nginxTransportServerTmpl)
+	if err != nil {
+		t.Fatalf("Failed to create template executor: %v", err)
+	}
+
+	listenerCfg := TLSPassthroughListenerConfig{
+		Name:              "tls-passthrough-8443",
+		Port:              8443,
+		Variable:          "$dest_passthrough_tls_passthrough_8443",
+		DefaultUnixSocket: "unix:/var/lib/nginx/non-existing-unix-socket.sock",
+		Hosts: TLSPassthroughHostsConfig{
+			"app.example.com": "unix:/var/lib/nginx/passthrough-default_secure-app.sock",
+		},
+		ProxyProtocol: true,
+		SetRealIPFrom: []string{"10.0.0.0/8"},
+	}
+
+	data, err := executor.ExecuteTLSPassthroughListenerTemplate(&listenerCfg)
+	if err != nil {
+		t.Fatalf("Failed to execute template: %v", err)
+	}
+
+	t.Log(string(data))
+}
+
+func TestPolicyCacheZones(t *testing.T) {
+	executor, err := NewTemplateExecutor(nginxVirtualServerTmpl, nginxTransportServerTmpl)
+	if err != nil {
+		t.Fatalf("Failed to create template executor: %v", err)
+	}
+
+	zones := []CacheZone{
+		{
+			Name:    "pol_cache_default_cache-policy",
+			Path:    "/var/cache/nginx/pol_cache_default_cache-policy",
+			Size:    "10m",
+			MaxSize: "1g",
+		},
+	}
+
+	data, err := executor.ExecutePolicyCacheZonesTemplate(zones)
+	if err != nil {
+		t.Fatalf("Failed to execute template: %v", err)
+	}
+
+	t.Log(string(data))
+}
@@ -2,13 +2,14 @@ package configs
 
 import (
 	"fmt"
+	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/golang/glog"
 	"github.com/nginxinc/kubernetes-ingress/internal/k8s/secrets"
 	"github.com/nginxinc/kubernetes-ingress/internal/nginx"
-	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
 	api_v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
@@ -87,6 +88,8 @@ type VirtualServerEx struct {
 	LogConfRefs         map[string]*unstructured.Unstructured
 	DosProtectedRefs    map[string]*unstructured.Unstructured
 	DosProtectedEx      map[string]*DosEx
+	// CanaryWeights holds the weights of the second split of the routes with a canary, indexed by the path of the route.
+	CanaryWeights map[string]int
 }
 
 func (vsx *VirtualServerEx) String() string {
@@ -127,6 +130,19 @@ func GenerateEndpointsKey(
 	return fmt.Sprintf("%s/%s:%d", serviceNamespace, serviceName, port)
 }
 
+// GenerateUpstreamNameFromAction generates the name of the NGINX upstream that the action of a route passes requests to.
+// virtualServerRoute must be nil if the route belongs to the VirtualServer.
+func GenerateUpstreamNameFromAction(
+	virtualServer *conf_v1.VirtualServer,
+	virtualServerRoute *conf_v1.VirtualServerRoute,
+	action *conf_v1.Action,
+) string {
+	if virtualServerRoute != nil {
+		return newUpstreamNamerForVirtualServerRoute(virtualServer, virtualServerRoute).GetNameForUpstreamFromAction(action)
+	}
+	return newUpstreamNamerForVirtualServer(virtualServer).GetNameForUpstreamFromAction(action)
+}
+
 type upstreamNamer struct {
 	prefix    string
 	namespace string
@@ -155,7 +171,7 @@ func newUpstreamNamerForVirtualServerRoute(
 	}
 }
 
-func newUpstreamNamerForTransportServer(transportServer *conf_v1alpha1.TransportServer) *upstreamNamer {
+func newUpstreamNamerForTransportServer(transportServer *conf_v1.TransportServer) *upstreamNamer {
 	return &upstreamNamer{
 		prefix: fmt.Sprintf("ts_%s_%s", transportServer.Namespace, transportServer.Name),
 	}
@@ -191,6 +207,18 @@ func (namer *variableNamer) GetNameForSplitClientVariable(index int) string {
 	return fmt.Sprintf("$vs_%s_splits_%d", namer.safeNsName, index)
 }
 
+func (namer *variableNamer) GetNameForMirrorSampleVariable(percentage int) string {
+	return fmt.Sprintf("$vs_%s_mirror_%d", namer.safeNsName, percentage)
+}
+
+func (namer *variableNamer) GetNameForFaultSampleVariable(index int) string {
+	return fmt.Sprintf("$vs_%s_fault_%d_sample", namer.safeNsName, index)
+}
+
+func (namer *variableNamer) GetNameForFaultHeaderVariable(index int) string {
+	return fmt.Sprintf("$vs_%s_fault_%d_header", namer.safeNsName, index)
+}
+
 func (namer *variableNamer) GetNameForVariableForMatchesRouteMap(
 	matchesIndex int,
 	matchIndex int,
@@ -199,6 +227,15 @@ func (namer *variableNamer) GetNameForVariableForMatchesRouteMap(
 	return fmt.Sprintf("$vs_%s_matches_%d_match_%d_cond_%d", namer.safeNsName, matchesIndex, matchIndex, conditionIndex)
 }
 
+func (namer *variableNamer) GetNameForVariableForMatchesRouteMapAlternative(
+	matchesIndex int,
+	matchIndex int,
+	conditionIndex int,
+	alternativeIndex int,
+) string {
+	return fmt.Sprintf("$vs_%s_matches_%d_match_%d_cond_%d_any_%d", namer.safeNsName, matchesIndex, matchIndex, conditionIndex, alternativeIndex)
+}
+
 func (namer *variableNamer) GetNameForVariableForMatchesRouteMainMap(matchesIndex int) string {
 	return fmt.Sprintf("$vs_%s_matches_%d", namer.safeNsName, matchesIndex)
 }
@@ -279,6 +316,39 @@ func newVirtualServerConfigurator(
 	}
 }
 
+// generateExternalAuthUpstreams generates the upstreams of the authorization services of the externalAuth policies
+// referenced by the VirtualServer and its VirtualServerRoutes.
+func (vsc *virtualServerConfigurator) generateExternalAuthUpstreams(virtualServerEx *VirtualServerEx) []version2.Upstream {
+	var upstreams []version2.Upstream
+
+	keys := make([]string, 0, len(virtualServerEx.Policies))
+	for k := range virtualServerEx.Policies {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		pol := virtualServerEx.Policies[k]
+		if pol.Spec.ExternalAuth == nil || pol.Spec.ExternalAuth.AuthServiceName == "" {
+			continue
+		}
+
+		u := conf_v1.Upstream{
+			Name:    fmt.Sprintf("ext-auth-%v", pol.Name),
+			Service: pol.Spec.ExternalAuth.AuthServiceName,
+			Port:    uint16(pol.Spec.ExternalAuth.AuthServicePort),
+		}
+		upstreamName := generateExternalAuthUpstreamName(pol.Namespace, pol.Name, virtualServerEx.VirtualServer.Namespace, virtualServerEx.VirtualServer.Name)
+		endpoints := vsc.generateEndpointsForUpstream(virtualServerEx.VirtualServer, pol.Namespace, u, virtualServerEx)
+
+		// isExternalNameSvc is always false for OSS
+		_, isExternalNameSvc := virtualServerEx.ExternalNameSvcs[GenerateExternalNameSvcKey(pol.Namespace, u.Service)]
+		upstreams = append(upstreams, vsc.generateUpstream(virtualServerEx.VirtualServer, upstreamName, u, isExternalNameSvc, endpoints))
+	}
+
+	return upstreams
+}
+
 func (vsc *virtualServerConfigurator) generateEndpointsForUpstream(
 	owner runtime.Object,
 	namespace string,
@@ -338,8 +408,20 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 	var statusMatches []version2.StatusMatch
 	var healthChecks []version2.HealthCheck
 	var limitReqZones []version2.LimitReqZone
+	var limitConnZones []version2.LimitConnZone
+	var externalAuthLocations []version2.ExternalAuthLocation
+	var cacheZones []version2.CacheZone
+	var policyCacheZones []version2.CacheZone
+	var policyMaps []version2.Map
+	var policySplitClients []version2.SplitClient
 
 	limitReqZones = append(limitReqZones, policiesCfg.LimitReqZones...)
+	limitConnZones = append(limitConnZones, policiesCfg.LimitConnZones...)
+	externalAuthLocations = append(externalAuthLocations, policiesCfg.ExternalAuthLocations...)
+	cacheZones = append(cacheZones, policiesCfg.CacheZones...)
+	policyCacheZones = append(policyCacheZones, policiesCfg.PolicyCacheZones...)
+	policyMaps = append(policyMaps, policiesCfg.Maps...)
+	policySplitClients = append(policySplitClients, policiesCfg.SplitClients...)
 
 	// generate upstreams for VirtualServer
 	for _, u := range vsEx.VirtualServer.Spec.Upstreams {
@@ -370,6 +452,8 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 			}
 		}
 	}
+	upstreams = append(upstreams, vsc.generateExternalAuthUpstreams(vsEx)...)
+
 	// generate upstreams for each VirtualServerRoute
 	for _, vsr := range vsEx.VirtualServerRoutes {
 		upstreamNamer := newUpstreamNamerForVirtualServerRoute(vsEx.VirtualServer, vsr)
@@ -407,17 +491,20 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 	var splitClients []version2.SplitClient
 	var maps []version2.Map
 	var errorPageLocations []version2.ErrorPageLocation
+	var faultLocations []version2.FaultLocation
 	vsrErrorPagesFromVs := make(map[string][]conf_v1.ErrorPage)
 	vsrErrorPagesRouteIndex := make(map[string]int)
 	vsrLocationSnippetsFromVs := make(map[string]string)
 	vsrPoliciesFromVs := make(map[string][]conf_v1.PolicyReference)
 	isVSR := false
 	matchesRoutes := 0
+	faultRoutes := 0
 
 	variableNamer := newVariableNamer(vsEx.VirtualServer)
 
 	// generates config for VirtualServer routes
 	for _, r := range vsEx.VirtualServer.Spec.Routes {
+		r = applyCanaryWeight(r, vsEx.CanaryWeights)
 		errorPages := errorPageDetails{
 			pages: r.ErrorPages,
 			index: len(errorPageLocations),
@@ -463,6 +550,12 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 			routePoliciesCfg.OIDC = policiesCfg.OIDC
 		}
 		limitReqZones = append(limitReqZones, routePoliciesCfg.LimitReqZones...)
+		limitConnZones = append(limitConnZones, routePoliciesCfg.LimitConnZones...)
+		externalAuthLocations = append(externalAuthLocations, routePoliciesCfg.ExternalAuthLocations...)
+		cacheZones = append(cacheZones, routePoliciesCfg.CacheZones...)
+		policyCacheZones = append(policyCacheZones, routePoliciesCfg.PolicyCacheZones...)
+		policyMaps = append(policyMaps, routePoliciesCfg.Maps...)
+		policySplitClients = append(policySplitClients, routePoliciesCfg.SplitClients...)
 
 		dosRouteCfg := generateDosCfg(dosResources[r.Path])
 
@@ -501,6 +594,18 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 			locations = append(locations, cfg.Locations...)
 			internalRedirectLocations = append(internalRedirectLocations, cfg.InternalRedirectLocation)
 			returnLocations = append(returnLocations, cfg.ReturnLocations...)
+		} else if r.Action.Fault != nil {
+			cfg := generateFaultConfig(r, virtualServerUpstreamNamer, crUpstreams, variableNamer, faultRoutes, vsc.cfgParams,
+				errorPages, vsLocSnippets, vsc.enableSnippets, isVSR, "", "", vsc.warnings)
+			addPoliciesCfgToLocations(routePoliciesCfg, cfg.Locations)
+			addDosConfigToLocations(dosRouteCfg, cfg.Locations)
+
+			maps = append(maps, cfg.Maps...)
+			splitClients = append(splitClients, cfg.SplitClients...)
+			locations = append(locations, cfg.Locations...)
+			internalRedirectLocations = append(internalRedirectLocations, cfg.InternalRedirectLocation)
+			faultLocations = append(faultLocations, cfg.FaultLocations...)
+			faultRoutes++
 		} else {
 			upstreamName := virtualServerUpstreamNamer.GetNameForUpstreamFromAction(r.Action)
 			upstream := crUpstreams[upstreamName]
@@ -509,6 +614,7 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 
 			loc, returnLoc := generateLocation(r.Path, upstreamName, upstream, r.Action, vsc.cfgParams, errorPages, false,
 				proxySSLName, r.Path, vsLocSnippets, vsc.enableSnippets, len(returnLocations), isVSR, "", "", vsc.warnings)
+			loc.Mirror = generateMirror(r.Action, virtualServerUpstreamNamer, crUpstreams, variableNamer, vsc.cfgParams)
 			addPoliciesCfgToLocation(routePoliciesCfg, &loc)
 			loc.Dos = dosRouteCfg
 
@@ -524,6 +630,7 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 		isVSR := true
 		upstreamNamer := newUpstreamNamerForVirtualServerRoute(vsEx.VirtualServer, vsr)
 		for _, r := range vsr.Spec.Subroutes {
+			r = applyCanaryWeight(r, vsEx.CanaryWeights)
 			errorPages := errorPageDetails{
 				pages: r.ErrorPages,
 				index: len(errorPageLocations),
@@ -573,6 +680,12 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 				routePoliciesCfg.OIDC = policiesCfg.OIDC
 			}
 			limitReqZones = append(limitReqZones, routePoliciesCfg.LimitReqZones...)
+			limitConnZones = append(limitConnZones, routePoliciesCfg.LimitConnZones...)
+			externalAuthLocations = append(externalAuthLocations, routePoliciesCfg.ExternalAuthLocations...)
+			cacheZones = append(cacheZones, routePoliciesCfg.CacheZones...)
+			policyCacheZones = append(policyCacheZones, routePoliciesCfg.PolicyCacheZones...)
+			policyMaps = append(policyMaps, routePoliciesCfg.Maps...)
+			policySplitClients = append(policySplitClients, routePoliciesCfg.SplitClients...)
 
 			dosRouteCfg := generateDosCfg(dosResources[r.Path])
 
@@ -613,6 +726,18 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 				locations = append(locations, cfg.Locations...)
 				internalRedirectLocations = append(internalRedirectLocations, cfg.InternalRedirectLocation)
 				returnLocations = append(returnLocations, cfg.ReturnLocations...)
+			} else if r.Action.Fault != nil {
+				cfg := generateFaultConfig(r, upstreamNamer, crUpstreams, variableNamer, faultRoutes, vsc.cfgParams,
+					errorPages, locSnippets, vsc.enableSnippets, isVSR, vsr.Name, vsr.Namespace, vsc.warnings)
+				addPoliciesCfgToLocations(routePoliciesCfg, cfg.Locations)
+				addDosConfigToLocations(dosRouteCfg, cfg.Locations)
+
+				maps = append(maps, cfg.Maps...)
+				splitClients = append(splitClients, cfg.SplitClients...)
+				locations = append(locations, cfg.Locations...)
+				internalRedirectLocations = append(internalRedirectLocations, cfg.InternalRedirectLocation)
+				faultLocations = append(faultLocations, cfg.FaultLocations...)
+				faultRoutes++
 			} else {
 				upstreamName := upstreamNamer.GetNameForUpstreamFromAction(r.Action)
 				upstream := crUpstreams[upstreamName]
@@ -620,6 +745,7 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 
 				loc, returnLoc := generateLocation(r.Path, upstreamName, upstream, r.Action, vsc.cfgParams, errorPages, false,
 					proxySSLName, r.Path, locSnippets, vsc.enableSnippets, len(returnLocations), isVSR, vsr.Name, vsr.Namespace, vsc.warnings)
+				loc.Mirror = generateMirror(r.Action, upstreamNamer, crUpstreams, variableNamer, vsc.cfgParams)
 				addPoliciesCfgToLocation(routePoliciesCfg, &loc)
 				loc.Dos = dosRouteCfg
 
@@ -638,13 +764,24 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 		vsc.cfgParams.ServerSnippets,
 	)
 
+	maps = append(maps, removeDuplicateMaps(policyMaps)...)
+
+	mirrorLocations, mirrorSplitClients := generateMirrorLocations(locations)
+	splitClients = append(splitClients, mirrorSplitClients...)
+	splitClients = append(splitClients, removeDuplicateSplitClients(policySplitClients)...)
+
+	locations = append(locations, generateRetryFallbackLocations(locations)...)
+
 	vsCfg := version2.VirtualServerConfig{
-		Upstreams:     upstreams,
-		SplitClients:  splitClients,
-		Maps:          maps,
-		StatusMatches: statusMatches,
-		LimitReqZones: removeDuplicateLimitReqZones(limitReqZones),
-		HTTPSnippets:  httpSnippets,
+		Upstreams:        upstreams,
+		SplitClients:     splitClients,
+		Maps:             maps,
+		StatusMatches:    statusMatches,
+		LimitReqZones:    removeDuplicateLimitReqZones(limitReqZones),
+		LimitConnZones:   removeDuplicateLimitConnZones(limitConnZones),
+		CacheZones:       removeDuplicateCacheZones(cacheZones),
+		PolicyCacheZones: removeDuplicateCacheZones(policyCacheZones),
+		HTTPSnippets:     httpSnippets,
 		Server: version2.Server{
 			ServerName:                vsEx.VirtualServer.Spec.Host,
 			StatusZone:                vsEx.VirtualServer.Spec.Host,
@@ -658,15 +795,24 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 			InternalRedirectLocations: internalRedirectLocations,
 			Locations:                 locations,
 			ReturnLocations:           returnLocations,
+			MirrorLocations:           mirrorLocations,
+			FaultLocations:            faultLocations,
 			HealthChecks:              healthChecks,
 			TLSRedirect:               tlsRedirectConfig,
 			ErrorPageLocations:        errorPageLocations,
+			ExternalAuthLocations:     removeDuplicateExternalAuthLocations(externalAuthLocations),
 			TLSPassthrough:            vsc.isTLSPassthrough,
 			Allow:                     policiesCfg.Allow,
 			Deny:                      policiesCfg.Deny,
 			LimitReqOptions:           policiesCfg.LimitReqOptions,
 			LimitReqs:                 policiesCfg.LimitReqs,
+			LimitConnOptions:          policiesCfg.LimitConnOptions,
+			LimitConns:                policiesCfg.LimitConns,
 			JWTAuth:                   policiesCfg.JWTAuth,
+			BasicAuth:                 policiesCfg.BasicAuth,
+			ExternalAuth:              policiesCfg.ExternalAuth,
+			CORS:                      policiesCfg.CORS,
+			Cache:                     policiesCfg.Cache,
 			IngressMTLS:               policiesCfg.IngressMTLS,
 			EgressMTLS:                policiesCfg.EgressMTLS,
 			OIDC:                      vsc.oidcPolCfg.oidc,
@@ -683,17 +829,40 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 }
 
 type policiesCfg struct {
-	Allow           []string
-	Deny            []string
-	LimitReqOptions version2.LimitReqOptions
-	LimitReqZones   []version2.LimitReqZone
-	LimitReqs       []version2.LimitReq
-	JWTAuth         *version2.JWTAuth
-	IngressMTLS     *version2.IngressMTLS
-	EgressMTLS      *version2.EgressMTLS
-	OIDC            bool
-	WAF             *version2.WAF
-	ErrorReturn     *version2.Return
+	Allow                 []string
+	Deny                  []string
+	LimitReqOptions       version2.LimitReqOptions
+	LimitReqZones         []version2.LimitReqZone
+	LimitReqs             []version2.LimitReq
+	LimitConnOptions      version2.LimitConnOptions
+	LimitConnZones        []version2.LimitConnZone
+	LimitConns            []version2.LimitConn
+	JWTAuth               *version2.JWTAuth
+	BasicAuth             *version2.BasicAuth
+	ExternalAuth          *version2.ExternalAuth
+	ExternalAuthLocations []version2.ExternalAuthLocation
+	CacheZones            []version2.CacheZone
+	PolicyCacheZones      []version2.CacheZone
+	CORS                  *version2.CORS
+	Cache                 *version2.Cache
+	Maps                  []version2.Map
+	SplitClients          []version2.SplitClient
+	Retry                 *retryCfg
+	IngressMTLS           *version2.IngressMTLS
+	EgressMTLS            *version2.EgressMTLS
+	OIDC                  bool
+	WAF                   *version2.WAF
+	ErrorReturn           *version2.Return
+}
+
+// retryCfg holds the directives of a retry policy for the locations of a route.
+type retryCfg struct {
+	NextUpstream        string
+	NextUpstreamTimeout string
+	NextUpstreamTries   int
+	PerTryTimeout       string
+	// EligibilityVariable is empty for the requests that must not be retried.
+	EligibilityVariable string
 }
 
 func newPoliciesConfig() *policiesCfg {
@@ -767,6 +936,39 @@ func (p *policiesCfg) addRateLimitConfig(
 	return res
 }
 
+func (p *policiesCfg) addConnectionLimitConfig(
+	connectionLimit *conf_v1.ConnectionLimit,
+	polKey string,
+	polNamespace string,
+	polName string,
+	vsNamespace string,
+	vsName string,
+) *validationResults {
+	clZoneName := fmt.Sprintf("pol_cl_%v_%v_%v_%v", polNamespace, polName, vsNamespace, vsName)
+	return p.addLimitConnConfig(connectionLimit, polKey, clZoneName)
+}
+
+func (p *policiesCfg) addLimitConnConfig(connectionLimit *conf_v1.ConnectionLimit, polKey string, clZoneName string) *validationResults {
+	res := newValidationResults()
+	p.LimitConns = append(p.LimitConns, generateLimitConn(clZoneName, connectionLimit))
+	p.LimitConnZones = append(p.LimitConnZones, generateLimitConnZone(clZoneName, connectionLimit))
+	if len(p.LimitConns) == 1 {
+		p.LimitConnOptions = generateLimitConnOptions(connectionLimit)
+	} else {
+		curOptions := generateLimitConnOptions(connectionLimit)
+		if curOptions.DryRun != p.LimitConnOptions.DryRun {
+			res.addWarningf("ConnectionLimit policy %s with limit connection option dryRun='%v' is overridden to dryRun='%v' by the first policy reference in this context", polKey, curOptions.DryRun, p.LimitConnOptions.DryRun)
+		}
+		if curOptions.LogLevel != p.LimitConnOptions.LogLevel {
+			res.addWarningf("ConnectionLimit policy %s with limit connection option logLevel='%v' is overridden to logLevel='%v' by the first policy reference in this context", polKey, curOptions.LogLevel, p.LimitConnOptions.LogLevel)
+		}
+		if curOptions.RejectCode != p.LimitConnOptions.RejectCode {
+			res.addWarningf("ConnectionLimit policy %s with limit connection option rejectCode='%v' is overridden to rejectCode='%v' by the first policy reference in this context", polKey, curOptions.RejectCode, p.LimitConnOptions.RejectCode)
+		}
+	}
+	return res
+}
+
 func (p *policiesCfg) addJWTAuthConfig(
 	jwtAuth *conf_v1.JWTAuth,
 	polKey string,
@@ -803,6 +1005,365 @@ func (p *policiesCfg) addJWTAuthConfig(
 	return res
 }
 
+func (p *policiesCfg) addBasicAuthConfig(
+	basicAuth *conf_v1.BasicAuth,
+	polKey string,
+	polNamespace string,
+	secretRefs map[string]*secrets.SecretReference,
+) *validationResults {
+	res := newValidationResults()
+	if p.BasicAuth != nil {
+		res.addWarningf("Multiple basic auth policies in the same context is not valid. Basic auth policy %s will be ignored", polKey)
+		return res
+	}
+
+	basicSecretKey := fmt.Sprintf("%v/%v", polNamespace, basicAuth.Secret)
+	secretRef := secretRefs[basicSecretKey]
+	var secretType api_v1.SecretType
+	if secretRef.Secret != nil {
+		secretType = secretRef.Secret.Type
+	}
+	if secretType != "" && secretType != secrets.SecretTypeHtpasswd {
+		res.addWarningf("Basic Auth policy %s references a secret %s of a wrong type '%s', must be '%s'", polKey, basicSecretKey, secretType, secrets.SecretTypeHtpasswd)
+		res.isError = true
+		return res
+	} else if secretRef.Error != nil {
+		res.addWarningf("Basic Auth policy %s references an invalid secret %s: %v", polKey, basicSecretKey, secretRef.Error)
+		res.isError = true
+		return res
+	}
+
+	p.BasicAuth = &version2.BasicAuth{
+		Secret: secretRef.Path,
+		Realm:  basicAuth.Realm,
+	}
+	return res
+}
+
+func (p *policiesCfg) addExternalAuthConfig(
+	extAuth *conf_v1.ExternalAuth,
+	polKey string,
+	polNamespace string,
+	polName string,
+	vsNamespace string,
+	vsName string,
+) *validationResults {
+	res := newValidationResults()
+	if p.ExternalAuth != nil {
+		res.addWarningf("Multiple externalAuth policies in the same context is not valid. ExternalAuth policy %s will be ignored", polKey)
+		return res
+	}
+
+	name := fmt.Sprintf("%v_%v", polNamespace, polName)
+
+	proxyPass := extAuth.AuthURL
+	if proxyPass == "" {
+		// the endpoints of the service are discovered by the Ingress Controller, see generateExternalAuthUpstreams
+		upstreamName := generateExternalAuthUpstreamName(polNamespace, polName, vsNamespace, vsName)
+		proxyPass = fmt.Sprintf("http://%v%v", upstreamName, generateString(extAuth.AuthURI, "/"))
+	}
+
+	authLocation := version2.ExternalAuthLocation{
+		Path:      fmt.Sprintf("/_ext_auth_%v", name),
+		ProxyPass: proxyPass,
+	}
+
+	// the cache key includes the request headers that the authorization service bases the decision on
+	cacheKey := "$request_method$host$request_uri"
+	if len(extAuth.ForwardHeaders) > 0 {
+		for _, h := range extAuth.ForwardHeaders {
+			variable := generateHeaderVariable("$http_", h)
+			authLocation.ForwardHeaders = append(authLocation.ForwardHeaders, version2.ExternalAuthForwardHeader{
+				Name:     h,
+				Variable: variable,
+			})
+			cacheKey += variable
+		}
+	} else {
+		cacheKey += "$http_authorization$http_cookie"
+	}
+
+	if extAuth.CacheTTL != "" {
+		zoneName := fmt.Sprintf("ext_auth_%v_%v_%v_%v", polNamespace, polName, vsNamespace, vsName)
+		p.CacheZones = append(p.CacheZones, version2.CacheZone{
+			Name:     zoneName,
+			Path:     fmt.Sprintf("/var/cache/nginx/%v", zoneName),
+			Size:     "1m",
+			Inactive: extAuth.CacheTTL,
+		})
+		authLocation.CacheZone = zoneName
+		authLocation.CacheKey = cacheKey
+		authLocation.CacheValid = extAuth.CacheTTL
+	}
+
+	if extAuth.SigninURL != "" {
+		separator := "?"
+		if strings.Contains(extAuth.SigninURL, "?") {
+			separator = "&"
+		}
+		authLocation.SigninLocation = fmt.Sprintf("@ext_auth_signin_%v", name)
+		// NGINX can't escape variables without third-party modules, so the query string of the original request URI
+		// is passed unescaped and must be the last part of the rd parameter.
+		authLocation.SigninURL = fmt.Sprintf("%v%vrd=$scheme://$host$request_uri", extAuth.SigninURL, separator)
+	}
+
+	p.ExternalAuthLocations = append(p.ExternalAuthLocations, authLocation)
+
+	p.ExternalAuth = &version2.ExternalAuth{
+		Location:       authLocation.Path,
+		SigninLocation: authLocation.SigninLocation,
+	}
+	for _, h := range extAuth.ResponseHeaders {
+		p.ExternalAuth.ResponseHeaders = append(p.ExternalAuth.ResponseHeaders, version2.ExternalAuthResponseHeader{
+			Name:             h,
+			Variable:         generateHeaderVariable("$ext_auth_", h),
+			UpstreamVariable: generateHeaderVariable("$upstream_http_", h),
+		})
+	}
+
+	return res
+}
+
+// generateExternalAuthUpstreamName generates the name of the upstream of the authorization service of an externalAuth policy.
+// The upstream belongs to the VirtualServer, so that the VirtualServers that reference the same policy don't declare the same upstream.
+func generateExternalAuthUpstreamName(polNamespace string, polName string, vsNamespace string, vsName string) string {
+	return fmt.Sprintf("vs_%v_%v_ext_auth_%v_%v", vsNamespace, vsName, polNamespace, polName)
+}
+
+// generateHeaderVariable generates the name of the NGINX variable for the header with the prefix.
+// For example, the header X-User with the prefix $http_ becomes $http_x_user.
+func generateHeaderVariable(prefix string, header string) string {
+	return prefix + strings.ReplaceAll(strings.ToLower(header), "-", "_")
+}
+
+func (p *policiesCfg) addCORSConfig(
+	cors *conf_v1.CORS,
+	polKey string,
+	polNamespace string,
+	polName string,
+	vsNamespace string,
+	vsName string,
+) *validationResults {
+	res := newValidationResults()
+	if p.CORS != nil {
+		res.addWarningf("Multiple cors policies in the same context is not valid. CORS policy %s will be ignored", polKey)
+		return res
+	}
+
+	name := strings.ReplaceAll(fmt.Sprintf("%v_%v_%v_%v", polNamespace, polName, vsNamespace, vsName), "-", "_")
+	originVariable := fmt.Sprintf("$cors_origin_%v", name)
+	preflightVariable := fmt.Sprintf("$cors_preflight_%v", name)
+
+	// the origin map reflects the origin of the request if the origin is allowed
+	originMap := version2.Map{
+		Source:   "$http_origin",
+		Variable: originVariable,
+	}
+	defaultOrigin := `""`
+	allowCredentials := cors.AllowCredentials
+	for _, origin := range cors.AllowOrigins {
+		if origin == "*" {
+			// all origins get a literal *, so browsers never send credentials to them.
+			// Requests without an origin don't get the CORS headers.
+			originMap.Parameters = append(originMap.Parameters, version2.Parameter{
+				Value:  `""`,
+				Result: `""`,
+			})
+			defaultOrigin = `"*"`
+			allowCredentials = false
+			continue
+		}
+		originMap.Parameters = append(originMap.Parameters, version2.Parameter{
+			Value:  generateCORSOriginMapValue(origin),
+			Result: "$http_origin",
+		})
+	}
+	originMap.Parameters = append(originMap.Parameters, version2.Parameter{
+		Value:  "default",
+		Result: defaultOrigin,
+	})
+
+	// a preflight request is an OPTIONS request with the Access-Control-Request-Method header from an allowed origin
+	preflightMap := version2.Map{
+		Source:   fmt.Sprintf("$request_method:$http_access_control_request_method:%v", originVariable),
+		Variable: preflightVariable,
+		Parameters: []version2.Parameter{
+			{
+				Value:  `"~^OPTIONS:[^:]+:."`,
+				Result: "1",
+			},
+			{
+				Value:  "default",
+				Result: "0",
+			},
+		},
+	}
+
+	p.Maps = append(p.Maps, originMap, preflightMap)
+
+	p.CORS = &version2.CORS{
+		OriginVariable:    originVariable,
+		PreflightVariable: preflightVariable,
+		AllowMethods:      generateString(strings.Join(cors.AllowMethods, ", "), "GET, HEAD, POST"),
+		AllowHeaders:      strings.Join(cors.AllowHeaders, ", "),
+		ExposeHeaders:     strings.Join(cors.ExposeHeaders, ", "),
+		AllowCredentials:  allowCredentials,
+	}
+	if cors.MaxAge != nil {
+		p.CORS.MaxAge = strconv.Itoa(*cors.MaxAge)
+	}
+
+	return res
+}
+
+// generateCORSOriginMapValue generates the map value that matches an origin of a CORS policy.
+// A regular expression is used as is. An origin with a wildcard host, for example https://*.example.com,
+// becomes a case-insensitive regular expression where the wildcard matches one or more labels.
+func generateCORSOriginMapValue(origin string) string {
+	if strings.HasPrefix(origin, "~") {
+		return fmt.Sprintf(`"%s"`, origin)
+	}
+
+	if parts := strings.SplitN(origin, "://*.", 2); len(parts) == 2 {
+		return fmt.Sprintf(`"~*^%s://[a-z0-9.-]+\.%s$"`, parts[0], strings.ReplaceAll(parts[1], ".", `\.`))
+	}
+
+	return fmt.Sprintf(`"%s"`, origin)
+}
+
+func (p *policiesCfg) addCacheConfig(
+	cache *conf_v1.Cache,
+	polKey string,
+	polNamespace string,
+	polName string,
+) *validationResults {
+	res := newValidationResults()
+	if p.Cache != nil {
+		res.addWarningf("Multiple cache policies in the same context is not valid. Cache policy %s will be ignored", polKey)
+		return res
+	}
+
+	// the VirtualServers that reference the policy share the cache
+	zoneName := fmt.Sprintf("pol_cache_%v_%v", polNamespace, polName)
+	p.PolicyCacheZones = append(p.PolicyCacheZones, version2.CacheZone{
+		Name:    zoneName,
+		Path:    fmt.Sprintf("/var/cache/nginx/%v", zoneName),
+		Size:    cache.ZoneSize,
+		MaxSize: cache.MaxSize,
+	})
+
+	p.Cache = &version2.Cache{
+		ZoneName:             zoneName,
+		Key:                  cache.Key,
+		Methods:              strings.Join(cache.Methods, " "),
+		Bypass:               cache.Bypass,
+		Lock:                 cache.Lock,
+		StaleWhileRevalidate: cache.StaleWhileRevalidate,
+	}
+	for _, v := range cache.Valid {
+		p.Cache.Valid = append(p.Cache.Valid, version2.CacheValid{
+			Codes: v.Codes,
+			Time:  v.Time,
+		})
+	}
+
+	return res
+}
+
+// nonIdempotentMethods includes the methods that NGINX doesn't retry unless non_idempotent is set in proxy_next_upstream.
+var nonIdempotentMethods = map[string]bool{
+	"POST":  true,
+	"LOCK":  true,
+	"PATCH": true,
+}
+
+func (p *policiesCfg) addRetryConfig(
+	retry *conf_v1.Retry,
+	polKey string,
+	polNamespace string,
+	polName string,
+	vsNamespace string,
+	vsName string,
+	context string,
+) *validationResults {
+	res := newValidationResults()
+	if context == specContext {
+		res.addWarningf("Retry policy %s is not allowed in the %v context", polKey, context)
+		res.isError = true
+		return res
+	}
+	if p.Retry != nil {
+		res.addWarningf("Multiple retry policies in the same context is not valid. Retry policy %s will be ignored", polKey)
+		return res
+	}
+
+	conditions := retry.Conditions
+	if len(conditions) == 0 {
+		conditions = []string{"error", "timeout"}
+	}
+	nextUpstream := strings.Join(conditions, " ")
+	for _, m := range retry.Methods {
+		if nonIdempotentMethods[m] {
+			nextUpstream += " non_idempotent"
+			break
+		}
+	}
+
+	p.Retry = &retryCfg{
+		NextUpstream:        nextUpstream,
+		NextUpstreamTimeout: generateString(retry.Timeout, "0s"),
+		NextUpstreamTries:   generateIntFromPointer(retry.Attempts, 0),
+		PerTryTimeout:       retry.PerTryTimeout,
+	}
+
+	name := strings.ReplaceAll(fmt.Sprintf("%v_%v_%v_%v", polNamespace, polName, vsNamespace, vsName), "-", "_")
+
+	// the sample selects the requests that can be retried. It is not a retry budget: NGINX doesn't count
+	// the retries across requests, so the retries are bounded by the sample and the attempts instead.
+	eligible := "1"
+	if retry.Sample != nil {
+		sampleVariable := fmt.Sprintf("$retry_sample_%v", name)
+		p.SplitClients = append(p.SplitClients, version2.SplitClient{
+			Source:   "$request_id",
+			Variable: sampleVariable,
+			Distributions: []version2.Distribution{
+				{
+					Weight: fmt.Sprintf("%d%%", retry.Sample.Percentage),
+					Value:  "1",
+				},
+				{
+					Weight: "*",
+					Value:  `""`,
+				},
+			},
+		})
+		eligible = sampleVariable
+		p.Retry.EligibilityVariable = sampleVariable
+	}
+
+	if len(retry.Methods) > 0 {
+		methodsVariable := fmt.Sprintf("$retry_methods_%v", name)
+		methodsMap := version2.Map{
+			Source:   "$request_method",
+			Variable: methodsVariable,
+		}
+		for _, m := range retry.Methods {
+			methodsMap.Parameters = append(methodsMap.Parameters, version2.Parameter{
+				Value:  m,
+				Result: eligible,
+			})
+		}
+		methodsMap.Parameters = append(methodsMap.Parameters, version2.Parameter{
+			Value:  "default",
+			Result: `""`,
+		})
+		p.Maps = append(p.Maps, methodsMap)
+		p.Retry.EligibilityVariable = methodsVariable
+	}
+
+	return res
+}
+
 func (p *policiesCfg) addIngressMTLSConfig(
 	ingressMTLS *conf_v1.IngressMTLS,
 	polKey string,
@@ -1094,8 +1655,54 @@ func (vsc *virtualServerConfigurator) generatePolicies(
 					ownerDetails.vsNamespace,
 					ownerDetails.vsName,
 				)
+			case pol.Spec.ConnectionLimit != nil:
+				res = config.addConnectionLimitConfig(
+					pol.Spec.ConnectionLimit,
+					key,
+					polNamespace,
+					p.Name,
+					ownerDetails.vsNamespace,
+					ownerDetails.vsName,
+				)
 			case pol.Spec.JWTAuth != nil:
 				res = config.addJWTAuthConfig(pol.Spec.JWTAuth, key, polNamespace, policyOpts.secretRefs)
+			case pol.Spec.BasicAuth != nil:
+				res = config.addBasicAuthConfig(pol.Spec.BasicAuth, key, polNamespace, policyOpts.secretRefs)
+			case pol.Spec.ExternalAuth != nil:
+				res = config.addExternalAuthConfig(
+					pol.Spec.ExternalAuth,
+					key,
+					polNamespace,
+					p.Name,
+					ownerDetails.vsNamespace,
+					ownerDetails.vsName,
+				)
+			case pol.Spec.CORS != nil:
+				res = config.addCORSConfig(
+					pol.Spec.CORS,
+					key,
+					polNamespace,
+					p.Name,
+					ownerDetails.vsNamespace,
+					ownerDetails.vsName,
+				)
+			case pol.Spec.Cache != nil:
+				res = config.addCacheConfig(
+					pol.Spec.Cache,
+					key,
+					polNamespace,
+					p.Name,
+				)
+			case pol.Spec.Retry != nil:
+				res = config.addRetryConfig(
+					pol.Spec.Retry,
+					key,
+					polNamespace,
+					p.Name,
+					ownerDetails.vsNamespace,
+					ownerDetails.vsName,
+					context,
+				)
 			case pol.Spec.IngressMTLS != nil:
 				res = config.addIngressMTLSConfig(
 					pol.Spec.IngressMTLS,
@@ -1168,6 +1775,29 @@ func generateLimitReqOptions(rateLimitPol *conf_v1.RateLimit) version2.LimitReqO
 	}
 }
 
+func generateLimitConn(zoneName string, connectionLimitPol *conf_v1.ConnectionLimit) version2.LimitConn {
+	return version2.LimitConn{
+		ZoneName:    zoneName,
+		Connections: connectionLimitPol.MaxConnections,
+	}
+}
+
+func generateLimitConnZone(zoneName string, connectionLimitPol *conf_v1.ConnectionLimit) version2.LimitConnZone {
+	return version2.LimitConnZone{
+		ZoneName: zoneName,
+		Key:      connectionLimitPol.Key,
+		ZoneSize: connectionLimitPol.ZoneSize,
+	}
+}
+
+func generateLimitConnOptions(connectionLimitPol *conf_v1.ConnectionLimit) version2.LimitConnOptions {
+	return version2.LimitConnOptions{
+		DryRun:     generateBool(connectionLimitPol.DryRun, false),
+		LogLevel:   generateString(connectionLimitPol.LogLevel, "error"),
+		RejectCode: generateIntFromPointer(connectionLimitPol.RejectCode, 503),
+	}
+}
+
 func removeDuplicateLimitReqZones(rlz []version2.LimitReqZone) []version2.LimitReqZone {
 	encountered := make(map[string]bool)
 	result := []version2.LimitReqZone{}
@@ -1182,16 +1812,114 @@ func removeDuplicateLimitReqZones(rlz []version2.LimitReqZone) []version2.LimitR
 	return result
 }
 
+func removeDuplicateLimitConnZones(zones []version2.LimitConnZone) []version2.LimitConnZone {
+	encountered := make(map[string]bool)
+	var result []version2.LimitConnZone
+
+	for _, z := range zones {
+		if !encountered[z.ZoneName] {
+			encountered[z.ZoneName] = true
+			result = append(result, z)
+		}
+	}
+
+	return result
+}
+
+func removeDuplicateExternalAuthLocations(locations []version2.ExternalAuthLocation) []version2.ExternalAuthLocation {
+	encountered := make(map[string]bool)
+	var result []version2.ExternalAuthLocation
+
+	for _, l := range locations {
+		if !encountered[l.Path] {
+			encountered[l.Path] = true
+			result = append(result, l)
+		}
+	}
+
+	return result
+}
+
+func removeDuplicateMaps(maps []version2.Map) []version2.Map {
+	encountered := make(map[string]bool)
+	var result []version2.Map
+
+	for _, m := range maps {
+		if !encountered[m.Variable] {
+			encountered[m.Variable] = true
+			result = append(result, m)
+		}
+	}
+
+	return result
+}
+
+func removeDuplicateSplitClients(splitClients []version2.SplitClient) []version2.SplitClient {
+	encountered := make(map[string]bool)
+	var result []version2.SplitClient
+
+	for _, sc := range splitClients {
+		if !encountered[sc.Variable] {
+			encountered[sc.Variable] = true
+			result = append(result, sc)
+		}
+	}
+
+	return result
+}
+
+func removeDuplicateCacheZones(zones []version2.CacheZone) []version2.CacheZone {
+	encountered := make(map[string]bool)
+	var result []version2.CacheZone
+
+	for _, z := range zones {
+		if !encountered[z.Name] {
+			encountered[z.Name] = true
+			result = append(result, z)
+		}
+	}
+
+	return result
+}
+
 func addPoliciesCfgToLocation(cfg policiesCfg, location *version2.Location) {
 	location.Allow = cfg.Allow
 	location.Deny = cfg.Deny
 	location.LimitReqOptions = cfg.LimitReqOptions
 	location.LimitReqs = cfg.LimitReqs
+	location.LimitConnOptions = cfg.LimitConnOptions
+	location.LimitConns = cfg.LimitConns
 	location.JWTAuth = cfg.JWTAuth
+	location.BasicAuth = cfg.BasicAuth
+	location.ExternalAuth = cfg.ExternalAuth
+	location.CORS = cfg.CORS
+	location.Cache = cfg.Cache
 	location.EgressMTLS = cfg.EgressMTLS
 	location.OIDC = cfg.OIDC
 	location.WAF = cfg.WAF
 	location.PoliciesErrorReturn = cfg.ErrorReturn
+
+	if cfg.Retry != nil && (location.ProxyPass != "" || location.GRPCPass != "") {
+		addRetryCfgToLocation(cfg.Retry, location)
+	}
+}
+
+func addRetryCfgToLocation(cfg *retryCfg, location *version2.Location) {
+	location.ProxyNextUpstream = cfg.NextUpstream
+	location.ProxyNextUpstreamTimeout = cfg.NextUpstreamTimeout
+	location.ProxyNextUpstreamTries = cfg.NextUpstreamTries
+
+	if cfg.PerTryTimeout != "" {
+		location.ProxyConnectTimeout = cfg.PerTryTimeout
+		location.ProxyReadTimeout = cfg.PerTryTimeout
+		location.ProxySendTimeout = cfg.PerTryTimeout
+	}
+
+	if cfg.EligibilityVariable != "" {
+		location.RetryEligibility = &version2.RetryEligibility{
+			Variable: cfg.EligibilityVariable,
+		}
+	}
 }
 
 func addPoliciesCfgToLocations(cfg policiesCfg, locations []version2.Location) {
@@ -1714,6 +2442,157 @@ func generateLocationForProxying(path string, upstreamName string, upstream conf
 	}
 }
 
+// generateMirror generates the mirroring of the requests of a location for an action with a proxy mirror.
+// The requests are mirrored to an internal location, which is unique for the mirror upstream and the percentage.
+func generateMirror(action *conf_v1.Action, upstreamNamer *upstreamNamer, crUpstreams map[string]conf_v1.Upstream,
+	variableNamer *variableNamer, cfgParams *ConfigParams) *version2.Mirror {
+	if action.Proxy == nil || action.Proxy.Mirror == nil {
+		return nil
+	}
+
+	mirror := action.Proxy.Mirror
+	upstreamName := upstreamNamer.GetNameForUpstream(mirror.Upstream)
+	upstream := crUpstreams[upstreamName]
+
+	location := version2.MirrorLocation{
+		Path:         fmt.Sprintf("/%vmirror_%s", internalLocationPrefix, upstreamName),
+		ProxyPass:    generateProxyPass(upstream.TLS.Enable, upstreamName, true, nil),
+		ProxySSLName: generateProxySSLName(upstream.Service, upstreamNamer.namespace),
+		HasKeepalive: upstreamHasKeepalive(upstream, cfgParams),
+		RequestBody:  generateBool(mirror.RequestBody, true),
+	}
+
+	if mirror.Percentage != nil && *mirror.Percentage < 100 {
+		location.Path = fmt.Sprintf("%s_%d", location.Path, *mirror.Percentage)
+		location.SampleVariable = variableNamer.GetNameForMirrorSampleVariable(*mirror.Percentage)
+		location.Percentage = *mirror.Percentage
+	}
+
+	if !location.RequestBody {
+		location.Path += "_no_body"
+	}
+
+	return &version2.Mirror{
+		Location: location,
+	}
+}
+
+// generateMirrorLocations returns the unique mirror locations of the locations and the split clients,
+// which sample the requests for the mirror locations with a percentage.
+// A mirror location gets the SSL settings of the location. If locations with different SSL settings mirror the requests
+// to the same upstream, they get different mirror locations.
+func generateMirrorLocations(locations []version2.Location) ([]version2.MirrorLocation, []version2.SplitClient) {
+	var mirrorLocations []version2.MirrorLocation
+	var splitClients []version2.SplitClient
+
+	indexes := make(map[string]int)
+	variables := make(map[string]bool)
+
+	for _, l := range locations {
+		if l.Mirror == nil {
+			continue
+		}
+
+		ml := &l.Mirror.Location
+		ml.EgressMTLS = l.EgressMTLS
+
+		basePath := ml.Path
+		exists := false
+		for n := 1; ; n++ {
+			i, found := indexes[ml.Path]
+			if !found {
+				break
+			}
+			if reflect.DeepEqual(mirrorLocations[i], *ml) {
+				exists = true
+				break
+			}
+			ml.Path = fmt.Sprintf("%s_ssl_%d", basePath, n)
+		}
+
+		if exists {
+			continue
+		}
+
+		indexes[ml.Path] = len(mirrorLocations)
+		mirrorLocations = append(mirrorLocations, *ml)
+
+		if ml.SampleVariable == "" || variables[ml.SampleVariable] {
+			continue
+		}
+
+		variables[ml.SampleVariable] = true
+		splitClients = append(splitClients, version2.SplitClient{
+			Source:   "$request_id",
+			Variable: ml.SampleVariable,
+			Distributions: []version2.Distribution{
+				{
+					Weight: fmt.Sprintf("%d%%", ml.Percentage),
+					Value:  "1",
+				},
+				{
+					Weight: "*",
+					Value:  `""`,
+				},
+			},
+		})
+	}
+
+	return mirrorLocations, splitClients
+}
+
+// generateRetryFallbackLocations generates an internal location for every location with a retry eligibility.
+// The fallback location is a copy of the location that doesn't retry requests. The location rewrites the requests
+// which are not eligible for retries to it, which, unlike error_page, keeps the method of the requests.
+func generateRetryFallbackLocations(locations []version2.Location) []version2.Location {
+	var fallbackLocations []version2.Location
+
+	for i := range locations {
+		if locations[i].RetryEligibility == nil {
+			continue
+		}
+
+		fallback := locations[i]
+		fallback.Path = fmt.Sprintf("/%vretry_fallback_%d", internalLocationPrefix, len(fallbackLocations))
+		fallback.RetryEligibility = nil
+		fallback.ProxyNextUpstream = "off"
+		if !fallback.Internal {
+			convertToInternalLocation(&fallback, locations[i].Path)
+		}
+		fallbackLocations = append(fallbackLocations, fallback)
+
+		locations[i].RetryEligibility = &version2.RetryEligibility{
+			Variable:         locations[i].RetryEligibility.Variable,
+			FallbackLocation: fallback.Path,
+		}
+	}
+
+	return fallbackLocations
+}
+
+// convertToInternalLocation makes a copy of the location of a route internal. The rewrite of the requests
+// to the copy changes their URI, so, like in the locations of splits and matches, the copy recovers the original URI
+// from $request_uri. path is the path of the location of the route.
+func convertToInternalLocation(loc *version2.Location, path string) {
+	loc.Internal = true
+
+	switch {
+	case loc.ProxyPassRewrite != "":
+		trimmedPath := strings.TrimSpace(strings.TrimPrefix(path, "="))
+		loc.Rewrites = []string{
+			"^ $request_uri_no_args",
+			fmt.Sprintf(`"^%v(.*)$" "%v$1" break`, trimmedPath, loc.ProxyPassRewrite),
+		}
+		loc.ProxyPassRewrite = ""
+	case len(loc.Rewrites) > 0:
+		loc.Rewrites = append([]string{"^ $request_uri_no_args"}, loc.Rewrites...)
+	case loc.GRPCPass != "":
+		loc.Rewrites = []string{"^ $request_uri break"}
+	default:
+		loc.ProxyPass = fmt.Sprintf("%v$request_uri", loc.ProxyPass)
+	}
+}
+
 func generateProxyInterceptErrors(errorPages []conf_v1.ErrorPage) bool {
 	return len(errorPages) > 0
 }
@@ -1784,6 +2663,7 @@ type routingCfg struct {
 	Locations                []version2.Location
 	InternalRedirectLocation version2.InternalRedirectLocation
 	ReturnLocations          []version2.ReturnLocation
+	FaultLocations           []version2.FaultLocation
 }
 
 func generateSplits(
@@ -1806,6 +2686,10 @@ func generateSplits(
 	var distributions []version2.Distribution
 
 	for i, s := range splits {
+		if s.Weight == 0 {
+			// a split can get a zero weight when its canary is rolled back
+			continue
+		}
 		d := version2.Distribution{
 			Weight: fmt.Sprintf("%d%%", s.Weight),
 			Value:  fmt.Sprintf("/%vsplits_%d_split_%d", internalLocationPrefix, scIndex, i),
@@ -1830,6 +2714,7 @@ func generateSplits(
 		newRetLocIndex := retLocIndex + len(returnLocations)
 		loc, returnLoc := generateLocation(path, upstreamName, upstream, s.Action, cfgParams, errorPages, true,
 			proxySSLName, originalPath, locSnippets, enableSnippets, newRetLocIndex, isVSR, vsrName, vsrNamespace, vscWarnings)
+		loc.Mirror = generateMirror(s.Action, upstreamNamer, crUpstreams, variableNamer, cfgParams)
 		locations = append(locations, loc)
 		if returnLoc != nil {
 			returnLocations = append(returnLocations, *returnLoc)
@@ -1839,6 +2724,22 @@ func generateSplits(
 	return splitClient, locations, returnLocations
 }
 
+// applyCanaryWeight returns the route with the weights of its splits set according to the progress of its canary.
+func applyCanaryWeight(route conf_v1.Route, canaryWeights map[string]int) conf_v1.Route {
+	weight, exists := canaryWeights[route.Path]
+	if !exists || len(route.Splits) != 2 {
+		return route
+	}
+
+	splits := make([]conf_v1.Split, len(route.Splits))
+	copy(splits, route.Splits)
+	splits[0].Weight = 100 - weight
+	splits[1].Weight = weight
+	route.Splits = splits
+
+	return route
+}
+
 func generateDefaultSplitsConfig(
 	route conf_v1.Route,
 	upstreamNamer *upstreamNamer,
@@ -1874,6 +2775,130 @@ func generateDefaultSplitsConfig(
 	}
 }
 
+// generateFaultConfig generates the config for a route whose action injects faults. Like for splits, the location of
+// the route redirects the requests to an internal location: the fault location for the requests selected by
+// the percentage and the header of the fault, and the location of the action for the rest.
+// The internal fault location only applies the policies of the route and then passes the requests to the named
+// location that injects the fault (try_files works after the access phase), so that the policies run before the fault.
+func generateFaultConfig(
+	route conf_v1.Route,
+	upstreamNamer *upstreamNamer,
+	crUpstreams map[string]conf_v1.Upstream,
+	variableNamer *variableNamer,
+	index int,
+	cfgParams *ConfigParams,
+	errorPages errorPageDetails,
+	locSnippets string,
+	enableSnippets bool,
+	isVSR bool,
+	vsrName string,
+	vsrNamespace string,
+	vscWarnings Warnings,
+) routingCfg {
+	fault := route.Action.Fault
+	faultPath := fmt.Sprintf("/%vfault_%d", internalLocationPrefix, index)
+	passPath := fmt.Sprintf("/%vfault_%d_pass", internalLocationPrefix, index)
+	injectPath := fmt.Sprintf("@fault_%d", index)
+
+	upstreamName := upstreamNamer.GetNameForUpstreamFromAction(route.Action)
+	upstream := crUpstreams[upstreamName]
+	proxySSLName := generateProxySSLName(upstream.Service, upstreamNamer.namespace)
+	loc, _ := generateLocation(passPath, upstreamName, upstream, route.Action, cfgParams, errorPages, true,
+		proxySSLName, route.Path, locSnippets, enableSnippets, 0, isVSR, vsrName, vsrNamespace, vscWarnings)
+	loc.Mirror = generateMirror(route.Action, upstreamNamer, crUpstreams, variableNamer, cfgParams)
+
+	gateLoc := version2.Location{
+		Path:          faultPath,
+		Internal:      true,
+		ServiceName:   loc.ServiceName,
+		IsVSR:         loc.IsVSR,
+		VSRName:       loc.VSRName,
+		VSRNamespace:  loc.VSRNamespace,
+		FaultLocation: injectPath,
+	}
+	locations := []version2.Location{loc, gateLoc}
+
+	faultLoc := version2.FaultLocation{
+		Path:  injectPath,
+		Delay: fault.Delay,
+	}
+	if fault.Abort != nil {
+		faultLoc.AbortCode = fault.Abort.Code
+	}
+	if fault.Delay != "" {
+		if fault.Abort != nil {
+			faultLoc.DelayedLocation = fmt.Sprintf("@fault_%d_abort", index)
+		} else {
+			// error_page changes the method of the requests to GET unless it redirects them to a named location,
+			// so the delayed requests are passed to a named copy of the location of the action
+			delayedLoc := loc
+			delayedLoc.Path = fmt.Sprintf("@fault_%d_delayed", index)
+			delayedLoc.Internal = false
+			locations = append(locations, delayedLoc)
+			faultLoc.DelayedLocation = delayedLoc.Path
+		}
+	}
+
+	var splitClients []version2.SplitClient
+	var maps []version2.Map
+	destination := faultPath
+
+	if fault.Percentage != nil && *fault.Percentage < 100 {
+		sampleVariable := variableNamer.GetNameForFaultSampleVariable(index)
+		splitClients = append(splitClients, version2.SplitClient{
+			Source:   "$request_id",
+			Variable: sampleVariable,
+			Distributions: []version2.Distribution{
+				{
+					Weight: fmt.Sprintf("%d%%", *fault.Percentage),
+					Value:  faultPath,
+				},
+				{
+					Weight: "*",
+					Value:  passPath,
+				},
+			},
+		})
+		destination = sampleVariable
+	}
+
+	if fault.Header != nil {
+		value, isNegative := generateValueForMatchesRouteMap(fault.Header.Value)
+		valueResult, defaultResult := destination, passPath
+		if isNegative {
+			valueResult, defaultResult = passPath, destination
+		}
+
+		headerVariable := variableNamer.GetNameForFaultHeaderVariable(index)
+		maps = append(maps, version2.Map{
+			Source:   fmt.Sprintf("$http_%s", strings.ReplaceAll(fault.Header.Name, "-", "_")),
+			Variable: headerVariable,
+			Parameters: []version2.Parameter{
+				{
+					Value:  value,
+					Result: valueResult,
+				},
+				{
+					Value:  "default",
+					Result: defaultResult,
+				},
+			},
+		})
+		destination = headerVariable
+	}
+
+	return routingCfg{
+		Maps:         maps,
+		SplitClients: splitClients,
+		Locations:    locations,
+		InternalRedirectLocation: version2.InternalRedirectLocation{
+			Path:        route.Path,
+			Destination: destination,
+		},
+		FaultLocations: []version2.FaultLocation{faultLoc},
+	}
+}
+
 func generateMatchesConfig(route conf_v1.Route, upstreamNamer *upstreamNamer, crUpstreams map[string]conf_v1.Upstream,
 	variableNamer *variableNamer, index int, scIndex int, cfgParams *ConfigParams, errorPages errorPageDetails,
 	locSnippets string, enableSnippets bool, retLocIndex int, isVSR bool, vsrName string, vsrNamespace string, vscWarnings Warnings) routingCfg {
@@ -1881,22 +2906,39 @@ func generateMatchesConfig(route conf_v1.Route, upstreamNamer *upstreamNamer, cr
 	var maps []version2.Map
 
 	for i, m := range route.Matches {
-		for j, c := range m.Conditions {
-			source := getNameForSourceForMatchesRouteMapFromCondition(c)
-			variable := variableNamer.GetNameForVariableForMatchesRouteMap(index, i, j)
+		// the groups of anyOf follow the conditions in the chain of the maps of the match
+		groups := make([][]conf_v1.Condition, 0, len(m.Conditions)+len(m.AnyOf))
+		for _, c := range m.Conditions {
+			groups = append(groups, []conf_v1.Condition{c})
+		}
+		for _, g := range m.AnyOf {
+			groups = append(groups, g.Conditions)
+		}
+
+		for j, conditions := range groups {
 			successfulResult := "1"
-			if j < len(m.Conditions)-1 {
+			if j < len(groups)-1 {
 				successfulResult = variableNamer.GetNameForVariableForMatchesRouteMap(index, i, j+1)
 			}
 
-			params := generateParametersForMatchesRouteMap(c.Value, successfulResult)
+			// a request that doesn't satisfy a condition of a group falls back to the map of the next condition
+			for k, c := range conditions {
+				variable := variableNamer.GetNameForVariableForMatchesRouteMap(index, i, j)
+				if k > 0 {
+					variable = variableNamer.GetNameForVariableForMatchesRouteMapAlternative(index, i, j, k)
+				}
+				failedResult := "0"
+				if k < len(conditions)-1 {
+					failedResult = variableNamer.GetNameForVariableForMatchesRouteMapAlternative(index, i, j, k+1)
+				}
 
-			matchMap := version2.Map{
-				Source:     source,
-				Variable:   variable,
-				Parameters: params,
+				matchMap := version2.Map{
+					Source:     getNameForSourceForMatchesRouteMapFromCondition(c),
+					Variable:   variable,
+					Parameters: generateParametersForMatchesRouteMapFromCondition(c, successfulResult, failedResult),
+				}
+				maps = append(maps, matchMap)
 			}
-			maps = append(maps, matchMap)
 		}
 	}
 
@@ -1980,6 +3022,7 @@ func generateMatchesConfig(route conf_v1.Route, upstreamNamer *upstreamNamer, cr
 			newRetLocIndex := retLocIndex + len(returnLocations)
 			loc, returnLoc := generateLocation(path, upstreamName, upstream, m.Action, cfgParams, errorPages, true,
 				proxySSLName, route.Path, locSnippets, enableSnippets, newRetLocIndex, isVSR, vsrName, vsrNamespace, vscWarnings)
+			loc.Mirror = generateMirror(m.Action, upstreamNamer, crUpstreams, variableNamer, cfgParams)
 			locations = append(locations, loc)
 			if returnLoc != nil {
 				returnLocations = append(returnLocations, *returnLoc)
@@ -2018,6 +3061,7 @@ func generateMatchesConfig(route conf_v1.Route, upstreamNamer *upstreamNamer, cr
 		newRetLocIndex := retLocIndex + len(returnLocations)
 		loc, returnLoc := generateLocation(path, upstreamName, upstream, route.Action, cfgParams, errorPages, true,
 			proxySSLName, route.Path, locSnippets, enableSnippets, newRetLocIndex, isVSR, vsrName, vsrNamespace, vscWarnings)
+		loc.Mirror = generateMirror(route.Action, upstreamNamer, crUpstreams, variableNamer, cfgParams)
 		locations = append(locations, loc)
 		if returnLoc != nil {
 			returnLocations = append(returnLocations, *returnLoc)
@@ -2065,29 +3109,120 @@ func generateValueForMatchesRouteMap(matchedValue string) (value string, isNegat
 
 func generateParametersForMatchesRouteMap(matchedValue string, successfulResult string) []version2.Parameter {
 	value, isNegative := generateValueForMatchesRouteMap(matchedValue)
+	return generateParametersForMatchesRouteMapValues([]string{value}, isNegative, successfulResult, "0")
+}
+
+func generateParametersForMatchesRouteMapFromCondition(condition conf_v1.Condition, successfulResult string,
+	failedResult string) []version2.Parameter {
+	values, isNegative := generateValuesForMatchesRouteMapFromCondition(condition)
+	return generateParametersForMatchesRouteMapValues(values, isNegative, successfulResult, failedResult)
+}
 
+func generateParametersForMatchesRouteMapValues(values []string, isNegative bool, successfulResult string,
+	failedResult string) []version2.Parameter {
 	valueResult := successfulResult
-	defaultResult := "0"
+	defaultResult := failedResult
 	if isNegative {
-		valueResult = "0"
+		valueResult = failedResult
 		defaultResult = successfulResult
 	}
 
-	params := []version2.Parameter{
-		{
-			Value:  value,
+	var params []version2.Parameter
+	for _, v := range values {
+		params = append(params, version2.Parameter{
+			Value:  v,
 			Result: valueResult,
-		},
-		{
-			Value:  "default",
-			Result: defaultResult,
-		},
+		})
 	}
 
+	params = append(params, version2.Parameter{
+		Value:  "default",
+		Result: defaultResult,
+	})
+
 	return params
 }
 
+// generateValuesForMatchesRouteMapFromCondition returns the values of the map of a condition.
+// The prefix, suffix and regex operators are converted to regular expressions. The exact operator uses a string
+// comparison, which is case-insensitive in NGINX maps.
+func generateValuesForMatchesRouteMapFromCondition(condition conf_v1.Condition) (values []string, isNegative bool) {
+	if len(condition.Method) > 0 {
+		for _, m := range condition.Method {
+			values = append(values, fmt.Sprintf(`"%s"`, m))
+		}
+		return values, false
+	}
+
+	switch condition.Operator {
+	case "present":
+		// a missing header, cookie or argument has an empty value
+		return []string{`""`}, true
+	case "absent":
+		return []string{`""`}, false
+	}
+
+	if condition.Operator == "" || condition.Operator == "exact" {
+		value, isNegative := generateValueForMatchesRouteMap(condition.Value)
+		return []string{value}, isNegative
+	}
+
+	matchedValue := condition.Value
+	if strings.HasPrefix(matchedValue, "!") {
+		isNegative = true
+		matchedValue = matchedValue[1:]
+	}
+
+	var regex string
+	switch condition.Operator {
+	case "prefix":
+		regex = "^" + escapeMatchValueForRegex(matchedValue)
+	case "suffix":
+		regex = escapeMatchValueForRegex(matchedValue) + "$"
+	case "regex":
+		regex = matchedValue
+	}
+
+	modifier := "~"
+	if condition.IgnoreCase {
+		modifier = "~*"
+	}
+
+	return []string{fmt.Sprintf(`"%s%s"`, modifier, regex)}, isNegative
+}
+
+// escapeMatchValueForRegex escapes the regular expression metacharacters of a match value.
+// The value is an escaped string, so the escape sequences are kept, except for an escaped backslash,
+// which NGINX unescapes before compiling the regular expression.
+func escapeMatchValueForRegex(value string) string {
+	var b strings.Builder
+
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if c == '\\' && i+1 < len(value) {
+			i++
+			if value[i] == '\\' {
+				b.WriteString(`\\\\`)
+			} else {
+				b.WriteByte(c)
+				b.WriteByte(value[i])
+			}
+			continue
+		}
+		if strings.IndexByte(`.+*?()|[]{}^$`, c) != -1 {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(c)
+	}
+
+	return b.String()
+}
+
 func getNameForSourceForMatchesRouteMapFromCondition(condition conf_v1.Condition) string {
+	if len(condition.Method) > 0 {
+		return "$request_method"
+	}
+
 	if condition.Header != "" {
 		return fmt.Sprintf("$http_%s", strings.ReplaceAll(condition.Header, "-", "_"))
 	}
@@ -2232,6 +3367,14 @@ func createUpstreamsForPlus(
 		}
 	}
 
+	for _, ups := range vsc.generateExternalAuthUpstreams(virtualServerEx) {
+		if ups.Resolve {
+			glog.V(3).Infof("Service %s is Type ExternalName, skipping NGINX Plus endpoints update via API", ups.UpstreamLabels.Service)
+			continue
+		}
+		upstreams = append(upstreams, ups)
+	}
+
 	return upstreams
 }
 
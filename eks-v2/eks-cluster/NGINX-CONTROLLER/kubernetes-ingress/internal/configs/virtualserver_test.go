@@ -128,6 +128,50 @@ func TestUpstreamNamerForVirtualServerRoute(t *testing.T) {
 	}
 }
 
+func TestGenerateUpstreamNameFromAction(t *testing.T) {
+	virtualServer := conf_v1.VirtualServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "cafe",
+			Namespace: "default",
+		},
+	}
+	virtualServerRoute := conf_v1.VirtualServerRoute{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "coffee",
+			Namespace: "default",
+		},
+	}
+	tests := []struct {
+		vsr      *conf_v1.VirtualServerRoute
+		action   *conf_v1.Action
+		expected string
+	}{
+		{
+			vsr: nil,
+			action: &conf_v1.Action{
+				Pass: "test",
+			},
+			expected: "vs_default_cafe_test",
+		},
+		{
+			vsr: &virtualServerRoute,
+			action: &conf_v1.Action{
+				Proxy: &conf_v1.ActionProxy{
+					Upstream: "test",
+				},
+			},
+			expected: "vs_default_cafe_vsr_default_coffee_test",
+		},
+	}
+
+	for _, test := range tests {
+		result := GenerateUpstreamNameFromAction(&virtualServer, test.vsr, test.action)
+		if result != test.expected {
+			t.Errorf("GenerateUpstreamNameFromAction() returned %q but expected %q", result, test.expected)
+		}
+	}
+}
+
 func TestVariableNamerSafeNsName(t *testing.T) {
 	virtualServer := conf_v1.VirtualServer{
 		ObjectMeta: meta_v1.ObjectMeta{
@@ -2570,6 +2614,12 @@ func TestGeneratePolicies(t *testing.T) {
 				},
 				Path: "/etc/nginx/secrets/default-jwt-secret",
 			},
+			"default/htpasswd-secret": {
+				Secret: &api_v1.Secret{
+					Type: secrets.SecretTypeHtpasswd,
+				},
+				Path: "/etc/nginx/secrets/default-htpasswd-secret",
+			},
 			"default/oidc-secret": {
 				Secret: &api_v1.Secret{
 					Type: secrets.SecretTypeOIDC,
@@ -2708,6 +2758,46 @@ func TestGeneratePolicies(t *testing.T) {
 			},
 			msg: "rate limit reference",
 		},
+		{
+			policyRefs: []conf_v1.PolicyReference{
+				{
+					Name:      "connectionLimit-policy",
+					Namespace: "default",
+				},
+			},
+			policies: map[string]*conf_v1.Policy{
+				"default/connectionLimit-policy": {
+					Spec: conf_v1.PolicySpec{
+						ConnectionLimit: &conf_v1.ConnectionLimit{
+							Key:            "test",
+							ZoneSize:       "10M",
+							MaxConnections: 10,
+							RejectCode:     createPointerFromInt(429),
+						},
+					},
+				},
+			},
+			expected: policiesCfg{
+				LimitConnZones: []version2.LimitConnZone{
+					{
+						Key:      "test",
+						ZoneSize: "10M",
+						ZoneName: "pol_cl_default_connectionLimit-policy_default_test",
+					},
+				},
+				LimitConnOptions: version2.LimitConnOptions{
+					LogLevel:   "error",
+					RejectCode: 429,
+				},
+				LimitConns: []version2.LimitConn{
+					{
+						ZoneName:    "pol_cl_default_connectionLimit-policy_default_test",
+						Connections: 10,
+					},
+				},
+			},
+			msg: "connection limit reference",
+		},
 		{
 			policyRefs: []conf_v1.PolicyReference{
 				{
@@ -2801,213 +2891,611 @@ func TestGeneratePolicies(t *testing.T) {
 		{
 			policyRefs: []conf_v1.PolicyReference{
 				{
-					Name:      "ingress-mtls-policy",
+					Name:      "basic-auth-policy",
 					Namespace: "default",
 				},
 			},
 			policies: map[string]*conf_v1.Policy{
-				"default/ingress-mtls-policy": {
+				"default/basic-auth-policy": {
 					ObjectMeta: meta_v1.ObjectMeta{
-						Name:      "ingress-mtls-policy",
+						Name:      "basic-auth-policy",
 						Namespace: "default",
 					},
 					Spec: conf_v1.PolicySpec{
-						IngressMTLS: &conf_v1.IngressMTLS{
-							ClientCertSecret: "ingress-mtls-secret",
-							VerifyClient:     "off",
+						BasicAuth: &conf_v1.BasicAuth{
+							Realm:  "My Test Dashboard",
+							Secret: "htpasswd-secret",
 						},
 					},
 				},
 			},
-			context: "spec",
 			expected: policiesCfg{
-				IngressMTLS: &version2.IngressMTLS{
-					ClientCert:   ingressMTLSCertPath,
-					VerifyClient: "off",
-					VerifyDepth:  1,
+				BasicAuth: &version2.BasicAuth{
+					Secret: "/etc/nginx/secrets/default-htpasswd-secret",
+					Realm:  "My Test Dashboard",
 				},
 			},
-			msg: "ingressMTLS reference",
+			msg: "basic auth reference",
 		},
 		{
 			policyRefs: []conf_v1.PolicyReference{
 				{
-					Name:      "egress-mtls-policy",
+					Name:      "external-auth-policy",
 					Namespace: "default",
 				},
 			},
 			policies: map[string]*conf_v1.Policy{
-				"default/egress-mtls-policy": {
+				"default/external-auth-policy": {
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      "external-auth-policy",
+						Namespace: "default",
+					},
 					Spec: conf_v1.PolicySpec{
-						EgressMTLS: &conf_v1.EgressMTLS{
-							TLSSecret:         "egress-mtls-secret",
-							ServerName:        true,
-							SessionReuse:      createPointerFromBool(false),
-							TrustedCertSecret: "egress-trusted-ca-secret",
+						ExternalAuth: &conf_v1.ExternalAuth{
+							AuthServiceName: "authz",
+							AuthServicePort: 8080,
+							AuthURI:         "/check",
+							ForwardHeaders:  []string{"Authorization"},
+							ResponseHeaders: []string{"X-User"},
+							SigninURL:       "https://login.example.com/signin",
+							CacheTTL:        "30s",
 						},
 					},
 				},
 			},
-			context: "route",
 			expected: policiesCfg{
-				EgressMTLS: &version2.EgressMTLS{
-					Certificate:    "/etc/nginx/secrets/default-egress-mtls-secret",
-					CertificateKey: "/etc/nginx/secrets/default-egress-mtls-secret",
-					Ciphers:        "DEFAULT",
-					Protocols:      "TLSv1 TLSv1.1 TLSv1.2",
-					ServerName:     true,
-					SessionReuse:   false,
-					VerifyDepth:    1,
-					VerifyServer:   false,
-					TrustedCert:    "/etc/nginx/secrets/default-egress-trusted-ca-secret",
-					SSLName:        "$proxy_host",
+				ExternalAuth: &version2.ExternalAuth{
+					Location: "/_ext_auth_default_external-auth-policy",
+					ResponseHeaders: []version2.ExternalAuthResponseHeader{
+						{
+							Name:             "X-User",
+							Variable:         "$ext_auth_x_user",
+							UpstreamVariable: "$upstream_http_x_user",
+						},
+					},
+					SigninLocation: "@ext_auth_signin_default_external-auth-policy",
+				},
+				ExternalAuthLocations: []version2.ExternalAuthLocation{
+					{
+						Path:      "/_ext_auth_default_external-auth-policy",
+						ProxyPass: "http://vs_default_test_ext_auth_default_external-auth-policy/check",
+						ForwardHeaders: []version2.ExternalAuthForwardHeader{
+							{
+								Name:     "Authorization",
+								Variable: "$http_authorization",
+							},
+						},
+						CacheZone:      "ext_auth_default_external-auth-policy_default_test",
+						CacheKey:       "$request_method$host$request_uri$http_authorization",
+						CacheValid:     "30s",
+						SigninLocation: "@ext_auth_signin_default_external-auth-policy",
+						SigninURL:      "https://login.example.com/signin?rd=$scheme://$host$request_uri",
+					},
+				},
+				CacheZones: []version2.CacheZone{
+					{
+						Name:     "ext_auth_default_external-auth-policy_default_test",
+						Path:     "/var/cache/nginx/ext_auth_default_external-auth-policy_default_test",
+						Size:     "1m",
+						Inactive: "30s",
+					},
 				},
 			},
-			msg: "egressMTLS reference",
+			msg: "external auth reference",
 		},
 		{
 			policyRefs: []conf_v1.PolicyReference{
 				{
-					Name:      "oidc-policy",
+					Name:      "cors-policy",
 					Namespace: "default",
 				},
 			},
 			policies: map[string]*conf_v1.Policy{
-				"default/oidc-policy": {
+				"default/cors-policy": {
 					ObjectMeta: meta_v1.ObjectMeta{
-						Name:      "oidc-policy",
+						Name:      "cors-policy",
 						Namespace: "default",
 					},
 					Spec: conf_v1.PolicySpec{
-						OIDC: &conf_v1.OIDC{
-							AuthEndpoint:  "http://example.com/auth",
-							TokenEndpoint: "http://example.com/token",
-							JWKSURI:       "http://example.com/jwks",
-							ClientID:      "client-id",
-							ClientSecret:  "oidc-secret",
-							Scope:         "scope",
-							RedirectURI:   "/redirect",
+						CORS: &conf_v1.CORS{
+							AllowOrigins:     []string{"https://app.example.com", "https://*.example.org", `~^https://app[0-9]+\.example\.com$`},
+							AllowMethods:     []string{"GET", "POST"},
+							AllowHeaders:     []string{"Authorization", "Content-Type"},
+							ExposeHeaders:    []string{"X-Request-ID"},
+							AllowCredentials: true,
+							MaxAge:           createPointerFromInt(600),
 						},
 					},
 				},
 			},
 			expected: policiesCfg{
-				OIDC: true,
+				CORS: &version2.CORS{
+					OriginVariable:    "$cors_origin_default_cors_policy_default_test",
+					PreflightVariable: "$cors_preflight_default_cors_policy_default_test",
+					AllowMethods:      "GET, POST",
+					AllowHeaders:      "Authorization, Content-Type",
+					ExposeHeaders:     "X-Request-ID",
+					AllowCredentials:  true,
+					MaxAge:            "600",
+				},
+				Maps: []version2.Map{
+					{
+						Source:   "$http_origin",
+						Variable: "$cors_origin_default_cors_policy_default_test",
+						Parameters: []version2.Parameter{
+							{
+								Value:  `"https://app.example.com"`,
+								Result: "$http_origin",
+							},
+							{
+								Value:  `"~*^https://[a-z0-9.-]+\.example\.org$"`,
+								Result: "$http_origin",
+							},
+							{
+								Value:  `"~^https://app[0-9]+\.example\.com$"`,
+								Result: "$http_origin",
+							},
+							{
+								Value:  "default",
+								Result: `""`,
+							},
+						},
+					},
+					{
+						Source:   "$request_method:$http_access_control_request_method:$cors_origin_default_cors_policy_default_test",
+						Variable: "$cors_preflight_default_cors_policy_default_test",
+						Parameters: []version2.Parameter{
+							{
+								Value:  `"~^OPTIONS:[^:]+:."`,
+								Result: "1",
+							},
+							{
+								Value:  "default",
+								Result: "0",
+							},
+						},
+					},
+				},
 			},
-			msg: "oidc reference",
+			msg: "cors reference",
 		},
 		{
 			policyRefs: []conf_v1.PolicyReference{
 				{
-					Name:      "waf-policy",
+					Name:      "cors-policy",
 					Namespace: "default",
 				},
 			},
 			policies: map[string]*conf_v1.Policy{
-				"default/waf-policy": {
+				"default/cors-policy": {
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      "cors-policy",
+						Namespace: "default",
+					},
 					Spec: conf_v1.PolicySpec{
-						WAF: &conf_v1.WAF{
-							Enable:   true,
-							ApPolicy: "default/dataguard-alarm",
-							SecurityLog: &conf_v1.SecurityLog{
-								Enable:    true,
-								ApLogConf: "default/logconf",
-								LogDest:   "syslog:server=127.0.0.1:514",
-							},
+						CORS: &conf_v1.CORS{
+							AllowOrigins:     []string{"*"},
+							AllowCredentials: true,
 						},
 					},
 				},
 			},
-			context: "route",
 			expected: policiesCfg{
-				WAF: &version2.WAF{
-					Enable:              "on",
-					ApPolicy:            "/etc/nginx/waf/nac-policies/default-dataguard-alarm",
-					ApSecurityLogEnable: true,
-					ApLogConf:           "/etc/nginx/waf/nac-logconfs/default-logconf syslog:server=127.0.0.1:514",
+				CORS: &version2.CORS{
+					OriginVariable:    "$cors_origin_default_cors_policy_default_test",
+					PreflightVariable: "$cors_preflight_default_cors_policy_default_test",
+					AllowMethods:      "GET, HEAD, POST",
+				},
+				Maps: []version2.Map{
+					{
+						Source:   "$http_origin",
+						Variable: "$cors_origin_default_cors_policy_default_test",
+						Parameters: []version2.Parameter{
+							{
+								Value:  `""`,
+								Result: `""`,
+							},
+							{
+								Value:  "default",
+								Result: `"*"`,
+							},
+						},
+					},
+					{
+						Source:   "$request_method:$http_access_control_request_method:$cors_origin_default_cors_policy_default_test",
+						Variable: "$cors_preflight_default_cors_policy_default_test",
+						Parameters: []version2.Parameter{
+							{
+								Value:  `"~^OPTIONS:[^:]+:."`,
+								Result: "1",
+							},
+							{
+								Value:  "default",
+								Result: "0",
+							},
+						},
+					},
 				},
 			},
-			msg: "WAF reference",
+			msg: "cors reference with all origins",
 		},
-	}
-
-	vsc := newVirtualServerConfigurator(&ConfigParams{}, false, false, &StaticConfigParams{}, false)
-
-	for _, test := range tests {
-		result := vsc.generatePolicies(ownerDetails, test.policyRefs, test.policies, test.context, policyOpts)
-		if diff := cmp.Diff(test.expected, result); diff != "" {
-			t.Errorf("generatePolicies() '%v' mismatch (-want +got):\n%s", test.msg, diff)
-		}
-		if len(vsc.warnings) > 0 {
-			t.Errorf("generatePolicies() returned unexpected warnings %v for the case of %s", vsc.warnings, test.msg)
-		}
-	}
-}
-
-func TestGeneratePoliciesFails(t *testing.T) {
-	ownerDetails := policyOwnerDetails{
-		owner:          nil, // nil is OK for the unit test
-		ownerNamespace: "default",
-		vsNamespace:    "default",
-		vsName:         "test",
-	}
-
-	dryRunOverride := true
-	rejectCodeOverride := 505
-
-	tests := []struct {
-		policyRefs        []conf_v1.PolicyReference
-		policies          map[string]*conf_v1.Policy
-		policyOpts        policyOptions
-		trustedCAFileName string
-		context           string
-		oidcPolCfg        *oidcPolicyCfg
-		expected          policiesCfg
-		expectedWarnings  Warnings
-		expectedOidc      *oidcPolicyCfg
-		msg               string
-	}{
 		{
 			policyRefs: []conf_v1.PolicyReference{
 				{
-					Name:      "allow-policy",
+					Name:      "cache-policy",
 					Namespace: "default",
 				},
 			},
-			policies:   map[string]*conf_v1.Policy{},
-			policyOpts: policyOptions{},
-			expected: policiesCfg{
-				ErrorReturn: &version2.Return{
-					Code: 500,
+			policies: map[string]*conf_v1.Policy{
+				"default/cache-policy": {
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      "cache-policy",
+						Namespace: "default",
+					},
+					Spec: conf_v1.PolicySpec{
+						Cache: &conf_v1.Cache{
+							ZoneSize: "10m",
+							MaxSize:  "1g",
+							Valid: []conf_v1.CacheValid{
+								{
+									Codes: []int{200, 301},
+									Time:  "10m",
+								},
+								{
+									Time: "1m",
+								},
+							},
+							Key:                  "${scheme}${host}${request_uri}",
+							Methods:              []string{"GET", "HEAD"},
+							Bypass:               []string{"${cookie_nocache}"},
+							Lock:                 true,
+							StaleWhileRevalidate: true,
+						},
+					},
 				},
 			},
-			expectedWarnings: Warnings{
-				nil: {
-					"Policy default/allow-policy is missing or invalid",
+			expected: policiesCfg{
+				Cache: &version2.Cache{
+					ZoneName: "pol_cache_default_cache-policy",
+					Key:      "${scheme}${host}${request_uri}",
+					Valid: []version2.CacheValid{
+						{
+							Codes: []int{200, 301},
+							Time:  "10m",
+						},
+						{
+							Time: "1m",
+						},
+					},
+					Methods:              "GET HEAD",
+					Bypass:               []string{"${cookie_nocache}"},
+					Lock:                 true,
+					StaleWhileRevalidate: true,
+				},
+				PolicyCacheZones: []version2.CacheZone{
+					{
+						Name:    "pol_cache_default_cache-policy",
+						Path:    "/var/cache/nginx/pol_cache_default_cache-policy",
+						Size:    "10m",
+						MaxSize: "1g",
+					},
 				},
 			},
-			expectedOidc: &oidcPolicyCfg{},
-			msg:          "missing policy",
+			msg: "cache reference",
 		},
 		{
 			policyRefs: []conf_v1.PolicyReference{
 				{
-					Name: "allow-policy",
-				},
-				{
-					Name: "deny-policy",
+					Name:      "retry-policy",
+					Namespace: "default",
 				},
 			},
 			policies: map[string]*conf_v1.Policy{
-				"default/allow-policy": {
+				"default/retry-policy": {
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      "retry-policy",
+						Namespace: "default",
+					},
 					Spec: conf_v1.PolicySpec{
-						AccessControl: &conf_v1.AccessControl{
-							Allow: []string{"127.0.0.1"},
-						},
+						Retry: &conf_v1.Retry{},
 					},
 				},
-				"default/deny-policy": {
-					Spec: conf_v1.PolicySpec{
+			},
+			context: "route",
+			expected: policiesCfg{
+				Retry: &retryCfg{
+					NextUpstream:        "error timeout",
+					NextUpstreamTimeout: "0s",
+					NextUpstreamTries:   0,
+				},
+			},
+			msg: "retry reference with defaults",
+		},
+		{
+			policyRefs: []conf_v1.PolicyReference{
+				{
+					Name:      "retry-policy",
+					Namespace: "default",
+				},
+			},
+			policies: map[string]*conf_v1.Policy{
+				"default/retry-policy": {
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      "retry-policy",
+						Namespace: "default",
+					},
+					Spec: conf_v1.PolicySpec{
+						Retry: &conf_v1.Retry{
+							Conditions:    []string{"error", "http_503"},
+							Methods:       []string{"GET", "POST"},
+							Attempts:      createPointerFromInt(3),
+							PerTryTimeout: "2s",
+							Timeout:       "10s",
+							Sample: &conf_v1.RetrySample{
+								Percentage: 20,
+							},
+						},
+					},
+				},
+			},
+			context: "subroute",
+			expected: policiesCfg{
+				Retry: &retryCfg{
+					NextUpstream:        "error http_503 non_idempotent",
+					NextUpstreamTimeout: "10s",
+					NextUpstreamTries:   3,
+					PerTryTimeout:       "2s",
+					EligibilityVariable: "$retry_methods_default_retry_policy_default_test",
+				},
+				SplitClients: []version2.SplitClient{
+					{
+						Source:   "$request_id",
+						Variable: "$retry_sample_default_retry_policy_default_test",
+						Distributions: []version2.Distribution{
+							{
+								Weight: "20%",
+								Value:  "1",
+							},
+							{
+								Weight: "*",
+								Value:  `""`,
+							},
+						},
+					},
+				},
+				Maps: []version2.Map{
+					{
+						Source:   "$request_method",
+						Variable: "$retry_methods_default_retry_policy_default_test",
+						Parameters: []version2.Parameter{
+							{
+								Value:  "GET",
+								Result: "$retry_sample_default_retry_policy_default_test",
+							},
+							{
+								Value:  "POST",
+								Result: "$retry_sample_default_retry_policy_default_test",
+							},
+							{
+								Value:  "default",
+								Result: `""`,
+							},
+						},
+					},
+				},
+			},
+			msg: "retry reference with methods and sample",
+		},
+		{
+			policyRefs: []conf_v1.PolicyReference{
+				{
+					Name:      "ingress-mtls-policy",
+					Namespace: "default",
+				},
+			},
+			policies: map[string]*conf_v1.Policy{
+				"default/ingress-mtls-policy": {
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      "ingress-mtls-policy",
+						Namespace: "default",
+					},
+					Spec: conf_v1.PolicySpec{
+						IngressMTLS: &conf_v1.IngressMTLS{
+							ClientCertSecret: "ingress-mtls-secret",
+							VerifyClient:     "off",
+						},
+					},
+				},
+			},
+			context: "spec",
+			expected: policiesCfg{
+				IngressMTLS: &version2.IngressMTLS{
+					ClientCert:   ingressMTLSCertPath,
+					VerifyClient: "off",
+					VerifyDepth:  1,
+				},
+			},
+			msg: "ingressMTLS reference",
+		},
+		{
+			policyRefs: []conf_v1.PolicyReference{
+				{
+					Name:      "egress-mtls-policy",
+					Namespace: "default",
+				},
+			},
+			policies: map[string]*conf_v1.Policy{
+				"default/egress-mtls-policy": {
+					Spec: conf_v1.PolicySpec{
+						EgressMTLS: &conf_v1.EgressMTLS{
+							TLSSecret:         "egress-mtls-secret",
+							ServerName:        true,
+							SessionReuse:      createPointerFromBool(false),
+							TrustedCertSecret: "egress-trusted-ca-secret",
+						},
+					},
+				},
+			},
+			context: "route",
+			expected: policiesCfg{
+				EgressMTLS: &version2.EgressMTLS{
+					Certificate:    "/etc/nginx/secrets/default-egress-mtls-secret",
+					CertificateKey: "/etc/nginx/secrets/default-egress-mtls-secret",
+					Ciphers:        "DEFAULT",
+					Protocols:      "TLSv1 TLSv1.1 TLSv1.2",
+					ServerName:     true,
+					SessionReuse:   false,
+					VerifyDepth:    1,
+					VerifyServer:   false,
+					TrustedCert:    "/etc/nginx/secrets/default-egress-trusted-ca-secret",
+					SSLName:        "$proxy_host",
+				},
+			},
+			msg: "egressMTLS reference",
+		},
+		{
+			policyRefs: []conf_v1.PolicyReference{
+				{
+					Name:      "oidc-policy",
+					Namespace: "default",
+				},
+			},
+			policies: map[string]*conf_v1.Policy{
+				"default/oidc-policy": {
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      "oidc-policy",
+						Namespace: "default",
+					},
+					Spec: conf_v1.PolicySpec{
+						OIDC: &conf_v1.OIDC{
+							AuthEndpoint:  "http://example.com/auth",
+							TokenEndpoint: "http://example.com/token",
+							JWKSURI:       "http://example.com/jwks",
+							ClientID:      "client-id",
+							ClientSecret:  "oidc-secret",
+							Scope:         "scope",
+							RedirectURI:   "/redirect",
+						},
+					},
+				},
+			},
+			expected: policiesCfg{
+				OIDC: true,
+			},
+			msg: "oidc reference",
+		},
+		{
+			policyRefs: []conf_v1.PolicyReference{
+				{
+					Name:      "waf-policy",
+					Namespace: "default",
+				},
+			},
+			policies: map[string]*conf_v1.Policy{
+				"default/waf-policy": {
+					Spec: conf_v1.PolicySpec{
+						WAF: &conf_v1.WAF{
+							Enable:   true,
+							ApPolicy: "default/dataguard-alarm",
+							SecurityLog: &conf_v1.SecurityLog{
+								Enable:    true,
+								ApLogConf: "default/logconf",
+								LogDest:   "syslog:server=127.0.0.1:514",
+							},
+						},
+					},
+				},
+			},
+			context: "route",
+			expected: policiesCfg{
+				WAF: &version2.WAF{
+					Enable:              "on",
+					ApPolicy:            "/etc/nginx/waf/nac-policies/default-dataguard-alarm",
+					ApSecurityLogEnable: true,
+					ApLogConf:           "/etc/nginx/waf/nac-logconfs/default-logconf syslog:server=127.0.0.1:514",
+				},
+			},
+			msg: "WAF reference",
+		},
+	}
+
+	vsc := newVirtualServerConfigurator(&ConfigParams{}, false, false, &StaticConfigParams{}, false)
+
+	for _, test := range tests {
+		result := vsc.generatePolicies(ownerDetails, test.policyRefs, test.policies, test.context, policyOpts)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("generatePolicies() '%v' mismatch (-want +got):\n%s", test.msg, diff)
+		}
+		if len(vsc.warnings) > 0 {
+			t.Errorf("generatePolicies() returned unexpected warnings %v for the case of %s", vsc.warnings, test.msg)
+		}
+	}
+}
+
+func TestGeneratePoliciesFails(t *testing.T) {
+	ownerDetails := policyOwnerDetails{
+		owner:          nil, // nil is OK for the unit test
+		ownerNamespace: "default",
+		vsNamespace:    "default",
+		vsName:         "test",
+	}
+
+	dryRunOverride := true
+	rejectCodeOverride := 505
+
+	tests := []struct {
+		policyRefs        []conf_v1.PolicyReference
+		policies          map[string]*conf_v1.Policy
+		policyOpts        policyOptions
+		trustedCAFileName string
+		context           string
+		oidcPolCfg        *oidcPolicyCfg
+		expected          policiesCfg
+		expectedWarnings  Warnings
+		expectedOidc      *oidcPolicyCfg
+		msg               string
+	}{
+		{
+			policyRefs: []conf_v1.PolicyReference{
+				{
+					Name:      "allow-policy",
+					Namespace: "default",
+				},
+			},
+			policies:   map[string]*conf_v1.Policy{},
+			policyOpts: policyOptions{},
+			expected: policiesCfg{
+				ErrorReturn: &version2.Return{
+					Code: 500,
+				},
+			},
+			expectedWarnings: Warnings{
+				nil: {
+					"Policy default/allow-policy is missing or invalid",
+				},
+			},
+			expectedOidc: &oidcPolicyCfg{},
+			msg:          "missing policy",
+		},
+		{
+			policyRefs: []conf_v1.PolicyReference{
+				{
+					Name: "allow-policy",
+				},
+				{
+					Name: "deny-policy",
+				},
+			},
+			policies: map[string]*conf_v1.Policy{
+				"default/allow-policy": {
+					Spec: conf_v1.PolicySpec{
+						AccessControl: &conf_v1.AccessControl{
+							Allow: []string{"127.0.0.1"},
+						},
+					},
+				},
+				"default/deny-policy": {
+					Spec: conf_v1.PolicySpec{
 						AccessControl: &conf_v1.AccessControl{
 							Deny: []string{"127.0.0.2"},
 						},
@@ -3190,12 +3678,55 @@ func TestGeneratePoliciesFails(t *testing.T) {
 		{
 			policyRefs: []conf_v1.PolicyReference{
 				{
-					Name:      "jwt-policy",
+					Name:      "basic-auth-policy",
 					Namespace: "default",
 				},
-				{
-					Name:      "jwt-policy2",
-					Namespace: "default",
+			},
+			policies: map[string]*conf_v1.Policy{
+				"default/basic-auth-policy": {
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      "basic-auth-policy",
+						Namespace: "default",
+					},
+					Spec: conf_v1.PolicySpec{
+						BasicAuth: &conf_v1.BasicAuth{
+							Realm:  "test",
+							Secret: "htpasswd-secret",
+						},
+					},
+				},
+			},
+			policyOpts: policyOptions{
+				secretRefs: map[string]*secrets.SecretReference{
+					"default/htpasswd-secret": {
+						Secret: &api_v1.Secret{
+							Type: secrets.SecretTypeCA,
+						},
+					},
+				},
+			},
+			expected: policiesCfg{
+				ErrorReturn: &version2.Return{
+					Code: 500,
+				},
+			},
+			expectedWarnings: Warnings{
+				nil: {
+					`Basic Auth policy default/basic-auth-policy references a secret default/htpasswd-secret of a wrong type 'nginx.org/ca', must be 'nginx.org/htpasswd'`,
+				},
+			},
+			expectedOidc: &oidcPolicyCfg{},
+			msg:          "basic auth references wrong secret type",
+		},
+		{
+			policyRefs: []conf_v1.PolicyReference{
+				{
+					Name:      "jwt-policy",
+					Namespace: "default",
+				},
+				{
+					Name:      "jwt-policy2",
+					Namespace: "default",
 				},
 			},
 			policies: map[string]*conf_v1.Policy{
@@ -3443,6 +3974,79 @@ func TestGeneratePoliciesFails(t *testing.T) {
 			expectedOidc: &oidcPolicyCfg{},
 			msg:          "ingress mtls in the wrong context",
 		},
+		{
+			policyRefs: []conf_v1.PolicyReference{
+				{
+					Name:      "retry-policy",
+					Namespace: "default",
+				},
+			},
+			policies: map[string]*conf_v1.Policy{
+				"default/retry-policy": {
+					Spec: conf_v1.PolicySpec{
+						Retry: &conf_v1.Retry{},
+					},
+				},
+			},
+			policyOpts: policyOptions{},
+			context:    "spec",
+			expected: policiesCfg{
+				ErrorReturn: &version2.Return{
+					Code: 500,
+				},
+			},
+			expectedWarnings: Warnings{
+				nil: {
+					`Retry policy default/retry-policy is not allowed in the spec context`,
+				},
+			},
+			expectedOidc: &oidcPolicyCfg{},
+			msg:          "retry in the wrong context",
+		},
+		{
+			policyRefs: []conf_v1.PolicyReference{
+				{
+					Name:      "retry-policy",
+					Namespace: "default",
+				},
+				{
+					Name:      "retry-policy2",
+					Namespace: "default",
+				},
+			},
+			policies: map[string]*conf_v1.Policy{
+				"default/retry-policy": {
+					Spec: conf_v1.PolicySpec{
+						Retry: &conf_v1.Retry{
+							Attempts: createPointerFromInt(2),
+						},
+					},
+				},
+				"default/retry-policy2": {
+					Spec: conf_v1.PolicySpec{
+						Retry: &conf_v1.Retry{
+							Attempts: createPointerFromInt(3),
+						},
+					},
+				},
+			},
+			policyOpts: policyOptions{},
+			context:    "route",
+			expected: policiesCfg{
+				Retry: &retryCfg{
+					NextUpstream:        "error timeout",
+					NextUpstreamTimeout: "0s",
+					NextUpstreamTries:   2,
+				},
+			},
+			expectedWarnings: Warnings{
+				nil: {
+					`Multiple retry policies in the same context is not valid. Retry policy default/retry-policy2 will be ignored`,
+				},
+			},
+			expectedOidc: &oidcPolicyCfg{},
+			msg:          "multi retry reference",
+		},
 		{
 			policyRefs: []conf_v1.PolicyReference{
 				{
@@ -4121,6 +4725,91 @@ func TestRemoveDuplicates(t *testing.T) {
 	}
 }
 
+func TestRemoveDuplicateLimitConnZones(t *testing.T) {
+	zones := []version2.LimitConnZone{
+		{ZoneName: "test"},
+		{ZoneName: "test"},
+		{ZoneName: "test2"},
+	}
+	expected := []version2.LimitConnZone{
+		{ZoneName: "test"},
+		{ZoneName: "test2"},
+	}
+
+	result := removeDuplicateLimitConnZones(zones)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("removeDuplicateLimitConnZones() returned \n%v, but expected \n%v", result, expected)
+	}
+}
+
+func TestRemoveDuplicateCacheZones(t *testing.T) {
+	zones := []version2.CacheZone{
+		{Name: "test"},
+		{Name: "test"},
+		{Name: "test2"},
+	}
+	expected := []version2.CacheZone{
+		{Name: "test"},
+		{Name: "test2"},
+	}
+
+	result := removeDuplicateCacheZones(zones)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("removeDuplicateCacheZones() returned \n%v, but expected \n%v", result, expected)
+	}
+}
+
+func TestRemoveDuplicateMaps(t *testing.T) {
+	maps := []version2.Map{
+		{Variable: "$cors_origin_default_cors"},
+		{Variable: "$cors_origin_default_cors"},
+		{Variable: "$cors_preflight_default_cors"},
+	}
+	expected := []version2.Map{
+		{Variable: "$cors_origin_default_cors"},
+		{Variable: "$cors_preflight_default_cors"},
+	}
+
+	result := removeDuplicateMaps(maps)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("removeDuplicateMaps() returned \n%v, but expected \n%v", result, expected)
+	}
+}
+
+func TestRemoveDuplicateSplitClients(t *testing.T) {
+	splitClients := []version2.SplitClient{
+		{Variable: "$retry_sample_default_retry_default_cafe"},
+		{Variable: "$retry_sample_default_retry_default_cafe"},
+		{Variable: "$retry_sample_default_retry2_default_cafe"},
+	}
+	expected := []version2.SplitClient{
+		{Variable: "$retry_sample_default_retry_default_cafe"},
+		{Variable: "$retry_sample_default_retry2_default_cafe"},
+	}
+
+	result := removeDuplicateSplitClients(splitClients)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("removeDuplicateSplitClients() returned \n%v, but expected \n%v", result, expected)
+	}
+}
+
+func TestRemoveDuplicateExternalAuthLocations(t *testing.T) {
+	locations := []version2.ExternalAuthLocation{
+		{Path: "/_ext_auth_default_auth"},
+		{Path: "/_ext_auth_default_auth"},
+		{Path: "/_ext_auth_default_auth2"},
+	}
+	expected := []version2.ExternalAuthLocation{
+		{Path: "/_ext_auth_default_auth"},
+		{Path: "/_ext_auth_default_auth2"},
+	}
+
+	result := removeDuplicateExternalAuthLocations(locations)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("removeDuplicateExternalAuthLocations() returned \n%v, but expected \n%v", result, expected)
+	}
+}
+
 func TestAddPoliciesCfgToLocations(t *testing.T) {
 	cfg := policiesCfg{
 		Allow: []string{"127.0.0.1"},
@@ -4153,6 +4842,62 @@ func TestAddPoliciesCfgToLocations(t *testing.T) {
 	}
 }
 
+func TestAddPoliciesCfgToLocationsWithRetry(t *testing.T) {
+	cfg := policiesCfg{
+		Retry: &retryCfg{
+			NextUpstream:        "error timeout http_503",
+			NextUpstreamTimeout: "10s",
+			NextUpstreamTries:   3,
+			PerTryTimeout:       "2s",
+			EligibilityVariable: "$retry_methods_default_retry_default_cafe",
+		},
+	}
+
+	locations := []version2.Location{
+		{
+			Path:                "/",
+			Rewrites:            []string{"^ @split_0 last"},
+			ProxyConnectTimeout: "60s",
+		},
+		{
+			Path:                     "@split_0",
+			ProxyPass:                "http://vs_default_cafe_tea",
+			ProxyConnectTimeout:      "60s",
+			ProxyReadTimeout:         "60s",
+			ProxySendTimeout:         "60s",
+			ProxyNextUpstream:        "error",
+			ProxyNextUpstreamTimeout: "5s",
+			ProxyNextUpstreamTries:   5,
+		},
+	}
+
+	expectedLocations := []version2.Location{
+		{
+			Path:                "/",
+			Rewrites:            []string{"^ @split_0 last"},
+			ProxyConnectTimeout: "60s",
+		},
+		{
+			Path:                     "@split_0",
+			ProxyPass:                "http://vs_default_cafe_tea",
+			ProxyConnectTimeout:      "2s",
+			ProxyReadTimeout:         "2s",
+			ProxySendTimeout:         "2s",
+			ProxyNextUpstream:        "error timeout http_503",
+			ProxyNextUpstreamTimeout: "10s",
+			ProxyNextUpstreamTries:   3,
+			RetryEligibility: &version2.RetryEligibility{
+				Variable: "$retry_methods_default_retry_default_cafe",
+			},
+		},
+	}
+
+	addPoliciesCfgToLocations(cfg, locations)
+	if diff := cmp.Diff(expectedLocations, locations); diff != "" {
+		t.Errorf("addPoliciesCfgToLocations() returned unexpected locations (-want +got):\n%s", diff)
+	}
+}
+
 func TestGenerateUpstream(t *testing.T) {
 	name := "test-upstream"
 	upstream := conf_v1.Upstream{Service: name, Port: 80}
@@ -4263,132 +5008,613 @@ func TestGenerateUpstreamWithKeepalive(t *testing.T) {
 		},
 	}
 
-	for _, test := range tests {
-		vsc := newVirtualServerConfigurator(test.cfgParams, false, false, &StaticConfigParams{}, false)
-		result := vsc.generateUpstream(nil, name, test.upstream, false, endpoints)
-		if !reflect.DeepEqual(result, test.expected) {
-			t.Errorf("generateUpstream() returned %v but expected %v for the case of %v", result, test.expected, test.msg)
-		}
+	for _, test := range tests {
+		vsc := newVirtualServerConfigurator(test.cfgParams, false, false, &StaticConfigParams{}, false)
+		result := vsc.generateUpstream(nil, name, test.upstream, false, endpoints)
+		if !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("generateUpstream() returned %v but expected %v for the case of %v", result, test.expected, test.msg)
+		}
+
+		if len(vsc.warnings) != 0 {
+			t.Errorf("generateUpstream() returned warnings for %v", test.upstream)
+		}
+	}
+}
+
+func TestGenerateUpstreamForExternalNameService(t *testing.T) {
+	name := "test-upstream"
+	endpoints := []string{"example.com"}
+	upstream := conf_v1.Upstream{Service: name}
+	cfgParams := ConfigParams{}
+
+	expected := version2.Upstream{
+		Name: name,
+		UpstreamLabels: version2.UpstreamLabels{
+			Service: "test-upstream",
+		},
+		Servers: []version2.UpstreamServer{
+			{
+				Address: "example.com",
+			},
+		},
+		Resolve: true,
+	}
+
+	vsc := newVirtualServerConfigurator(&cfgParams, true, true, &StaticConfigParams{}, false)
+	result := vsc.generateUpstream(nil, name, upstream, true, endpoints)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("generateUpstream() returned %v but expected %v", result, expected)
+	}
+
+	if len(vsc.warnings) != 0 {
+		t.Errorf("generateUpstream() returned warnings for %v", upstream)
+	}
+}
+
+func TestGenerateUpstreamWithNTLM(t *testing.T) {
+	name := "test-upstream"
+	upstream := conf_v1.Upstream{Service: name, Port: 80, NTLM: true}
+	endpoints := []string{
+		"192.168.10.10:8080",
+	}
+	cfgParams := ConfigParams{
+		LBMethod:         "random",
+		MaxFails:         1,
+		MaxConns:         0,
+		FailTimeout:      "10s",
+		Keepalive:        21,
+		UpstreamZoneSize: "256k",
+	}
+
+	expected := version2.Upstream{
+		Name: "test-upstream",
+		UpstreamLabels: version2.UpstreamLabels{
+			Service: "test-upstream",
+		},
+		Servers: []version2.UpstreamServer{
+			{
+				Address: "192.168.10.10:8080",
+			},
+		},
+		MaxFails:         1,
+		MaxConns:         0,
+		FailTimeout:      "10s",
+		LBMethod:         "random",
+		Keepalive:        21,
+		UpstreamZoneSize: "256k",
+		NTLM:             true,
+	}
+
+	vsc := newVirtualServerConfigurator(&cfgParams, true, false, &StaticConfigParams{}, false)
+	result := vsc.generateUpstream(nil, name, upstream, false, endpoints)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("generateUpstream() returned %v but expected %v", result, expected)
+	}
+
+	if len(vsc.warnings) != 0 {
+		t.Errorf("generateUpstream returned warnings for %v", upstream)
+	}
+}
+
+func TestGenerateProxyPass(t *testing.T) {
+	tests := []struct {
+		tlsEnabled   bool
+		upstreamName string
+		internal     bool
+		expected     string
+	}{
+		{
+			tlsEnabled:   false,
+			upstreamName: "test-upstream",
+			internal:     false,
+			expected:     "http://test-upstream",
+		},
+		{
+			tlsEnabled:   true,
+			upstreamName: "test-upstream",
+			internal:     false,
+			expected:     "https://test-upstream",
+		},
+		{
+			tlsEnabled:   false,
+			upstreamName: "test-upstream",
+			internal:     true,
+			expected:     "http://test-upstream$request_uri",
+		},
+		{
+			tlsEnabled:   true,
+			upstreamName: "test-upstream",
+			internal:     true,
+			expected:     "https://test-upstream$request_uri",
+		},
+	}
+
+	for _, test := range tests {
+		result := generateProxyPass(test.tlsEnabled, test.upstreamName, test.internal, nil)
+		if result != test.expected {
+			t.Errorf("generateProxyPass(%v, %v, %v) returned %v but expected %v", test.tlsEnabled, test.upstreamName, test.internal, result, test.expected)
+		}
+	}
+}
+
+func TestGenerateMirror(t *testing.T) {
+	vs := &conf_v1.VirtualServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "cafe",
+			Namespace: "default",
+		},
+	}
+	upstreamNamer := newUpstreamNamerForVirtualServer(vs)
+	variableNamer := newVariableNamer(vs)
+	crUpstreams := map[string]conf_v1.Upstream{
+		"vs_default_cafe_tea": {
+			Name:    "tea",
+			Service: "tea-svc",
+		},
+		"vs_default_cafe_tea-v2": {
+			Name:    "tea-v2",
+			Service: "tea-v2-svc",
+			TLS: conf_v1.UpstreamTLS{
+				Enable: true,
+			},
+			Keepalive: createPointerFromInt(16),
+		},
+	}
+	cfgParams := &ConfigParams{}
+
+	tests := []struct {
+		action   *conf_v1.Action
+		expected *version2.Mirror
+		msg      string
+	}{
+		{
+			action: &conf_v1.Action{
+				Pass: "tea",
+			},
+			expected: nil,
+			msg:      "pass action",
+		},
+		{
+			action: &conf_v1.Action{
+				Proxy: &conf_v1.ActionProxy{
+					Upstream: "tea",
+				},
+			},
+			expected: nil,
+			msg:      "proxy action without mirror",
+		},
+		{
+			action: &conf_v1.Action{
+				Proxy: &conf_v1.ActionProxy{
+					Upstream: "tea",
+					Mirror: &conf_v1.ProxyMirror{
+						Upstream: "tea-v2",
+					},
+				},
+			},
+			expected: &version2.Mirror{
+				Location: version2.MirrorLocation{
+					Path:         "/internal_location_mirror_vs_default_cafe_tea-v2",
+					ProxyPass:    "https://vs_default_cafe_tea-v2$request_uri",
+					ProxySSLName: "tea-v2-svc.default.svc",
+					HasKeepalive: true,
+					RequestBody:  true,
+				},
+			},
+			msg: "mirror of all requests",
+		},
+		{
+			action: &conf_v1.Action{
+				Proxy: &conf_v1.ActionProxy{
+					Upstream: "tea-v2",
+					Mirror: &conf_v1.ProxyMirror{
+						Upstream:    "tea",
+						Percentage:  createPointerFromInt(10),
+						RequestBody: createPointerFromBool(false),
+					},
+				},
+			},
+			expected: &version2.Mirror{
+				Location: version2.MirrorLocation{
+					Path:           "/internal_location_mirror_vs_default_cafe_tea_10_no_body",
+					ProxyPass:      "http://vs_default_cafe_tea$request_uri",
+					ProxySSLName:   "tea-svc.default.svc",
+					SampleVariable: "$vs_default_cafe_mirror_10",
+					Percentage:     10,
+					RequestBody:    false,
+				},
+			},
+			msg: "mirror of a percentage of requests without the request body",
+		},
+		{
+			action: &conf_v1.Action{
+				Proxy: &conf_v1.ActionProxy{
+					Upstream: "tea-v2",
+					Mirror: &conf_v1.ProxyMirror{
+						Upstream:   "tea",
+						Percentage: createPointerFromInt(100),
+					},
+				},
+			},
+			expected: &version2.Mirror{
+				Location: version2.MirrorLocation{
+					Path:         "/internal_location_mirror_vs_default_cafe_tea",
+					ProxyPass:    "http://vs_default_cafe_tea$request_uri",
+					ProxySSLName: "tea-svc.default.svc",
+					RequestBody:  true,
+				},
+			},
+			msg: "mirror of 100 percent of requests",
+		},
+	}
+
+	for _, test := range tests {
+		result := generateMirror(test.action, upstreamNamer, crUpstreams, variableNamer, cfgParams)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("generateMirror() returned unexpected result for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestGenerateMirrorLocationsForDifferentRequestBodies(t *testing.T) {
+	vs := &conf_v1.VirtualServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "cafe",
+			Namespace: "default",
+		},
+	}
+	upstreamNamer := newUpstreamNamerForVirtualServer(vs)
+	variableNamer := newVariableNamer(vs)
+	crUpstreams := map[string]conf_v1.Upstream{
+		"vs_default_cafe_tea-v2": {
+			Name:    "tea-v2",
+			Service: "tea-v2-svc",
+		},
+	}
+	mirrorAction := func(requestBody *bool) *conf_v1.Action {
+		return &conf_v1.Action{
+			Proxy: &conf_v1.ActionProxy{
+				Upstream: "tea",
+				Mirror: &conf_v1.ProxyMirror{
+					Upstream:    "tea-v2",
+					RequestBody: requestBody,
+				},
+			},
+		}
+	}
+
+	locations := []version2.Location{
+		{
+			Path:   "/tea",
+			Mirror: generateMirror(mirrorAction(nil), upstreamNamer, crUpstreams, variableNamer, &ConfigParams{}),
+		},
+		{
+			Path:   "/upload",
+			Mirror: generateMirror(mirrorAction(createPointerFromBool(false)), upstreamNamer, crUpstreams, variableNamer, &ConfigParams{}),
+		},
+	}
+
+	expected := []version2.MirrorLocation{
+		{
+			Path:         "/internal_location_mirror_vs_default_cafe_tea-v2",
+			ProxyPass:    "http://vs_default_cafe_tea-v2$request_uri",
+			ProxySSLName: "tea-v2-svc.default.svc",
+			RequestBody:  true,
+		},
+		{
+			Path:         "/internal_location_mirror_vs_default_cafe_tea-v2_no_body",
+			ProxyPass:    "http://vs_default_cafe_tea-v2$request_uri",
+			ProxySSLName: "tea-v2-svc.default.svc",
+			RequestBody:  false,
+		},
+	}
 
-		if len(vsc.warnings) != 0 {
-			t.Errorf("generateUpstream() returned warnings for %v", test.upstream)
+	mirrorLocations, _ := generateMirrorLocations(locations)
+	if diff := cmp.Diff(expected, mirrorLocations); diff != "" {
+		t.Errorf("generateMirrorLocations() returned unexpected mirror locations (-want +got):\n%s", diff)
+	}
+
+	for i, l := range locations {
+		if l.Mirror.Location.Path != expected[i].Path {
+			t.Errorf("generateMirrorLocations() set mirror path %q for location %s but expected %q", l.Mirror.Location.Path, l.Path, expected[i].Path)
 		}
 	}
 }
 
-func TestGenerateUpstreamForExternalNameService(t *testing.T) {
-	name := "test-upstream"
-	endpoints := []string{"example.com"}
-	upstream := conf_v1.Upstream{Service: name}
-	cfgParams := ConfigParams{}
+func TestGenerateMirrorLocationsWithSSLSettings(t *testing.T) {
+	mirror := version2.MirrorLocation{
+		Path:         "/internal_location_mirror_vs_default_cafe_tea-v2",
+		ProxyPass:    "https://vs_default_cafe_tea-v2$request_uri",
+		ProxySSLName: "tea-v2-svc.default.svc",
+	}
+	egressMTLS := &version2.EgressMTLS{
+		Certificate:    "/etc/nginx/secrets/default-egress-mtls-secret",
+		CertificateKey: "/etc/nginx/secrets/default-egress-mtls-secret",
+		VerifyDepth:    1,
+		Protocols:      "TLSv1 TLSv1.1 TLSv1.2",
+		Ciphers:        "DEFAULT",
+		ServerName:     true,
+		SSLName:        "tea-v2.example.com",
+	}
 
-	expected := version2.Upstream{
-		Name: name,
-		UpstreamLabels: version2.UpstreamLabels{
-			Service: "test-upstream",
+	locations := []version2.Location{
+		{
+			Path:   "/tea",
+			Mirror: &version2.Mirror{Location: mirror},
 		},
-		Servers: []version2.UpstreamServer{
-			{
-				Address: "example.com",
-			},
+		{
+			Path:       "/tea-mtls",
+			EgressMTLS: egressMTLS,
+			Mirror:     &version2.Mirror{Location: mirror},
+		},
+		{
+			Path:       "/tea-mtls-other",
+			EgressMTLS: egressMTLS,
+			Mirror:     &version2.Mirror{Location: mirror},
 		},
-		Resolve: true,
 	}
 
-	vsc := newVirtualServerConfigurator(&cfgParams, true, true, &StaticConfigParams{}, false)
-	result := vsc.generateUpstream(nil, name, upstream, true, endpoints)
-	if !reflect.DeepEqual(result, expected) {
-		t.Errorf("generateUpstream() returned %v but expected %v", result, expected)
+	mirrorWithSSLSettings := mirror
+	mirrorWithSSLSettings.Path = "/internal_location_mirror_vs_default_cafe_tea-v2_ssl_1"
+	mirrorWithSSLSettings.EgressMTLS = egressMTLS
+
+	expected := []version2.MirrorLocation{mirror, mirrorWithSSLSettings}
+
+	mirrorLocations, _ := generateMirrorLocations(locations)
+	if diff := cmp.Diff(expected, mirrorLocations); diff != "" {
+		t.Errorf("generateMirrorLocations() returned unexpected mirror locations (-want +got):\n%s", diff)
 	}
 
-	if len(vsc.warnings) != 0 {
-		t.Errorf("generateUpstream() returned warnings for %v", upstream)
+	for _, l := range locations[1:] {
+		if l.Mirror.Location.Path != mirrorWithSSLSettings.Path {
+			t.Errorf("generateMirrorLocations() set mirror path %q for location %s but expected %q", l.Mirror.Location.Path, l.Path, mirrorWithSSLSettings.Path)
+		}
 	}
 }
 
-func TestGenerateUpstreamWithNTLM(t *testing.T) {
-	name := "test-upstream"
-	upstream := conf_v1.Upstream{Service: name, Port: 80, NTLM: true}
-	endpoints := []string{
-		"192.168.10.10:8080",
+func TestGenerateMirrorLocations(t *testing.T) {
+	mirrorAll := version2.MirrorLocation{
+		Path:        "/internal_location_mirror_vs_default_cafe_tea-v2",
+		ProxyPass:   "http://vs_default_cafe_tea-v2$request_uri",
+		RequestBody: true,
 	}
-	cfgParams := ConfigParams{
-		LBMethod:         "random",
-		MaxFails:         1,
-		MaxConns:         0,
-		FailTimeout:      "10s",
-		Keepalive:        21,
-		UpstreamZoneSize: "256k",
+	mirrorSampled := version2.MirrorLocation{
+		Path:           "/internal_location_mirror_vs_default_cafe_tea-v2_10_no_body",
+		ProxyPass:      "http://vs_default_cafe_tea-v2$request_uri",
+		SampleVariable: "$vs_default_cafe_mirror_10",
+		Percentage:     10,
+	}
+	mirrorSampledWithBody := version2.MirrorLocation{
+		Path:           "/internal_location_mirror_vs_default_cafe_tea-v2_10",
+		ProxyPass:      "http://vs_default_cafe_tea-v2$request_uri",
+		SampleVariable: "$vs_default_cafe_mirror_10",
+		Percentage:     10,
+		RequestBody:    true,
+	}
+	mirrorSampledOther := version2.MirrorLocation{
+		Path:           "/internal_location_mirror_vs_default_cafe_coffee-v2_10",
+		ProxyPass:      "http://vs_default_cafe_coffee-v2$request_uri",
+		SampleVariable: "$vs_default_cafe_mirror_10",
+		Percentage:     10,
 	}
 
-	expected := version2.Upstream{
-		Name: "test-upstream",
-		UpstreamLabels: version2.UpstreamLabels{
-			Service: "test-upstream",
+	locations := []version2.Location{
+		{
+			Path: "/tea",
+			Mirror: &version2.Mirror{
+				Location: mirrorAll,
+			},
 		},
-		Servers: []version2.UpstreamServer{
-			{
-				Address: "192.168.10.10:8080",
+		{
+			Path: "/coffee",
+		},
+		{
+			Path: "/tea-sampled",
+			Mirror: &version2.Mirror{
+				Location: mirrorSampled,
+			},
+		},
+		{
+			Path: "/tea-sampled-with-body",
+			Mirror: &version2.Mirror{
+				Location: mirrorSampledWithBody,
+			},
+		},
+		{
+			Path: "/coffee-sampled",
+			Mirror: &version2.Mirror{
+				Location: mirrorSampledOther,
 			},
 		},
-		MaxFails:         1,
-		MaxConns:         0,
-		FailTimeout:      "10s",
-		LBMethod:         "random",
-		Keepalive:        21,
-		UpstreamZoneSize: "256k",
-		NTLM:             true,
 	}
 
-	vsc := newVirtualServerConfigurator(&cfgParams, true, false, &StaticConfigParams{}, false)
-	result := vsc.generateUpstream(nil, name, upstream, false, endpoints)
-	if !reflect.DeepEqual(result, expected) {
-		t.Errorf("generateUpstream() returned %v but expected %v", result, expected)
+	expectedMirrorLocations := []version2.MirrorLocation{mirrorAll, mirrorSampled, mirrorSampledWithBody, mirrorSampledOther}
+	expectedSplitClients := []version2.SplitClient{
+		{
+			Source:   "$request_id",
+			Variable: "$vs_default_cafe_mirror_10",
+			Distributions: []version2.Distribution{
+				{
+					Weight: "10%",
+					Value:  "1",
+				},
+				{
+					Weight: "*",
+					Value:  `""`,
+				},
+			},
+		},
 	}
 
-	if len(vsc.warnings) != 0 {
-		t.Errorf("generateUpstream returned warnings for %v", upstream)
+	mirrorLocations, splitClients := generateMirrorLocations(locations)
+	if diff := cmp.Diff(expectedMirrorLocations, mirrorLocations); diff != "" {
+		t.Errorf("generateMirrorLocations() returned unexpected mirror locations (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedSplitClients, splitClients); diff != "" {
+		t.Errorf("generateMirrorLocations() returned unexpected split clients (-want +got):\n%s", diff)
 	}
 }
 
-func TestGenerateProxyPass(t *testing.T) {
-	tests := []struct {
-		tlsEnabled   bool
-		upstreamName string
-		internal     bool
-		expected     string
-	}{
+func TestGenerateRetryFallbackLocations(t *testing.T) {
+	locations := []version2.Location{
 		{
-			tlsEnabled:   false,
-			upstreamName: "test-upstream",
-			internal:     false,
-			expected:     "http://test-upstream",
+			Path:      "/coffee",
+			ProxyPass: "http://vs_default_cafe_coffee",
 		},
 		{
-			tlsEnabled:   true,
-			upstreamName: "test-upstream",
-			internal:     false,
-			expected:     "https://test-upstream",
+			Path:                   "/tea",
+			ProxyPass:              "http://vs_default_cafe_tea",
+			ProxyNextUpstream:      "error timeout non_idempotent",
+			ProxyNextUpstreamTries: 3,
+			RetryEligibility: &version2.RetryEligibility{
+				Variable: "$retry_methods_default_retry_default_cafe",
+			},
 		},
 		{
-			tlsEnabled:   false,
-			upstreamName: "test-upstream",
-			internal:     true,
-			expected:     "http://test-upstream$request_uri",
+			Path:              "/internal_location_splits_0_split_0",
+			Internal:          true,
+			ProxyPass:         "http://vs_default_cafe_juice$request_uri",
+			ProxyNextUpstream: "error timeout",
+			RetryEligibility: &version2.RetryEligibility{
+				Variable: "$retry_sample_default_retry2_default_cafe",
+			},
 		},
 		{
-			tlsEnabled:   true,
-			upstreamName: "test-upstream",
-			internal:     true,
-			expected:     "https://test-upstream$request_uri",
+			Path:              "/latte",
+			ProxyPass:         "http://vs_default_cafe_latte",
+			ProxyPassRewrite:  "/rewrite",
+			ProxyNextUpstream: "error timeout",
+			RetryEligibility: &version2.RetryEligibility{
+				Variable: "$retry_methods_default_retry_default_cafe",
+			},
+		},
+		{
+			Path:              `~ "^/mocha/(.*)$"`,
+			ProxyPass:         "http://vs_default_cafe_mocha",
+			Rewrites:          []string{`"^/mocha/(.*)$" "/$1" break`},
+			ProxyNextUpstream: "error timeout",
+			RetryEligibility: &version2.RetryEligibility{
+				Variable: "$retry_methods_default_retry_default_cafe",
+			},
+		},
+		{
+			Path:              "/grpc",
+			ProxyPass:         "http://vs_default_cafe_grpc",
+			GRPCPass:          "grpc://vs_default_cafe_grpc",
+			ProxyNextUpstream: "error timeout",
+			RetryEligibility: &version2.RetryEligibility{
+				Variable: "$retry_methods_default_retry_default_cafe",
+			},
 		},
 	}
 
-	for _, test := range tests {
-		result := generateProxyPass(test.tlsEnabled, test.upstreamName, test.internal, nil)
-		if result != test.expected {
-			t.Errorf("generateProxyPass(%v, %v, %v) returned %v but expected %v", test.tlsEnabled, test.upstreamName, test.internal, result, test.expected)
-		}
+	expectedLocations := []version2.Location{
+		{
+			Path:      "/coffee",
+			ProxyPass: "http://vs_default_cafe_coffee",
+		},
+		{
+			Path:                   "/tea",
+			ProxyPass:              "http://vs_default_cafe_tea",
+			ProxyNextUpstream:      "error timeout non_idempotent",
+			ProxyNextUpstreamTries: 3,
+			RetryEligibility: &version2.RetryEligibility{
+				Variable:         "$retry_methods_default_retry_default_cafe",
+				FallbackLocation: "/internal_location_retry_fallback_0",
+			},
+		},
+		{
+			Path:              "/internal_location_splits_0_split_0",
+			Internal:          true,
+			ProxyPass:         "http://vs_default_cafe_juice$request_uri",
+			ProxyNextUpstream: "error timeout",
+			RetryEligibility: &version2.RetryEligibility{
+				Variable:         "$retry_sample_default_retry2_default_cafe",
+				FallbackLocation: "/internal_location_retry_fallback_1",
+			},
+		},
+		{
+			Path:              "/latte",
+			ProxyPass:         "http://vs_default_cafe_latte",
+			ProxyPassRewrite:  "/rewrite",
+			ProxyNextUpstream: "error timeout",
+			RetryEligibility: &version2.RetryEligibility{
+				Variable:         "$retry_methods_default_retry_default_cafe",
+				FallbackLocation: "/internal_location_retry_fallback_2",
+			},
+		},
+		{
+			Path:              `~ "^/mocha/(.*)$"`,
+			ProxyPass:         "http://vs_default_cafe_mocha",
+			Rewrites:          []string{`"^/mocha/(.*)$" "/$1" break`},
+			ProxyNextUpstream: "error timeout",
+			RetryEligibility: &version2.RetryEligibility{
+				Variable:         "$retry_methods_default_retry_default_cafe",
+				FallbackLocation: "/internal_location_retry_fallback_3",
+			},
+		},
+		{
+			Path:              "/grpc",
+			ProxyPass:         "http://vs_default_cafe_grpc",
+			GRPCPass:          "grpc://vs_default_cafe_grpc",
+			ProxyNextUpstream: "error timeout",
+			RetryEligibility: &version2.RetryEligibility{
+				Variable:         "$retry_methods_default_retry_default_cafe",
+				FallbackLocation: "/internal_location_retry_fallback_4",
+			},
+		},
+	}
+
+	expectedFallbackLocations := []version2.Location{
+		{
+			Path:                   "/internal_location_retry_fallback_0",
+			Internal:               true,
+			ProxyPass:              "http://vs_default_cafe_tea$request_uri",
+			ProxyNextUpstream:      "off",
+			ProxyNextUpstreamTries: 3,
+		},
+		{
+			Path:              "/internal_location_retry_fallback_1",
+			Internal:          true,
+			ProxyPass:         "http://vs_default_cafe_juice$request_uri",
+			ProxyNextUpstream: "off",
+		},
+		{
+			Path:      "/internal_location_retry_fallback_2",
+			Internal:  true,
+			ProxyPass: "http://vs_default_cafe_latte",
+			Rewrites: []string{
+				"^ $request_uri_no_args",
+				`"^/latte(.*)$" "/rewrite$1" break`,
+			},
+			ProxyNextUpstream: "off",
+		},
+		{
+			Path:      "/internal_location_retry_fallback_3",
+			Internal:  true,
+			ProxyPass: "http://vs_default_cafe_mocha",
+			Rewrites: []string{
+				"^ $request_uri_no_args",
+				`"^/mocha/(.*)$" "/$1" break`,
+			},
+			ProxyNextUpstream: "off",
+		},
+		{
+			Path:              "/internal_location_retry_fallback_4",
+			Internal:          true,
+			ProxyPass:         "http://vs_default_cafe_grpc",
+			GRPCPass:          "grpc://vs_default_cafe_grpc",
+			Rewrites:          []string{"^ $request_uri break"},
+			ProxyNextUpstream: "off",
+		},
+	}
+
+	fallbackLocations := generateRetryFallbackLocations(locations)
+	if diff := cmp.Diff(expectedFallbackLocations, fallbackLocations); diff != "" {
+		t.Errorf("generateRetryFallbackLocations() returned unexpected fallback locations (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedLocations, locations); diff != "" {
+		t.Errorf("generateRetryFallbackLocations() changed the locations unexpectedly (-want +got):\n%s", diff)
 	}
 }
 
@@ -5445,52 +6671,155 @@ func TestGenerateSplits(t *testing.T) {
 			InternalProxyPass: "http://unix:/var/lib/nginx/nginx-418-server.sock",
 		},
 	}
-	expectedReturnLocations := []version2.ReturnLocation{
-		{
-			Name:        "@return_1",
-			DefaultType: "text/plain",
-			Return: version2.Return{
-				Code: 0,
-				Text: "hello",
+	expectedReturnLocations := []version2.ReturnLocation{
+		{
+			Name:        "@return_1",
+			DefaultType: "text/plain",
+			Return: version2.Return{
+				Code: 0,
+				Text: "hello",
+			},
+		},
+	}
+	returnLocationIndex := 1
+
+	errorPageDetails := errorPageDetails{
+		pages: errorPages,
+		index: 0,
+		owner: nil,
+	}
+
+	vsc := newVirtualServerConfigurator(&cfgParams, false, false, &StaticConfigParams{}, false)
+
+	resultSplitClient, resultLocations, resultReturnLocations := generateSplits(
+		splits,
+		upstreamNamer,
+		crUpstreams,
+		variableNamer,
+		scIndex,
+		&cfgParams,
+		errorPageDetails,
+		originalPath,
+		locSnippet,
+		enableSnippets,
+		returnLocationIndex,
+		true,
+		"coffee",
+		"default",
+		vsc.warnings,
+	)
+
+	if diff := cmp.Diff(expectedSplitClient, resultSplitClient); diff != "" {
+		t.Errorf("generateSplits() resultSplitClient mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedLocations, resultLocations); diff != "" {
+		t.Errorf("generateSplits() resultLocations mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedReturnLocations, resultReturnLocations); diff != "" {
+		t.Errorf("generateSplits() resultReturnLocations mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerateSplitsSkipsZeroWeight(t *testing.T) {
+	splits := []conf_v1.Split{
+		{
+			Weight: 100,
+			Action: &conf_v1.Action{
+				Pass: "coffee-v1",
+			},
+		},
+		{
+			Weight: 0,
+			Action: &conf_v1.Action{
+				Pass: "coffee-v2",
+			},
+		},
+	}
+
+	virtualServer := conf_v1.VirtualServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "cafe",
+			Namespace: "default",
+		},
+	}
+	crUpstreams := map[string]conf_v1.Upstream{
+		"vs_default_cafe_coffee-v1": {
+			Service: "coffee-v1",
+		},
+		"vs_default_cafe_coffee-v2": {
+			Service: "coffee-v2",
+		},
+	}
+	cfgParams := ConfigParams{}
+	vsc := newVirtualServerConfigurator(&cfgParams, false, false, &StaticConfigParams{}, false)
+
+	expectedSplitClient := version2.SplitClient{
+		Source:   "$request_id",
+		Variable: "$vs_default_cafe_splits_0",
+		Distributions: []version2.Distribution{
+			{
+				Weight: "100%",
+				Value:  "/internal_location_splits_0_split_0",
 			},
 		},
 	}
-	returnLocationIndex := 1
-
-	errorPageDetails := errorPageDetails{
-		pages: errorPages,
-		index: 0,
-		owner: nil,
-	}
-
-	vsc := newVirtualServerConfigurator(&cfgParams, false, false, &StaticConfigParams{}, false)
 
-	resultSplitClient, resultLocations, resultReturnLocations := generateSplits(
+	resultSplitClient, resultLocations, _ := generateSplits(
 		splits,
-		upstreamNamer,
+		newUpstreamNamerForVirtualServer(&virtualServer),
 		crUpstreams,
-		variableNamer,
-		scIndex,
+		newVariableNamer(&virtualServer),
+		0,
 		&cfgParams,
-		errorPageDetails,
-		originalPath,
-		locSnippet,
-		enableSnippets,
-		returnLocationIndex,
-		true,
-		"coffee",
-		"default",
+		errorPageDetails{},
+		"/coffee",
+		"",
+		false,
+		0,
+		false,
+		"",
+		"",
 		vsc.warnings,
 	)
 
 	if diff := cmp.Diff(expectedSplitClient, resultSplitClient); diff != "" {
 		t.Errorf("generateSplits() resultSplitClient mismatch (-want +got):\n%s", diff)
 	}
-	if diff := cmp.Diff(expectedLocations, resultLocations); diff != "" {
-		t.Errorf("generateSplits() resultLocations mismatch (-want +got):\n%s", diff)
+	if len(resultLocations) != 2 {
+		t.Errorf("generateSplits() returned %d locations but expected 2", len(resultLocations))
 	}
-	if diff := cmp.Diff(expectedReturnLocations, resultReturnLocations); diff != "" {
-		t.Errorf("generateSplits() resultReturnLocations mismatch (-want +got):\n%s", diff)
+}
+
+func TestApplyCanaryWeight(t *testing.T) {
+	route := conf_v1.Route{
+		Path: "/coffee",
+		Splits: []conf_v1.Split{
+			{
+				Weight: 90,
+				Action: &conf_v1.Action{
+					Pass: "coffee-v1",
+				},
+			},
+			{
+				Weight: 10,
+				Action: &conf_v1.Action{
+					Pass: "coffee-v2",
+				},
+			},
+		},
+	}
+
+	result := applyCanaryWeight(route, map[string]int{"/coffee": 25})
+	if result.Splits[0].Weight != 75 || result.Splits[1].Weight != 25 {
+		t.Errorf("applyCanaryWeight() returned weights %d and %d but expected 75 and 25", result.Splits[0].Weight, result.Splits[1].Weight)
+	}
+	if route.Splits[0].Weight != 90 || route.Splits[1].Weight != 10 {
+		t.Errorf("applyCanaryWeight() modified the splits of the original route")
+	}
+
+	result = applyCanaryWeight(route, map[string]int{"/tea": 25})
+	if diff := cmp.Diff(route, result); diff != "" {
+		t.Errorf("applyCanaryWeight() mismatch for a route without a canary (-want +got):\n%s", diff)
 	}
 }
 
@@ -5602,6 +6931,258 @@ func TestGenerateDefaultSplitsConfig(t *testing.T) {
 	}
 }
 
+func TestGenerateVirtualServerConfigForFaultWithPolicies(t *testing.T) {
+	virtualServerEx := VirtualServerEx{
+		VirtualServer: &conf_v1.VirtualServer{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "cafe",
+				Namespace: "default",
+			},
+			Spec: conf_v1.VirtualServerSpec{
+				Host: "cafe.example.com",
+				Upstreams: []conf_v1.Upstream{
+					{
+						Name:    "coffee",
+						Service: "coffee-svc",
+						Port:    80,
+					},
+				},
+				Routes: []conf_v1.Route{
+					{
+						Path: "/coffee",
+						Policies: []conf_v1.PolicyReference{
+							{
+								Name: "allow-office",
+							},
+						},
+						Action: &conf_v1.Action{
+							Pass: "coffee",
+							Fault: &conf_v1.ActionFault{
+								Delay: "2s",
+								Abort: &conf_v1.FaultAbort{
+									Code: 503,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Policies: map[string]*conf_v1.Policy{
+			"default/allow-office": {
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "allow-office",
+					Namespace: "default",
+				},
+				Spec: conf_v1.PolicySpec{
+					AccessControl: &conf_v1.AccessControl{
+						Allow: []string{"10.0.0.0/8"},
+					},
+				},
+			},
+		},
+	}
+
+	vsc := newVirtualServerConfigurator(&ConfigParams{}, false, false, &StaticConfigParams{}, false)
+	result, _ := vsc.GenerateVirtualServerConfig(&virtualServerEx, nil, nil)
+
+	// the policies of the route must run before the fault, so that denied clients are neither delayed nor aborted
+	var gateLocation *version2.Location
+	for i, l := range result.Server.Locations {
+		if l.Path == "/internal_location_fault_0" {
+			gateLocation = &result.Server.Locations[i]
+		}
+	}
+	if gateLocation == nil {
+		t.Fatalf("GenerateVirtualServerConfig() didn't generate the fault location /internal_location_fault_0")
+	}
+	if gateLocation.FaultLocation != "@fault_0" {
+		t.Errorf("GenerateVirtualServerConfig() generated fault location %q but expected %q", gateLocation.FaultLocation, "@fault_0")
+	}
+	if diff := cmp.Diff([]string{"10.0.0.0/8"}, gateLocation.Allow); diff != "" {
+		t.Errorf("GenerateVirtualServerConfig() didn't apply the route policies to the fault location (-want +got):\n%s", diff)
+	}
+
+	expectedFaultLocations := []version2.FaultLocation{
+		{
+			Path:            "@fault_0",
+			Delay:           "2s",
+			DelayedLocation: "@fault_0_abort",
+			AbortCode:       503,
+		},
+	}
+	if diff := cmp.Diff(expectedFaultLocations, result.Server.FaultLocations); diff != "" {
+		t.Errorf("GenerateVirtualServerConfig() returned unexpected fault locations (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerateFaultConfig(t *testing.T) {
+	virtualServer := conf_v1.VirtualServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "cafe",
+			Namespace: "default",
+		},
+	}
+	upstreamNamer := newUpstreamNamerForVirtualServer(&virtualServer)
+	variableNamer := newVariableNamer(&virtualServer)
+	cfgParams := ConfigParams{}
+	crUpstreams := map[string]conf_v1.Upstream{
+		"vs_default_cafe_coffee": {
+			Service: "coffee",
+		},
+	}
+
+	passLocation := version2.Location{
+		Path:                     "/internal_location_fault_1_pass",
+		ProxyPass:                "http://vs_default_cafe_coffee$request_uri",
+		ProxyNextUpstream:        "error timeout",
+		ProxyNextUpstreamTimeout: "0s",
+		ProxyNextUpstreamTries:   0,
+		Internal:                 true,
+		ProxySSLName:             "coffee.default.svc",
+		ProxyPassRequestHeaders:  true,
+		ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
+		ServiceName:              "coffee",
+	}
+	gateLocation := version2.Location{
+		Path:          "/internal_location_fault_1",
+		Internal:      true,
+		ServiceName:   "coffee",
+		FaultLocation: "@fault_1",
+	}
+	delayedLocation := passLocation
+	delayedLocation.Path = "@fault_1_delayed"
+	delayedLocation.Internal = false
+
+	tests := []struct {
+		fault    *conf_v1.ActionFault
+		expected routingCfg
+		msg      string
+	}{
+		{
+			fault: &conf_v1.ActionFault{
+				Delay: "5s",
+			},
+			expected: routingCfg{
+				Locations: []version2.Location{passLocation, gateLocation, delayedLocation},
+				InternalRedirectLocation: version2.InternalRedirectLocation{
+					Path:        "/coffee",
+					Destination: "/internal_location_fault_1",
+				},
+				FaultLocations: []version2.FaultLocation{
+					{
+						Path:            "@fault_1",
+						Delay:           "5s",
+						DelayedLocation: "@fault_1_delayed",
+					},
+				},
+			},
+			msg: "delay",
+		},
+		{
+			fault: &conf_v1.ActionFault{
+				Abort: &conf_v1.FaultAbort{
+					Code: 503,
+				},
+				Percentage: createPointerFromInt(10),
+			},
+			expected: routingCfg{
+				SplitClients: []version2.SplitClient{
+					{
+						Source:   "$request_id",
+						Variable: "$vs_default_cafe_fault_1_sample",
+						Distributions: []version2.Distribution{
+							{
+								Weight: "10%",
+								Value:  "/internal_location_fault_1",
+							},
+							{
+								Weight: "*",
+								Value:  "/internal_location_fault_1_pass",
+							},
+						},
+					},
+				},
+				Locations: []version2.Location{passLocation, gateLocation},
+				InternalRedirectLocation: version2.InternalRedirectLocation{
+					Path:        "/coffee",
+					Destination: "$vs_default_cafe_fault_1_sample",
+				},
+				FaultLocations: []version2.FaultLocation{
+					{
+						Path:      "@fault_1",
+						AbortCode: 503,
+					},
+				},
+			},
+			msg: "abort with percentage",
+		},
+		{
+			fault: &conf_v1.ActionFault{
+				Delay: "1s",
+				Abort: &conf_v1.FaultAbort{
+					Code: 504,
+				},
+				Percentage: createPointerFromInt(100),
+				Header: &conf_v1.FaultHeader{
+					Name:  "X-Fault-Test",
+					Value: "!off",
+				},
+			},
+			expected: routingCfg{
+				Maps: []version2.Map{
+					{
+						Source:   "$http_X_Fault_Test",
+						Variable: "$vs_default_cafe_fault_1_header",
+						Parameters: []version2.Parameter{
+							{
+								Value:  `"off"`,
+								Result: "/internal_location_fault_1_pass",
+							},
+							{
+								Value:  "default",
+								Result: "/internal_location_fault_1",
+							},
+						},
+					},
+				},
+				Locations: []version2.Location{passLocation, gateLocation},
+				InternalRedirectLocation: version2.InternalRedirectLocation{
+					Path:        "/coffee",
+					Destination: "$vs_default_cafe_fault_1_header",
+				},
+				FaultLocations: []version2.FaultLocation{
+					{
+						Path:            "@fault_1",
+						Delay:           "1s",
+						DelayedLocation: "@fault_1_abort",
+						AbortCode:       504,
+					},
+				},
+			},
+			msg: "delay and abort with negative header",
+		},
+	}
+
+	vsc := newVirtualServerConfigurator(&cfgParams, false, false, &StaticConfigParams{}, false)
+
+	for _, test := range tests {
+		route := conf_v1.Route{
+			Path: "/coffee",
+			Action: &conf_v1.Action{
+				Pass:  "coffee",
+				Fault: test.fault,
+			},
+		}
+
+		result := generateFaultConfig(route, upstreamNamer, crUpstreams, variableNamer, 1, &cfgParams,
+			errorPageDetails{}, "", false, false, "", "", vsc.warnings)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("generateFaultConfig() returned unexpected result for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
 func TestGenerateMatchesConfig(t *testing.T) {
 	route := conf_v1.Route{
 		Path: "/",
@@ -5969,48 +7550,180 @@ func TestGenerateMatchesConfig(t *testing.T) {
 						Value:  "/internal_location_splits_2_split_0",
 					},
 					{
-						Weight: "10%",
-						Value:  "/internal_location_splits_2_split_1",
+						Weight: "10%",
+						Value:  "/internal_location_splits_2_split_1",
+					},
+				},
+			},
+		},
+	}
+
+	cfgParams := ConfigParams{}
+	enableSnippets := false
+	locSnippets := ""
+	crUpstreams := map[string]conf_v1.Upstream{
+		"vs_default_cafe_coffee-v1": {Service: "coffee-v1"},
+		"vs_default_cafe_coffee-v2": {Service: "coffee-v2"},
+		"vs_default_cafe_tea":       {Service: "tea"},
+	}
+
+	errorPageDetails := errorPageDetails{
+		pages: errorPages,
+		index: 2,
+		owner: nil,
+	}
+
+	result := generateMatchesConfig(
+		route,
+		upstreamNamer,
+		crUpstreams,
+		variableNamer,
+		index,
+		scIndex,
+		&cfgParams,
+		errorPageDetails,
+		locSnippets,
+		enableSnippets,
+		0,
+		false,
+		"",
+		"",
+		Warnings{},
+	)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("generateMatchesConfig() returned \n%+v but expected \n%+v", result, expected)
+	}
+}
+
+func TestGenerateMatchesConfigWithAnyOf(t *testing.T) {
+	route := conf_v1.Route{
+		Path: "/",
+		Matches: []conf_v1.Match{
+			{
+				Conditions: []conf_v1.Condition{
+					{
+						Header: "x-version",
+						Value:  "v2",
+					},
+				},
+				AnyOf: []conf_v1.ConditionGroup{
+					{
+						Conditions: []conf_v1.Condition{
+							{
+								Method: []string{"POST"},
+							},
+							{
+								Argument: "debug",
+								Operator: "present",
+							},
+						},
 					},
 				},
+				Action: &conf_v1.Action{
+					Pass: "coffee-v2",
+				},
 			},
 		},
+		Action: &conf_v1.Action{
+			Pass: "coffee-v1",
+		},
+	}
+	virtualServer := conf_v1.VirtualServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "cafe",
+			Namespace: "default",
+		},
 	}
-
 	cfgParams := ConfigParams{}
-	enableSnippets := false
-	locSnippets := ""
 	crUpstreams := map[string]conf_v1.Upstream{
-		"vs_default_cafe_coffee-v1": {Service: "coffee-v1"},
-		"vs_default_cafe_coffee-v2": {Service: "coffee-v2"},
-		"vs_default_cafe_tea":       {Service: "tea"},
+		"vs_default_cafe_coffee-v1": {
+			Service: "coffee-v1",
+		},
+		"vs_default_cafe_coffee-v2": {
+			Service: "coffee-v2",
+		},
 	}
 
-	errorPageDetails := errorPageDetails{
-		pages: errorPages,
-		index: 2,
-		owner: nil,
+	expectedMaps := []version2.Map{
+		{
+			Source:   "$http_x_version",
+			Variable: "$vs_default_cafe_matches_0_match_0_cond_0",
+			Parameters: []version2.Parameter{
+				{
+					Value:  `"v2"`,
+					Result: "$vs_default_cafe_matches_0_match_0_cond_1",
+				},
+				{
+					Value:  "default",
+					Result: "0",
+				},
+			},
+		},
+		{
+			Source:   "$request_method",
+			Variable: "$vs_default_cafe_matches_0_match_0_cond_1",
+			Parameters: []version2.Parameter{
+				{
+					Value:  `"POST"`,
+					Result: "1",
+				},
+				{
+					Value:  "default",
+					Result: "$vs_default_cafe_matches_0_match_0_cond_1_any_1",
+				},
+			},
+		},
+		{
+			Source:   "$arg_debug",
+			Variable: "$vs_default_cafe_matches_0_match_0_cond_1_any_1",
+			Parameters: []version2.Parameter{
+				{
+					Value:  `""`,
+					Result: "0",
+				},
+				{
+					Value:  "default",
+					Result: "1",
+				},
+			},
+		},
+		{
+			Source:   "$vs_default_cafe_matches_0_match_0_cond_0",
+			Variable: "$vs_default_cafe_matches_0",
+			Parameters: []version2.Parameter{
+				{
+					Value:  "~^1",
+					Result: "/internal_location_matches_0_match_0",
+				},
+				{
+					Value:  "default",
+					Result: "/internal_location_matches_0_default",
+				},
+			},
+		},
 	}
 
+	vsc := newVirtualServerConfigurator(&cfgParams, false, false, &StaticConfigParams{}, false)
+
 	result := generateMatchesConfig(
 		route,
-		upstreamNamer,
+		newUpstreamNamerForVirtualServer(&virtualServer),
 		crUpstreams,
-		variableNamer,
-		index,
-		scIndex,
+		newVariableNamer(&virtualServer),
+		0,
+		0,
 		&cfgParams,
-		errorPageDetails,
-		locSnippets,
-		enableSnippets,
+		errorPageDetails{},
+		"",
+		false,
 		0,
 		false,
 		"",
 		"",
-		Warnings{},
+		vsc.warnings,
 	)
-	if !reflect.DeepEqual(result, expected) {
-		t.Errorf("generateMatchesConfig() returned \n%+v but expected \n%+v", result, expected)
+	if diff := cmp.Diff(expectedMaps, result.Maps); diff != "" {
+		t.Errorf("generateMatchesConfig() returned unexpected maps (-want +got):\n%s", diff)
 	}
 }
 
@@ -6531,6 +8244,179 @@ func TestGenerateParametersForMatchesRouteMap(t *testing.T) {
 	}
 }
 
+func TestGenerateParametersForMatchesRouteMapFromCondition(t *testing.T) {
+	tests := []struct {
+		condition conf_v1.Condition
+		expected  []version2.Parameter
+		msg       string
+	}{
+		{
+			condition: conf_v1.Condition{
+				Header: "x-version",
+				Value:  "!v1",
+			},
+			expected: []version2.Parameter{
+				{
+					Value:  `"v1"`,
+					Result: "$next",
+				},
+				{
+					Value:  "default",
+					Result: "$success",
+				},
+			},
+			msg: "negated exact value",
+		},
+		{
+			condition: conf_v1.Condition{
+				Argument: "path",
+				Value:    "/api/",
+				Operator: "prefix",
+			},
+			expected: []version2.Parameter{
+				{
+					Value:  `"~^/api/"`,
+					Result: "$success",
+				},
+				{
+					Value:  "default",
+					Result: "$next",
+				},
+			},
+			msg: "prefix",
+		},
+		{
+			condition: conf_v1.Condition{
+				Cookie:   "file",
+				Value:    "!.jpg",
+				Operator: "suffix",
+			},
+			expected: []version2.Parameter{
+				{
+					Value:  `"~\.jpg$"`,
+					Result: "$next",
+				},
+				{
+					Value:  "default",
+					Result: "$success",
+				},
+			},
+			msg: "negated suffix",
+		},
+		{
+			condition: conf_v1.Condition{
+				Header:     "user-agent",
+				Value:      "(android|iphone)",
+				Operator:   "regex",
+				IgnoreCase: true,
+			},
+			expected: []version2.Parameter{
+				{
+					Value:  `"~*(android|iphone)"`,
+					Result: "$success",
+				},
+				{
+					Value:  "default",
+					Result: "$next",
+				},
+			},
+			msg: "case-insensitive regex",
+		},
+		{
+			condition: conf_v1.Condition{
+				Header:   "x-debug",
+				Operator: "present",
+			},
+			expected: []version2.Parameter{
+				{
+					Value:  `""`,
+					Result: "$next",
+				},
+				{
+					Value:  "default",
+					Result: "$success",
+				},
+			},
+			msg: "presence",
+		},
+		{
+			condition: conf_v1.Condition{
+				Header:   "x-debug",
+				Operator: "absent",
+			},
+			expected: []version2.Parameter{
+				{
+					Value:  `""`,
+					Result: "$success",
+				},
+				{
+					Value:  "default",
+					Result: "$next",
+				},
+			},
+			msg: "absence",
+		},
+		{
+			condition: conf_v1.Condition{
+				Method: []string{"GET", "HEAD"},
+			},
+			expected: []version2.Parameter{
+				{
+					Value:  `"GET"`,
+					Result: "$success",
+				},
+				{
+					Value:  `"HEAD"`,
+					Result: "$success",
+				},
+				{
+					Value:  "default",
+					Result: "$next",
+				},
+			},
+			msg: "method",
+		},
+	}
+
+	for _, test := range tests {
+		result := generateParametersForMatchesRouteMapFromCondition(test.condition, "$success", "$next")
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("generateParametersForMatchesRouteMapFromCondition() returned unexpected result for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestEscapeMatchValueForRegex(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{
+			input:    "abc",
+			expected: "abc",
+		},
+		{
+			input:    "a.b*c?(d)|[e]{f}^$+",
+			expected: `a\.b\*c\?\(d\)\|\[e\]\{f\}\^\$\+`,
+		},
+		{
+			input:    `a\"b`,
+			expected: `a\"b`,
+		},
+		{
+			input:    `a\\b`,
+			expected: `a\\\\b`,
+		},
+	}
+
+	for _, test := range tests {
+		result := escapeMatchValueForRegex(test.input)
+		if result != test.expected {
+			t.Errorf("escapeMatchValueForRegex(%q) returned %q but expected %q", test.input, result, test.expected)
+		}
+	}
+}
+
 func TestGetNameForSourceForMatchesRouteMapFromCondition(t *testing.T) {
 	tests := []struct {
 		input    conf_v1.Condition
@@ -6560,6 +8446,12 @@ func TestGetNameForSourceForMatchesRouteMapFromCondition(t *testing.T) {
 			},
 			expected: "$request_method",
 		},
+		{
+			input: conf_v1.Condition{
+				Method: []string{"GET"},
+			},
+			expected: "$request_method",
+		},
 	}
 
 	for _, test := range tests {
@@ -6945,6 +8837,124 @@ func TestGenerateGrpcHealthCheck(t *testing.T) {
 	}
 }
 
+func TestGenerateExternalAuthUpstreams(t *testing.T) {
+	virtualServer := &conf_v1.VirtualServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "cafe",
+			Namespace: "default",
+		},
+	}
+	policies := map[string]*conf_v1.Policy{
+		"default/external-auth-policy": {
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "external-auth-policy",
+				Namespace: "default",
+			},
+			Spec: conf_v1.PolicySpec{
+				ExternalAuth: &conf_v1.ExternalAuth{
+					AuthServiceName: "authz",
+					AuthServicePort: 8080,
+				},
+			},
+		},
+		"default/external-auth-url-policy": {
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "external-auth-url-policy",
+				Namespace: "default",
+			},
+			Spec: conf_v1.PolicySpec{
+				ExternalAuth: &conf_v1.ExternalAuth{
+					AuthURL: "https://auth.example.com/check",
+				},
+			},
+		},
+	}
+	cfgParams := ConfigParams{
+		LBMethod:         "random two least_conn",
+		MaxFails:         1,
+		FailTimeout:      "10s",
+		UpstreamZoneSize: "256k",
+	}
+	upstreamLabels := version2.UpstreamLabels{
+		Service:           "authz",
+		ResourceType:      "virtualserver",
+		ResourceName:      "cafe",
+		ResourceNamespace: "default",
+	}
+
+	tests := []struct {
+		endpoints map[string][]string
+		isPlus    bool
+		expected  []version2.Upstream
+		msg       string
+	}{
+		{
+			endpoints: map[string][]string{
+				"default/authz:8080": {"10.0.0.20:8080"},
+			},
+			expected: []version2.Upstream{
+				{
+					Name:             "vs_default_cafe_ext_auth_default_external-auth-policy",
+					UpstreamLabels:   upstreamLabels,
+					Servers:          []version2.UpstreamServer{{Address: "10.0.0.20:8080"}},
+					LBMethod:         "random two least_conn",
+					MaxFails:         1,
+					FailTimeout:      "10s",
+					UpstreamZoneSize: "256k",
+				},
+			},
+			msg: "service with endpoints",
+		},
+		{
+			endpoints: map[string][]string{},
+			expected: []version2.Upstream{
+				{
+					Name:             "vs_default_cafe_ext_auth_default_external-auth-policy",
+					UpstreamLabels:   upstreamLabels,
+					Servers:          []version2.UpstreamServer{{Address: nginx502Server}},
+					LBMethod:         "random two least_conn",
+					MaxFails:         1,
+					FailTimeout:      "10s",
+					UpstreamZoneSize: "256k",
+				},
+			},
+			msg: "service does not exist",
+		},
+		{
+			endpoints: map[string][]string{},
+			isPlus:    true,
+			expected: []version2.Upstream{
+				{
+					Name:             "vs_default_cafe_ext_auth_default_external-auth-policy",
+					UpstreamLabels:   upstreamLabels,
+					LBMethod:         "random two least_conn",
+					MaxFails:         1,
+					FailTimeout:      "10s",
+					UpstreamZoneSize: "256k",
+				},
+			},
+			msg: "service does not exist in NGINX Plus",
+		},
+	}
+
+	for _, test := range tests {
+		vsEx := &VirtualServerEx{
+			VirtualServer: virtualServer,
+			Endpoints:     test.endpoints,
+			Policies:      policies,
+		}
+
+		vsc := newVirtualServerConfigurator(&cfgParams, test.isPlus, false, &StaticConfigParams{}, false)
+		result := vsc.generateExternalAuthUpstreams(vsEx)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("generateExternalAuthUpstreams() '%v' mismatch (-want +got):\n%s", test.msg, diff)
+		}
+		if len(vsc.warnings) != 0 {
+			t.Errorf("generateExternalAuthUpstreams() '%v' returned unexpected warnings %v", test.msg, vsc.warnings)
+		}
+	}
+}
+
 func TestGenerateEndpointsForUpstream(t *testing.T) {
 	name := "test"
 	namespace := "test-namespace"
@@ -0,0 +1,302 @@
+package k8s
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/golang/glog"
+	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
+	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
+	"github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/validation"
+	admission_v1 "k8s.io/api/admission/v1"
+	networking "k8s.io/api/networking/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// maxAdmissionReviewSize limits the size of the body of an AdmissionReview request.
+// The Kubernetes API server limits the size of a resource to 3MB.
+const maxAdmissionReviewSize = 3 * 1024 * 1024
+
+// ServeAdmissionReview handles the AdmissionReview requests sent by the Kubernetes API server to the validating admission webhook.
+// Only the resources that the Ingress Controller handles (with the matching class) are validated, with the same rules that the
+// Ingress Controller applies when it processes the resources. Additionally, a resource is denied if it claims a host or
+// a listener that is already taken by another resource.
+func (lbc *LoadBalancerController) ServeAdmissionReview(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxAdmissionReviewSize))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error reading the request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	var review admission_v1.AdmissionReview
+	err = json.Unmarshal(body, &review)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error decoding the AdmissionReview: %v", err), http.StatusBadRequest)
+		return
+	}
+	if review.Request == nil {
+		http.Error(w, "AdmissionReview has no request", http.StatusBadRequest)
+		return
+	}
+
+	review.Response = lbc.reviewAdmissionRequest(review.Request)
+	review.Request = nil
+
+	resp, err := json.Marshal(review)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error encoding the AdmissionReview: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(resp)
+	if err != nil {
+		glog.Errorf("Error writing the AdmissionReview response: %v", err)
+	}
+}
+
+func (lbc *LoadBalancerController) reviewAdmissionRequest(req *admission_v1.AdmissionRequest) *admission_v1.AdmissionResponse {
+	resp := &admission_v1.AdmissionResponse{
+		UID:     req.UID,
+		Allowed: true,
+	}
+
+	err := lbc.validateAdmissionRequest(req)
+	if err != nil {
+		glog.V(3).Infof("Denying %v %v/%v: %v", req.Kind.Kind, req.Namespace, req.Name, err)
+		resp.Allowed = false
+		resp.Result = &meta_v1.Status{
+			Status:  meta_v1.StatusFailure,
+			Reason:  meta_v1.StatusReasonInvalid,
+			Code:    http.StatusUnprocessableEntity,
+			Message: err.Error(),
+		}
+	}
+
+	return resp
+}
+
+func (lbc *LoadBalancerController) validateAdmissionRequest(req *admission_v1.AdmissionRequest) error {
+	if req.Operation != admission_v1.Create && req.Operation != admission_v1.Update {
+		return nil
+	}
+
+	switch req.Kind.Group {
+	case networking.GroupName:
+		if req.Kind.Kind == "Ingress" {
+			return lbc.validateIngressForAdmission(req)
+		}
+	case conf_v1.SchemeGroupVersion.Group:
+		switch req.Kind.Kind {
+		case "VirtualServer":
+			return lbc.validateVirtualServerForAdmission(req)
+		case "VirtualServerRoute":
+			return lbc.validateVirtualServerRouteForAdmission(req)
+		case "TransportServer":
+			return lbc.validateTransportServerForAdmission(req)
+		case "Policy":
+			return lbc.validatePolicyForAdmission(req)
+		}
+	}
+
+	glog.V(3).Infof("Allowing %v %v/%v that is not handled by the admission webhook", req.Kind.Kind, req.Namespace, req.Name)
+
+	return nil
+}
+
+func (lbc *LoadBalancerController) validateIngressForAdmission(req *admission_v1.AdmissionRequest) error {
+	var ing, oldIng networking.Ingress
+	err := decodeAdmissionObjects(req, &ing, &oldIng)
+	if err != nil {
+		return err
+	}
+
+	if !lbc.HasCorrectIngressClass(&ing) || !lbc.isSelectedResource(&ing.ObjectMeta) {
+		return nil
+	}
+
+	err = lbc.configuration.ValidateIngress(&ing)
+	if err != nil {
+		return err
+	}
+
+	// the hosts of minions are held by their masters
+	if isMinion(&ing) {
+		return nil
+	}
+
+	return lbc.validateHostsForAdmission(getResourceKeyWithKind(ingressKind, &ing.ObjectMeta), getIngressHosts(&ing), getIngressHosts(&oldIng))
+}
+
+func (lbc *LoadBalancerController) validateVirtualServerForAdmission(req *admission_v1.AdmissionRequest) error {
+	var vs, oldVS conf_v1.VirtualServer
+	err := decodeAdmissionObjects(req, &vs, &oldVS)
+	if err != nil {
+		return err
+	}
+
+	if !lbc.HasCorrectIngressClass(&vs) || !lbc.isSelectedResource(&vs.ObjectMeta) {
+		return nil
+	}
+
+	err = lbc.configuration.ValidateVirtualServer(&vs)
+	if err != nil {
+		return err
+	}
+
+	return lbc.validateHostsForAdmission(getResourceKeyWithKind(virtualServerKind, &vs.ObjectMeta), []string{vs.Spec.Host}, []string{oldVS.Spec.Host})
+}
+
+func (lbc *LoadBalancerController) validateVirtualServerRouteForAdmission(req *admission_v1.AdmissionRequest) error {
+	var vsr, oldVSR conf_v1.VirtualServerRoute
+	err := decodeAdmissionObjects(req, &vsr, &oldVSR)
+	if err != nil {
+		return err
+	}
+
+	if !lbc.HasCorrectIngressClass(&vsr) || !lbc.isSelectedResource(&vsr.ObjectMeta) {
+		return nil
+	}
+
+	return lbc.configuration.ValidateVirtualServerRoute(&vsr)
+}
+
+func (lbc *LoadBalancerController) validateTransportServerForAdmission(req *admission_v1.AdmissionRequest) error {
+	ts, oldTS, err := decodeTransportServersForAdmission(req)
+	if err != nil {
+		return err
+	}
+
+	if !lbc.HasCorrectIngressClass(&ts) || !lbc.isSelectedResource(&ts.ObjectMeta) {
+		return nil
+	}
+
+	err = lbc.configuration.ValidateTransportServer(&ts)
+	if err != nil {
+		return err
+	}
+
+	keyWithKind := getResourceKeyWithKind(transportServerKind, &ts.ObjectMeta)
+
+	if ts.Spec.Listener.Protocol == conf_v1.TLSPassthroughListenerProtocol {
+		return lbc.validateHostsForAdmission(keyWithKind, []string{getHostKeyForTransportServer(&ts)}, []string{getHostKeyForTransportServer(&oldTS)})
+	}
+
+	if ts.Spec.Listener.Name == oldTS.Spec.Listener.Name {
+		return nil
+	}
+
+	holder, exists := lbc.configuration.GetListenerHolder(ts.Spec.Listener.Name)
+	if exists && holder != keyWithKind {
+		return fmt.Errorf("listener %s is taken by %s", ts.Spec.Listener.Name, holder)
+	}
+
+	return nil
+}
+
+func (lbc *LoadBalancerController) validatePolicyForAdmission(req *admission_v1.AdmissionRequest) error {
+	pol, err := decodePolicyForAdmission(req)
+	if err != nil {
+		return err
+	}
+
+	if !lbc.HasCorrectIngressClass(&pol) || !lbc.isSelectedResource(&pol.ObjectMeta) {
+		return nil
+	}
+
+	return validation.ValidatePolicy(&pol, lbc.isNginxPlus, lbc.enablePreviewPolicies, lbc.appProtectEnabled)
+}
+
+// validateHostsForAdmission returns an error if any of the hosts of a resource is taken by another resource.
+// The hosts that the resource already had before an update are not checked, so that the resources which lost a host
+// to another resource can still be updated.
+func (lbc *LoadBalancerController) validateHostsForAdmission(keyWithKind string, hosts []string, oldHosts []string) error {
+	existingHosts := make(map[string]bool)
+	for _, h := range oldHosts {
+		existingHosts[h] = true
+	}
+
+	for _, h := range hosts {
+		if existingHosts[h] {
+			continue
+		}
+
+		holder, exists := lbc.configuration.GetHostHolder(h)
+		if exists && holder != keyWithKind {
+			return fmt.Errorf("host %s is taken by %s", h, holder)
+		}
+	}
+
+	return nil
+}
+
+// decodeAdmissionObjects decodes the object of the request and, for updates, the old object.
+func decodeAdmissionObjects(req *admission_v1.AdmissionRequest, obj runtime.Object, oldObj runtime.Object) error {
+	err := json.Unmarshal(req.Object.Raw, obj)
+	if err != nil {
+		return fmt.Errorf("Error decoding %v: %w", req.Kind.Kind, err)
+	}
+
+	if req.Operation == admission_v1.Update && len(req.OldObject.Raw) > 0 {
+		err = json.Unmarshal(req.OldObject.Raw, oldObj)
+		if err != nil {
+			return fmt.Errorf("Error decoding the old %v: %w", req.Kind.Kind, err)
+		}
+	}
+
+	return nil
+}
+
+// decodeTransportServersForAdmission decodes the TransportServers of an AdmissionRequest and converts them to
+// the storage version, which the Ingress Controller works with.
+func decodeTransportServersForAdmission(req *admission_v1.AdmissionRequest) (conf_v1.TransportServer, conf_v1.TransportServer, error) {
+	var ts, oldTS conf_v1.TransportServer
+
+	if req.Kind.Version != conf_v1alpha1.SchemeGroupVersion.Version {
+		err := decodeAdmissionObjects(req, &ts, &oldTS)
+		return ts, oldTS, err
+	}
+
+	var v1alpha1TS, v1alpha1OldTS conf_v1alpha1.TransportServer
+	err := decodeAdmissionObjects(req, &v1alpha1TS, &v1alpha1OldTS)
+	if err != nil {
+		return ts, oldTS, err
+	}
+
+	return *conf_v1alpha1.ConvertTransportServerToV1(&v1alpha1TS), *conf_v1alpha1.ConvertTransportServerToV1(&v1alpha1OldTS), nil
+}
+
+// decodePolicyForAdmission decodes the Policy of an AdmissionRequest and converts it to the storage version,
+// which the Ingress Controller works with. The old Policy is not needed for the validation.
+func decodePolicyForAdmission(req *admission_v1.AdmissionRequest) (conf_v1.Policy, error) {
+	var pol, oldPol conf_v1.Policy
+
+	if req.Kind.Version != conf_v1alpha1.SchemeGroupVersion.Version {
+		err := decodeAdmissionObjects(req, &pol, &oldPol)
+		return pol, err
+	}
+
+	var v1alpha1Pol, v1alpha1OldPol conf_v1alpha1.Policy
+	err := decodeAdmissionObjects(req, &v1alpha1Pol, &v1alpha1OldPol)
+	if err != nil {
+		return pol, err
+	}
+
+	return *conf_v1alpha1.ConvertPolicyToV1(&v1alpha1Pol), nil
+}
+
+func getIngressHosts(ing *networking.Ingress) []string {
+	var hosts []string
+	for _, rule := range ing.Spec.Rules {
+		hosts = append(hosts, rule.Host)
+	}
+	return hosts
+}
@@ -0,0 +1,335 @@
+package k8s
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
+	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
+	admission_v1 "k8s.io/api/admission/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func createTestAdmissionController() *LoadBalancerController {
+	lbc := &LoadBalancerController{
+		ingressClass:  "nginx",
+		configuration: createTestConfiguration(),
+	}
+
+	lbc.configuration.AddOrUpdateVirtualServer(createTestVirtualServer("cafe", "cafe.example.com"))
+	lbc.configuration.AddOrUpdateIngress(createTestIngress("tea", "tea.example.com"))
+	lbc.configuration.AddOrUpdateGlobalConfiguration(createTestGlobalConfiguration([]conf_v1.Listener{
+		{
+			Name:     "tcp-7777",
+			Port:     7777,
+			Protocol: "TCP",
+		},
+	}))
+	lbc.configuration.AddOrUpdateTransportServer(createTestTransportServer("transportserver", "tcp-7777", "TCP"))
+
+	return lbc
+}
+
+func createTestAdmissionRequest(t *testing.T, kind string, operation admission_v1.Operation, obj runtime.Object, oldObj runtime.Object) *admission_v1.AdmissionRequest {
+	t.Helper()
+
+	group := conf_v1.SchemeGroupVersion.Group
+	if kind == "Ingress" {
+		group = "networking.k8s.io"
+	}
+
+	req := &admission_v1.AdmissionRequest{
+		UID:       types.UID("test-uid"),
+		Kind:      meta_v1.GroupVersionKind{Group: group, Kind: kind},
+		Operation: operation,
+	}
+
+	if obj != nil {
+		raw, err := json.Marshal(obj)
+		if err != nil {
+			t.Fatalf("Failed to marshal the object: %v", err)
+		}
+		req.Object = runtime.RawExtension{Raw: raw}
+	}
+
+	if oldObj != nil {
+		raw, err := json.Marshal(oldObj)
+		if err != nil {
+			t.Fatalf("Failed to marshal the old object: %v", err)
+		}
+		req.OldObject = runtime.RawExtension{Raw: raw}
+	}
+
+	return req
+}
+
+func TestReviewAdmissionRequest(t *testing.T) {
+	validVS := createTestVirtualServer("coffee", "coffee.example.com")
+
+	invalidVS := createTestVirtualServer("coffee", "")
+
+	conflictingVS := createTestVirtualServer("coffee", "cafe.example.com")
+
+	otherClassVS := createTestVirtualServer("coffee", "")
+	otherClassVS.Spec.IngressClass = "other"
+
+	winnerVS := createTestVirtualServer("cafe", "cafe.example.com")
+
+	loserVS := createTestVirtualServer("coffee", "cafe.example.com")
+
+	conflictingIngress := createTestIngress("coffee", "coffee.example.com", "tea.example.com")
+
+	invalidIngress := createTestIngress("coffee", "coffee.example.com")
+	invalidIngress.Annotations["nginx.org/lb-method"] = "invalid"
+
+	minionIngress := createTestIngressMinion("tea-minion", "tea.example.com", "/tea")
+
+	conflictingTS := createTestTransportServer("other-transportserver", "tcp-7777", "TCP")
+
+	validTS := createTestTransportServer("other-transportserver", "tcp-8888", "TCP")
+
+	conflictingPassthroughTS := createTestTLSPassthroughTransportServer("passthrough", "cafe.example.com")
+
+	invalidPolicy := &conf_v1.Policy{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "policy",
+			Namespace: "default",
+		},
+		Spec: conf_v1.PolicySpec{},
+	}
+
+	invalidV1alpha1Policy := &conf_v1alpha1.Policy{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "policy",
+			Namespace: "default",
+		},
+		Spec: conf_v1alpha1.PolicySpec{
+			AccessControl: &conf_v1alpha1.AccessControl{
+				Allow: []string{"invalid"},
+			},
+		},
+	}
+
+	validV1alpha1Policy := &conf_v1alpha1.Policy{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "policy",
+			Namespace: "default",
+		},
+		Spec: conf_v1alpha1.PolicySpec{
+			AccessControl: &conf_v1alpha1.AccessControl{
+				Allow: []string{"10.0.0.0/8"},
+			},
+		},
+	}
+
+	tests := []struct {
+		req      func(t *testing.T) *admission_v1.AdmissionRequest
+		expected bool
+		msg      string
+	}{
+		{
+			req: func(t *testing.T) *admission_v1.AdmissionRequest {
+				return createTestAdmissionRequest(t, "VirtualServer", admission_v1.Create, validVS, nil)
+			},
+			expected: true,
+			msg:      "valid VirtualServer",
+		},
+		{
+			req: func(t *testing.T) *admission_v1.AdmissionRequest {
+				return createTestAdmissionRequest(t, "VirtualServer", admission_v1.Create, invalidVS, nil)
+			},
+			expected: false,
+			msg:      "invalid VirtualServer",
+		},
+		{
+			req: func(t *testing.T) *admission_v1.AdmissionRequest {
+				return createTestAdmissionRequest(t, "VirtualServer", admission_v1.Create, otherClassVS, nil)
+			},
+			expected: true,
+			msg:      "invalid VirtualServer of another class",
+		},
+		{
+			req: func(t *testing.T) *admission_v1.AdmissionRequest {
+				return createTestAdmissionRequest(t, "VirtualServer", admission_v1.Create, conflictingVS, nil)
+			},
+			expected: false,
+			msg:      "VirtualServer with a taken host",
+		},
+		{
+			req: func(t *testing.T) *admission_v1.AdmissionRequest {
+				return createTestAdmissionRequest(t, "VirtualServer", admission_v1.Update, winnerVS, winnerVS)
+			},
+			expected: true,
+			msg:      "update of the VirtualServer that holds the host",
+		},
+		{
+			req: func(t *testing.T) *admission_v1.AdmissionRequest {
+				return createTestAdmissionRequest(t, "VirtualServer", admission_v1.Update, loserVS, loserVS)
+			},
+			expected: true,
+			msg:      "update of a VirtualServer that already had the taken host",
+		},
+		{
+			req: func(t *testing.T) *admission_v1.AdmissionRequest {
+				return createTestAdmissionRequest(t, "VirtualServer", admission_v1.Update, conflictingVS, validVS)
+			},
+			expected: false,
+			msg:      "update of a VirtualServer to a taken host",
+		},
+		{
+			req: func(t *testing.T) *admission_v1.AdmissionRequest {
+				return createTestAdmissionRequest(t, "VirtualServer", admission_v1.Delete, nil, invalidVS)
+			},
+			expected: true,
+			msg:      "deletion",
+		},
+		{
+			req: func(t *testing.T) *admission_v1.AdmissionRequest {
+				return createTestAdmissionRequest(t, "Ingress", admission_v1.Create, conflictingIngress, nil)
+			},
+			expected: false,
+			msg:      "Ingress with a taken host",
+		},
+		{
+			req: func(t *testing.T) *admission_v1.AdmissionRequest {
+				return createTestAdmissionRequest(t, "Ingress", admission_v1.Create, invalidIngress, nil)
+			},
+			expected: false,
+			msg:      "Ingress with an invalid annotation",
+		},
+		{
+			req: func(t *testing.T) *admission_v1.AdmissionRequest {
+				return createTestAdmissionRequest(t, "Ingress", admission_v1.Create, minionIngress, nil)
+			},
+			expected: true,
+			msg:      "minion Ingress with a taken host",
+		},
+		{
+			req: func(t *testing.T) *admission_v1.AdmissionRequest {
+				return createTestAdmissionRequest(t, "TransportServer", admission_v1.Create, conflictingTS, nil)
+			},
+			expected: false,
+			msg:      "TransportServer with a taken listener",
+		},
+		{
+			req: func(t *testing.T) *admission_v1.AdmissionRequest {
+				req := createTestAdmissionRequest(t, "TransportServer", admission_v1.Create, conf_v1alpha1.ConvertTransportServerFromV1(conflictingTS), nil)
+				req.Kind.Version = conf_v1alpha1.SchemeGroupVersion.Version
+				return req
+			},
+			expected: false,
+			msg:      "v1alpha1 TransportServer with a taken listener",
+		},
+		{
+			req: func(t *testing.T) *admission_v1.AdmissionRequest {
+				return createTestAdmissionRequest(t, "TransportServer", admission_v1.Create, validTS, nil)
+			},
+			expected: true,
+			msg:      "TransportServer with a free listener",
+		},
+		{
+			req: func(t *testing.T) *admission_v1.AdmissionRequest {
+				return createTestAdmissionRequest(t, "TransportServer", admission_v1.Create, conflictingPassthroughTS, nil)
+			},
+			expected: false,
+			msg:      "TLS Passthrough TransportServer with a taken host",
+		},
+		{
+			req: func(t *testing.T) *admission_v1.AdmissionRequest {
+				return createTestAdmissionRequest(t, "Policy", admission_v1.Create, invalidPolicy, nil)
+			},
+			expected: false,
+			msg:      "invalid Policy",
+		},
+		{
+			req: func(t *testing.T) *admission_v1.AdmissionRequest {
+				req := createTestAdmissionRequest(t, "Policy", admission_v1.Create, invalidV1alpha1Policy, nil)
+				req.Kind.Version = conf_v1alpha1.SchemeGroupVersion.Version
+				return req
+			},
+			expected: false,
+			msg:      "invalid v1alpha1 Policy",
+		},
+		{
+			req: func(t *testing.T) *admission_v1.AdmissionRequest {
+				req := createTestAdmissionRequest(t, "Policy", admission_v1.Update, validV1alpha1Policy, invalidV1alpha1Policy)
+				req.Kind.Version = conf_v1alpha1.SchemeGroupVersion.Version
+				return req
+			},
+			expected: true,
+			msg:      "valid v1alpha1 Policy",
+		},
+		{
+			req: func(t *testing.T) *admission_v1.AdmissionRequest {
+				return createTestAdmissionRequest(t, "GlobalConfiguration", admission_v1.Create, createTestGlobalConfiguration(nil), nil)
+			},
+			expected: true,
+			msg:      "unhandled kind",
+		},
+	}
+
+	lbc := createTestAdmissionController()
+
+	for _, test := range tests {
+		resp := lbc.reviewAdmissionRequest(test.req(t))
+
+		if resp.UID != "test-uid" {
+			t.Errorf("reviewAdmissionRequest() returned UID %q but expected %q for the case of %s", resp.UID, "test-uid", test.msg)
+		}
+		if resp.Allowed != test.expected {
+			t.Errorf("reviewAdmissionRequest() returned allowed %v (result %v) but expected %v for the case of %s", resp.Allowed, resp.Result, test.expected, test.msg)
+		}
+		if !resp.Allowed && resp.Result == nil {
+			t.Errorf("reviewAdmissionRequest() returned no result for a denied request for the case of %s", test.msg)
+		}
+	}
+}
+
+func TestServeAdmissionReview(t *testing.T) {
+	lbc := createTestAdmissionController()
+
+	review := admission_v1.AdmissionReview{
+		TypeMeta: meta_v1.TypeMeta{
+			APIVersion: "admission.k8s.io/v1",
+			Kind:       "AdmissionReview",
+		},
+		Request: createTestAdmissionRequest(t, "VirtualServer", admission_v1.Create, createTestVirtualServer("coffee", "cafe.example.com"), nil),
+	}
+
+	body, err := json.Marshal(review)
+	if err != nil {
+		t.Fatalf("Failed to marshal the AdmissionReview: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	lbc.ServeAdmissionReview(rec, httptest.NewRequest(http.MethodPost, "/validate", bytes.NewReader(body)))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("ServeAdmissionReview() returned status %v but expected %v", rec.Code, http.StatusOK)
+	}
+
+	var result admission_v1.AdmissionReview
+	err = json.Unmarshal(rec.Body.Bytes(), &result)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal the response: %v", err)
+	}
+
+	if result.Kind != "AdmissionReview" || result.APIVersion != "admission.k8s.io/v1" {
+		t.Errorf("ServeAdmissionReview() returned %v/%v but expected admission.k8s.io/v1/AdmissionReview", result.APIVersion, result.Kind)
+	}
+	if result.Response == nil || result.Response.Allowed {
+		t.Errorf("ServeAdmissionReview() returned response %v but expected a denied response", result.Response)
+	}
+
+	rec = httptest.NewRecorder()
+	lbc.ServeAdmissionReview(rec, httptest.NewRequest(http.MethodPost, "/validate", bytes.NewReader([]byte("{}"))))
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("ServeAdmissionReview() returned status %v but expected %v for a review without a request", rec.Code, http.StatusBadRequest)
+	}
+}
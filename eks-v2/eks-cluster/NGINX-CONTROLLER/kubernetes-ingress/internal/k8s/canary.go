@@ -0,0 +1,381 @@
+package k8s
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/nginxinc/kubernetes-ingress/internal/configs"
+	"github.com/nginxinc/kubernetes-ingress/internal/metrics/collectors"
+	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
+)
+
+const (
+	canaryPhaseProgressing = "Progressing"
+	canaryPhasePromoted    = "Promoted"
+	canaryPhaseRolledBack  = "RolledBack"
+)
+
+// canaryCheckPeriod is how often the canaryManager checks if the current step of a canary is over.
+const canaryCheckPeriod = 5 * time.Second
+
+// canaryState holds the progress of the canary of a route.
+type canaryState struct {
+	path        string
+	fingerprint string
+	upstream    string
+
+	steps         []int
+	interval      time.Duration
+	maxErrorRate  *int
+	maxLatencyP99 time.Duration
+	minRequests   uint64
+
+	phase       string
+	step        int
+	weight      int
+	message     string
+	stepStarted time.Time
+	baseline    collectors.UpstreamStats
+}
+
+func (s *canaryState) status() conf_v1.CanaryStatus {
+	return conf_v1.CanaryStatus{
+		Path:        s.path,
+		Phase:       s.phase,
+		Weight:      s.weight,
+		Step:        s.step,
+		Message:     s.message,
+		Fingerprint: s.fingerprint,
+	}
+}
+
+// restore continues the canary from the progress reported in the status of the VirtualServer, so that a restarted
+// Ingress Controller pod doesn't restart the canary. A progress that doesn't fit the steps of the canary is ignored.
+func (s *canaryState) restore(status conf_v1.CanaryStatus) {
+	switch status.Phase {
+	case canaryPhaseProgressing:
+		if status.Step < 0 || status.Step >= len(s.steps) {
+			return
+		}
+		s.weight = s.steps[status.Step]
+	case canaryPhasePromoted:
+		s.weight = 100
+	case canaryPhaseRolledBack:
+		s.weight = 0
+	default:
+		return
+	}
+
+	s.phase = status.Phase
+	s.step = status.Step
+	s.message = status.Message
+}
+
+// canaryManager progressively shifts the traffic of the routes with a canary from the first split to the second one.
+// At the end of every step, it compares the error rate and the latency of the upstream of the second split, recorded by
+// the latency collector during the step, with the limits of the canary. Then it moves to the next step, promotes
+// the canary or rolls it back. Only the leader Ingress Controller pod makes the decisions, based on the requests it
+// proxies, and reports the progress in the status of the VirtualServer along with the fingerprint of the canary.
+// The other pods follow the reported progress, and a restarted pod continues the canaries from it.
+type canaryManager struct {
+	// states are indexed by the key of the VirtualServer and then by the path of the route.
+	states           map[string]map[string]*canaryState
+	mutex            sync.Mutex
+	latencyCollector collectors.LatencyCollector
+	now              func() time.Time
+	// isLeader tells if the pod makes the decisions about the canaries.
+	isLeader func() bool
+	// onChange is called with the key of a VirtualServer and the new status of a canary after the canary moved to
+	// the next step, was promoted or was rolled back.
+	onChange func(key string, status conf_v1.CanaryStatus)
+}
+
+func newCanaryManager(latencyCollector collectors.LatencyCollector, isLeader func() bool, onChange func(string, conf_v1.CanaryStatus)) *canaryManager {
+	return &canaryManager{
+		states:           make(map[string]map[string]*canaryState),
+		latencyCollector: latencyCollector,
+		now:              time.Now,
+		isLeader:         isLeader,
+		onChange:         onChange,
+	}
+}
+
+// Run periodically evaluates the canaries until stopCh is closed.
+func (cm *canaryManager) Run(stopCh <-chan struct{}) {
+	ticker := time.NewTicker(canaryCheckPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			cm.evaluate()
+		case <-stopCh:
+			return
+		}
+	}
+}
+
+type canaryChange struct {
+	key    string
+	status conf_v1.CanaryStatus
+}
+
+// evaluate ends the steps of the canaries whose interval has elapsed. Only the leader evaluates the canaries.
+func (cm *canaryManager) evaluate() {
+	if !cm.isLeader() {
+		return
+	}
+
+	var changes []canaryChange
+
+	cm.mutex.Lock()
+	now := cm.now()
+	for key, states := range cm.states {
+		for _, s := range states {
+			if s.phase != canaryPhaseProgressing || now.Sub(s.stepStarted) < s.interval {
+				continue
+			}
+			if cm.endStep(s, now) {
+				changes = append(changes, canaryChange{key: key, status: s.status()})
+			}
+		}
+	}
+	cm.mutex.Unlock()
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].key != changes[j].key {
+			return changes[i].key < changes[j].key
+		}
+		return changes[i].status.Path < changes[j].status.Path
+	})
+
+	for _, c := range changes {
+		cm.onChange(c.key, c.status)
+	}
+}
+
+// endStep decides the outcome of the current step of a canary. It returns false if the step must be extended because
+// the upstream didn't receive enough requests.
+func (cm *canaryManager) endStep(s *canaryState, now time.Time) bool {
+	current := cm.latencyCollector.GetUpstreamStats(s.upstream)
+	window := current.Sub(s.baseline)
+
+	if window.Requests < s.minRequests {
+		s.message = fmt.Sprintf("Waiting for %d requests to upstream %s, got %d", s.minRequests, s.upstream, window.Requests)
+		return false
+	}
+
+	defer func() {
+		s.baseline = current
+		s.stepStarted = now
+	}()
+
+	if s.maxErrorRate != nil && window.Requests > 0 {
+		errorRate := float64(window.ServerErrors) * 100 / float64(window.Requests)
+		if errorRate > float64(*s.maxErrorRate) {
+			s.rollBack(fmt.Sprintf("Error rate %.2f%% exceeded the maximum of %d%%", errorRate, *s.maxErrorRate))
+			return true
+		}
+	}
+
+	// the percentile is estimated from the latency buckets, so it is the upper bound of the bucket that contains it
+	if s.maxLatencyP99 > 0 && window.Percentile(99) > float64(s.maxLatencyP99.Milliseconds()) {
+		s.rollBack(fmt.Sprintf("P99 latency exceeded the maximum of %v", s.maxLatencyP99))
+		return true
+	}
+
+	if s.step < len(s.steps)-1 {
+		s.step++
+		s.weight = s.steps[s.step]
+		s.message = fmt.Sprintf("Moved to step %d with weight %d%%", s.step, s.weight)
+		return true
+	}
+
+	s.phase = canaryPhasePromoted
+	s.weight = 100
+	s.message = fmt.Sprintf("Promoted after %d steps", len(s.steps))
+
+	return true
+}
+
+func (s *canaryState) rollBack(reason string) {
+	s.phase = canaryPhaseRolledBack
+	s.weight = 0
+	s.message = fmt.Sprintf("Rolled back at step %d: %s", s.step, reason)
+}
+
+// apply sets the weights of the canaries of the VirtualServerEx according to their progress.
+// A canary starts from the first step when it is first seen, unless its progress is reported in the status
+// of the VirtualServer, or when the canary or the splits of its route change.
+// reportedCanaries is the latest progress reported in the status, which the pods other than the leader follow.
+func (cm *canaryManager) apply(vsEx *configs.VirtualServerEx, reportedCanaries []conf_v1.CanaryStatus) {
+	key := getResourceKey(&vsEx.VirtualServer.ObjectMeta)
+
+	cm.mutex.Lock()
+	defer cm.mutex.Unlock()
+
+	previous := cm.states[key]
+	states := make(map[string]*canaryState)
+
+	reported := make(map[string]conf_v1.CanaryStatus)
+	for _, status := range reportedCanaries {
+		reported[status.Path] = status
+	}
+
+	cm.addStatesForRoutes(vsEx.VirtualServer, nil, vsEx.VirtualServer.Spec.Routes, previous, reported, states)
+	for _, vsr := range vsEx.VirtualServerRoutes {
+		cm.addStatesForRoutes(vsEx.VirtualServer, vsr, vsr.Spec.Subroutes, previous, reported, states)
+	}
+
+	if len(states) == 0 {
+		delete(cm.states, key)
+		return
+	}
+	cm.states[key] = states
+
+	vsEx.CanaryWeights = make(map[string]int)
+	for path, s := range states {
+		vsEx.CanaryWeights[path] = s.weight
+	}
+}
+
+// getStatuses returns the statuses of the canaries of a VirtualServer sorted by the path of their routes.
+func (cm *canaryManager) getStatuses(key string) []conf_v1.CanaryStatus {
+	cm.mutex.Lock()
+	defer cm.mutex.Unlock()
+
+	var statuses []conf_v1.CanaryStatus
+	for _, s := range cm.states[key] {
+		statuses = append(statuses, s.status())
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Path < statuses[j].Path
+	})
+
+	return statuses
+}
+
+func (cm *canaryManager) addStatesForRoutes(
+	vs *conf_v1.VirtualServer,
+	vsr *conf_v1.VirtualServerRoute,
+	routes []conf_v1.Route,
+	previous map[string]*canaryState,
+	reported map[string]conf_v1.CanaryStatus,
+	states map[string]*canaryState,
+) {
+	for i := range routes {
+		r := &routes[i]
+		if r.Canary == nil || len(r.Splits) != 2 || r.Splits[1].Action == nil || len(r.Canary.Steps) == 0 {
+			continue
+		}
+
+		fingerprint := getCanaryFingerprint(r)
+
+		s, exists := previous[r.Path]
+		isNew := !exists || s.fingerprint != fingerprint
+		if isNew {
+			var err error
+			s, err = cm.newCanaryState(vs, vsr, r, fingerprint)
+			if err != nil {
+				glog.Warningf("Ignoring the canary of the route %v of %v: %v", r.Path, getResourceKey(&vs.ObjectMeta), err)
+				continue
+			}
+		}
+
+		// the leader keeps its own progress, while the other pods follow the progress reported by the leader
+		if status, exists := reported[r.Path]; exists && status.Fingerprint == fingerprint && (isNew || !cm.isLeader()) {
+			step, phase := s.step, s.phase
+			s.restore(status)
+			if s.step != step || s.phase != phase {
+				s.stepStarted = cm.now()
+				s.baseline = cm.latencyCollector.GetUpstreamStats(s.upstream)
+			}
+		}
+
+		states[r.Path] = s
+	}
+}
+
+func (cm *canaryManager) newCanaryState(
+	vs *conf_v1.VirtualServer,
+	vsr *conf_v1.VirtualServerRoute,
+	route *conf_v1.Route,
+	fingerprint string,
+) (*canaryState, error) {
+	interval, err := configs.ParseTimeDuration(route.Canary.Interval)
+	if err != nil {
+		return nil, fmt.Errorf("invalid interval: %w", err)
+	}
+
+	var maxLatencyP99 time.Duration
+	if route.Canary.MaxLatencyP99 != "" {
+		maxLatencyP99, err = configs.ParseTimeDuration(route.Canary.MaxLatencyP99)
+		if err != nil {
+			return nil, fmt.Errorf("invalid maxLatencyP99: %w", err)
+		}
+	}
+
+	upstream := configs.GenerateUpstreamNameFromAction(vs, vsr, route.Splits[1].Action)
+
+	return &canaryState{
+		path:          route.Path,
+		fingerprint:   fingerprint,
+		upstream:      upstream,
+		steps:         route.Canary.Steps,
+		interval:      interval,
+		maxErrorRate:  route.Canary.MaxErrorRate,
+		maxLatencyP99: maxLatencyP99,
+		minRequests:   uint64(route.Canary.MinRequests),
+		phase:         canaryPhaseProgressing,
+		weight:        route.Canary.Steps[0],
+		message:       fmt.Sprintf("Started with weight %d%%", route.Canary.Steps[0]),
+		stepStarted:   cm.now(),
+		baseline:      cm.latencyCollector.GetUpstreamStats(upstream),
+	}, nil
+}
+
+// delete removes the progress of the canaries of a VirtualServer.
+func (cm *canaryManager) delete(key string) {
+	cm.mutex.Lock()
+	defer cm.mutex.Unlock()
+
+	delete(cm.states, key)
+}
+
+func hasCanaries(vsConfig *VirtualServerConfiguration) bool {
+	for _, r := range vsConfig.VirtualServer.Spec.Routes {
+		if r.Canary != nil {
+			return true
+		}
+	}
+	for _, vsr := range vsConfig.VirtualServerRoutes {
+		for _, r := range vsr.Spec.Subroutes {
+			if r.Canary != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getCanaryFingerprint returns a hash that changes when the canary or the splits of a route change,
+// so that the canary restarts from the first step.
+func getCanaryFingerprint(route *conf_v1.Route) string {
+	b, err := json.Marshal(struct {
+		Canary *conf_v1.Canary
+		Splits []conf_v1.Split
+	}{
+		Canary: route.Canary,
+		Splits: route.Splits,
+	})
+	if err != nil {
+		glog.Errorf("Error when marshalling the canary of the route %v: %v", route.Path, err)
+	}
+
+	return fmt.Sprintf("%x", sha256.Sum256(b))
+}
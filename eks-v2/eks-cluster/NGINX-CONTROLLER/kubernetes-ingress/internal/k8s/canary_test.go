@@ -0,0 +1,424 @@
+package k8s
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nginxinc/kubernetes-ingress/internal/configs"
+	"github.com/nginxinc/kubernetes-ingress/internal/metrics/collectors"
+	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func createPointerFromInt(n int) *int {
+	return &n
+}
+
+type fakeCanaryLatencyCollector struct {
+	collectors.LatencyFakeCollector
+	stats map[string]collectors.UpstreamStats
+}
+
+func (f *fakeCanaryLatencyCollector) GetUpstreamStats(upstream string) collectors.UpstreamStats {
+	return f.stats[upstream]
+}
+
+func createTestUpstreamStats(requests uint64, serverErrors uint64, latencyBucket int) collectors.UpstreamStats {
+	buckets := make([]uint64, 26)
+	buckets[latencyBucket] = requests
+	return collectors.UpstreamStats{
+		Requests:       requests,
+		ServerErrors:   serverErrors,
+		LatencyBuckets: buckets,
+	}
+}
+
+func createTestVirtualServerWithCanary(canary *conf_v1.Canary) *conf_v1.VirtualServer {
+	return &conf_v1.VirtualServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "cafe",
+			Namespace: "default",
+		},
+		Spec: conf_v1.VirtualServerSpec{
+			Routes: []conf_v1.Route{
+				{
+					Path: "/coffee",
+					Splits: []conf_v1.Split{
+						{
+							Weight: 90,
+							Action: &conf_v1.Action{
+								Pass: "coffee-v1",
+							},
+						},
+						{
+							Weight: 10,
+							Action: &conf_v1.Action{
+								Pass: "coffee-v2",
+							},
+						},
+					},
+					Canary: canary,
+				},
+			},
+		},
+	}
+}
+
+type canaryManagerTester struct {
+	cm        *canaryManager
+	collector *fakeCanaryLatencyCollector
+	now       time.Time
+	isLeader  bool
+	changes   []conf_v1.CanaryStatus
+}
+
+func newCanaryManagerTester() *canaryManagerTester {
+	tester := &canaryManagerTester{
+		collector: &fakeCanaryLatencyCollector{
+			stats: make(map[string]collectors.UpstreamStats),
+		},
+		now:      time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+		isLeader: true,
+	}
+	isLeader := func() bool {
+		return tester.isLeader
+	}
+	tester.cm = newCanaryManager(tester.collector, isLeader, func(_ string, status conf_v1.CanaryStatus) {
+		tester.changes = append(tester.changes, status)
+	})
+	tester.cm.now = func() time.Time {
+		return tester.now
+	}
+	return tester
+}
+
+func (tester *canaryManagerTester) apply(vs *conf_v1.VirtualServer) map[string]int {
+	vsEx := &configs.VirtualServerEx{
+		VirtualServer: vs,
+	}
+	tester.cm.apply(vsEx, vs.Status.Canaries)
+	return vsEx.CanaryWeights
+}
+
+func TestCanaryManagerPromotes(t *testing.T) {
+	tester := newCanaryManagerTester()
+	vs := createTestVirtualServerWithCanary(&conf_v1.Canary{
+		Steps:         []int{10, 50},
+		Interval:      "1m",
+		MaxErrorRate:  createPointerFromInt(5),
+		MaxLatencyP99: "100ms",
+	})
+
+	weights := tester.apply(vs)
+	if diff := cmp.Diff(map[string]int{"/coffee": 10}, weights); diff != "" {
+		t.Errorf("apply() returned unexpected weights (-want +got):\n%s", diff)
+	}
+
+	tester.collector.stats["vs_default_cafe_coffee-v2"] = createTestUpstreamStats(100, 1, 10)
+	tester.now = tester.now.Add(30 * time.Second)
+	tester.cm.evaluate()
+	if len(tester.changes) != 0 {
+		t.Errorf("evaluate() changed the canary before the end of the interval: %v", tester.changes)
+	}
+
+	tester.now = tester.now.Add(30 * time.Second)
+	tester.cm.evaluate()
+
+	tester.collector.stats["vs_default_cafe_coffee-v2"] = createTestUpstreamStats(200, 2, 10)
+	tester.now = tester.now.Add(time.Minute)
+	tester.cm.evaluate()
+
+	fingerprint := getCanaryFingerprint(&vs.Spec.Routes[0])
+	expectedChanges := []conf_v1.CanaryStatus{
+		{
+			Path:        "/coffee",
+			Phase:       canaryPhaseProgressing,
+			Weight:      50,
+			Step:        1,
+			Message:     "Moved to step 1 with weight 50%",
+			Fingerprint: fingerprint,
+		},
+		{
+			Path:        "/coffee",
+			Phase:       canaryPhasePromoted,
+			Weight:      100,
+			Step:        1,
+			Message:     "Promoted after 2 steps",
+			Fingerprint: fingerprint,
+		},
+	}
+	if diff := cmp.Diff(expectedChanges, tester.changes); diff != "" {
+		t.Errorf("evaluate() made unexpected changes (-want +got):\n%s", diff)
+	}
+
+	weights = tester.apply(vs)
+	if diff := cmp.Diff(map[string]int{"/coffee": 100}, weights); diff != "" {
+		t.Errorf("apply() returned unexpected weights (-want +got):\n%s", diff)
+	}
+
+	statuses := tester.cm.getStatuses("default/cafe")
+	if diff := cmp.Diff(expectedChanges[1:], statuses); diff != "" {
+		t.Errorf("getStatuses() returned unexpected statuses (-want +got):\n%s", diff)
+	}
+}
+
+func TestCanaryManagerRollsBack(t *testing.T) {
+	tests := []struct {
+		canary  *conf_v1.Canary
+		stats   collectors.UpstreamStats
+		message string
+		msg     string
+	}{
+		{
+			canary: &conf_v1.Canary{
+				Steps:        []int{10, 50},
+				Interval:     "1m",
+				MaxErrorRate: createPointerFromInt(5),
+			},
+			stats:   createTestUpstreamStats(100, 10, 0),
+			message: "Rolled back at step 0: Error rate 10.00% exceeded the maximum of 5%",
+			msg:     "error rate",
+		},
+		{
+			canary: &conf_v1.Canary{
+				Steps:         []int{10, 50},
+				Interval:      "1m",
+				MaxLatencyP99: "100ms",
+			},
+			stats:   createTestUpstreamStats(100, 0, 11),
+			message: "Rolled back at step 0: P99 latency exceeded the maximum of 100ms",
+			msg:     "latency",
+		},
+	}
+
+	for _, test := range tests {
+		tester := newCanaryManagerTester()
+		vs := createTestVirtualServerWithCanary(test.canary)
+
+		tester.apply(vs)
+
+		tester.collector.stats["vs_default_cafe_coffee-v2"] = test.stats
+		tester.now = tester.now.Add(time.Minute)
+		tester.cm.evaluate()
+
+		expectedChanges := []conf_v1.CanaryStatus{
+			{
+				Path:        "/coffee",
+				Phase:       canaryPhaseRolledBack,
+				Weight:      0,
+				Step:        0,
+				Message:     test.message,
+				Fingerprint: getCanaryFingerprint(&vs.Spec.Routes[0]),
+			},
+		}
+		if diff := cmp.Diff(expectedChanges, tester.changes); diff != "" {
+			t.Errorf("evaluate() made unexpected changes for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+
+		weights := tester.apply(vs)
+		if diff := cmp.Diff(map[string]int{"/coffee": 0}, weights); diff != "" {
+			t.Errorf("apply() returned unexpected weights for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestCanaryManagerWaitsForMinRequests(t *testing.T) {
+	tester := newCanaryManagerTester()
+	vs := createTestVirtualServerWithCanary(&conf_v1.Canary{
+		Steps:       []int{10, 50},
+		Interval:    "1m",
+		MinRequests: 100,
+	})
+
+	tester.collector.stats["vs_default_cafe_coffee-v2"] = createTestUpstreamStats(1000, 0, 0)
+	tester.apply(vs)
+
+	tester.collector.stats["vs_default_cafe_coffee-v2"] = createTestUpstreamStats(1050, 0, 0)
+	tester.now = tester.now.Add(time.Minute)
+	tester.cm.evaluate()
+	if len(tester.changes) != 0 {
+		t.Errorf("evaluate() changed the canary before receiving the minimum number of requests: %v", tester.changes)
+	}
+
+	expectedMessage := "Waiting for 100 requests to upstream vs_default_cafe_coffee-v2, got 50"
+	if statuses := tester.cm.getStatuses("default/cafe"); statuses[0].Message != expectedMessage {
+		t.Errorf("getStatuses() returned message %q but expected %q", statuses[0].Message, expectedMessage)
+	}
+
+	tester.collector.stats["vs_default_cafe_coffee-v2"] = createTestUpstreamStats(1100, 0, 0)
+	tester.now = tester.now.Add(canaryCheckPeriod)
+	tester.cm.evaluate()
+	if len(tester.changes) != 1 || tester.changes[0].Weight != 50 {
+		t.Errorf("evaluate() made changes %v but expected the canary to move to the next step", tester.changes)
+	}
+}
+
+func TestCanaryManagerRestartsOnChange(t *testing.T) {
+	tester := newCanaryManagerTester()
+	vs := createTestVirtualServerWithCanary(&conf_v1.Canary{
+		Steps:    []int{10, 50},
+		Interval: "1m",
+	})
+
+	tester.apply(vs)
+	tester.now = tester.now.Add(time.Minute)
+	tester.cm.evaluate()
+
+	weights := tester.apply(vs)
+	if diff := cmp.Diff(map[string]int{"/coffee": 50}, weights); diff != "" {
+		t.Errorf("apply() returned unexpected weights (-want +got):\n%s", diff)
+	}
+
+	updatedVs := createTestVirtualServerWithCanary(&conf_v1.Canary{
+		Steps:    []int{20, 50},
+		Interval: "1m",
+	})
+	weights = tester.apply(updatedVs)
+	if diff := cmp.Diff(map[string]int{"/coffee": 20}, weights); diff != "" {
+		t.Errorf("apply() returned unexpected weights after the canary changed (-want +got):\n%s", diff)
+	}
+
+	updatedVs.Spec.Routes[0].Canary = nil
+	weights = tester.apply(updatedVs)
+	if weights != nil {
+		t.Errorf("apply() returned weights %v for a VirtualServer without canaries", weights)
+	}
+	if statuses := tester.cm.getStatuses("default/cafe"); statuses != nil {
+		t.Errorf("getStatuses() returned %v for a VirtualServer without canaries", statuses)
+	}
+}
+
+func TestCanaryManagerRestoresFromStatus(t *testing.T) {
+	canary := &conf_v1.Canary{
+		Steps:    []int{10, 50},
+		Interval: "1m",
+	}
+	fingerprint := getCanaryFingerprint(&createTestVirtualServerWithCanary(canary).Spec.Routes[0])
+
+	tests := []struct {
+		status   conf_v1.CanaryStatus
+		expected map[string]int
+		msg      string
+	}{
+		{
+			status: conf_v1.CanaryStatus{
+				Path:        "/coffee",
+				Phase:       canaryPhaseProgressing,
+				Weight:      50,
+				Step:        1,
+				Fingerprint: fingerprint,
+			},
+			expected: map[string]int{"/coffee": 50},
+			msg:      "progressing canary",
+		},
+		{
+			status: conf_v1.CanaryStatus{
+				Path:        "/coffee",
+				Phase:       canaryPhasePromoted,
+				Weight:      100,
+				Step:        1,
+				Fingerprint: fingerprint,
+			},
+			expected: map[string]int{"/coffee": 100},
+			msg:      "promoted canary",
+		},
+		{
+			status: conf_v1.CanaryStatus{
+				Path:        "/coffee",
+				Phase:       canaryPhaseRolledBack,
+				Weight:      0,
+				Step:        0,
+				Fingerprint: fingerprint,
+			},
+			expected: map[string]int{"/coffee": 0},
+			msg:      "rolled back canary",
+		},
+		{
+			status: conf_v1.CanaryStatus{
+				Path:        "/coffee",
+				Phase:       canaryPhasePromoted,
+				Weight:      100,
+				Step:        1,
+				Fingerprint: "other",
+			},
+			expected: map[string]int{"/coffee": 10},
+			msg:      "canary with another fingerprint",
+		},
+		{
+			status: conf_v1.CanaryStatus{
+				Path:        "/coffee",
+				Phase:       canaryPhaseProgressing,
+				Weight:      50,
+				Step:        5,
+				Fingerprint: fingerprint,
+			},
+			expected: map[string]int{"/coffee": 10},
+			msg:      "progressing canary with an unknown step",
+		},
+	}
+
+	for _, test := range tests {
+		tester := newCanaryManagerTester()
+		vs := createTestVirtualServerWithCanary(canary)
+		vs.Status.Canaries = []conf_v1.CanaryStatus{test.status}
+
+		weights := tester.apply(vs)
+		if diff := cmp.Diff(test.expected, weights); diff != "" {
+			t.Errorf("apply() returned unexpected weights for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestCanaryManagerFollowsLeader(t *testing.T) {
+	tester := newCanaryManagerTester()
+	tester.isLeader = false
+	vs := createTestVirtualServerWithCanary(&conf_v1.Canary{
+		Steps:    []int{10, 50},
+		Interval: "1m",
+	})
+
+	tester.apply(vs)
+	tester.now = tester.now.Add(time.Minute)
+	tester.cm.evaluate()
+	if len(tester.changes) != 0 {
+		t.Errorf("evaluate() made changes %v in a pod that is not the leader", tester.changes)
+	}
+
+	vs.Status.Canaries = []conf_v1.CanaryStatus{
+		{
+			Path:        "/coffee",
+			Phase:       canaryPhaseProgressing,
+			Weight:      50,
+			Step:        1,
+			Message:     "Moved to step 1 with weight 50%",
+			Fingerprint: getCanaryFingerprint(&vs.Spec.Routes[0]),
+		},
+	}
+
+	weights := tester.apply(vs)
+	if diff := cmp.Diff(map[string]int{"/coffee": 50}, weights); diff != "" {
+		t.Errorf("apply() returned unexpected weights (-want +got):\n%s", diff)
+	}
+}
+
+func TestCanaryManagerDelete(t *testing.T) {
+	tester := newCanaryManagerTester()
+	vs := createTestVirtualServerWithCanary(&conf_v1.Canary{
+		Steps:    []int{10, 50},
+		Interval: "1m",
+	})
+
+	tester.apply(vs)
+	tester.cm.delete("default/cafe")
+
+	if statuses := tester.cm.getStatuses("default/cafe"); statuses != nil {
+		t.Errorf("getStatuses() returned %v for a deleted VirtualServer", statuses)
+	}
+
+	tester.now = tester.now.Add(time.Minute)
+	tester.cm.evaluate()
+	if len(tester.changes) != 0 {
+		t.Errorf("evaluate() made changes %v for a deleted VirtualServer", tester.changes)
+	}
+}
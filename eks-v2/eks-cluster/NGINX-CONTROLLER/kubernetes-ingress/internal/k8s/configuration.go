@@ -9,7 +9,6 @@ import (
 
 	"github.com/nginxinc/kubernetes-ingress/internal/configs"
 	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
-	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
 	"github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/validation"
 	networking "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -259,12 +258,12 @@ func (vsc *VirtualServerConfiguration) IsEqual(resource Resource) bool {
 // TransportServerConfiguration holds a TransportServer resource.
 type TransportServerConfiguration struct {
 	ListenerPort    int
-	TransportServer *conf_v1alpha1.TransportServer
+	TransportServer *conf_v1.TransportServer
 	Warnings        []string
 }
 
 // NewTransportServerConfiguration creates a new TransportServerConfiguration.
-func NewTransportServerConfiguration(ts *conf_v1alpha1.TransportServer) *TransportServerConfiguration {
+func NewTransportServerConfiguration(ts *conf_v1.TransportServer) *TransportServerConfiguration {
 	return &TransportServerConfiguration{
 		TransportServer: ts,
 	}
@@ -332,9 +331,15 @@ type Configuration struct {
 	ingresses           map[string]*networking.Ingress
 	virtualServers      map[string]*conf_v1.VirtualServer
 	virtualServerRoutes map[string]*conf_v1.VirtualServerRoute
-	transportServers    map[string]*conf_v1alpha1.TransportServer
+	transportServers    map[string]*conf_v1.TransportServer
 
-	globalConfiguration *conf_v1alpha1.GlobalConfiguration
+	globalConfiguration *conf_v1.GlobalConfiguration
+
+	// gatewayListeners, gatewayTransportServers and gatewayVirtualServers are generated from the Gateway API resources.
+	// Unlike TransportServer and VirtualServer resources, they are valid by construction.
+	gatewayListeners        []conf_v1.Listener
+	gatewayTransportServers map[string]*conf_v1.TransportServer
+	gatewayVirtualServers   map[string]*conf_v1.VirtualServer
 
 	hostProblems     map[string]ConfigurationProblem
 	listenerProblems map[string]ConfigurationProblem
@@ -381,7 +386,9 @@ func NewConfiguration(
 		ingresses:                    make(map[string]*networking.Ingress),
 		virtualServers:               make(map[string]*conf_v1.VirtualServer),
 		virtualServerRoutes:          make(map[string]*conf_v1.VirtualServerRoute),
-		transportServers:             make(map[string]*conf_v1alpha1.TransportServer),
+		transportServers:             make(map[string]*conf_v1.TransportServer),
+		gatewayTransportServers:      make(map[string]*conf_v1.TransportServer),
+		gatewayVirtualServers:        make(map[string]*conf_v1.VirtualServer),
 		hostProblems:                 make(map[string]ConfigurationProblem),
 		hasCorrectIngressClass:       hasCorrectIngressClass,
 		virtualServerValidator:       virtualServerValidator,
@@ -583,7 +590,7 @@ func (c *Configuration) DeleteVirtualServerRoute(key string) ([]ResourceChange,
 }
 
 // AddOrUpdateGlobalConfiguration adds or updates the GlobalConfiguration.
-func (c *Configuration) AddOrUpdateGlobalConfiguration(gc *conf_v1alpha1.GlobalConfiguration) ([]ResourceChange, []ConfigurationProblem, error) {
+func (c *Configuration) AddOrUpdateGlobalConfiguration(gc *conf_v1.GlobalConfiguration) ([]ResourceChange, []ConfigurationProblem, error) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
@@ -596,6 +603,13 @@ func (c *Configuration) AddOrUpdateGlobalConfiguration(gc *conf_v1alpha1.GlobalC
 
 	changes, problems := c.rebuildListeners()
 
+	if c.isTLSPassthroughEnabled {
+		hostChanges, hostProblems := c.rebuildHosts()
+
+		changes = append(changes, hostChanges...)
+		problems = append(problems, hostProblems...)
+	}
+
 	return changes, problems, validationErr
 }
 
@@ -607,11 +621,18 @@ func (c *Configuration) DeleteGlobalConfiguration() ([]ResourceChange, []Configu
 	c.globalConfiguration = nil
 	changes, problems := c.rebuildListeners()
 
+	if c.isTLSPassthroughEnabled {
+		hostChanges, hostProblems := c.rebuildHosts()
+
+		changes = append(changes, hostChanges...)
+		problems = append(problems, hostProblems...)
+	}
+
 	return changes, problems
 }
 
 // GetGlobalConfiguration returns the current GlobalConfiguration.
-func (c *Configuration) GetGlobalConfiguration() *conf_v1alpha1.GlobalConfiguration {
+func (c *Configuration) GetGlobalConfiguration() *conf_v1.GlobalConfiguration {
 	c.lock.RLock()
 	defer c.lock.RUnlock()
 
@@ -619,7 +640,7 @@ func (c *Configuration) GetGlobalConfiguration() *conf_v1alpha1.GlobalConfigurat
 }
 
 // AddOrUpdateTransportServer adds or updates the TransportServer.
-func (c *Configuration) AddOrUpdateTransportServer(ts *conf_v1alpha1.TransportServer) ([]ResourceChange, []ConfigurationProblem) {
+func (c *Configuration) AddOrUpdateTransportServer(ts *conf_v1.TransportServer) ([]ResourceChange, []ConfigurationProblem) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
@@ -700,6 +721,163 @@ func (c *Configuration) DeleteTransportServer(key string) ([]ResourceChange, []C
 	return changes, problems
 }
 
+// SetGatewayTransportServers replaces the listeners and the TransportServers generated from the Gateway API resources.
+// The listeners play the same role as the listeners of the GlobalConfiguration. However, if a listener uses the same port
+// and protocol as a listener of the GlobalConfiguration, the listener of the GlobalConfiguration wins
+// (see GetConflictedGatewayListeners).
+func (c *Configuration) SetGatewayTransportServers(listeners []conf_v1.Listener, transportServers []*conf_v1.TransportServer) ([]ResourceChange, []ConfigurationProblem) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.gatewayListeners = listeners
+	c.gatewayTransportServers = make(map[string]*conf_v1.TransportServer)
+	for _, ts := range transportServers {
+		c.gatewayTransportServers[getResourceKey(&ts.ObjectMeta)] = ts
+	}
+
+	changes, problems := c.rebuildListeners()
+
+	if c.isTLSPassthroughEnabled {
+		hostChanges, hostProblems := c.rebuildHosts()
+
+		changes = append(changes, hostChanges...)
+		problems = append(problems, hostProblems...)
+	}
+
+	return changes, problems
+}
+
+// SetGatewayVirtualServers replaces the VirtualServers generated from the Gateway API resources.
+// The VirtualServers compete for hosts with the other resources the same way VirtualServer resources do.
+func (c *Configuration) SetGatewayVirtualServers(virtualServers []*conf_v1.VirtualServer) ([]ResourceChange, []ConfigurationProblem) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.gatewayVirtualServers = make(map[string]*conf_v1.VirtualServer)
+	for _, vs := range virtualServers {
+		c.gatewayVirtualServers[getResourceKey(&vs.ObjectMeta)] = vs
+	}
+
+	return c.rebuildHosts()
+}
+
+// getAllVirtualServers returns the VirtualServer resources along with the VirtualServers generated from
+// the Gateway API resources.
+func (c *Configuration) getAllVirtualServers() map[string]*conf_v1.VirtualServer {
+	result := make(map[string]*conf_v1.VirtualServer, len(c.virtualServers)+len(c.gatewayVirtualServers))
+
+	for key, vs := range c.virtualServers {
+		result[key] = vs
+	}
+	for key, vs := range c.gatewayVirtualServers {
+		result[key] = vs
+	}
+
+	return result
+}
+
+// getAllTransportServers returns the TransportServer resources along with the TransportServers generated from
+// the Gateway API resources.
+func (c *Configuration) getAllTransportServers() map[string]*conf_v1.TransportServer {
+	result := make(map[string]*conf_v1.TransportServer, len(c.transportServers)+len(c.gatewayTransportServers))
+
+	for key, ts := range c.transportServers {
+		result[key] = ts
+	}
+	for key, ts := range c.gatewayTransportServers {
+		result[key] = ts
+	}
+
+	return result
+}
+
+// getAllListeners returns the listeners of the GlobalConfiguration along with the listeners generated from
+// the Gateway API resources that don't conflict with them.
+func (c *Configuration) getAllListeners() []conf_v1.Listener {
+	var result []conf_v1.Listener
+
+	if c.globalConfiguration != nil {
+		result = append(result, c.globalConfiguration.Spec.Listeners...)
+	}
+
+	conflicts := c.getConflictedGatewayListeners()
+
+	for _, l := range c.gatewayListeners {
+		if _, conflicted := conflicts[l.Name]; conflicted {
+			continue
+		}
+		result = append(result, l)
+	}
+
+	return result
+}
+
+// getConflictedGatewayListeners returns the listeners generated from the Gateway API resources that use the same port
+// and protocol as a listener of the GlobalConfiguration. The result maps the name of such a listener to the name of
+// the listener of the GlobalConfiguration.
+func (c *Configuration) getConflictedGatewayListeners() map[string]string {
+	result := make(map[string]string)
+
+	if c.globalConfiguration == nil {
+		return result
+	}
+
+	portProtocols := make(map[string]string)
+	for _, l := range c.globalConfiguration.Spec.Listeners {
+		portProtocols[generateListenerPortProtocolKey(l)] = l.Name
+	}
+
+	for _, l := range c.gatewayListeners {
+		if name, exists := portProtocols[generateListenerPortProtocolKey(l)]; exists {
+			result[l.Name] = name
+		}
+	}
+
+	return result
+}
+
+// GetConflictedGatewayListeners returns the listeners generated from the Gateway API resources that are not used,
+// because they conflict with a listener of the GlobalConfiguration. The result maps the name of such a listener to
+// the name of the listener of the GlobalConfiguration.
+func (c *Configuration) GetConflictedGatewayListeners() map[string]string {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.getConflictedGatewayListeners()
+}
+
+func generateListenerPortProtocolKey(listener conf_v1.Listener) string {
+	protocol := listener.Protocol
+	// TLS Passthrough listeners accept TCP connections, so they conflict with TCP listeners with the same port.
+	if protocol == conf_v1.TLSPassthroughListenerProtocol {
+		protocol = "TCP"
+	}
+
+	return fmt.Sprintf("%d/%s", listener.Port, protocol)
+}
+
+// findTLSPassthroughListener finds the custom TLS Passthrough listener referenced by the TransportServer.
+func (c *Configuration) findTLSPassthroughListener(ts *conf_v1.TransportServer) (conf_v1.Listener, bool) {
+	for _, l := range c.getAllListeners() {
+		if ts.Spec.Listener.Name == l.Name && l.Protocol == conf_v1.TLSPassthroughListenerProtocol {
+			return l, true
+		}
+	}
+
+	return conf_v1.Listener{}, false
+}
+
+// getHostKeyForTransportServer returns the key of the host of a TLS Passthrough TransportServer.
+// The hosts of the built-in listener share port 443 with Ingress and VirtualServer resources, while the hosts
+// of a custom listener only need to be unique among the TransportServers of that listener.
+func getHostKeyForTransportServer(ts *conf_v1.TransportServer) string {
+	if ts.Spec.Listener.Name == conf_v1.TLSPassthroughListenerName {
+		return ts.Spec.Host
+	}
+
+	return fmt.Sprintf("%s/%s", ts.Spec.Listener.Name, ts.Spec.Host)
+}
+
 func (c *Configuration) rebuildListeners() ([]ResourceChange, []ConfigurationProblem) {
 	newListeners, newTSConfigs := c.buildListenersAndTSConfigurations()
 
@@ -736,21 +914,19 @@ func (c *Configuration) buildListenersAndTSConfigurations() (newListeners map[st
 	newListeners = make(map[string]*TransportServerConfiguration)
 	newTSConfigs = make(map[string]*TransportServerConfiguration)
 
-	for key, ts := range c.transportServers {
-		if ts.Spec.Listener.Protocol == conf_v1alpha1.TLSPassthroughListenerProtocol {
+	listeners := c.getAllListeners()
+
+	for key, ts := range c.getAllTransportServers() {
+		if ts.Spec.Listener.Protocol == conf_v1.TLSPassthroughListenerProtocol {
 			continue
 		}
 
 		tsc := NewTransportServerConfiguration(ts)
 		newTSConfigs[key] = tsc
 
-		if c.globalConfiguration == nil {
-			continue
-		}
-
 		found := false
-		var listener conf_v1alpha1.Listener
-		for _, l := range c.globalConfiguration.Spec.Listeners {
+		var listener conf_v1.Listener
+		for _, l := range listeners {
 			if ts.Spec.Listener.Name == l.Name && ts.Spec.Listener.Protocol == l.Protocol {
 				listener = l
 				found = true
@@ -1061,7 +1237,19 @@ func (c *Configuration) addProblemsForResourcesWithoutActiveHost(resources map[s
 				problems[r.GetKeyWithKind()] = p
 			}
 		case *TransportServerConfiguration:
-			res := c.hosts[impl.TransportServer.Spec.Host]
+			res, exists := c.hosts[getHostKeyForTransportServer(impl.TransportServer)]
+
+			// only a TransportServer with a custom listener that doesn't exist has no holder of its host
+			if !exists {
+				p := ConfigurationProblem{
+					Object:  impl.TransportServer,
+					IsError: false,
+					Reason:  "Rejected",
+					Message: fmt.Sprintf("Listener %s doesn't exist", impl.TransportServer.Spec.Listener.Name),
+				}
+				problems[r.GetKeyWithKind()] = p
+				continue
+			}
 
 			if res.GetKeyWithKind() != r.GetKeyWithKind() {
 				p := ConfigurationProblem{
@@ -1317,8 +1505,10 @@ func (c *Configuration) buildHostsAndResources() (newHosts map[string]Resource,
 
 	// Step 2 - Build hosts from VirtualServer resources
 
-	for _, key := range getSortedVirtualServerKeys(c.virtualServers) {
-		vs := c.virtualServers[key]
+	virtualServers := c.getAllVirtualServers()
+
+	for _, key := range getSortedVirtualServerKeys(virtualServers) {
+		vs := virtualServers[key]
 
 		vsrs, warnings := c.buildVirtualServerRoutes(vs)
 		resource := NewVirtualServerConfiguration(vs, vsrs, warnings)
@@ -1344,26 +1534,39 @@ func (c *Configuration) buildHostsAndResources() (newHosts map[string]Resource,
 	// Step - 3 - Build hosts from TransportServer resources if TLS Passthrough is enabled
 
 	if c.isTLSPassthroughEnabled {
-		for _, key := range getSortedTransportServerKeys(c.transportServers) {
-			ts := c.transportServers[key]
+		transportServers := c.getAllTransportServers()
+
+		for _, key := range getSortedTransportServerKeys(transportServers) {
+			ts := transportServers[key]
 
-			if ts.Spec.Listener.Name != conf_v1alpha1.TLSPassthroughListenerName && ts.Spec.Listener.Protocol != conf_v1alpha1.TLSPassthroughListenerProtocol {
+			if ts.Spec.Listener.Name != conf_v1.TLSPassthroughListenerName && ts.Spec.Listener.Protocol != conf_v1.TLSPassthroughListenerProtocol {
 				continue
 			}
 
 			resource := NewTransportServerConfiguration(ts)
 			newResources[resource.GetKeyWithKind()] = resource
 
-			holder, exists := newHosts[ts.Spec.Host]
+			if ts.Spec.Listener.Name != conf_v1.TLSPassthroughListenerName {
+				listener, found := c.findTLSPassthroughListener(ts)
+				if !found {
+					continue
+				}
+
+				resource.ListenerPort = listener.Port
+			}
+
+			hostKey := getHostKeyForTransportServer(ts)
+
+			holder, exists := newHosts[hostKey]
 			if !exists {
-				newHosts[ts.Spec.Host] = resource
+				newHosts[hostKey] = resource
 				continue
 			}
 
 			warning := fmt.Sprintf("host %s is taken by another resource", ts.Spec.Host)
 
 			if !holder.Wins(resource) {
-				newHosts[ts.Spec.Host] = resource
+				newHosts[hostKey] = resource
 				holder.AddWarning(warning)
 			} else {
 				resource.AddWarning(warning)
@@ -1457,6 +1660,80 @@ func (c *Configuration) buildVirtualServerRoutes(vs *conf_v1.VirtualServer) ([]*
 	return vsrs, warnings
 }
 
+// ValidateIngress validates the Ingress with the same rules that AddOrUpdateIngress applies.
+func (c *Configuration) ValidateIngress(ing *networking.Ingress) error {
+	return validateIngress(ing, c.isPlus, c.appProtectEnabled, c.appProtectDosEnabled, c.internalRoutesEnabled, c.snippetsEnabled).ToAggregate()
+}
+
+// ValidateVirtualServer validates the VirtualServer with the same rules that AddOrUpdateVirtualServer applies.
+func (c *Configuration) ValidateVirtualServer(vs *conf_v1.VirtualServer) error {
+	return c.virtualServerValidator.ValidateVirtualServer(vs)
+}
+
+// ValidateVirtualServerRoute validates the VirtualServerRoute with the same rules that AddOrUpdateVirtualServerRoute applies.
+func (c *Configuration) ValidateVirtualServerRoute(vsr *conf_v1.VirtualServerRoute) error {
+	return c.virtualServerValidator.ValidateVirtualServerRoute(vsr)
+}
+
+// ValidateTransportServer validates the TransportServer with the same rules that AddOrUpdateTransportServer applies.
+func (c *Configuration) ValidateTransportServer(ts *conf_v1.TransportServer) error {
+	return c.transportServerValidator.ValidateTransportServer(ts)
+}
+
+// GetHostHolder returns the key with the kind of the resource that holds the host.
+func (c *Configuration) GetHostHolder(host string) (string, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	holder, exists := c.hosts[host]
+	if !exists {
+		return "", false
+	}
+
+	return holder.GetKeyWithKind(), true
+}
+
+// GetListenerHolder returns the key with the kind of the TransportServer that holds the listener.
+func (c *Configuration) GetListenerHolder(listener string) (string, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	holder, exists := c.listeners[listener]
+	if !exists {
+		return "", false
+	}
+
+	return holder.GetKeyWithKind(), true
+}
+
+// GetProblems returns the current problems of the resources caused by the host and listener collisions
+// and by the missing or invalid references among the resources.
+func (c *Configuration) GetProblems() []ConfigurationProblem {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	var keys []string
+	problems := make(map[string]ConfigurationProblem)
+
+	for _, m := range []map[string]ConfigurationProblem{c.hostProblems, c.listenerProblems} {
+		for k, p := range m {
+			if _, exists := problems[k]; !exists {
+				keys = append(keys, k)
+			}
+			problems[k] = p
+		}
+	}
+
+	sort.Strings(keys)
+
+	var result []ConfigurationProblem
+	for _, k := range keys {
+		result = append(result, problems[k])
+	}
+
+	return result
+}
+
 // GetTransportServerMetrics returns metrics about TransportServers
 func (c *Configuration) GetTransportServerMetrics() *TransportServerMetrics {
 	var metrics TransportServerMetrics
@@ -1541,7 +1818,7 @@ func getSortedResourceKeys(m map[string]Resource) []string {
 	return keys
 }
 
-func getSortedTransportServerKeys(m map[string]*conf_v1alpha1.TransportServer) []string {
+func getSortedTransportServerKeys(m map[string]*conf_v1.TransportServer) []string {
 	var keys []string
 
 	for k := range m {
@@ -7,7 +7,6 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
-	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
 	"github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/validation"
 	networking "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -29,7 +28,7 @@ func createTestConfiguration() *Configuration {
 		appProtectEnabled,
 		appProtectDosEnabled,
 		internalRoutesEnabled,
-		validation.NewVirtualServerValidator(isTLSPassthroughEnabled, appProtectDosEnabled),
+		validation.NewVirtualServerValidator(isTLSPassthroughEnabled, appProtectDosEnabled, false),
 		validation.NewGlobalConfigurationValidator(map[int]bool{
 			80:  true,
 			443: true,
@@ -1874,7 +1873,7 @@ func TestHostCollisions(t *testing.T) {
 func TestAddTransportServer(t *testing.T) {
 	configuration := createTestConfiguration()
 
-	listeners := []conf_v1alpha1.Listener{
+	listeners := []conf_v1.Listener{
 		{
 			Name:     "tcp-7777",
 			Port:     7777,
@@ -2048,10 +2047,120 @@ func TestAddTransportServerForTLSPassthrough(t *testing.T) {
 	}
 }
 
+func TestAddTransportServerForCustomTLSPassthroughListener(t *testing.T) {
+	configuration := createTestConfiguration()
+
+	ts := createTestTLSPassthroughTransportServer("transportserver", "foo.example.com")
+	ts.Spec.Listener.Name = "tls-passthrough-8443"
+
+	// Add TransportServer without the listener
+
+	var expectedChanges []ResourceChange
+	expectedProblems := []ConfigurationProblem{
+		{
+			Object:  ts,
+			IsError: false,
+			Reason:  "Rejected",
+			Message: "Listener tls-passthrough-8443 doesn't exist",
+		},
+	}
+
+	changes, problems := configuration.AddOrUpdateTransportServer(ts)
+	if diff := cmp.Diff(expectedChanges, changes); diff != "" {
+		t.Errorf("AddOrUpdateTransportServer() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedProblems, problems); diff != "" {
+		t.Errorf("AddOrUpdateTransportServer() returned unexpected result (-want +got):\n%s", diff)
+	}
+
+	// Add GlobalConfiguration with the listener
+
+	listeners := []conf_v1.Listener{
+		{
+			Name:     "tls-passthrough-8443",
+			Port:     8443,
+			Protocol: "TLS_PASSTHROUGH",
+		},
+	}
+	gc := createTestGlobalConfiguration(listeners)
+
+	expectedChanges = []ResourceChange{
+		{
+			Op: AddOrUpdate,
+			Resource: &TransportServerConfiguration{
+				ListenerPort:    8443,
+				TransportServer: ts,
+			},
+		},
+	}
+	expectedProblems = nil
+
+	changes, problems, err := configuration.AddOrUpdateGlobalConfiguration(gc)
+	if diff := cmp.Diff(expectedChanges, changes); diff != "" {
+		t.Errorf("AddOrUpdateGlobalConfiguration() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedProblems, problems); diff != "" {
+		t.Errorf("AddOrUpdateGlobalConfiguration() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if err != nil {
+		t.Errorf("AddOrUpdateGlobalConfiguration() returned unexpected error: %v", err)
+	}
+
+	// Add TransportServer with the same host for the built-in listener
+
+	builtInTS := createTestTLSPassthroughTransportServer("transportserver-built-in", "foo.example.com")
+
+	expectedChanges = []ResourceChange{
+		{
+			Op: AddOrUpdate,
+			Resource: &TransportServerConfiguration{
+				ListenerPort:    0,
+				TransportServer: builtInTS,
+			},
+		},
+	}
+
+	changes, problems = configuration.AddOrUpdateTransportServer(builtInTS)
+	if diff := cmp.Diff(expectedChanges, changes); diff != "" {
+		t.Errorf("AddOrUpdateTransportServer() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedProblems, problems); diff != "" {
+		t.Errorf("AddOrUpdateTransportServer() returned unexpected result (-want +got):\n%s", diff)
+	}
+
+	// Delete GlobalConfiguration
+
+	expectedChanges = []ResourceChange{
+		{
+			Op: Delete,
+			Resource: &TransportServerConfiguration{
+				ListenerPort:    0,
+				TransportServer: ts,
+			},
+		},
+	}
+	expectedProblems = []ConfigurationProblem{
+		{
+			Object:  ts,
+			IsError: false,
+			Reason:  "Rejected",
+			Message: "Listener tls-passthrough-8443 doesn't exist",
+		},
+	}
+
+	changes, problems = configuration.DeleteGlobalConfiguration()
+	if diff := cmp.Diff(expectedChanges, changes); diff != "" {
+		t.Errorf("DeleteGlobalConfiguration() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedProblems, problems); diff != "" {
+		t.Errorf("DeleteGlobalConfiguration() returned unexpected result (-want +got):\n%s", diff)
+	}
+}
+
 func TestListenerFlip(t *testing.T) {
 	configuration := createTestConfiguration()
 
-	listeners := []conf_v1alpha1.Listener{
+	listeners := []conf_v1.Listener{
 		{
 			Name:     "tcp-7777",
 			Port:     7777,
@@ -2241,7 +2350,7 @@ func TestAddTransportServerWithIncorrectClass(t *testing.T) {
 func TestAddTransportServerWithNonExistingListener(t *testing.T) {
 	configuration := createTestConfiguration()
 
-	gc := createTestGlobalConfiguration([]conf_v1alpha1.Listener{})
+	gc := createTestGlobalConfiguration([]conf_v1.Listener{})
 	mustInitGlobalConfiguration(configuration, gc)
 
 	ts := createTestTransportServer("transportserver", "tcp-7777", "TCP")
@@ -2283,7 +2392,7 @@ func TestDeleteNonExistingTransportServer(t *testing.T) {
 func TestAddGlobalConfiguration(t *testing.T) {
 	configuration := createTestConfiguration()
 
-	listeners := []conf_v1alpha1.Listener{
+	listeners := []conf_v1.Listener{
 		{
 			Name:     "tcp-7777",
 			Port:     7777,
@@ -2297,7 +2406,7 @@ func TestAddGlobalConfiguration(t *testing.T) {
 	}
 	gc := createTestGlobalConfiguration(listeners)
 
-	var nilGC *conf_v1alpha1.GlobalConfiguration
+	var nilGC *conf_v1.GlobalConfiguration
 
 	var expectedChanges []ResourceChange
 	var expectedProblems []ConfigurationProblem
@@ -2568,7 +2677,7 @@ func TestAddGlobalConfiguration(t *testing.T) {
 func TestPortCollisions(t *testing.T) {
 	configuration := createTestConfiguration()
 
-	listeners := []conf_v1alpha1.Listener{
+	listeners := []conf_v1.Listener{
 		{
 			Name:     "tcp-7777",
 			Port:     7777,
@@ -2675,7 +2784,197 @@ func TestPortCollisions(t *testing.T) {
 	}
 }
 
-func mustInitGlobalConfiguration(c *Configuration, gc *conf_v1alpha1.GlobalConfiguration) {
+func TestSetGatewayTransportServers(t *testing.T) {
+	configuration := createTestConfiguration()
+
+	listeners := []conf_v1.Listener{
+		{
+			Name:     "gateway_default_gateway_tcp",
+			Port:     5432,
+			Protocol: "TCP",
+		},
+	}
+	tcpTS := createTestTransportServer("gateway_db_default_gateway_tcp", "gateway_default_gateway_tcp", "TCP")
+	tlsTS := createTestTLSPassthroughTransportServer("gateway_app_foo__example__com", "foo.example.com")
+
+	// Set TransportServers
+
+	expectedChanges := []ResourceChange{
+		{
+			Op: AddOrUpdate,
+			Resource: &TransportServerConfiguration{
+				ListenerPort:    5432,
+				TransportServer: tcpTS,
+			},
+		},
+		{
+			Op: AddOrUpdate,
+			Resource: &TransportServerConfiguration{
+				ListenerPort:    0,
+				TransportServer: tlsTS,
+			},
+		},
+	}
+	var expectedProblems []ConfigurationProblem
+
+	changes, problems := configuration.SetGatewayTransportServers(listeners, []*conf_v1.TransportServer{tcpTS, tlsTS})
+	if diff := cmp.Diff(expectedChanges, changes); diff != "" {
+		t.Errorf("SetGatewayTransportServers() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedProblems, problems); diff != "" {
+		t.Errorf("SetGatewayTransportServers() returned unexpected result (-want +got):\n%s", diff)
+	}
+
+	// Add GlobalConfiguration with a listener that uses the same port
+
+	gc := createTestGlobalConfiguration([]conf_v1.Listener{
+		{
+			Name:     "tcp-5432",
+			Port:     5432,
+			Protocol: "TCP",
+		},
+	})
+
+	expectedChanges = []ResourceChange{
+		{
+			Op: Delete,
+			Resource: &TransportServerConfiguration{
+				ListenerPort:    5432,
+				TransportServer: tcpTS,
+			},
+		},
+	}
+	expectedProblems = []ConfigurationProblem{
+		{
+			Object:  tcpTS,
+			IsError: false,
+			Reason:  "Rejected",
+			Message: "Listener gateway_default_gateway_tcp doesn't exist",
+		},
+	}
+
+	changes, problems, err := configuration.AddOrUpdateGlobalConfiguration(gc)
+	if diff := cmp.Diff(expectedChanges, changes); diff != "" {
+		t.Errorf("AddOrUpdateGlobalConfiguration() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedProblems, problems); diff != "" {
+		t.Errorf("AddOrUpdateGlobalConfiguration() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if err != nil {
+		t.Errorf("AddOrUpdateGlobalConfiguration() returned unexpected error: %v", err)
+	}
+
+	expectedConflicts := map[string]string{
+		"gateway_default_gateway_tcp": "tcp-5432",
+	}
+	if diff := cmp.Diff(expectedConflicts, configuration.GetConflictedGatewayListeners()); diff != "" {
+		t.Errorf("GetConflictedGatewayListeners() returned unexpected result (-want +got):\n%s", diff)
+	}
+
+	// Remove TransportServers
+
+	expectedChanges = []ResourceChange{
+		{
+			Op: Delete,
+			Resource: &TransportServerConfiguration{
+				ListenerPort:    0,
+				TransportServer: tlsTS,
+			},
+		},
+	}
+	expectedProblems = nil
+
+	changes, problems = configuration.SetGatewayTransportServers(nil, nil)
+	if diff := cmp.Diff(expectedChanges, changes); diff != "" {
+		t.Errorf("SetGatewayTransportServers() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedProblems, problems); diff != "" {
+		t.Errorf("SetGatewayTransportServers() returned unexpected result (-want +got):\n%s", diff)
+	}
+}
+
+func TestSetGatewayVirtualServers(t *testing.T) {
+	configuration := createTestConfiguration()
+
+	gatewayVS := createTestVirtualServer("gateway_foo__example__com", "foo.example.com")
+	gatewayVS.Spec.IngressClass = ""
+
+	// Set VirtualServers
+
+	expectedChanges := []ResourceChange{
+		{
+			Op: AddOrUpdate,
+			Resource: &VirtualServerConfiguration{
+				VirtualServer: gatewayVS,
+			},
+		},
+	}
+	var expectedProblems []ConfigurationProblem
+
+	changes, problems := configuration.SetGatewayVirtualServers([]*conf_v1.VirtualServer{gatewayVS})
+	if diff := cmp.Diff(expectedChanges, changes); diff != "" {
+		t.Errorf("SetGatewayVirtualServers() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedProblems, problems); diff != "" {
+		t.Errorf("SetGatewayVirtualServers() returned unexpected result (-want +got):\n%s", diff)
+	}
+
+	// Add VirtualServer with the same host
+
+	vs := createTestVirtualServer("virtualserver", "foo.example.com")
+	vs.CreationTimestamp.Time = gatewayVS.CreationTimestamp.Add(time.Second)
+
+	expectedChanges = nil
+	expectedProblems = []ConfigurationProblem{
+		{
+			Object:  vs,
+			IsError: false,
+			Reason:  "Rejected",
+			Message: "Host is taken by another resource",
+		},
+	}
+
+	changes, problems = configuration.AddOrUpdateVirtualServer(vs)
+	if diff := cmp.Diff(expectedChanges, changes); diff != "" {
+		t.Errorf("AddOrUpdateVirtualServer() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedProblems, problems); diff != "" {
+		t.Errorf("AddOrUpdateVirtualServer() returned unexpected result (-want +got):\n%s", diff)
+	}
+
+	holder, _ := configuration.GetHostHolder("foo.example.com")
+	if expected := "VirtualServer/default/gateway_foo__example__com"; holder != expected {
+		t.Errorf("GetHostHolder() returned %q but expected %q", holder, expected)
+	}
+
+	// Remove VirtualServers
+
+	expectedChanges = []ResourceChange{
+		{
+			Op: Delete,
+			Resource: &VirtualServerConfiguration{
+				VirtualServer: gatewayVS,
+			},
+		},
+		{
+			Op: AddOrUpdate,
+			Resource: &VirtualServerConfiguration{
+				VirtualServer: vs,
+			},
+		},
+	}
+	expectedProblems = nil
+
+	changes, problems = configuration.SetGatewayVirtualServers(nil)
+	if diff := cmp.Diff(expectedChanges, changes); diff != "" {
+		t.Errorf("SetGatewayVirtualServers() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedProblems, problems); diff != "" {
+		t.Errorf("SetGatewayVirtualServers() returned unexpected result (-want +got):\n%s", diff)
+	}
+}
+
+func mustInitGlobalConfiguration(c *Configuration, gc *conf_v1.GlobalConfiguration) {
 	changes, problems, err := c.AddOrUpdateGlobalConfiguration(gc)
 
 	// when adding a valid GlobalConfiguration to a new Configuration, no changes, problems and errors are expected
@@ -2782,47 +3081,47 @@ func createTestVirtualServerRoute(name string, host string, path string) *conf_v
 	}
 }
 
-func createTestTransportServer(name string, listenerName string, listenerProtocol string) *conf_v1alpha1.TransportServer {
-	return &conf_v1alpha1.TransportServer{
+func createTestTransportServer(name string, listenerName string, listenerProtocol string) *conf_v1.TransportServer {
+	return &conf_v1.TransportServer{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:              name,
 			Namespace:         "default",
 			CreationTimestamp: metav1.Now(),
 			Generation:        1,
 		},
-		Spec: conf_v1alpha1.TransportServerSpec{
-			Listener: conf_v1alpha1.TransportServerListener{
+		Spec: conf_v1.TransportServerSpec{
+			Listener: conf_v1.TransportServerListener{
 				Name:     listenerName,
 				Protocol: listenerProtocol,
 			},
-			Upstreams: []conf_v1alpha1.Upstream{
+			Upstreams: []conf_v1.TransportServerUpstream{
 				{
 					Name:    "myapp",
 					Service: "myapp-svc",
 					Port:    1234,
 				},
 			},
-			Action: &conf_v1alpha1.Action{
+			Action: &conf_v1.TransportServerAction{
 				Pass: "myapp",
 			},
 		},
 	}
 }
 
-func createTestTLSPassthroughTransportServer(name string, host string) *conf_v1alpha1.TransportServer {
-	ts := createTestTransportServer(name, conf_v1alpha1.TLSPassthroughListenerName, conf_v1alpha1.TLSPassthroughListenerProtocol)
+func createTestTLSPassthroughTransportServer(name string, host string) *conf_v1.TransportServer {
+	ts := createTestTransportServer(name, conf_v1.TLSPassthroughListenerName, conf_v1.TLSPassthroughListenerProtocol)
 	ts.Spec.Host = host
 
 	return ts
 }
 
-func createTestGlobalConfiguration(listeners []conf_v1alpha1.Listener) *conf_v1alpha1.GlobalConfiguration {
-	return &conf_v1alpha1.GlobalConfiguration{
+func createTestGlobalConfiguration(listeners []conf_v1.Listener) *conf_v1.GlobalConfiguration {
+	return &conf_v1.GlobalConfiguration{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "globalconfiguration",
 			Namespace: "nginx-ingress",
 		},
-		Spec: conf_v1alpha1.GlobalConfigurationSpec{
+		Spec: conf_v1.GlobalConfigurationSpec{
 			Listeners: listeners,
 		},
 	}
@@ -3064,7 +3363,7 @@ func (rc *testReferenceChecker) IsReferencedByVirtualServerRoute(namespace strin
 	return rc.onlyVirtualServerRoutes && namespace == rc.resourceNamespace && name == rc.resourceName
 }
 
-func (rc *testReferenceChecker) IsReferencedByTransportServer(namespace string, name string, _ *conf_v1alpha1.TransportServer) bool {
+func (rc *testReferenceChecker) IsReferencedByTransportServer(namespace string, name string, _ *conf_v1.TransportServer) bool {
 	return rc.onlyTransportServers && namespace == rc.resourceNamespace && name == rc.resourceName
 }
 
@@ -3084,7 +3383,7 @@ func TestFindResourcesForResourceReference(t *testing.T) {
 		})
 	vsr := createTestVirtualServerRoute("virtualserverroute", "asd.example.com", "/")
 	tsPassthrough := createTestTLSPassthroughTransportServer("transportserver-passthrough", "ts.example.com")
-	listeners := []conf_v1alpha1.Listener{
+	listeners := []conf_v1.Listener{
 		{
 			Name:     "tcp-7777",
 			Port:     7777,
@@ -3197,7 +3496,7 @@ func TestGetResources(t *testing.T) {
 	passTS := createTestTLSPassthroughTransportServer("transportserver", "abc.example.com")
 	ts := createTestTransportServer("transportserver-tcp", "tcp-7777", "TCP")
 
-	listeners := []conf_v1alpha1.Listener{
+	listeners := []conf_v1.Listener{
 		{
 			Name:     "tcp-7777",
 			Port:     7777,
@@ -3264,7 +3563,7 @@ func TestGetTransportServerMetrics(t *testing.T) {
 	tsUDP := createTestTransportServer("transportserver-udp", "udp-7777", "UDP")
 
 	tests := []struct {
-		tses     []*conf_v1alpha1.TransportServer
+		tses     []*conf_v1.TransportServer
 		expected *TransportServerMetrics
 		msg      string
 	}{
@@ -3278,7 +3577,7 @@ func TestGetTransportServerMetrics(t *testing.T) {
 			msg: "no TransportServers",
 		},
 		{
-			tses: []*conf_v1alpha1.TransportServer{
+			tses: []*conf_v1.TransportServer{
 				tsPass,
 			},
 			expected: &TransportServerMetrics{
@@ -3289,7 +3588,7 @@ func TestGetTransportServerMetrics(t *testing.T) {
 			msg: "one TLSPassthrough TransportServer",
 		},
 		{
-			tses: []*conf_v1alpha1.TransportServer{
+			tses: []*conf_v1.TransportServer{
 				tsTCP,
 			},
 			expected: &TransportServerMetrics{
@@ -3300,7 +3599,7 @@ func TestGetTransportServerMetrics(t *testing.T) {
 			msg: "one TCP TransportServer",
 		},
 		{
-			tses: []*conf_v1alpha1.TransportServer{
+			tses: []*conf_v1.TransportServer{
 				tsUDP,
 			},
 			expected: &TransportServerMetrics{
@@ -3311,7 +3610,7 @@ func TestGetTransportServerMetrics(t *testing.T) {
 			msg: "one UDP TransportServer",
 		},
 		{
-			tses: []*conf_v1alpha1.TransportServer{
+			tses: []*conf_v1.TransportServer{
 				tsPass, tsTCP, tsUDP,
 			},
 			expected: &TransportServerMetrics{
@@ -3323,7 +3622,7 @@ func TestGetTransportServerMetrics(t *testing.T) {
 		},
 	}
 
-	listeners := []conf_v1alpha1.Listener{
+	listeners := []conf_v1.Listener{
 		{
 			Name:     "tcp-7777",
 			Port:     7777,
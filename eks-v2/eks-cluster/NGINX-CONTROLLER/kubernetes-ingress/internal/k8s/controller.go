@@ -18,7 +18,12 @@ package k8s
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
+	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -28,12 +33,12 @@ import (
 	"github.com/nginxinc/kubernetes-ingress/internal/k8s/appprotect"
 	"github.com/nginxinc/kubernetes-ingress/internal/k8s/appprotectcommon"
 	"github.com/nginxinc/kubernetes-ingress/internal/k8s/appprotectdos"
-	"k8s.io/client-go/informers"
 
 	"github.com/golang/glog"
 	"github.com/nginxinc/kubernetes-ingress/internal/k8s/secrets"
 	"github.com/spiffe/go-spiffe/workload"
 
+	discovery_v1 "k8s.io/api/discovery/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -52,12 +57,11 @@ import (
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
-	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
 	"github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/validation"
 	k8s_nginx "github.com/nginxinc/kubernetes-ingress/pkg/client/clientset/versioned"
-	k8s_nginx_informers "github.com/nginxinc/kubernetes-ingress/pkg/client/informers/externalversions"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/dynamic/dynamicinformer"
@@ -96,15 +100,18 @@ type LoadBalancerController struct {
 	confClient                    k8s_nginx.Interface
 	dynClient                     dynamic.Interface
 	cacheSyncs                    []cache.InformerSynced
-	sharedInformerFactory         informers.SharedInformerFactory
-	confSharedInformerFactorry    k8s_nginx_informers.SharedInformerFactory
+	namespacedInformers           map[string]*namespacedInformer
+	namespacedIndexers            []*namespacedIndexer
+	namespaceController           cache.Controller
+	namespaceLister               cache.Store
 	configMapController           cache.Controller
-	dynInformerFactory            dynamicinformer.DynamicSharedInformerFactory
 	globalConfigurationController cache.Controller
 	ingressLinkInformer           cache.SharedIndexInformer
+	gatewayClassInformer          cache.SharedIndexInformer
 	ingressLister                 storeToIngressLister
 	svcLister                     cache.Store
 	endpointLister                storeToEndpointLister
+	endpointSliceLister           storeToEndpointSliceLister
 	configMapLister               storeToConfigMapLister
 	podLister                     indexerToPodLister
 	secretLister                  cache.Store
@@ -120,13 +127,24 @@ type LoadBalancerController struct {
 	transportServerLister         cache.Store
 	policyLister                  cache.Store
 	ingressLinkLister             cache.Store
+	gatewayClassLister            cache.Store
+	gatewayLister                 cache.Store
+	httpRouteLister               cache.Store
+	tcpRouteLister                cache.Store
+	udpRouteLister                cache.Store
+	tlsRouteLister                cache.Store
 	syncQueue                     *taskQueue
 	ctx                           context.Context
 	cancel                        context.CancelFunc
 	configurator                  *configs.Configurator
+	watchNamespaceLabel           bool
+	resourceSelector              labels.Selector
 	watchNginxConfigMaps          bool
 	watchGlobalConfiguration      bool
 	watchIngressLink              bool
+	watchGatewayAPI               bool
+	watchGatewayAPIL4Routes       bool
+	areEndpointSlicesEnabled      bool
 	isNginxPlus                   bool
 	appProtectEnabled             bool
 	appProtectDosEnabled          bool
@@ -139,7 +157,6 @@ type LoadBalancerController struct {
 	isLeaderElectionEnabled       bool
 	leaderElectionLockName        string
 	resync                        time.Duration
-	namespace                     string
 	controllerNamespace           string
 	wildcardTLSSecret             string
 	areCustomResourcesEnabled     bool
@@ -147,6 +164,7 @@ type LoadBalancerController struct {
 	metricsCollector              collectors.ControllerCollector
 	globalConfigurationValidator  *validation.GlobalConfigurationValidator
 	transportServerValidator      *validation.TransportServerValidator
+	virtualServerValidator        *validation.VirtualServerValidator
 	spiffeController              *SpiffeController
 	internalRoutesEnabled         bool
 	syncLock                      sync.Mutex
@@ -158,6 +176,17 @@ type LoadBalancerController struct {
 	appProtectConfiguration       appprotect.Configuration
 	dosConfiguration              *appprotectdos.Configuration
 	configMap                     *api_v1.ConfigMap
+	gatewayTranslation            *gatewayTranslation
+	l4ListenerValidator           *l4ListenerValidator
+	pendingStatusUpdates          map[string]pendingStatusUpdate
+	pendingGatewayAPIStatuses     bool
+	canaryManager                 *canaryManager
+}
+
+// pendingStatusUpdate is the status update of a resource which configuration waits for the pending reload of NGINX.
+type pendingStatusUpdate struct {
+	resource Resource
+	update   func(reloadErr error)
 }
 
 var keyFunc = cache.DeletionHandlingMetaNamespaceKeyFunc
@@ -168,7 +197,9 @@ type NewLoadBalancerControllerInput struct {
 	ConfClient                   k8s_nginx.Interface
 	DynClient                    dynamic.Interface
 	ResyncPeriod                 time.Duration
-	Namespace                    string
+	Namespaces                   []string
+	NamespaceSelector            labels.Selector
+	ResourceSelector             labels.Selector
 	NginxConfigurator            *configs.Configurator
 	DefaultServerSecret          string
 	AppProtectEnabled            bool
@@ -194,8 +225,15 @@ type NewLoadBalancerControllerInput struct {
 	InternalRoutesEnabled        bool
 	IsPrometheusEnabled          bool
 	IsLatencyMetricsEnabled      bool
+	LatencyCollector             collectors.LatencyCollector
 	IsTLSPassthroughEnabled      bool
 	SnippetsEnabled              bool
+	GatewayAPIEnabled            bool
+	GatewayAPIL4RoutesEnabled    bool
+	EndpointSlicesEnabled        bool
+	ReloadInterval               time.Duration
+	ReloadMaxDelay               time.Duration
+	ManagerMetricsCollector      collectors.ManagerCollector
 }
 
 // NewLoadBalancerController creates a controller
@@ -214,7 +252,6 @@ func NewLoadBalancerController(input NewLoadBalancerControllerInput) *LoadBalanc
 		isLeaderElectionEnabled:      input.IsLeaderElectionEnabled,
 		leaderElectionLockName:       input.LeaderElectionLockName,
 		resync:                       input.ResyncPeriod,
-		namespace:                    input.Namespace,
 		controllerNamespace:          input.ControllerNamespace,
 		wildcardTLSSecret:            input.WildcardTLSSecret,
 		areCustomResourcesEnabled:    input.AreCustomResourcesEnabled,
@@ -222,9 +259,13 @@ func NewLoadBalancerController(input NewLoadBalancerControllerInput) *LoadBalanc
 		metricsCollector:             input.MetricsCollector,
 		globalConfigurationValidator: input.GlobalConfigurationValidator,
 		transportServerValidator:     input.TransportServerValidator,
+		virtualServerValidator:       input.VirtualServerValidator,
 		internalRoutesEnabled:        input.InternalRoutesEnabled,
 		isPrometheusEnabled:          input.IsPrometheusEnabled,
 		isLatencyMetricsEnabled:      input.IsLatencyMetricsEnabled,
+		pendingStatusUpdates:         make(map[string]pendingStatusUpdate),
+		namespacedInformers:          make(map[string]*namespacedInformer),
+		resourceSelector:             input.ResourceSelector,
 	}
 
 	eventBroadcaster := record.NewBroadcaster()
@@ -236,6 +277,15 @@ func NewLoadBalancerController(input NewLoadBalancerControllerInput) *LoadBalanc
 		api_v1.EventSource{Component: "nginx-ingress-controller"})
 
 	lbc.syncQueue = newTaskQueue(lbc.sync)
+	if input.ReloadInterval > 0 {
+		lbc.configurator.EnableReloadBatching(input.ReloadInterval, input.ReloadMaxDelay, input.ManagerMetricsCollector, func() {
+			lbc.syncQueue.EnqueueTask(task{Kind: pendingReload, Key: "pending-reload"})
+		})
+	}
+	if input.IsLatencyMetricsEnabled && input.LatencyCollector != nil {
+		// the pod that reports the status of the custom resources also decides the progress of the canaries
+		lbc.canaryManager = newCanaryManager(input.LatencyCollector, lbc.reportCustomResourceStatusEnabled, lbc.onCanaryChange)
+	}
 	if input.SpireAgentAddress != "" {
 		var err error
 		lbc.spiffeController, err = NewSpiffeController(lbc.syncSVIDRotation, input.SpireAgentAddress)
@@ -246,44 +296,65 @@ func NewLoadBalancerController(input NewLoadBalancerControllerInput) *LoadBalanc
 
 	glog.V(3).Infof("Nginx Ingress Controller has class: %v", input.IngressClass)
 
-	lbc.sharedInformerFactory = informers.NewSharedInformerFactoryWithOptions(lbc.client, input.ResyncPeriod, informers.WithNamespace(lbc.namespace))
-
-	// create handlers for resources we care about
-	lbc.addSecretHandler(createSecretHandlers(lbc))
-	lbc.addIngressHandler(createIngressHandlers(lbc))
-	lbc.addServiceHandler(createServiceHandlers(lbc))
-	lbc.addEndpointHandler(createEndpointHandlers(lbc))
-	lbc.addPodHandler()
-
-	if lbc.areCustomResourcesEnabled {
-		lbc.confSharedInformerFactorry = k8s_nginx_informers.NewSharedInformerFactoryWithOptions(lbc.confClient, input.ResyncPeriod, k8s_nginx_informers.WithNamespace(lbc.namespace))
-
-		lbc.addVirtualServerHandler(createVirtualServerHandlers(lbc))
-		lbc.addVirtualServerRouteHandler(createVirtualServerRouteHandlers(lbc))
-		lbc.addTransportServerHandler(createTransportServerHandlers(lbc))
-		lbc.addPolicyHandler(createPolicyHandlers(lbc))
+	lbc.areEndpointSlicesEnabled = input.EndpointSlicesEnabled
+	lbc.watchGatewayAPI = input.GatewayAPIEnabled
+	lbc.watchGatewayAPIL4Routes = input.GatewayAPIEnabled && input.GatewayAPIL4RoutesEnabled
+
+	// the listers of the namespaced resources combine the stores of the informers of the watched namespaces
+	lbc.secretLister = lbc.newNamespacedIndexer()
+	lbc.ingressLister.Store = lbc.newNamespacedIndexer()
+	lbc.svcLister = lbc.newNamespacedIndexer()
+	lbc.endpointLister.Store = lbc.newNamespacedIndexer()
+	lbc.endpointSliceLister.Store = lbc.newNamespacedIndexer()
+	lbc.podLister.Indexer = lbc.newNamespacedIndexer()
+	lbc.virtualServerLister = lbc.newNamespacedIndexer()
+	lbc.virtualServerRouteLister = lbc.newNamespacedIndexer()
+	lbc.transportServerLister = lbc.newNamespacedIndexer()
+	lbc.policyLister = lbc.newNamespacedIndexer()
+	lbc.appProtectPolicyLister = lbc.newNamespacedIndexer()
+	lbc.appProtectLogConfLister = lbc.newNamespacedIndexer()
+	lbc.appProtectUserSigLister = lbc.newNamespacedIndexer()
+	lbc.appProtectDosPolicyLister = lbc.newNamespacedIndexer()
+	lbc.appProtectDosLogConfLister = lbc.newNamespacedIndexer()
+	lbc.appProtectDosProtectedLister = lbc.newNamespacedIndexer()
+	lbc.gatewayLister = lbc.newNamespacedIndexer()
+	lbc.httpRouteLister = lbc.newNamespacedIndexer()
+	lbc.tcpRouteLister = lbc.newNamespacedIndexer()
+	lbc.udpRouteLister = lbc.newNamespacedIndexer()
+	lbc.tlsRouteLister = lbc.newNamespacedIndexer()
+
+	namespaces := input.Namespaces
+	if input.NamespaceSelector != nil {
+		lbc.watchNamespaceLabel = true
+		lbc.addNamespaceHandler(createNamespaceHandlers(lbc), input.NamespaceSelector)
+
+		// the namespaces that match the selector at the start are watched before the initial sync.
+		// After that, the controller reacts to the changes of the namespaces.
+		nsList, err := lbc.client.CoreV1().Namespaces().List(context.TODO(), meta_v1.ListOptions{LabelSelector: input.NamespaceSelector.String()})
+		if err != nil {
+			glog.Fatalf("Failed to list the namespaces that match the selector %v: %v", input.NamespaceSelector, err)
+		}
 
-		if input.GlobalConfiguration != "" {
-			lbc.watchGlobalConfiguration = true
-			ns, name, _ := ParseNamespaceName(input.GlobalConfiguration)
-			lbc.addGlobalConfigurationHandler(createGlobalConfigurationHandlers(lbc), ns, name)
+		namespaces = nil
+		for _, ns := range nsList.Items {
+			namespaces = append(namespaces, ns.Name)
 		}
+	} else if len(namespaces) == 0 {
+		namespaces = []string{api_v1.NamespaceAll}
 	}
 
-	if lbc.appProtectEnabled || lbc.appProtectDosEnabled {
-		lbc.dynInformerFactory = dynamicinformer.NewFilteredDynamicSharedInformerFactory(lbc.dynClient, 0, lbc.namespace, nil)
-
-		if lbc.appProtectEnabled {
-			lbc.addAppProtectPolicyHandler(createAppProtectPolicyHandlers(lbc))
-			lbc.addAppProtectLogConfHandler(createAppProtectLogConfHandlers(lbc))
-			lbc.addAppProtectUserSigHandler(createAppProtectUserSigHandlers(lbc))
-		}
+	// create handlers for resources we care about
+	for _, ns := range namespaces {
+		nsi := lbc.newNamespacedInformer(ns)
+		// the controller waits for the caches of these namespaces before it starts the sync queue
+		nsi.synced = true
+		lbc.cacheSyncs = append(lbc.cacheSyncs, nsi.cacheSyncs...)
+	}
 
-		if lbc.appProtectDosEnabled {
-			lbc.addAppProtectDosPolicyHandler(createAppProtectDosPolicyHandlers(lbc))
-			lbc.addAppProtectDosLogConfHandler(createAppProtectDosLogConfHandlers(lbc))
-			lbc.addAppProtectDosProtectedResourceHandler(createAppProtectDosProtectedResourceHandlers(lbc))
-		}
+	if lbc.areCustomResourcesEnabled && input.GlobalConfiguration != "" {
+		lbc.watchGlobalConfiguration = true
+		ns, name, _ := ParseNamespaceName(input.GlobalConfiguration)
+		lbc.addGlobalConfigurationHandler(createGlobalConfigurationHandlers(lbc), ns, name)
 	}
 
 	if input.ConfigMaps != "" {
@@ -301,6 +372,16 @@ func NewLoadBalancerController(input NewLoadBalancerControllerInput) *LoadBalanc
 		lbc.addIngressLinkHandler(createIngressLinkHandlers(lbc), input.IngressLink)
 	}
 
+	if lbc.watchGatewayAPI {
+		if lbc.watchGatewayAPIL4Routes {
+			lbc.l4ListenerValidator = &l4ListenerValidator{
+				globalConfigurationValidator: input.GlobalConfigurationValidator,
+				isTLSPassthroughEnabled:      input.IsTLSPassthroughEnabled,
+			}
+		}
+		lbc.addGatewayClassHandler(createGatewayAPIHandlers(lbc))
+	}
+
 	if input.IsLeaderElectionEnabled {
 		lbc.addLeaderHandler(createLeaderHandler(lbc))
 	}
@@ -314,8 +395,15 @@ func NewLoadBalancerController(input NewLoadBalancerControllerInput) *LoadBalanc
 		virtualServerRouteLister: lbc.virtualServerRouteLister,
 		transportServerLister:    lbc.transportServerLister,
 		policyLister:             lbc.policyLister,
+		gatewayClassLister:       lbc.gatewayClassLister,
+		gatewayLister:            lbc.gatewayLister,
+		httpRouteLister:          lbc.httpRouteLister,
+		tcpRouteLister:           lbc.tcpRouteLister,
+		udpRouteLister:           lbc.udpRouteLister,
+		tlsRouteLister:           lbc.tlsRouteLister,
 		keyFunc:                  keyFunc,
 		confClient:               input.ConfClient,
+		dynClient:                input.DynClient,
 		hasCorrectIngressClass:   lbc.HasCorrectIngressClass,
 	}
 
@@ -354,93 +442,58 @@ func (lbc *LoadBalancerController) AddSyncQueue(item interface{}) {
 }
 
 // addAppProtectPolicyHandler creates dynamic informers for custom appprotect policy resource
-func (lbc *LoadBalancerController) addAppProtectPolicyHandler(handlers cache.ResourceEventHandlerFuncs) {
-	informer := lbc.dynInformerFactory.ForResource(appprotect.PolicyGVR).Informer()
-	informer.AddEventHandler(handlers)
-	lbc.appProtectPolicyLister = informer.GetStore()
-
-	lbc.cacheSyncs = append(lbc.cacheSyncs, informer.HasSynced)
+func (lbc *LoadBalancerController) addAppProtectPolicyHandler(nsi *namespacedInformer, handlers cache.ResourceEventHandlerFuncs) {
+	nsi.addInformer(lbc.appProtectPolicyLister, nsi.dynInformerFactory.ForResource(appprotect.PolicyGVR).Informer(), handlers)
 }
 
 // addAppProtectLogConfHandler creates dynamic informer for custom appprotect logging config resource
-func (lbc *LoadBalancerController) addAppProtectLogConfHandler(handlers cache.ResourceEventHandlerFuncs) {
-	informer := lbc.dynInformerFactory.ForResource(appprotect.LogConfGVR).Informer()
-	informer.AddEventHandler(handlers)
-	lbc.appProtectLogConfLister = informer.GetStore()
-
-	lbc.cacheSyncs = append(lbc.cacheSyncs, informer.HasSynced)
+func (lbc *LoadBalancerController) addAppProtectLogConfHandler(nsi *namespacedInformer, handlers cache.ResourceEventHandlerFuncs) {
+	nsi.addInformer(lbc.appProtectLogConfLister, nsi.dynInformerFactory.ForResource(appprotect.LogConfGVR).Informer(), handlers)
 }
 
 // addAppProtectUserSigHandler creates dynamic informer for custom appprotect user defined signature resource
-func (lbc *LoadBalancerController) addAppProtectUserSigHandler(handlers cache.ResourceEventHandlerFuncs) {
-	informer := lbc.dynInformerFactory.ForResource(appprotect.UserSigGVR).Informer()
-	informer.AddEventHandler(handlers)
-	lbc.appProtectUserSigLister = informer.GetStore()
-
-	lbc.cacheSyncs = append(lbc.cacheSyncs, informer.HasSynced)
+func (lbc *LoadBalancerController) addAppProtectUserSigHandler(nsi *namespacedInformer, handlers cache.ResourceEventHandlerFuncs) {
+	nsi.addInformer(lbc.appProtectUserSigLister, nsi.dynInformerFactory.ForResource(appprotect.UserSigGVR).Informer(), handlers)
 }
 
 // addAppProtectDosPolicyHandler creates dynamic informers for custom appprotectdos policy resource
-func (lbc *LoadBalancerController) addAppProtectDosPolicyHandler(handlers cache.ResourceEventHandlerFuncs) {
-	informer := lbc.dynInformerFactory.ForResource(appprotectdos.DosPolicyGVR).Informer()
-	informer.AddEventHandler(handlers)
-	lbc.appProtectDosPolicyLister = informer.GetStore()
-
-	lbc.cacheSyncs = append(lbc.cacheSyncs, informer.HasSynced)
+func (lbc *LoadBalancerController) addAppProtectDosPolicyHandler(nsi *namespacedInformer, handlers cache.ResourceEventHandlerFuncs) {
+	nsi.addInformer(lbc.appProtectDosPolicyLister, nsi.dynInformerFactory.ForResource(appprotectdos.DosPolicyGVR).Informer(), handlers)
 }
 
 // addAppProtectDosLogConfHandler creates dynamic informer for custom appprotectdos logging config resource
-func (lbc *LoadBalancerController) addAppProtectDosLogConfHandler(handlers cache.ResourceEventHandlerFuncs) {
-	informer := lbc.dynInformerFactory.ForResource(appprotectdos.DosLogConfGVR).Informer()
-	informer.AddEventHandler(handlers)
-	lbc.appProtectDosLogConfLister = informer.GetStore()
-
-	lbc.cacheSyncs = append(lbc.cacheSyncs, informer.HasSynced)
+func (lbc *LoadBalancerController) addAppProtectDosLogConfHandler(nsi *namespacedInformer, handlers cache.ResourceEventHandlerFuncs) {
+	nsi.addInformer(lbc.appProtectDosLogConfLister, nsi.dynInformerFactory.ForResource(appprotectdos.DosLogConfGVR).Informer(), handlers)
 }
 
 // addAppProtectDosLogConfHandler creates dynamic informer for custom appprotectdos logging config resource
-func (lbc *LoadBalancerController) addAppProtectDosProtectedResourceHandler(handlers cache.ResourceEventHandlerFuncs) {
-	informer := lbc.confSharedInformerFactorry.Appprotectdos().V1beta1().DosProtectedResources().Informer()
-	informer.AddEventHandler(handlers)
-	lbc.appProtectDosProtectedLister = informer.GetStore()
-
-	lbc.cacheSyncs = append(lbc.cacheSyncs, informer.HasSynced)
+func (lbc *LoadBalancerController) addAppProtectDosProtectedResourceHandler(nsi *namespacedInformer, handlers cache.ResourceEventHandlerFuncs) {
+	nsi.addInformer(lbc.appProtectDosProtectedLister, nsi.confSharedInformerFactory.Appprotectdos().V1beta1().DosProtectedResources().Informer(), handlers)
 }
 
 // addSecretHandler adds the handler for secrets to the controller
-func (lbc *LoadBalancerController) addSecretHandler(handlers cache.ResourceEventHandlerFuncs) {
-	informer := lbc.sharedInformerFactory.Core().V1().Secrets().Informer()
-	informer.AddEventHandler(handlers)
-	lbc.secretLister = informer.GetStore()
-
-	lbc.cacheSyncs = append(lbc.cacheSyncs, informer.HasSynced)
+func (lbc *LoadBalancerController) addSecretHandler(nsi *namespacedInformer, handlers cache.ResourceEventHandlerFuncs) {
+	nsi.addInformer(lbc.secretLister, nsi.sharedInformerFactory.Core().V1().Secrets().Informer(), handlers)
 }
 
 // addServiceHandler adds the handler for services to the controller
-func (lbc *LoadBalancerController) addServiceHandler(handlers cache.ResourceEventHandlerFuncs) {
-	informer := lbc.sharedInformerFactory.Core().V1().Services().Informer()
-	informer.AddEventHandler(handlers)
-	lbc.svcLister = informer.GetStore()
-
-	lbc.cacheSyncs = append(lbc.cacheSyncs, informer.HasSynced)
+func (lbc *LoadBalancerController) addServiceHandler(nsi *namespacedInformer, handlers cache.ResourceEventHandlerFuncs) {
+	nsi.addInformer(lbc.svcLister, nsi.sharedInformerFactory.Core().V1().Services().Informer(), handlers)
 }
 
 // addIngressHandler adds the handler for ingresses to the controller
-func (lbc *LoadBalancerController) addIngressHandler(handlers cache.ResourceEventHandlerFuncs) {
-	informer := lbc.sharedInformerFactory.Networking().V1().Ingresses().Informer()
-	informer.AddEventHandler(handlers)
-	lbc.ingressLister.Store = informer.GetStore()
-
-	lbc.cacheSyncs = append(lbc.cacheSyncs, informer.HasSynced)
+func (lbc *LoadBalancerController) addIngressHandler(nsi *namespacedInformer, handlers cache.ResourceEventHandlerFuncs) {
+	nsi.addInformer(lbc.ingressLister.Store, nsi.selectedSharedInformerFactory.Networking().V1().Ingresses().Informer(), handlers)
 }
 
 // addEndpointHandler adds the handler for endpoints to the controller
-func (lbc *LoadBalancerController) addEndpointHandler(handlers cache.ResourceEventHandlerFuncs) {
-	informer := lbc.sharedInformerFactory.Core().V1().Endpoints().Informer()
-	informer.AddEventHandler(handlers)
-	lbc.endpointLister.Store = informer.GetStore()
+func (lbc *LoadBalancerController) addEndpointHandler(nsi *namespacedInformer, handlers cache.ResourceEventHandlerFuncs) {
+	nsi.addInformer(lbc.endpointLister.Store, nsi.sharedInformerFactory.Core().V1().Endpoints().Informer(), handlers)
+}
 
-	lbc.cacheSyncs = append(lbc.cacheSyncs, informer.HasSynced)
+// addEndpointSliceHandler adds the handler for EndpointSlices to the controller
+func (lbc *LoadBalancerController) addEndpointSliceHandler(nsi *namespacedInformer, handlers cache.ResourceEventHandlerFuncs) {
+	nsi.addInformer(lbc.endpointSliceLister.Store, nsi.sharedInformerFactory.Discovery().V1().EndpointSlices().Informer(), handlers)
 }
 
 // addConfigMapHandler adds the handler for config maps to the controller
@@ -458,57 +511,41 @@ func (lbc *LoadBalancerController) addConfigMapHandler(handlers cache.ResourceEv
 	lbc.cacheSyncs = append(lbc.cacheSyncs, lbc.configMapController.HasSynced)
 }
 
-func (lbc *LoadBalancerController) addPodHandler() {
-	informer := lbc.sharedInformerFactory.Core().V1().Pods().Informer()
-	lbc.podLister.Indexer = informer.GetIndexer()
+func (lbc *LoadBalancerController) addPodHandler(nsi *namespacedInformer) {
+	informer := nsi.sharedInformerFactory.Core().V1().Pods().Informer()
+	lbc.podLister.Indexer.(*namespacedIndexer).addNamespace(nsi.namespace, informer.GetIndexer())
 
-	lbc.cacheSyncs = append(lbc.cacheSyncs, informer.HasSynced)
+	nsi.cacheSyncs = append(nsi.cacheSyncs, informer.HasSynced)
 }
 
-func (lbc *LoadBalancerController) addVirtualServerHandler(handlers cache.ResourceEventHandlerFuncs) {
-	informer := lbc.confSharedInformerFactorry.K8s().V1().VirtualServers().Informer()
-	informer.AddEventHandler(handlers)
-	lbc.virtualServerLister = informer.GetStore()
-
-	lbc.cacheSyncs = append(lbc.cacheSyncs, informer.HasSynced)
+func (lbc *LoadBalancerController) addVirtualServerHandler(nsi *namespacedInformer, handlers cache.ResourceEventHandlerFuncs) {
+	nsi.addInformer(lbc.virtualServerLister, nsi.selectedConfSharedInformerFactory.K8s().V1().VirtualServers().Informer(), handlers)
 }
 
-func (lbc *LoadBalancerController) addVirtualServerRouteHandler(handlers cache.ResourceEventHandlerFuncs) {
-	informer := lbc.confSharedInformerFactorry.K8s().V1().VirtualServerRoutes().Informer()
-	informer.AddEventHandler(handlers)
-	lbc.virtualServerRouteLister = informer.GetStore()
-
-	lbc.cacheSyncs = append(lbc.cacheSyncs, informer.HasSynced)
+func (lbc *LoadBalancerController) addVirtualServerRouteHandler(nsi *namespacedInformer, handlers cache.ResourceEventHandlerFuncs) {
+	nsi.addInformer(lbc.virtualServerRouteLister, nsi.selectedConfSharedInformerFactory.K8s().V1().VirtualServerRoutes().Informer(), handlers)
 }
 
-func (lbc *LoadBalancerController) addPolicyHandler(handlers cache.ResourceEventHandlerFuncs) {
-	informer := lbc.confSharedInformerFactorry.K8s().V1().Policies().Informer()
-	informer.AddEventHandler(handlers)
-	lbc.policyLister = informer.GetStore()
-
-	lbc.cacheSyncs = append(lbc.cacheSyncs, informer.HasSynced)
+func (lbc *LoadBalancerController) addPolicyHandler(nsi *namespacedInformer, handlers cache.ResourceEventHandlerFuncs) {
+	nsi.addInformer(lbc.policyLister, nsi.selectedConfSharedInformerFactory.K8s().V1().Policies().Informer(), handlers)
 }
 
 func (lbc *LoadBalancerController) addGlobalConfigurationHandler(handlers cache.ResourceEventHandlerFuncs, namespace string, name string) {
 	lbc.globalConfigurationLister, lbc.globalConfigurationController = cache.NewInformer(
 		cache.NewListWatchFromClient(
-			lbc.confClient.K8sV1alpha1().RESTClient(),
+			lbc.confClient.K8sV1().RESTClient(),
 			"globalconfigurations",
 			namespace,
 			fields.Set{"metadata.name": name}.AsSelector()),
-		&conf_v1alpha1.GlobalConfiguration{},
+		&conf_v1.GlobalConfiguration{},
 		lbc.resync,
 		handlers,
 	)
 	lbc.cacheSyncs = append(lbc.cacheSyncs, lbc.globalConfigurationController.HasSynced)
 }
 
-func (lbc *LoadBalancerController) addTransportServerHandler(handlers cache.ResourceEventHandlerFuncs) {
-	informer := lbc.confSharedInformerFactorry.K8s().V1alpha1().TransportServers().Informer()
-	informer.AddEventHandler(handlers)
-	lbc.transportServerLister = informer.GetStore()
-
-	lbc.cacheSyncs = append(lbc.cacheSyncs, informer.HasSynced)
+func (lbc *LoadBalancerController) addTransportServerHandler(nsi *namespacedInformer, handlers cache.ResourceEventHandlerFuncs) {
+	nsi.addInformer(lbc.transportServerLister, nsi.selectedConfSharedInformerFactory.K8s().V1().TransportServers().Informer(), handlers)
 }
 
 func (lbc *LoadBalancerController) addIngressLinkHandler(handlers cache.ResourceEventHandlerFuncs, name string) {
@@ -527,6 +564,32 @@ func (lbc *LoadBalancerController) addIngressLinkHandler(handlers cache.Resource
 	lbc.cacheSyncs = append(lbc.cacheSyncs, lbc.ingressLinkInformer.HasSynced)
 }
 
+// addGatewayClassHandler creates the dynamic informer for GatewayClasses.
+// GatewayClass is a cluster-scoped resource, so it is watched in all namespaces.
+func (lbc *LoadBalancerController) addGatewayClassHandler(handlers cache.ResourceEventHandlerFuncs) {
+	gatewayClassInformer := dynamicinformer.NewFilteredDynamicInformer(lbc.dynClient, gatewayClassGVR, meta_v1.NamespaceAll, lbc.resync,
+		cache.Indexers{}, nil)
+	gatewayClassInformer.Informer().AddEventHandler(handlers)
+	lbc.gatewayClassInformer = gatewayClassInformer.Informer()
+	lbc.gatewayClassLister = gatewayClassInformer.Informer().GetStore()
+
+	lbc.cacheSyncs = append(lbc.cacheSyncs, lbc.gatewayClassInformer.HasSynced)
+}
+
+// addGatewayAPIHandlers creates dynamic informers for the namespaced Gateway API resources.
+func (lbc *LoadBalancerController) addGatewayAPIHandlers(nsi *namespacedInformer, handlers cache.ResourceEventHandlerFuncs) {
+	nsi.addInformer(lbc.gatewayLister, nsi.gatewayInformerFactory.ForResource(gatewayGVR).Informer(), handlers)
+	nsi.addInformer(lbc.httpRouteLister, nsi.gatewayInformerFactory.ForResource(httpRouteGVR).Informer(), handlers)
+
+	if !lbc.watchGatewayAPIL4Routes {
+		return
+	}
+
+	nsi.addInformer(lbc.tcpRouteLister, nsi.gatewayInformerFactory.ForResource(tcpRouteGVR).Informer(), handlers)
+	nsi.addInformer(lbc.udpRouteLister, nsi.gatewayInformerFactory.ForResource(udpRouteGVR).Informer(), handlers)
+	nsi.addInformer(lbc.tlsRouteLister, nsi.gatewayInformerFactory.ForResource(tlsRouteGVR).Informer(), handlers)
+}
+
 // Run starts the loadbalancer controller
 func (lbc *LoadBalancerController) Run() {
 	lbc.ctx, lbc.cancel = context.WithCancel(context.Background())
@@ -541,21 +604,23 @@ func (lbc *LoadBalancerController) Run() {
 		go lbc.leaderElector.Run(lbc.ctx)
 	}
 
-	go lbc.sharedInformerFactory.Start(lbc.ctx.Done())
+	for _, nsi := range lbc.namespacedInformers {
+		lbc.startNamespacedInformer(nsi)
+	}
+	if lbc.watchNamespaceLabel {
+		go lbc.namespaceController.Run(lbc.ctx.Done())
+	}
 	if lbc.watchNginxConfigMaps {
 		go lbc.configMapController.Run(lbc.ctx.Done())
 	}
-	if lbc.areCustomResourcesEnabled {
-		go lbc.confSharedInformerFactorry.Start(lbc.ctx.Done())
-	}
 	if lbc.watchGlobalConfiguration {
 		go lbc.globalConfigurationController.Run(lbc.ctx.Done())
 	}
 	if lbc.watchIngressLink {
 		go lbc.ingressLinkInformer.Run(lbc.ctx.Done())
 	}
-	if lbc.appProtectEnabled || lbc.appProtectDosEnabled {
-		go lbc.dynInformerFactory.Start(lbc.ctx.Done())
+	if lbc.watchGatewayAPI {
+		go lbc.gatewayClassInformer.Run(lbc.ctx.Done())
 	}
 
 	glog.V(3).Infof("Waiting for %d caches to sync", len(lbc.cacheSyncs))
@@ -564,11 +629,14 @@ func (lbc *LoadBalancerController) Run() {
 		return
 	}
 
-	lbc.preSyncSecrets()
+	lbc.preSyncSecrets(api_v1.NamespaceAll)
 
 	glog.V(3).Infof("Starting the queue with %d initial elements", lbc.syncQueue.Len())
 
 	go lbc.syncQueue.Run(time.Second, lbc.ctx.Done())
+	if lbc.canaryManager != nil {
+		go lbc.canaryManager.Run(lbc.ctx.Done())
+	}
 	<-lbc.ctx.Done()
 }
 
@@ -594,13 +662,38 @@ func (lbc *LoadBalancerController) syncEndpoints(task task) {
 	}
 
 	endp := obj.(*api_v1.Endpoints)
-	resources := lbc.configuration.FindResourcesForEndpoints(endp.Namespace, endp.Name)
+	lbc.updateEndpointsForService(endp.Namespace, endp.Name)
+}
+
+// syncEndpointSlice syncs the endpoints of a Service. The key of the task is the key of the Service
+// rather than of an EndpointSlice, as a Service can have multiple EndpointSlices.
+func (lbc *LoadBalancerController) syncEndpointSlice(task task) {
+	key := task.Key
+	glog.V(3).Infof("Syncing EndpointSlices of service %v", key)
+
+	namespace, name, err := ParseNamespaceName(key)
+	if err != nil {
+		glog.Warningf("EndpointSlice key %v is invalid: %v", key, err)
+		return
+	}
+
+	lbc.updateEndpointsForService(namespace, name)
+}
+
+// updateEndpointsForService updates the endpoints of the resources that reference the Service.
+func (lbc *LoadBalancerController) updateEndpointsForService(namespace string, name string) {
+	resources := lbc.configuration.FindResourcesForEndpoints(namespace, name)
+
+	if lbc.areCustomResourcesEnabled {
+		resources = append(resources, lbc.findResourcesForPoliciesWithService(namespace, name)...)
+		resources = removeDuplicateResources(resources)
+	}
 
 	resourceExes := lbc.createExtendedResources(resources)
 
 	if len(resourceExes.IngressExes) > 0 {
 		glog.V(3).Infof("Updating Endpoints for %v", resourceExes.IngressExes)
-		err = lbc.configurator.UpdateEndpoints(resourceExes.IngressExes)
+		err := lbc.configurator.UpdateEndpoints(resourceExes.IngressExes)
 		if err != nil {
 			glog.Errorf("Error updating endpoints for %v: %v", resourceExes.IngressExes, err)
 		}
@@ -608,13 +701,14 @@ func (lbc *LoadBalancerController) syncEndpoints(task task) {
 
 	if len(resourceExes.MergeableIngresses) > 0 {
 		glog.V(3).Infof("Updating Endpoints for %v", resourceExes.MergeableIngresses)
-		err = lbc.configurator.UpdateEndpointsMergeableIngress(resourceExes.MergeableIngresses)
+		err := lbc.configurator.UpdateEndpointsMergeableIngress(resourceExes.MergeableIngresses)
 		if err != nil {
 			glog.Errorf("Error updating endpoints for %v: %v", resourceExes.MergeableIngresses, err)
 		}
 	}
 
-	if lbc.areCustomResourcesEnabled {
+	// VirtualServers are also generated from the Gateway API HTTPRoutes
+	if lbc.areCustomResourcesEnabled || lbc.watchGatewayAPI {
 		if len(resourceExes.VirtualServerExes) > 0 {
 			glog.V(3).Infof("Updating endpoints for %v", resourceExes.VirtualServerExes)
 			err := lbc.configurator.UpdateEndpointsForVirtualServers(resourceExes.VirtualServerExes)
@@ -622,7 +716,10 @@ func (lbc *LoadBalancerController) syncEndpoints(task task) {
 				glog.Errorf("Error updating endpoints for %v: %v", resourceExes.VirtualServerExes, err)
 			}
 		}
+	}
 
+	// TransportServers are also generated from the Gateway API TCPRoutes, UDPRoutes and TLSRoutes
+	if lbc.areCustomResourcesEnabled || lbc.watchGatewayAPIL4Routes {
 		if len(resourceExes.TransportServerExes) > 0 {
 			glog.V(3).Infof("Updating endpoints for %v", resourceExes.TransportServerExes)
 			err := lbc.configurator.UpdateEndpointsForTransportServers(resourceExes.TransportServerExes)
@@ -639,8 +736,7 @@ func (lbc *LoadBalancerController) createExtendedResources(resources []Resource)
 	for _, r := range resources {
 		switch impl := r.(type) {
 		case *VirtualServerConfiguration:
-			vs := impl.VirtualServer
-			vsEx := lbc.createVirtualServerEx(vs, impl.VirtualServerRoutes)
+			vsEx := lbc.createVirtualServerExForConfiguration(impl)
 			result.VirtualServerExes = append(result.VirtualServerExes, vsEx)
 		case *IngressConfiguration:
 
@@ -699,17 +795,20 @@ func (lbc *LoadBalancerController) updateAllConfigs() {
 
 	warnings, updateErr := lbc.configurator.UpdateConfig(cfgParams, resourceExes)
 
+	// the ConfigMap is applied even if the configuration of some invalid resources was excluded
+	configMapErr := getOperationErrorForResource(lbc.configMap, updateErr)
+
 	eventTitle := "Updated"
 	eventType := api_v1.EventTypeNormal
 	eventWarningMessage := ""
 
-	if updateErr != nil {
+	if configMapErr != nil {
 		eventTitle = "UpdatedWithError"
 		eventType = api_v1.EventTypeWarning
-		eventWarningMessage = fmt.Sprintf("but was not applied: %v", updateErr)
+		eventWarningMessage = fmt.Sprintf("but was not applied: %v", configMapErr)
 	}
 
-	if len(warnings) > 0 && updateErr == nil {
+	if len(warnings) > 0 && configMapErr == nil {
 		eventWarningMessage = "with warnings. Please check the logs"
 	}
 
@@ -733,13 +832,19 @@ func (lbc *LoadBalancerController) updateAllConfigs() {
 // syncing an Ingress or other resource that references a Secret will happen before that Secret was synced.
 // As a result, the IC will generate configuration for that resource assuming that the Secret is missing and
 // it will report warnings. (See https://github.com/nginxinc/kubernetes-ingress/issues/1448 )
-func (lbc *LoadBalancerController) preSyncSecrets() {
+// The same applies to the resources of a namespace that starts being watched, so the Secrets of such a namespace
+// are added to the SecretStore too.
+func (lbc *LoadBalancerController) preSyncSecrets(namespace string) {
 	objects := lbc.secretLister.List()
 	glog.V(3).Infof("PreSync %d Secrets", len(objects))
 
 	for _, obj := range objects {
 		secret := obj.(*api_v1.Secret)
 
+		if namespace != api_v1.NamespaceAll && secret.Namespace != namespace {
+			continue
+		}
+
 		if !secrets.IsSupportedSecretType(secret.Type) {
 			glog.V(3).Infof("Ignoring Secret %s/%s of unsupported type %s", secret.Namespace, secret.Name, secret.Type)
 			continue
@@ -756,6 +861,12 @@ func (lbc *LoadBalancerController) sync(task task) {
 		lbc.syncLock.Lock()
 		defer lbc.syncLock.Unlock()
 	}
+
+	if namespace, _, err := cache.SplitMetaNamespaceKey(task.Key); err == nil && !lbc.isNamespaceSynced(namespace) {
+		glog.V(3).Infof("Skipping %v until the caches of namespace %v have synced", task.Key, namespace)
+		return
+	}
+
 	switch task.Kind {
 	case ingress:
 		lbc.syncIngress(task)
@@ -765,6 +876,8 @@ func (lbc *LoadBalancerController) sync(task task) {
 		lbc.syncConfigMap(task)
 	case endpoints:
 		lbc.syncEndpoints(task)
+	case endpointSlice:
+		lbc.syncEndpointSlice(task)
 	case secret:
 		lbc.syncSecret(task)
 	case service:
@@ -798,9 +911,17 @@ func (lbc *LoadBalancerController) sync(task task) {
 		lbc.syncDosProtectedResource(task)
 	case ingressLink:
 		lbc.syncIngressLink(task)
+	case gatewayClassResource, gatewayResource, httpRouteResource, tcpRouteResource, udpRouteResource, tlsRouteResource:
+		lbc.syncGatewayAPI(task)
+	case pendingReload:
+		lbc.syncPendingReload()
+	case canaryProgress:
+		lbc.syncCanaryProgress(task)
+	case namespaceResource:
+		lbc.syncNamespace(task)
 	}
 
-	if !lbc.isNginxReady && lbc.syncQueue.Len() == 0 {
+	if !lbc.isNginxReady && lbc.syncQueue.Len() == 0 && lbc.areNamespacesSynced() {
 		lbc.configurator.EnableReloads()
 		lbc.updateAllConfigs()
 
@@ -809,6 +930,108 @@ func (lbc *LoadBalancerController) sync(task task) {
 	}
 }
 
+// onCanaryChange reports the progress of a canary of a VirtualServer and queues the update of its configuration.
+func (lbc *LoadBalancerController) onCanaryChange(key string, status conf_v1.CanaryStatus) {
+	obj, exists, err := lbc.virtualServerLister.GetByKey(key)
+	if err != nil {
+		glog.Errorf("Error when getting VirtualServer for %v: %v", key, err)
+		return
+	}
+	if !exists {
+		return
+	}
+
+	eventType := api_v1.EventTypeNormal
+	if status.Phase == canaryPhaseRolledBack {
+		eventType = api_v1.EventTypeWarning
+	}
+	lbc.recorder.Eventf(obj.(*conf_v1.VirtualServer), eventType, "Canary"+status.Phase, "Canary of route %v: %v", status.Path, status.Message)
+
+	lbc.syncQueue.EnqueueTask(task{Kind: canaryProgress, Key: key})
+}
+
+// getReportedCanaries returns the progress of the canaries reported in the latest status of the VirtualServer.
+func (lbc *LoadBalancerController) getReportedCanaries(vs *conf_v1.VirtualServer) []conf_v1.CanaryStatus {
+	obj, exists, err := lbc.virtualServerLister.GetByKey(getResourceKey(&vs.ObjectMeta))
+	if err != nil || !exists {
+		return vs.Status.Canaries
+	}
+
+	return obj.(*conf_v1.VirtualServer).Status.Canaries
+}
+
+// syncCanaryProgress updates the configuration of a VirtualServer after one of its canaries made progress.
+func (lbc *LoadBalancerController) syncCanaryProgress(task task) {
+	for _, r := range lbc.configuration.GetResourcesWithFilter(resourceFilter{VirtualServers: true}) {
+		vsConfig := r.(*VirtualServerConfiguration)
+		if getResourceKey(&vsConfig.VirtualServer.ObjectMeta) != task.Key {
+			continue
+		}
+
+		glog.V(3).Infof("Updating the configuration of VirtualServer %v to apply the progress of its canaries", task.Key)
+
+		vsEx := lbc.createVirtualServerExForConfiguration(vsConfig)
+		warnings, err := lbc.configurator.AddOrUpdateVirtualServer(vsEx)
+		lbc.updateVirtualServerStatusAndEvents(vsConfig, warnings, err)
+		return
+	}
+}
+
+// syncPendingReload performs the pending reload of NGINX and reports the status of the resources
+// which configuration was applied by it.
+func (lbc *LoadBalancerController) syncPendingReload() {
+	glog.V(3).Infof("Reloading NGINX to apply the batched changes of %v resources", len(lbc.pendingStatusUpdates))
+
+	reloadErr := lbc.configurator.ReloadPending()
+	if reloadErr != nil {
+		glog.Errorf("Error when reloading NGINX to apply the batched changes: %v", reloadErr)
+	}
+
+	updates := lbc.pendingStatusUpdates
+	lbc.pendingStatusUpdates = make(map[string]pendingStatusUpdate)
+
+	currentResources := make(map[string]Resource)
+	for _, r := range lbc.configuration.GetResources() {
+		currentResources[r.GetKeyWithKind()] = r
+	}
+
+	for key, u := range updates {
+		// the resource could be deleted or become invalid after its status update was deferred
+		if r, exists := currentResources[key]; !exists || !r.IsEqual(u.resource) {
+			glog.V(3).Infof("Skipping the status update of %v because it was changed after the update", key)
+			continue
+		}
+		u.update(reloadErr)
+	}
+
+	if lbc.pendingGatewayAPIStatuses {
+		lbc.pendingGatewayAPIStatuses = false
+		// the latest translation includes all the changes of the Gateway API resources batched into the reload
+		if lbc.gatewayTranslation != nil && lbc.reportCustomResourceStatusEnabled() {
+			lbc.updateGatewayAPIStatuses(lbc.gatewayTranslation)
+		}
+	}
+}
+
+// deferStatusUpdate defers the status update of the resource until the pending reload of NGINX, if any,
+// so that the status reflects the result of the reload that actually applies the configuration of the resource.
+// It returns true if the update was deferred.
+func (lbc *LoadBalancerController) deferStatusUpdate(resource Resource, operationErr error, update func(reloadErr error)) bool {
+	key := resource.GetKeyWithKind()
+
+	if operationErr != nil || !lbc.configurator.IsReloadPending() {
+		delete(lbc.pendingStatusUpdates, key)
+		return false
+	}
+
+	lbc.pendingStatusUpdates[key] = pendingStatusUpdate{
+		resource: resource,
+		update:   update,
+	}
+
+	return true
+}
+
 func (lbc *LoadBalancerController) syncIngressLink(task task) {
 	key := task.Key
 	glog.V(2).Infof("Adding, Updating or Deleting IngressLink: %v", key)
@@ -865,6 +1088,351 @@ func (lbc *LoadBalancerController) syncIngressLink(task task) {
 	}
 }
 
+func (lbc *LoadBalancerController) syncGatewayAPI(task task) {
+	glog.V(2).Infof("Adding, Updating or Deleting Gateway API resources for %v", task.Key)
+
+	classes, gateways, routes, l4Routes := lbc.getGatewayAPIResources()
+	translation := translateGatewayAPI(classes, gateways, routes, l4Routes, lbc.virtualServerValidator, lbc.l4ListenerValidator)
+
+	var previous map[string]*gatewayVirtualServer
+	if lbc.gatewayTranslation != nil {
+		previous = lbc.gatewayTranslation.VirtualServers
+	}
+	lbc.gatewayTranslation = translation
+
+	var virtualServers []*conf_v1.VirtualServer
+	for key, gvs := range translation.VirtualServers {
+		// the generated VirtualServers have no generation, so we maintain one to let the Configuration detect changes
+		if prev, exists := previous[key]; exists {
+			gvs.VirtualServer.Generation = prev.VirtualServer.Generation
+			if !reflect.DeepEqual(prev, gvs) {
+				gvs.VirtualServer.Generation++
+			}
+		}
+		virtualServers = append(virtualServers, gvs.VirtualServer)
+	}
+
+	changes, problems := lbc.configuration.SetGatewayVirtualServers(virtualServers)
+	lbc.processChanges(changes)
+	lbc.processProblems(problems)
+
+	if lbc.watchGatewayAPIL4Routes {
+		var transportServers []*conf_v1.TransportServer
+		for _, gts := range translation.TransportServers {
+			transportServers = append(transportServers, gts.TransportServer)
+		}
+
+		changes, problems = lbc.configuration.SetGatewayTransportServers(translation.Listeners, transportServers)
+		lbc.processChanges(changes)
+		lbc.processProblems(problems)
+
+		conflictGatewayListeners(gateways, translation.GatewayStatuses, lbc.configuration.GetConflictedGatewayListeners())
+	}
+
+	// the statuses report the Gateways as ready, so they wait for the pending reload of NGINX, if any
+	if lbc.configurator.IsReloadPending() {
+		lbc.pendingGatewayAPIStatuses = true
+		return
+	}
+	lbc.pendingGatewayAPIStatuses = false
+
+	if lbc.reportCustomResourceStatusEnabled() {
+		lbc.updateGatewayAPIStatuses(translation)
+	}
+}
+
+// enqueueGatewayAPISync queues the translation of the Gateway API resources because of a change of another resource.
+// All Gateway API resources are translated together, so the kind of the task doesn't matter.
+func (lbc *LoadBalancerController) enqueueGatewayAPISync(key string) {
+	lbc.syncQueue.EnqueueTask(task{Kind: gatewayResource, Key: key})
+}
+
+func (lbc *LoadBalancerController) getGatewayAPIResources() ([]*gatewayClass, []*gateway, []*httpRoute, []*l4Route) {
+	var classes []*gatewayClass
+	for _, obj := range lbc.gatewayClassLister.List() {
+		var gc gatewayClass
+		if err := convertUnstructured(obj.(*unstructured.Unstructured), &gc); err != nil {
+			glog.Warningf("Error converting GatewayClass %v: %v", obj.(*unstructured.Unstructured).GetName(), err)
+			continue
+		}
+		classes = append(classes, &gc)
+	}
+
+	var gateways []*gateway
+	for _, obj := range lbc.gatewayLister.List() {
+		var gw gateway
+		if err := convertUnstructured(obj.(*unstructured.Unstructured), &gw); err != nil {
+			glog.Warningf("Error converting Gateway %v: %v", getGatewayAPIResourceKeyFromObject(obj.(*unstructured.Unstructured)), err)
+			continue
+		}
+		gateways = append(gateways, &gw)
+	}
+
+	var routes []*httpRoute
+	for _, obj := range lbc.httpRouteLister.List() {
+		var route httpRoute
+		if err := convertUnstructured(obj.(*unstructured.Unstructured), &route); err != nil {
+			glog.Warningf("Error converting HTTPRoute %v: %v", getGatewayAPIResourceKeyFromObject(obj.(*unstructured.Unstructured)), err)
+			continue
+		}
+		routes = append(routes, &route)
+	}
+
+	var l4Routes []*l4Route
+	if lbc.watchGatewayAPIL4Routes {
+		l4Routes = append(l4Routes, getL4Routes(lbc.tcpRouteLister, tcpRouteGVK.Kind)...)
+		l4Routes = append(l4Routes, getL4Routes(lbc.udpRouteLister, udpRouteGVK.Kind)...)
+		l4Routes = append(l4Routes, getL4Routes(lbc.tlsRouteLister, tlsRouteGVK.Kind)...)
+	}
+
+	return classes, gateways, routes, l4Routes
+}
+
+func getL4Routes(lister cache.Store, kind string) []*l4Route {
+	var routes []*l4Route
+	for _, obj := range lister.List() {
+		route := l4Route{Kind: kind}
+		if err := convertUnstructured(obj.(*unstructured.Unstructured), &route); err != nil {
+			glog.Warningf("Error converting %v %v: %v", kind, getGatewayAPIResourceKeyFromObject(obj.(*unstructured.Unstructured)), err)
+			continue
+		}
+		routes = append(routes, &route)
+	}
+	return routes
+}
+
+// getL4RouteListers returns the listers of TCPRoutes, UDPRoutes and TLSRoutes keyed by the kind.
+func (lbc *LoadBalancerController) getL4RouteListers() map[string]cache.Store {
+	return map[string]cache.Store{
+		tcpRouteGVK.Kind: lbc.tcpRouteLister,
+		udpRouteGVK.Kind: lbc.udpRouteLister,
+		tlsRouteGVK.Kind: lbc.tlsRouteLister,
+	}
+}
+
+// recordGatewayTransportServerEvent records an event for the route of a TransportServer generated from the Gateway API resources,
+// because the TransportServer itself doesn't exist in the cluster.
+func (lbc *LoadBalancerController) recordGatewayTransportServerEvent(ts *conf_v1.TransportServer, eventType string, reason string, msg string) {
+	if lbc.gatewayTranslation == nil {
+		return
+	}
+
+	gts, exists := lbc.gatewayTranslation.TransportServers[getResourceKey(&ts.ObjectMeta)]
+	if !exists {
+		return
+	}
+
+	parts := strings.SplitN(gts.Route, "/", 2)
+	lister, exists := lbc.getL4RouteListers()[parts[0]]
+	if !exists || lister == nil {
+		return
+	}
+
+	obj, exists, err := lister.GetByKey(parts[1])
+	if err != nil || !exists {
+		return
+	}
+
+	lbc.recorder.Eventf(obj.(*unstructured.Unstructured), eventType, reason, msg)
+}
+
+// recordGatewayVirtualServerEvent records an event for the HTTPRoutes of a VirtualServer generated from the Gateway API resources,
+// because the VirtualServer itself doesn't exist in the cluster.
+func (lbc *LoadBalancerController) recordGatewayVirtualServerEvent(vs *conf_v1.VirtualServer, eventType string, reason string, msg string) {
+	gvs := lbc.findGatewayVirtualServer(vs)
+	if gvs == nil {
+		return
+	}
+
+	for _, key := range gvs.Routes {
+		obj, exists, err := lbc.httpRouteLister.GetByKey(key)
+		if err != nil || !exists {
+			continue
+		}
+		lbc.recorder.Eventf(obj.(*unstructured.Unstructured), eventType, reason, msg)
+	}
+}
+
+// findGatewayVirtualServer returns the translation of the Gateway API resources behind a VirtualServer
+// or nil if the VirtualServer is a VirtualServer resource.
+func (lbc *LoadBalancerController) findGatewayVirtualServer(vs *conf_v1.VirtualServer) *gatewayVirtualServer {
+	if lbc.gatewayTranslation == nil || !isGatewayVirtualServer(vs) {
+		return nil
+	}
+
+	return lbc.gatewayTranslation.VirtualServers[getResourceKey(&vs.ObjectMeta)]
+}
+
+// createVirtualServerExForConfiguration creates a VirtualServerEx for a VirtualServerConfiguration,
+// which can hold either a VirtualServer resource or a VirtualServer generated from the Gateway API resources.
+func (lbc *LoadBalancerController) createVirtualServerExForConfiguration(vsConfig *VirtualServerConfiguration) *configs.VirtualServerEx {
+	if gvs := lbc.findGatewayVirtualServer(vsConfig.VirtualServer); gvs != nil {
+		return lbc.createGatewayVirtualServerEx(gvs)
+	}
+
+	return lbc.createVirtualServerEx(vsConfig.VirtualServer, vsConfig.VirtualServerRoutes)
+}
+
+// createGatewayVirtualServerEx creates a VirtualServerEx for a VirtualServer generated from the Gateway API resources.
+func (lbc *LoadBalancerController) createGatewayVirtualServerEx(gvs *gatewayVirtualServer) *configs.VirtualServerEx {
+	vsEx := lbc.createVirtualServerEx(gvs.VirtualServer, nil)
+
+	if gvs.TLSSecret != "" {
+		_, name, _ := ParseNamespaceName(gvs.TLSSecret)
+
+		vs := gvs.VirtualServer.DeepCopy()
+		vs.Spec.TLS = &conf_v1.TLS{Secret: name}
+
+		// the Secret belongs to the namespace of the Gateway, which can be different from the namespace of the VirtualServer
+		vsEx.VirtualServer = vs
+		vsEx.SecretRefs[fmt.Sprintf("%s/%s", vs.Namespace, name)] = lbc.secretStore.GetSecret(gvs.TLSSecret)
+	}
+
+	return vsEx
+}
+
+// updateGatewayVirtualServersForSecret updates the configuration of the VirtualServers generated from the Gateway API
+// resources that hold their hosts and use the Secret in the HTTPS listener of their Gateway.
+func (lbc *LoadBalancerController) updateGatewayVirtualServersForSecret(secretKey string) {
+	var resources []Resource
+	var vsExes []*configs.VirtualServerEx
+	for _, r := range lbc.configuration.GetResourcesWithFilter(resourceFilter{VirtualServers: true}) {
+		vsConfig := r.(*VirtualServerConfiguration)
+		gvs := lbc.findGatewayVirtualServer(vsConfig.VirtualServer)
+		if gvs == nil || gvs.TLSSecret != secretKey {
+			continue
+		}
+
+		resources = append(resources, vsConfig)
+		vsExes = append(vsExes, lbc.createGatewayVirtualServerEx(gvs))
+	}
+
+	if len(vsExes) == 0 {
+		return
+	}
+
+	glog.V(2).Infof("Found %v Gateway API VirtualServers with Secret %v", len(vsExes), secretKey)
+
+	warnings, err := lbc.configurator.AddOrUpdateVirtualServers(vsExes)
+	if err != nil {
+		glog.Errorf("Error when updating Secret %v for Gateway API resources: %v", secretKey, err)
+	}
+	lbc.updateResourcesStatusAndEvents(resources, warnings, err)
+}
+
+// getHTTPRouteStatuses returns the statuses of the HTTPRoutes. The parents of the HTTPRoutes of a VirtualServer
+// generated from the Gateway API resources are rejected if the VirtualServer lost its host to another resource.
+func (lbc *LoadBalancerController) getHTTPRouteStatuses(translation *gatewayTranslation) map[string][]routeParentStatus {
+	result := make(map[string][]routeParentStatus, len(translation.RouteStatuses))
+	for key, parents := range translation.RouteStatuses {
+		result[key] = parents
+	}
+
+	for _, gvs := range translation.VirtualServers {
+		host := gvs.VirtualServer.Spec.Host
+
+		holder, exists := lbc.configuration.GetHostHolder(host)
+		if !exists || holder == getResourceKeyWithKind(virtualServerKind, &gvs.VirtualServer.ObjectMeta) {
+			continue
+		}
+
+		for _, key := range gvs.Routes {
+			// the statuses of the translation must stay intact, because the host can be released later
+			parents := make([]routeParentStatus, len(result[key]))
+			for i, p := range result[key] {
+				p.Conditions = append([]meta_v1.Condition(nil), p.Conditions...)
+				parents[i] = p
+			}
+
+			rejectRouteParents(parents, "Rejected", fmt.Sprintf("Host %s is taken by %s", host, holder))
+			result[key] = parents
+		}
+	}
+
+	return result
+}
+
+// updateHTTPRouteStatusesForGatewayVirtualServer updates the statuses of the HTTPRoutes of a VirtualServer
+// generated from the Gateway API resources.
+func (lbc *LoadBalancerController) updateHTTPRouteStatusesForGatewayVirtualServer(vs *conf_v1.VirtualServer) {
+	gvs := lbc.findGatewayVirtualServer(vs)
+	if gvs == nil {
+		return
+	}
+
+	statuses := lbc.getHTTPRouteStatuses(lbc.gatewayTranslation)
+
+	for _, key := range gvs.Routes {
+		obj, exists, err := lbc.httpRouteLister.GetByKey(key)
+		if err != nil || !exists {
+			continue
+		}
+
+		err = lbc.statusUpdater.UpdateHTTPRouteStatus(obj.(*unstructured.Unstructured), statuses[key])
+		if err != nil {
+			glog.V(3).Infof("Failed to update HTTPRoute %v status: %v", key, err)
+		}
+	}
+}
+
+func (lbc *LoadBalancerController) updateGatewayAPIStatuses(translation *gatewayTranslation) {
+	for _, obj := range lbc.gatewayClassLister.List() {
+		gc := obj.(*unstructured.Unstructured)
+		status, exists := translation.ClassStatuses[gc.GetName()]
+		if !exists {
+			continue
+		}
+
+		err := lbc.statusUpdater.UpdateGatewayClassStatus(gc, status)
+		if err != nil {
+			glog.V(3).Infof("Failed to update GatewayClass %v status: %v", gc.GetName(), err)
+		}
+	}
+
+	for _, obj := range lbc.gatewayLister.List() {
+		gw := obj.(*unstructured.Unstructured)
+		key := getGatewayAPIResourceKeyFromObject(gw)
+		status, exists := translation.GatewayStatuses[key]
+		if !exists {
+			continue
+		}
+
+		err := lbc.statusUpdater.UpdateGatewayStatus(gw, status)
+		if err != nil {
+			glog.V(3).Infof("Failed to update Gateway %v status: %v", key, err)
+		}
+	}
+
+	routeStatuses := lbc.getHTTPRouteStatuses(translation)
+
+	for _, obj := range lbc.httpRouteLister.List() {
+		route := obj.(*unstructured.Unstructured)
+		key := getGatewayAPIResourceKeyFromObject(route)
+
+		// routes without parents handled by the Ingress Controller get their previously reported parents removed
+		err := lbc.statusUpdater.UpdateHTTPRouteStatus(route, routeStatuses[key])
+		if err != nil {
+			glog.V(3).Infof("Failed to update HTTPRoute %v status: %v", key, err)
+		}
+	}
+
+	if !lbc.watchGatewayAPIL4Routes {
+		return
+	}
+
+	for kind, lister := range lbc.getL4RouteListers() {
+		for _, obj := range lister.List() {
+			route := obj.(*unstructured.Unstructured)
+			key := fmt.Sprintf("%s/%s", kind, getGatewayAPIResourceKeyFromObject(route))
+
+			err := lbc.statusUpdater.UpdateL4RouteStatus(route, translation.L4RouteStatuses[key])
+			if err != nil {
+				glog.V(3).Infof("Failed to update %v status: %v", key, err)
+			}
+		}
+	}
+}
+
 func (lbc *LoadBalancerController) syncPolicy(task task) {
 	key := task.Key
 	obj, polExists, err := lbc.policyLister.GetByKey(key)
@@ -934,7 +1502,7 @@ func (lbc *LoadBalancerController) syncTransportServer(task task) {
 		changes, problems = lbc.configuration.DeleteTransportServer(key)
 	} else {
 		glog.V(2).Infof("Adding or Updating TransportServer: %v\n", key)
-		ts := obj.(*conf_v1alpha1.TransportServer)
+		ts := obj.(*conf_v1.TransportServer)
 		changes, problems = lbc.configuration.AddOrUpdateTransportServer(ts)
 	}
 
@@ -961,7 +1529,7 @@ func (lbc *LoadBalancerController) syncGlobalConfiguration(task task) {
 	} else {
 		glog.V(2).Infof("Adding or Updating GlobalConfiguration: %v\n", key)
 
-		gc := obj.(*conf_v1alpha1.GlobalConfiguration)
+		gc := obj.(*conf_v1.GlobalConfiguration)
 		changes, problems, validationErr = lbc.configuration.AddOrUpdateGlobalConfiguration(gc)
 	}
 
@@ -984,11 +1552,16 @@ func (lbc *LoadBalancerController) syncGlobalConfiguration(task task) {
 			eventMessage = fmt.Sprintf("%s; with reload error: %v", eventMessage, updateErr)
 		}
 
-		gc := obj.(*conf_v1alpha1.GlobalConfiguration)
+		gc := obj.(*conf_v1.GlobalConfiguration)
 		lbc.recorder.Eventf(gc, eventType, eventTitle, eventMessage)
 	}
 
 	lbc.processProblems(problems)
+
+	// the listeners of the Gateways can conflict with the listeners of the GlobalConfiguration
+	if lbc.watchGatewayAPIL4Routes {
+		lbc.enqueueGatewayAPISync(key)
+	}
 }
 
 func (lbc *LoadBalancerController) syncVirtualServer(task task) {
@@ -1022,6 +1595,20 @@ func (lbc *LoadBalancerController) processProblems(problems []ConfigurationProbl
 
 	for _, p := range problems {
 		eventType := api_v1.EventTypeWarning
+
+		if ts, ok := p.Object.(*conf_v1.TransportServer); ok && isGatewayTransportServer(ts) {
+			lbc.recordGatewayTransportServerEvent(ts, eventType, p.Reason, p.Message)
+			continue
+		}
+
+		if vs, ok := p.Object.(*conf_v1.VirtualServer); ok && isGatewayVirtualServer(vs) {
+			lbc.recordGatewayVirtualServerEvent(vs, eventType, p.Reason, p.Message)
+			if lbc.reportCustomResourceStatusEnabled() {
+				lbc.updateHTTPRouteStatusesForGatewayVirtualServer(vs)
+			}
+			continue
+		}
+
 		lbc.recorder.Event(p.Object, eventType, p.Reason, p.Message)
 
 		if lbc.reportCustomResourceStatusEnabled() {
@@ -1037,11 +1624,11 @@ func (lbc *LoadBalancerController) processProblems(problems []ConfigurationProbl
 					glog.V(3).Infof("Error when updating the status for Ingress %v/%v: %v", obj.Namespace, obj.Name, err)
 				}
 			case *conf_v1.VirtualServer:
-				err := lbc.statusUpdater.UpdateVirtualServerStatus(obj, state, p.Reason, p.Message)
+				err := lbc.statusUpdater.UpdateVirtualServerStatus(obj, state, p.Reason, p.Message, nil)
 				if err != nil {
 					glog.Errorf("Error when updating the status for VirtualServer %v/%v: %v", obj.Namespace, obj.Name, err)
 				}
-			case *conf_v1alpha1.TransportServer:
+			case *conf_v1.TransportServer:
 				err := lbc.statusUpdater.UpdateTransportServerStatus(obj, state, p.Reason, p.Message)
 				if err != nil {
 					glog.Errorf("Error when updating the status for TransportServer %v/%v: %v", obj.Namespace, obj.Name, err)
@@ -1064,7 +1651,7 @@ func (lbc *LoadBalancerController) processChanges(changes []ResourceChange) {
 		if c.Op == AddOrUpdate {
 			switch impl := c.Resource.(type) {
 			case *VirtualServerConfiguration:
-				vsEx := lbc.createVirtualServerEx(impl.VirtualServer, impl.VirtualServerRoutes)
+				vsEx := lbc.createVirtualServerExForConfiguration(impl)
 
 				warnings, addOrUpdateErr := lbc.configurator.AddOrUpdateVirtualServer(vsEx)
 				lbc.updateVirtualServerStatusAndEvents(impl, warnings, addOrUpdateErr)
@@ -1084,8 +1671,8 @@ func (lbc *LoadBalancerController) processChanges(changes []ResourceChange) {
 			case *TransportServerConfiguration:
 				tsEx := lbc.createTransportServerEx(impl.TransportServer, impl.ListenerPort)
 
-				addOrUpdateErr := lbc.configurator.AddOrUpdateTransportServer(tsEx)
-				lbc.updateTransportServerStatusAndEvents(impl, addOrUpdateErr)
+				warnings, addOrUpdateErr := lbc.configurator.AddOrUpdateTransportServer(tsEx)
+				lbc.updateTransportServerStatusAndEvents(impl, warnings, addOrUpdateErr)
 			}
 		} else if c.Op == Delete {
 			switch impl := c.Resource.(type) {
@@ -1097,6 +1684,10 @@ func (lbc *LoadBalancerController) processChanges(changes []ResourceChange) {
 					glog.Errorf("Error when deleting configuration for VirtualServer %v: %v", key, deleteErr)
 				}
 
+				if lbc.canaryManager != nil {
+					lbc.canaryManager.delete(key)
+				}
+
 				_, vsExists, err := lbc.virtualServerLister.GetByKey(key)
 				if err != nil {
 					glog.Errorf("Error when getting VirtualServer for %v: %v", key, err)
@@ -1412,7 +2003,7 @@ func (lbc *LoadBalancerController) UpdateVirtualServerStatusAndEventsOnDelete(vs
 		lbc.recorder.Eventf(vsConfig.VirtualServer, eventType, eventTitle, msg)
 
 		if lbc.reportCustomResourceStatusEnabled() {
-			err := lbc.statusUpdater.UpdateVirtualServerStatus(vsConfig.VirtualServer, state, eventTitle, msg)
+			err := lbc.statusUpdater.UpdateVirtualServerStatus(vsConfig.VirtualServer, state, eventTitle, msg, nil)
 			if err != nil {
 				glog.Errorf("Error when updating the status for VirtualServer %v/%v: %v", vsConfig.VirtualServer.Namespace, vsConfig.VirtualServer.Name, err)
 			}
@@ -1469,12 +2060,31 @@ func (lbc *LoadBalancerController) updateResourcesStatusAndEvents(resources []Re
 				lbc.updateRegularIngressStatusAndEvents(impl, warnings, operationErr)
 			}
 		case *TransportServerConfiguration:
-			lbc.updateTransportServerStatusAndEvents(impl, operationErr)
+			lbc.updateTransportServerStatusAndEvents(impl, warnings, operationErr)
 		}
 	}
 }
 
+// getOperationErrorForResource returns the error of a Configurator operation for a resource.
+// If NGINX was reloaded without the configuration of some invalid resources, the error applies only to those resources.
+func getOperationErrorForResource(obj runtime.Object, operationErr error) error {
+	var invalidResourcesErr *configs.InvalidResourcesError
+	if errors.As(operationErr, &invalidResourcesErr) {
+		return invalidResourcesErr.ErrorForResource(obj)
+	}
+
+	return operationErr
+}
+
 func (lbc *LoadBalancerController) updateMergeableIngressStatusAndEvents(ingConfig *IngressConfiguration, warnings configs.Warnings, operationErr error) {
+	if lbc.deferStatusUpdate(ingConfig, operationErr, func(reloadErr error) {
+		lbc.updateMergeableIngressStatusAndEvents(ingConfig, warnings, reloadErr)
+	}) {
+		return
+	}
+
+	operationErr = getOperationErrorForResource(ingConfig.Ingress, operationErr)
+
 	eventType := api_v1.EventTypeNormal
 	eventTitle := "AddedOrUpdated"
 	eventWarningMessage := ""
@@ -1543,6 +2153,14 @@ func (lbc *LoadBalancerController) updateMergeableIngressStatusAndEvents(ingConf
 }
 
 func (lbc *LoadBalancerController) updateRegularIngressStatusAndEvents(ingConfig *IngressConfiguration, warnings configs.Warnings, operationErr error) {
+	if lbc.deferStatusUpdate(ingConfig, operationErr, func(reloadErr error) {
+		lbc.updateRegularIngressStatusAndEvents(ingConfig, warnings, reloadErr)
+	}) {
+		return
+	}
+
+	operationErr = getOperationErrorForResource(ingConfig.Ingress, operationErr)
+
 	eventType := api_v1.EventTypeNormal
 	eventTitle := "AddedOrUpdated"
 	eventWarningMessage := ""
@@ -1576,7 +2194,15 @@ func (lbc *LoadBalancerController) updateRegularIngressStatusAndEvents(ingConfig
 	}
 }
 
-func (lbc *LoadBalancerController) updateTransportServerStatusAndEvents(tsConfig *TransportServerConfiguration, operationErr error) {
+func (lbc *LoadBalancerController) updateTransportServerStatusAndEvents(tsConfig *TransportServerConfiguration, warnings configs.Warnings, operationErr error) {
+	if lbc.deferStatusUpdate(tsConfig, operationErr, func(reloadErr error) {
+		lbc.updateTransportServerStatusAndEvents(tsConfig, warnings, reloadErr)
+	}) {
+		return
+	}
+
+	operationErr = getOperationErrorForResource(tsConfig.TransportServer, operationErr)
+
 	eventTitle := "AddedOrUpdated"
 	eventType := api_v1.EventTypeNormal
 	eventWarningMessage := ""
@@ -1589,6 +2215,13 @@ func (lbc *LoadBalancerController) updateTransportServerStatusAndEvents(tsConfig
 		state = conf_v1.StateWarning
 	}
 
+	if messages, ok := warnings[tsConfig.TransportServer]; ok {
+		eventType = api_v1.EventTypeWarning
+		eventTitle = "AddedOrUpdatedWithWarning"
+		eventWarningMessage = fmt.Sprintf("%s; with warning(s): %v", eventWarningMessage, formatWarningMessages(messages))
+		state = conf_v1.StateWarning
+	}
+
 	if operationErr != nil {
 		eventType = api_v1.EventTypeWarning
 		eventTitle = "AddedOrUpdatedWithError"
@@ -1597,6 +2230,12 @@ func (lbc *LoadBalancerController) updateTransportServerStatusAndEvents(tsConfig
 	}
 
 	msg := fmt.Sprintf("Configuration for %v was added or updated %s", getResourceKey(&tsConfig.TransportServer.ObjectMeta), eventWarningMessage)
+
+	if isGatewayTransportServer(tsConfig.TransportServer) {
+		lbc.recordGatewayTransportServerEvent(tsConfig.TransportServer, eventType, eventTitle, msg)
+		return
+	}
+
 	lbc.recorder.Eventf(tsConfig.TransportServer, eventType, eventTitle, msg)
 
 	if lbc.reportCustomResourceStatusEnabled() {
@@ -1608,6 +2247,14 @@ func (lbc *LoadBalancerController) updateTransportServerStatusAndEvents(tsConfig
 }
 
 func (lbc *LoadBalancerController) updateVirtualServerStatusAndEvents(vsConfig *VirtualServerConfiguration, warnings configs.Warnings, operationErr error) {
+	if lbc.deferStatusUpdate(vsConfig, operationErr, func(reloadErr error) {
+		lbc.updateVirtualServerStatusAndEvents(vsConfig, warnings, reloadErr)
+	}) {
+		return
+	}
+
+	operationErr = getOperationErrorForResource(vsConfig.VirtualServer, operationErr)
+
 	eventType := api_v1.EventTypeNormal
 	eventTitle := "AddedOrUpdated"
 	eventWarningMessage := ""
@@ -1627,6 +2274,13 @@ func (lbc *LoadBalancerController) updateVirtualServerStatusAndEvents(vsConfig *
 		state = conf_v1.StateWarning
 	}
 
+	if lbc.canaryManager == nil && hasCanaries(vsConfig) {
+		eventType = api_v1.EventTypeWarning
+		eventTitle = "AddedOrUpdatedWithWarning"
+		eventWarningMessage = fmt.Sprintf("%s; with warning(s): canaries are ignored because latency metrics are not enabled", eventWarningMessage)
+		state = conf_v1.StateWarning
+	}
+
 	if operationErr != nil {
 		eventType = api_v1.EventTypeWarning
 		eventTitle = "AddedOrUpdatedWithError"
@@ -1635,10 +2289,25 @@ func (lbc *LoadBalancerController) updateVirtualServerStatusAndEvents(vsConfig *
 	}
 
 	msg := fmt.Sprintf("Configuration for %v was added or updated %s", getResourceKey(&vsConfig.VirtualServer.ObjectMeta), eventWarningMessage)
+
+	if isGatewayVirtualServer(vsConfig.VirtualServer) {
+		msg = fmt.Sprintf("Configuration for host %v was added or updated %s", vsConfig.VirtualServer.Spec.Host, eventWarningMessage)
+		lbc.recordGatewayVirtualServerEvent(vsConfig.VirtualServer, eventType, eventTitle, msg)
+		if lbc.reportCustomResourceStatusEnabled() {
+			lbc.updateHTTPRouteStatusesForGatewayVirtualServer(vsConfig.VirtualServer)
+		}
+		return
+	}
+
 	lbc.recorder.Eventf(vsConfig.VirtualServer, eventType, eventTitle, msg)
 
 	if lbc.reportCustomResourceStatusEnabled() {
-		err := lbc.statusUpdater.UpdateVirtualServerStatus(vsConfig.VirtualServer, state, eventTitle, msg)
+		var canaries []conf_v1.CanaryStatus
+		if lbc.canaryManager != nil {
+			// the empty list clears the reported progress of the removed canaries
+			canaries = append([]conf_v1.CanaryStatus{}, lbc.canaryManager.getStatuses(getResourceKey(&vsConfig.VirtualServer.ObjectMeta))...)
+		}
+		err := lbc.statusUpdater.UpdateVirtualServerStatus(vsConfig.VirtualServer, state, eventTitle, msg, canaries)
 		if err != nil {
 			glog.Errorf("Error when updating the status for VirtualServer %v/%v: %v", vsConfig.VirtualServer.Namespace, vsConfig.VirtualServer.Name, err)
 		}
@@ -1805,6 +2474,11 @@ func (lbc *LoadBalancerController) syncService(task task) {
 
 	resources := lbc.configuration.FindResourcesForService(namespace, name)
 
+	if lbc.areCustomResourcesEnabled {
+		resources = append(resources, lbc.findResourcesForPoliciesWithService(namespace, name)...)
+		resources = removeDuplicateResources(resources)
+	}
+
 	if len(resources) == 0 {
 		return
 	}
@@ -1883,6 +2557,9 @@ func (lbc *LoadBalancerController) syncSecret(task task) {
 		if len(resources) > 0 {
 			lbc.handleRegularSecretDeletion(resources)
 		}
+		if lbc.watchGatewayAPI {
+			lbc.updateGatewayVirtualServersForSecret(key)
+		}
 		if lbc.isSpecialSecret(key) {
 			glog.Warningf("A special TLS Secret %v was removed. Retaining the Secret.", key)
 		}
@@ -1903,6 +2580,10 @@ func (lbc *LoadBalancerController) syncSecret(task task) {
 	if len(resources) > 0 {
 		lbc.handleSecretUpdate(secret, resources)
 	}
+
+	if lbc.watchGatewayAPI {
+		lbc.updateGatewayVirtualServersForSecret(key)
+	}
 }
 
 func removeDuplicateResources(resources []Resource) []Resource {
@@ -2017,7 +2698,7 @@ func (lbc *LoadBalancerController) updateVirtualServersStatusFromEvents() error
 			}
 		}
 
-		err = lbc.statusUpdater.UpdateVirtualServerStatus(vs, getStatusFromEventTitle(latestEvent.Reason), latestEvent.Reason, latestEvent.Message)
+		err = lbc.statusUpdater.UpdateVirtualServerStatus(vs, getStatusFromEventTitle(latestEvent.Reason), latestEvent.Reason, latestEvent.Message, nil)
 		if err != nil {
 			allErrs = append(allErrs, err)
 		}
@@ -2103,7 +2784,7 @@ func (lbc *LoadBalancerController) updatePoliciesStatus() error {
 func (lbc *LoadBalancerController) updateTransportServersStatusFromEvents() error {
 	var allErrs []error
 	for _, obj := range lbc.transportServerLister.List() {
-		ts := obj.(*conf_v1alpha1.TransportServer)
+		ts := obj.(*conf_v1.TransportServer)
 
 		events, err := lbc.client.CoreV1().Events(ts.Namespace).List(context.TODO(),
 			meta_v1.ListOptions{FieldSelector: fmt.Sprintf("involvedObject.name=%v,involvedObject.uid=%v", ts.Name, ts.UID)})
@@ -2404,6 +3085,10 @@ func (lbc *LoadBalancerController) createVirtualServerEx(virtualServer *conf_v1.
 	if err != nil {
 		glog.Warningf("Error getting JWT secrets for VirtualServer %v/%v: %v", virtualServer.Namespace, virtualServer.Name, err)
 	}
+	err = lbc.addBasicSecretRefs(virtualServerEx.SecretRefs, policies)
+	if err != nil {
+		glog.Warningf("Error getting Basic Auth secrets for VirtualServer %v/%v: %v", virtualServer.Namespace, virtualServer.Name, err)
+	}
 	err = lbc.addIngressMTLSSecretRefs(virtualServerEx.SecretRefs, policies)
 	if err != nil {
 		glog.Warningf("Error getting IngressMTLS secret for VirtualServer %v/%v: %v", virtualServer.Namespace, virtualServer.Name, err)
@@ -2494,6 +3179,10 @@ func (lbc *LoadBalancerController) createVirtualServerEx(virtualServer *conf_v1.
 		if err != nil {
 			glog.Warningf("Error getting JWT secrets for VirtualServer %v/%v: %v", virtualServer.Namespace, virtualServer.Name, err)
 		}
+		err = lbc.addBasicSecretRefs(virtualServerEx.SecretRefs, vsRoutePolicies)
+		if err != nil {
+			glog.Warningf("Error getting Basic Auth secrets for VirtualServer %v/%v: %v", virtualServer.Namespace, virtualServer.Name, err)
+		}
 		err = lbc.addEgressMTLSSecretRefs(virtualServerEx.SecretRefs, vsRoutePolicies)
 		if err != nil {
 			glog.Warningf("Error getting EgressMTLS secrets for VirtualServer %v/%v: %v", virtualServer.Namespace, virtualServer.Name, err)
@@ -2531,6 +3220,11 @@ func (lbc *LoadBalancerController) createVirtualServerEx(virtualServer *conf_v1.
 				glog.Warningf("Error getting JWT secrets for VirtualServerRoute %v/%v: %v", vsr.Namespace, vsr.Name, err)
 			}
 
+			err = lbc.addBasicSecretRefs(virtualServerEx.SecretRefs, vsrSubroutePolicies)
+			if err != nil {
+				glog.Warningf("Error getting Basic Auth secrets for VirtualServerRoute %v/%v: %v", vsr.Namespace, vsr.Name, err)
+			}
+
 			err = lbc.addEgressMTLSSecretRefs(virtualServerEx.SecretRefs, vsrSubroutePolicies)
 			if err != nil {
 				glog.Warningf("Error getting EgressMTLS secrets for VirtualServerRoute %v/%v: %v", vsr.Namespace, vsr.Name, err)
@@ -2599,15 +3293,43 @@ func (lbc *LoadBalancerController) createVirtualServerEx(virtualServer *conf_v1.
 		}
 	}
 
+	lbc.addExternalAuthEndpoints(endpoints, externalNameSvcs, policies)
+
 	virtualServerEx.Endpoints = endpoints
 	virtualServerEx.VirtualServerRoutes = virtualServerRoutes
 	virtualServerEx.ExternalNameSvcs = externalNameSvcs
 	virtualServerEx.Policies = createPolicyMap(policies)
 	virtualServerEx.PodsByIP = podsByIP
 
+	if lbc.canaryManager != nil {
+		lbc.canaryManager.apply(&virtualServerEx, lbc.getReportedCanaries(virtualServer))
+	}
+
 	return &virtualServerEx
 }
 
+// addExternalAuthEndpoints adds the endpoints of the authorization services of the externalAuth policies.
+func (lbc *LoadBalancerController) addExternalAuthEndpoints(endpoints map[string][]string, externalNameSvcs map[string]bool, policies []*conf_v1.Policy) {
+	for _, pol := range policies {
+		if pol.Spec.ExternalAuth == nil || pol.Spec.ExternalAuth.AuthServiceName == "" {
+			continue
+		}
+
+		svcName := pol.Spec.ExternalAuth.AuthServiceName
+		port := uint16(pol.Spec.ExternalAuth.AuthServicePort)
+
+		podEndps, external, err := lbc.getEndpointsForUpstream(pol.Namespace, svcName, port)
+		if err != nil {
+			glog.Warningf("Error getting Endpoints for the authorization service of Policy %v/%v: %v", pol.Namespace, pol.Name, err)
+		}
+		if err == nil && external && lbc.isNginxPlus {
+			externalNameSvcs[configs.GenerateExternalNameSvcKey(pol.Namespace, svcName)] = true
+		}
+
+		endpoints[configs.GenerateEndpointsKey(pol.Namespace, svcName, nil, port)] = getIPAddressesFromEndpoints(podEndps)
+	}
+}
+
 func createPolicyMap(policies []*conf_v1.Policy) map[string]*conf_v1.Policy {
 	result := make(map[string]*conf_v1.Policy)
 
@@ -2698,6 +3420,25 @@ func (lbc *LoadBalancerController) addJWTSecretRefs(secretRefs map[string]*secre
 	return nil
 }
 
+func (lbc *LoadBalancerController) addBasicSecretRefs(secretRefs map[string]*secrets.SecretReference, policies []*conf_v1.Policy) error {
+	for _, pol := range policies {
+		if pol.Spec.BasicAuth == nil {
+			continue
+		}
+
+		secretKey := fmt.Sprintf("%v/%v", pol.Namespace, pol.Spec.BasicAuth.Secret)
+		secretRef := lbc.secretStore.GetSecret(secretKey)
+
+		secretRefs[secretKey] = secretRef
+
+		if secretRef.Error != nil {
+			return secretRef.Error
+		}
+	}
+
+	return nil
+}
+
 func (lbc *LoadBalancerController) addIngressMTLSSecretRefs(secretRefs map[string]*secrets.SecretReference, policies []*conf_v1.Policy) error {
 	for _, pol := range policies {
 		if pol.Spec.IngressMTLS == nil {
@@ -2815,6 +3556,8 @@ func findPoliciesForSecret(policies []*conf_v1.Policy, secretNamespace string, s
 			res = append(res, pol)
 		} else if pol.Spec.JWTAuth != nil && pol.Spec.JWTAuth.Secret == secretName && pol.Namespace == secretNamespace {
 			res = append(res, pol)
+		} else if pol.Spec.BasicAuth != nil && pol.Spec.BasicAuth.Secret == secretName && pol.Namespace == secretNamespace {
+			res = append(res, pol)
 		} else if pol.Spec.EgressMTLS != nil && pol.Spec.EgressMTLS.TLSSecret == secretName && pol.Namespace == secretNamespace {
 			res = append(res, pol)
 		} else if pol.Spec.EgressMTLS != nil && pol.Spec.EgressMTLS.TrustedCertSecret == secretName && pol.Namespace == secretNamespace {
@@ -2827,6 +3570,29 @@ func findPoliciesForSecret(policies []*conf_v1.Policy, secretNamespace string, s
 	return res
 }
 
+// findResourcesForPoliciesWithService finds the resources that reference the policies that reference the service.
+func (lbc *LoadBalancerController) findResourcesForPoliciesWithService(svcNamespace string, svcName string) []Resource {
+	var resources []Resource
+
+	for _, pol := range findPoliciesForService(lbc.getAllPolicies(), svcNamespace, svcName) {
+		resources = append(resources, lbc.configuration.FindResourcesForPolicy(pol.Namespace, pol.Name)...)
+	}
+
+	return resources
+}
+
+func findPoliciesForService(policies []*conf_v1.Policy, svcNamespace string, svcName string) []*conf_v1.Policy {
+	var res []*conf_v1.Policy
+
+	for _, pol := range policies {
+		if pol.Spec.ExternalAuth != nil && pol.Spec.ExternalAuth.AuthServiceName == svcName && pol.Namespace == svcNamespace {
+			res = append(res, pol)
+		}
+	}
+
+	return res
+}
+
 func getWAFPoliciesForAppProtectPolicy(pols []*conf_v1.Policy, key string) []*conf_v1.Policy {
 	var policies []*conf_v1.Policy
 
@@ -2859,7 +3625,7 @@ func isMatchingResourceRef(ownerNs, resRef, key string) bool {
 	return resRef == key
 }
 
-func (lbc *LoadBalancerController) createTransportServerEx(transportServer *conf_v1alpha1.TransportServer, listenerPort int) *configs.TransportServerEx {
+func (lbc *LoadBalancerController) createTransportServerEx(transportServer *conf_v1.TransportServer, listenerPort int) *configs.TransportServerEx {
 	endpoints := make(map[string][]string)
 	podsByIP := make(map[string]string)
 
@@ -2886,11 +3652,51 @@ func (lbc *LoadBalancerController) createTransportServerEx(transportServer *conf
 		}
 	}
 
+	secretRefs := make(map[string]*secrets.SecretReference)
+
+	if transportServer.Spec.TLS != nil && transportServer.Spec.TLS.Secret != "" {
+		secretKey := transportServer.Namespace + "/" + transportServer.Spec.TLS.Secret
+
+		secretRef := lbc.secretStore.GetSecret(secretKey)
+		if secretRef.Error != nil {
+			glog.Warningf("Error trying to get the secret %v for TransportServer %v: %v", secretKey, transportServer.Name, secretRef.Error)
+		}
+
+		secretRefs[secretKey] = secretRef
+	}
+
+	for _, u := range transportServer.Spec.Upstreams {
+		if u.TLS == nil || !u.TLS.Enable || u.TLS.TrustedCertSecret == "" {
+			continue
+		}
+
+		secretKey := transportServer.Namespace + "/" + u.TLS.TrustedCertSecret
+
+		secretRef := lbc.secretStore.GetSecret(secretKey)
+		if secretRef.Error != nil {
+			glog.Warningf("Error trying to get the secret %v for TransportServer %v: %v", secretKey, transportServer.Name, secretRef.Error)
+		}
+
+		secretRefs[secretKey] = secretRef
+	}
+
+	policies, policyErrors := lbc.getPolicies(transportServer.Spec.Policies, transportServer.Namespace)
+	for _, err := range policyErrors {
+		glog.Warningf("Error getting policy for TransportServer %s/%s: %v", transportServer.Namespace, transportServer.Name, err)
+	}
+
+	err := lbc.addIngressMTLSSecretRefs(secretRefs, policies)
+	if err != nil {
+		glog.Warningf("Error getting IngressMTLS secret for TransportServer %v/%v: %v", transportServer.Namespace, transportServer.Name, err)
+	}
+
 	return &configs.TransportServerEx{
 		ListenerPort:    listenerPort,
 		TransportServer: transportServer,
 		Endpoints:       endpoints,
 		PodsByIP:        podsByIP,
+		Policies:        createPolicyMap(policies),
+		SecretRefs:      secretRefs,
 	}
 }
 
@@ -2953,6 +3759,17 @@ func (lbc *LoadBalancerController) getEndpointsForServiceWithSubselector(targetP
 		return nil, fmt.Errorf("Error getting pods in namespace %v that match the selector %v: %w", svc.Namespace, labels.Merge(svc.Spec.Selector, subselector), err)
 	}
 
+	if lbc.areEndpointSlicesEnabled {
+		slices, err := lbc.endpointSliceLister.GetServiceEndpointSlices(svc)
+		if err != nil {
+			glog.V(3).Infof("Error getting endpoint slices for service %s from the cache: %v", svc.Name, err)
+			return nil, err
+		}
+
+		endps = getEndpointsBySubselectedPodsFromEndpointSlices(targetPort, pods, slices, getServiceAddressType(svc))
+		return endps, nil
+	}
+
 	svcEps, err := lbc.endpointLister.GetServiceEndpoints(svc)
 	if err != nil {
 		glog.V(3).Infof("Error getting endpoints for service %s from the cache: %v", svc.Name, err)
@@ -2963,6 +3780,127 @@ func (lbc *LoadBalancerController) getEndpointsForServiceWithSubselector(targetP
 	return endps, nil
 }
 
+func getEndpointsBySubselectedPodsFromEndpointSlices(targetPort int32, pods []*api_v1.Pod, slices []discovery_v1.EndpointSlice, addressType discovery_v1.AddressType) (endps []podEndpoint) {
+	sliceEndps, _ := getEndpointSliceEndpointsForPort(slices, targetPort, addressType)
+
+	for _, pod := range pods {
+		for _, endp := range sliceEndps {
+			if !podHasIP(pod, endp.IP) {
+				continue
+			}
+			ownerType, ownerName := getPodOwnerTypeAndName(pod)
+			podEnd := podEndpoint{
+				Address: net.JoinHostPort(endp.IP, strconv.Itoa(int(targetPort))),
+				PodName: getPodName(endp.TargetRef),
+				MeshPodOwner: configs.MeshPodOwner{
+					OwnerType: ownerType,
+					OwnerName: ownerName,
+				},
+			}
+			endps = append(endps, podEnd)
+		}
+	}
+	return endps
+}
+
+func podHasIP(pod *api_v1.Pod, ip string) bool {
+	if pod.Status.PodIP == ip {
+		return true
+	}
+	for _, podIP := range pod.Status.PodIPs {
+		if podIP.IP == ip {
+			return true
+		}
+	}
+	return false
+}
+
+// endpointSliceEndpoint is an address of an EndpointSlice endpoint that serves a port of a service.
+type endpointSliceEndpoint struct {
+	IP        string
+	Port      int32
+	TargetRef *api_v1.ObjectReference
+}
+
+// getServiceAddressType returns the EndpointSlice address type that matches the primary IP family of the service.
+// For dual-stack services, Kubernetes creates the EndpointSlices for each IP family.
+func getServiceAddressType(svc *api_v1.Service) discovery_v1.AddressType {
+	if len(svc.Spec.IPFamilies) > 0 && svc.Spec.IPFamilies[0] == api_v1.IPv6Protocol {
+		return discovery_v1.AddressTypeIPv6
+	}
+	return discovery_v1.AddressTypeIPv4
+}
+
+// getEndpointSliceEndpointsForPort returns the endpoints of the EndpointSlices of the address type that serve the target port.
+// Ready endpoints are preferred. If none of the endpoints is ready, the endpoints that are terminating but still serving are
+// returned, so that the connections are drained rather than dropped during a rollout.
+// The returned bool is false if none of the EndpointSlices has the target port.
+func getEndpointSliceEndpointsForPort(slices []discovery_v1.EndpointSlice, targetPort int32, addressType discovery_v1.AddressType) ([]endpointSliceEndpoint, bool) {
+	var ready, servingTerminating []endpointSliceEndpoint
+	seen := make(map[string]bool)
+	portFound := false
+
+	for _, slice := range slices {
+		if slice.AddressType != addressType {
+			continue
+		}
+		for _, port := range slice.Ports {
+			if port.Port == nil || *port.Port != targetPort {
+				continue
+			}
+			portFound = true
+			for _, endp := range slice.Endpoints {
+				for _, ip := range endp.Addresses {
+					// the same endpoint can appear in more than one EndpointSlice while the slices are being updated
+					if seen[ip] {
+						continue
+					}
+					seen[ip] = true
+
+					sliceEndp := endpointSliceEndpoint{
+						IP:        ip,
+						Port:      targetPort,
+						TargetRef: endp.TargetRef,
+					}
+					if isEndpointReady(endp.Conditions) {
+						ready = append(ready, sliceEndp)
+					} else if isEndpointServingAndTerminating(endp.Conditions) {
+						servingTerminating = append(servingTerminating, sliceEndp)
+					}
+				}
+			}
+		}
+	}
+
+	result := ready
+	if len(result) == 0 {
+		result = servingTerminating
+	}
+
+	// the order of the EndpointSlices in the cache is not stable
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].IP < result[j].IP
+	})
+
+	return result, portFound
+}
+
+// isEndpointReady returns true if the endpoint is ready. A nil ready condition means the endpoint is ready.
+func isEndpointReady(conditions discovery_v1.EndpointConditions) bool {
+	return conditions.Ready == nil || *conditions.Ready
+}
+
+// isEndpointServingAndTerminating returns true if the endpoint is terminating but still able to serve traffic.
+// A nil serving condition has the same meaning as the ready condition.
+func isEndpointServingAndTerminating(conditions discovery_v1.EndpointConditions) bool {
+	terminating := conditions.Terminating != nil && *conditions.Terminating
+	serving := isEndpointReady(conditions)
+	if conditions.Serving != nil {
+		serving = *conditions.Serving
+	}
+	return terminating && serving
+}
+
 func getEndpointsBySubselectedPods(targetPort int32, pods []*api_v1.Pod, svcEps api_v1.Endpoints) (endps []podEndpoint) {
 	for _, pod := range pods {
 		for _, subset := range svcEps.Subsets {
@@ -3059,7 +3997,13 @@ func (lbc *LoadBalancerController) getExternalEndpointsForIngressBackend(backend
 }
 
 func (lbc *LoadBalancerController) getEndpointsForIngressBackend(backend *networking.IngressBackend, svc *api_v1.Service) (result []podEndpoint, isExternal bool, err error) {
-	endps, err := lbc.endpointLister.GetServiceEndpoints(svc)
+	var endps api_v1.Endpoints
+	var slices []discovery_v1.EndpointSlice
+	if lbc.areEndpointSlicesEnabled {
+		slices, err = lbc.endpointSliceLister.GetServiceEndpointSlices(svc)
+	} else {
+		endps, err = lbc.endpointLister.GetServiceEndpoints(svc)
+	}
 	if err != nil {
 		if svc.Spec.Type == api_v1.ServiceTypeExternalName {
 			if !lbc.isNginxPlus {
@@ -3072,7 +4016,11 @@ func (lbc *LoadBalancerController) getEndpointsForIngressBackend(backend *networ
 		return nil, false, err
 	}
 
-	result, err = lbc.getEndpointsForPort(endps, backend.Service.Port, svc)
+	if lbc.areEndpointSlicesEnabled {
+		result, err = lbc.getEndpointsForPortFromEndpointSlices(slices, backend.Service.Port, svc)
+	} else {
+		result, err = lbc.getEndpointsForPort(endps, backend.Service.Port, svc)
+	}
 	if err != nil {
 		glog.V(3).Infof("Error getting endpoints for service %s port %v: %v", svc.Name, configs.GetBackendPortAsString(backend.Service.Port), err)
 		return nil, false, err
@@ -3080,7 +4028,7 @@ func (lbc *LoadBalancerController) getEndpointsForIngressBackend(backend *networ
 	return result, false, nil
 }
 
-func (lbc *LoadBalancerController) getEndpointsForPort(endps api_v1.Endpoints, backendPort networking.ServiceBackendPort, svc *api_v1.Service) ([]podEndpoint, error) {
+func (lbc *LoadBalancerController) getTargetPortForIngressPort(backendPort networking.ServiceBackendPort, svc *api_v1.Service) (int32, error) {
 	var targetPort int32
 	var err error
 
@@ -3088,14 +4036,51 @@ func (lbc *LoadBalancerController) getEndpointsForPort(endps api_v1.Endpoints, b
 		if (backendPort.Name == "" && port.Port == backendPort.Number) || port.Name == backendPort.Name {
 			targetPort, err = lbc.getTargetPort(port, svc)
 			if err != nil {
-				return nil, fmt.Errorf("Error determining target port for port %v in Ingress: %w", backendPort, err)
+				return 0, fmt.Errorf("Error determining target port for port %v in Ingress: %w", backendPort, err)
 			}
 			break
 		}
 	}
 
 	if targetPort == 0 {
-		return nil, fmt.Errorf("No port %v in service %s", backendPort, svc.Name)
+		return 0, fmt.Errorf("No port %v in service %s", backendPort, svc.Name)
+	}
+
+	return targetPort, nil
+}
+
+func (lbc *LoadBalancerController) getEndpointsForPortFromEndpointSlices(slices []discovery_v1.EndpointSlice, backendPort networking.ServiceBackendPort, svc *api_v1.Service) ([]podEndpoint, error) {
+	targetPort, err := lbc.getTargetPortForIngressPort(backendPort, svc)
+	if err != nil {
+		return nil, err
+	}
+
+	sliceEndps, portFound := getEndpointSliceEndpointsForPort(slices, targetPort, getServiceAddressType(svc))
+	if !portFound {
+		return nil, fmt.Errorf("No endpoints for target port %v in service %s", targetPort, svc.Name)
+	}
+
+	var endpoints []podEndpoint
+	for _, endp := range sliceEndps {
+		podEnd := podEndpoint{
+			Address: net.JoinHostPort(endp.IP, strconv.Itoa(int(endp.Port))),
+		}
+		if endp.TargetRef != nil {
+			parentType, parentName := lbc.getPodOwnerTypeAndNameFromAddress(endp.TargetRef.Namespace, endp.TargetRef.Name)
+			podEnd.OwnerType = parentType
+			podEnd.OwnerName = parentName
+			podEnd.PodName = endp.TargetRef.Name
+		}
+		endpoints = append(endpoints, podEnd)
+	}
+
+	return endpoints, nil
+}
+
+func (lbc *LoadBalancerController) getEndpointsForPort(endps api_v1.Endpoints, backendPort networking.ServiceBackendPort, svc *api_v1.Service) ([]podEndpoint, error) {
+	targetPort, err := lbc.getTargetPortForIngressPort(backendPort, svc)
+	if err != nil {
+		return nil, err
 	}
 
 	for _, subset := range endps.Subsets {
@@ -3223,7 +4208,7 @@ func (lbc *LoadBalancerController) HasCorrectIngressClass(obj interface{}) bool
 		class = obj.Spec.IngressClass
 	case *conf_v1.VirtualServerRoute:
 		class = obj.Spec.IngressClass
-	case *conf_v1alpha1.TransportServer:
+	case *conf_v1.TransportServer:
 		class = obj.Spec.IngressClass
 	case *conf_v1.Policy:
 		class = obj.Spec.IngressClass
@@ -17,12 +17,13 @@ import (
 	"github.com/nginxinc/kubernetes-ingress/internal/metrics/collectors"
 	"github.com/nginxinc/kubernetes-ingress/internal/nginx"
 	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
-	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
 	api_v1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
+	discovery_v1 "k8s.io/api/discovery/v1"
 	networking "k8s.io/api/networking/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/tools/cache"
@@ -161,7 +162,7 @@ func deepCopyWithIngressClass(obj interface{}, class string) interface{} {
 		objCopy := obj.DeepCopy()
 		objCopy.Spec.IngressClass = class
 		return objCopy
-	case *conf_v1alpha1.TransportServer:
+	case *conf_v1.TransportServer:
 		objCopy := obj.DeepCopy()
 		objCopy.Spec.IngressClass = class
 		return objCopy
@@ -204,7 +205,7 @@ func TestIngressClassForCustomResources(t *testing.T) {
 	resources := []interface{}{
 		&conf_v1.VirtualServer{},
 		&conf_v1.VirtualServerRoute{},
-		&conf_v1alpha1.TransportServer{},
+		&conf_v1.TransportServer{},
 	}
 
 	for _, r := range resources {
@@ -544,6 +545,272 @@ func TestGetEndpointsBySubselectedPods(t *testing.T) {
 	}
 }
 
+func TestGetEndpointsBySubselectedPodsFromEndpointSlices(t *testing.T) {
+	boolPointer := func(b bool) *bool { return &b }
+	int32Pointer := func(i int32) *int32 { return &i }
+	tests := []struct {
+		desc        string
+		targetPort  int32
+		addressType discovery_v1.AddressType
+		expectedEps []podEndpoint
+	}{
+		{
+			desc:        "find one endpoint",
+			targetPort:  80,
+			addressType: discovery_v1.AddressTypeIPv4,
+			expectedEps: []podEndpoint{
+				{
+					Address: "1.2.3.4:80",
+					PodName: "pod-1",
+					MeshPodOwner: configs.MeshPodOwner{
+						OwnerType: "deployment",
+						OwnerName: "deploy-1",
+					},
+				},
+			},
+		},
+		{
+			desc:        "find one IPv6 endpoint",
+			targetPort:  80,
+			addressType: discovery_v1.AddressTypeIPv6,
+			expectedEps: []podEndpoint{
+				{
+					Address: "[fd00::1]:80",
+					PodName: "pod-1",
+					MeshPodOwner: configs.MeshPodOwner{
+						OwnerType: "deployment",
+						OwnerName: "deploy-1",
+					},
+				},
+			},
+		},
+		{
+			desc:        "targetPort mismatch",
+			targetPort:  21,
+			addressType: discovery_v1.AddressTypeIPv4,
+			expectedEps: nil,
+		},
+	}
+
+	pods := []*v1.Pod{
+		{
+			ObjectMeta: meta_v1.ObjectMeta{
+				OwnerReferences: []meta_v1.OwnerReference{
+					{
+						Kind:       "Deployment",
+						Name:       "deploy-1",
+						Controller: boolPointer(true),
+					},
+				},
+			},
+			Status: v1.PodStatus{
+				PodIP: "1.2.3.4",
+				PodIPs: []v1.PodIP{
+					{IP: "1.2.3.4"},
+					{IP: "fd00::1"},
+				},
+			},
+		},
+	}
+
+	targetRef := &v1.ObjectReference{
+		Namespace: "default",
+		Name:      "pod-1",
+	}
+
+	slices := []discovery_v1.EndpointSlice{
+		{
+			AddressType: discovery_v1.AddressTypeIPv4,
+			Endpoints: []discovery_v1.Endpoint{
+				{
+					Addresses: []string{"1.2.3.4"},
+					TargetRef: targetRef,
+				},
+			},
+			Ports: []discovery_v1.EndpointPort{
+				{
+					Port: int32Pointer(80),
+				},
+			},
+		},
+		{
+			AddressType: discovery_v1.AddressTypeIPv6,
+			Endpoints: []discovery_v1.Endpoint{
+				{
+					Addresses: []string{"fd00::1"},
+					TargetRef: targetRef,
+				},
+			},
+			Ports: []discovery_v1.EndpointPort{
+				{
+					Port: int32Pointer(80),
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			gotEndps := getEndpointsBySubselectedPodsFromEndpointSlices(test.targetPort, pods, slices, test.addressType)
+			if !reflect.DeepEqual(gotEndps, test.expectedEps) {
+				t.Errorf("getEndpointsBySubselectedPodsFromEndpointSlices() = %v, want %v", gotEndps, test.expectedEps)
+			}
+		})
+	}
+}
+
+func TestGetEndpointSliceEndpointsForPort(t *testing.T) {
+	boolPointer := func(b bool) *bool { return &b }
+	int32Pointer := func(i int32) *int32 { return &i }
+
+	createSlice := func(addressType discovery_v1.AddressType, port int32, endpoints ...discovery_v1.Endpoint) discovery_v1.EndpointSlice {
+		return discovery_v1.EndpointSlice{
+			AddressType: addressType,
+			Endpoints:   endpoints,
+			Ports: []discovery_v1.EndpointPort{
+				{
+					Port: int32Pointer(port),
+				},
+			},
+		}
+	}
+	createEndpoint := func(ip string, ready, serving, terminating *bool) discovery_v1.Endpoint {
+		return discovery_v1.Endpoint{
+			Addresses: []string{ip},
+			Conditions: discovery_v1.EndpointConditions{
+				Ready:       ready,
+				Serving:     serving,
+				Terminating: terminating,
+			},
+		}
+	}
+
+	tests := []struct {
+		slices            []discovery_v1.EndpointSlice
+		addressType       discovery_v1.AddressType
+		expectedIPs       []string
+		expectedPortFound bool
+		msg               string
+	}{
+		{
+			slices: []discovery_v1.EndpointSlice{
+				createSlice(discovery_v1.AddressTypeIPv4, 80,
+					createEndpoint("10.0.0.2", nil, nil, nil),
+					createEndpoint("10.0.0.1", boolPointer(true), nil, nil),
+					createEndpoint("10.0.0.3", boolPointer(false), boolPointer(false), nil),
+				),
+			},
+			addressType:       discovery_v1.AddressTypeIPv4,
+			expectedIPs:       []string{"10.0.0.1", "10.0.0.2"},
+			expectedPortFound: true,
+			msg:               "ready endpoints",
+		},
+		{
+			slices: []discovery_v1.EndpointSlice{
+				createSlice(discovery_v1.AddressTypeIPv4, 80,
+					createEndpoint("10.0.0.1", boolPointer(true), boolPointer(true), boolPointer(false)),
+					createEndpoint("10.0.0.2", boolPointer(false), boolPointer(true), boolPointer(true)),
+				),
+			},
+			addressType:       discovery_v1.AddressTypeIPv4,
+			expectedIPs:       []string{"10.0.0.1"},
+			expectedPortFound: true,
+			msg:               "ready endpoints are preferred over serving terminating endpoints",
+		},
+		{
+			slices: []discovery_v1.EndpointSlice{
+				createSlice(discovery_v1.AddressTypeIPv4, 80,
+					createEndpoint("10.0.0.1", boolPointer(false), boolPointer(true), boolPointer(true)),
+					createEndpoint("10.0.0.2", boolPointer(false), boolPointer(false), boolPointer(true)),
+					createEndpoint("10.0.0.3", boolPointer(false), boolPointer(true), boolPointer(false)),
+				),
+			},
+			addressType:       discovery_v1.AddressTypeIPv4,
+			expectedIPs:       []string{"10.0.0.1"},
+			expectedPortFound: true,
+			msg:               "serving terminating endpoints when no endpoints are ready",
+		},
+		{
+			slices: []discovery_v1.EndpointSlice{
+				createSlice(discovery_v1.AddressTypeIPv4, 80, createEndpoint("10.0.0.1", nil, nil, nil)),
+				createSlice(discovery_v1.AddressTypeIPv6, 80, createEndpoint("fd00::1", nil, nil, nil)),
+			},
+			addressType:       discovery_v1.AddressTypeIPv6,
+			expectedIPs:       []string{"fd00::1"},
+			expectedPortFound: true,
+			msg:               "dual-stack",
+		},
+		{
+			slices: []discovery_v1.EndpointSlice{
+				createSlice(discovery_v1.AddressTypeIPv4, 80, createEndpoint("10.0.0.1", nil, nil, nil)),
+				createSlice(discovery_v1.AddressTypeIPv4, 80, createEndpoint("10.0.0.1", nil, nil, nil)),
+			},
+			addressType:       discovery_v1.AddressTypeIPv4,
+			expectedIPs:       []string{"10.0.0.1"},
+			expectedPortFound: true,
+			msg:               "duplicated endpoint",
+		},
+		{
+			slices: []discovery_v1.EndpointSlice{
+				createSlice(discovery_v1.AddressTypeIPv4, 8080, createEndpoint("10.0.0.1", nil, nil, nil)),
+			},
+			addressType:       discovery_v1.AddressTypeIPv4,
+			expectedIPs:       nil,
+			expectedPortFound: false,
+			msg:               "no target port",
+		},
+	}
+
+	for _, test := range tests {
+		endps, portFound := getEndpointSliceEndpointsForPort(test.slices, 80, test.addressType)
+
+		var ips []string
+		for _, e := range endps {
+			ips = append(ips, e.IP)
+		}
+
+		if !reflect.DeepEqual(ips, test.expectedIPs) {
+			t.Errorf("getEndpointSliceEndpointsForPort() returned IPs %v but expected %v for the case of %s", ips, test.expectedIPs, test.msg)
+		}
+		if portFound != test.expectedPortFound {
+			t.Errorf("getEndpointSliceEndpointsForPort() returned %v but expected %v for the case of %s", portFound, test.expectedPortFound, test.msg)
+		}
+	}
+}
+
+func TestGetServiceAddressType(t *testing.T) {
+	tests := []struct {
+		ipFamilies []v1.IPFamily
+		expected   discovery_v1.AddressType
+	}{
+		{
+			ipFamilies: nil,
+			expected:   discovery_v1.AddressTypeIPv4,
+		},
+		{
+			ipFamilies: []v1.IPFamily{v1.IPv4Protocol, v1.IPv6Protocol},
+			expected:   discovery_v1.AddressTypeIPv4,
+		},
+		{
+			ipFamilies: []v1.IPFamily{v1.IPv6Protocol, v1.IPv4Protocol},
+			expected:   discovery_v1.AddressTypeIPv6,
+		},
+	}
+
+	for _, test := range tests {
+		svc := &v1.Service{
+			Spec: v1.ServiceSpec{
+				IPFamilies: test.ipFamilies,
+			},
+		}
+
+		result := getServiceAddressType(svc)
+		if result != test.expected {
+			t.Errorf("getServiceAddressType() returned %v but expected %v for IP families %v", result, test.expected, test.ipFamilies)
+		}
+	}
+}
+
 func TestGetStatusFromEventTitle(t *testing.T) {
 	tests := []struct {
 		eventTitle string
@@ -682,7 +949,7 @@ func TestGetPolicies(t *testing.T) {
 
 	expectedPolicies := []*conf_v1.Policy{validPolicy}
 	expectedErrors := []error{
-		errors.New("Policy default/invalid-policy is invalid: spec: Invalid value: \"\": must specify exactly one of: `accessControl`, `rateLimit`, `ingressMTLS`, `egressMTLS`, `jwt`, `oidc`, `waf`"),
+		errors.New("Policy default/invalid-policy is invalid: spec: Invalid value: \"\": must specify exactly one of: `accessControl`, `rateLimit`, `connectionLimit`, `ingressMTLS`, `egressMTLS`, `basicAuth`, `externalAuth`, `cors`, `cache`, `retry`, `jwt`, `oidc`, `waf`"),
 		errors.New("Policy nginx-ingress/valid-policy doesn't exist"),
 		errors.New("Failed to get policy nginx-ingress/some-policy: GetByKey error"),
 		errors.New("referenced policy default/valid-policy-ingress-class has incorrect ingress class: test-class (controller ingress class: )"),
@@ -1096,6 +1363,98 @@ func TestFindPoliciesForSecret(t *testing.T) {
 	}
 }
 
+func TestFindPoliciesForService(t *testing.T) {
+	extAuthPol1 := &conf_v1.Policy{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "external-auth-policy",
+			Namespace: "default",
+		},
+		Spec: conf_v1.PolicySpec{
+			ExternalAuth: &conf_v1.ExternalAuth{
+				AuthServiceName: "authz",
+				AuthServicePort: 8080,
+			},
+		},
+	}
+	extAuthPol2 := &conf_v1.Policy{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "external-auth-policy",
+			Namespace: "ns-1",
+		},
+		Spec: conf_v1.PolicySpec{
+			ExternalAuth: &conf_v1.ExternalAuth{
+				AuthServiceName: "authz",
+				AuthServicePort: 8080,
+			},
+		},
+	}
+	extAuthURLPol := &conf_v1.Policy{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "external-auth-url-policy",
+			Namespace: "default",
+		},
+		Spec: conf_v1.PolicySpec{
+			ExternalAuth: &conf_v1.ExternalAuth{
+				AuthURL: "https://auth.example.com/check",
+			},
+		},
+	}
+	jwtPol := &conf_v1.Policy{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "jwt-policy",
+			Namespace: "default",
+		},
+		Spec: conf_v1.PolicySpec{
+			JWTAuth: &conf_v1.JWTAuth{
+				Secret: "authz",
+			},
+		},
+	}
+
+	tests := []struct {
+		policies     []*conf_v1.Policy
+		svcNamespace string
+		svcName      string
+		expected     []*conf_v1.Policy
+		msg          string
+	}{
+		{
+			policies:     []*conf_v1.Policy{extAuthPol1},
+			svcNamespace: "default",
+			svcName:      "authz",
+			expected:     []*conf_v1.Policy{extAuthPol1},
+			msg:          "Find policy in default ns",
+		},
+		{
+			policies:     []*conf_v1.Policy{extAuthPol2},
+			svcNamespace: "default",
+			svcName:      "authz",
+			expected:     nil,
+			msg:          "Ignore policies in other namespaces",
+		},
+		{
+			policies:     []*conf_v1.Policy{extAuthPol1, extAuthURLPol, jwtPol},
+			svcNamespace: "default",
+			svcName:      "authz",
+			expected:     []*conf_v1.Policy{extAuthPol1},
+			msg:          "Find policy in default ns, ignore other types",
+		},
+		{
+			policies:     []*conf_v1.Policy{extAuthPol1},
+			svcNamespace: "default",
+			svcName:      "other-svc",
+			expected:     nil,
+			msg:          "Ignore policies for other services",
+		},
+	}
+	for _, test := range tests {
+		result := findPoliciesForService(test.policies, test.svcNamespace, test.svcName)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("findPoliciesForService() '%v' mismatch (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
 func errorComparer(e1, e2 error) bool {
 	if e1 == nil || e2 == nil {
 		return errors.Is(e1, e2)
@@ -1228,6 +1587,112 @@ func TestAddJWTSecrets(t *testing.T) {
 	}
 }
 
+func TestAddBasicSecrets(t *testing.T) {
+	invalidErr := errors.New("invalid")
+	validBasicSecret := &v1.Secret{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "valid-basic-auth-secret",
+			Namespace: "default",
+		},
+		Type: secrets.SecretTypeHtpasswd,
+	}
+	invalidBasicSecret := &v1.Secret{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "invalid-basic-auth-secret",
+			Namespace: "default",
+		},
+		Type: secrets.SecretTypeHtpasswd,
+	}
+
+	tests := []struct {
+		policies           []*conf_v1.Policy
+		expectedSecretRefs map[string]*secrets.SecretReference
+		wantErr            bool
+		msg                string
+	}{
+		{
+			policies: []*conf_v1.Policy{
+				{
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      "basic-auth-policy",
+						Namespace: "default",
+					},
+					Spec: conf_v1.PolicySpec{
+						BasicAuth: &conf_v1.BasicAuth{
+							Secret: "valid-basic-auth-secret",
+							Realm:  "My API",
+						},
+					},
+				},
+			},
+			expectedSecretRefs: map[string]*secrets.SecretReference{
+				"default/valid-basic-auth-secret": {
+					Secret: validBasicSecret,
+					Path:   "/etc/nginx/secrets/default-valid-basic-auth-secret",
+				},
+			},
+			wantErr: false,
+			msg:     "test getting valid secret",
+		},
+		{
+			policies:           []*conf_v1.Policy{},
+			expectedSecretRefs: map[string]*secrets.SecretReference{},
+			wantErr:            false,
+			msg:                "test getting valid secret with no policy",
+		},
+		{
+			policies: []*conf_v1.Policy{
+				{
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      "basic-auth-policy",
+						Namespace: "default",
+					},
+					Spec: conf_v1.PolicySpec{
+						BasicAuth: &conf_v1.BasicAuth{
+							Secret: "invalid-basic-auth-secret",
+							Realm:  "My API",
+						},
+					},
+				},
+			},
+			expectedSecretRefs: map[string]*secrets.SecretReference{
+				"default/invalid-basic-auth-secret": {
+					Secret: invalidBasicSecret,
+					Error:  invalidErr,
+				},
+			},
+			wantErr: true,
+			msg:     "test getting invalid secret",
+		},
+	}
+
+	lbc := LoadBalancerController{
+		secretStore: secrets.NewFakeSecretsStore(map[string]*secrets.SecretReference{
+			"default/valid-basic-auth-secret": {
+				Secret: validBasicSecret,
+				Path:   "/etc/nginx/secrets/default-valid-basic-auth-secret",
+			},
+			"default/invalid-basic-auth-secret": {
+				Secret: invalidBasicSecret,
+				Error:  invalidErr,
+			},
+		}),
+	}
+
+	for _, test := range tests {
+		result := make(map[string]*secrets.SecretReference)
+
+		err := lbc.addBasicSecretRefs(result, test.policies)
+		if (err != nil) != test.wantErr {
+			t.Errorf("addBasicSecretRefs() returned %v, for the case of %v", err, test.msg)
+		}
+
+		if diff := cmp.Diff(test.expectedSecretRefs, result, cmp.Comparer(errorComparer)); diff != "" {
+			t.Errorf("addBasicSecretRefs() '%v' mismatch (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
 func TestAddIngressMTLSSecret(t *testing.T) {
 	invalidErr := errors.New("invalid")
 	validSecret := &v1.Secret{
@@ -2003,7 +2468,7 @@ func TestPreSyncSecrets(t *testing.T) {
 		},
 	}
 
-	lbc.preSyncSecrets()
+	lbc.preSyncSecrets(api_v1.NamespaceAll)
 
 	supportedKey := "default/supported-secret"
 	ref := lbc.secretStore.GetSecret(supportedKey)
@@ -2017,3 +2482,26 @@ func TestPreSyncSecrets(t *testing.T) {
 		t.Errorf("GetSecret(%q) returned a reference without an expected error", unsupportedKey)
 	}
 }
+
+func TestGetOperationErrorForResource(t *testing.T) {
+	invalidVS := &conf_v1.VirtualServer{ObjectMeta: meta_v1.ObjectMeta{Name: "invalid", Namespace: "default"}}
+	validVS := &conf_v1.VirtualServer{ObjectMeta: meta_v1.ObjectMeta{Name: "valid", Namespace: "default"}}
+
+	invalidResourcesErr := fmt.Errorf("Error when reloading NGINX: %w", &configs.InvalidResourcesError{
+		Errors: map[runtime.Object][]string{
+			invalidVS: {"[emerg] unknown directive"},
+		},
+	})
+
+	if err := getOperationErrorForResource(invalidVS, invalidResourcesErr); err == nil {
+		t.Errorf("getOperationErrorForResource() returned no error for the excluded VirtualServer")
+	}
+	if err := getOperationErrorForResource(validVS, invalidResourcesErr); err != nil {
+		t.Errorf("getOperationErrorForResource() returned %v for a VirtualServer that was not excluded", err)
+	}
+
+	reloadErr := errors.New("nginx reload failed")
+	if err := getOperationErrorForResource(validVS, reloadErr); !errors.Is(err, reloadErr) {
+		t.Errorf("getOperationErrorForResource() returned %v but expected %v", err, reloadErr)
+	}
+}
@@ -0,0 +1,131 @@
+package k8s
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/golang/glog"
+	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
+	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
+	apiextensions_v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// maxConversionReviewSize limits the size of the body of a ConversionReview request.
+// Unlike an AdmissionReview, a ConversionReview includes all resources of a list request.
+const maxConversionReviewSize = 64 * 1024 * 1024
+
+// ServeConversionReview handles the ConversionReview requests sent by the Kubernetes API server to the conversion webhook
+// of the TransportServer and GlobalConfiguration resources. The resources are converted between the v1alpha1 and v1 versions.
+func ServeConversionReview(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxConversionReviewSize))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error reading the request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	var review apiextensions_v1.ConversionReview
+	err = json.Unmarshal(body, &review)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error decoding the ConversionReview: %v", err), http.StatusBadRequest)
+		return
+	}
+	if review.Request == nil {
+		http.Error(w, "ConversionReview has no request", http.StatusBadRequest)
+		return
+	}
+
+	review.Response = reviewConversionRequest(review.Request)
+	review.Request = nil
+
+	resp, err := json.Marshal(review)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error encoding the ConversionReview: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(resp)
+	if err != nil {
+		glog.Errorf("Error writing the ConversionReview response: %v", err)
+	}
+}
+
+func reviewConversionRequest(req *apiextensions_v1.ConversionRequest) *apiextensions_v1.ConversionResponse {
+	resp := &apiextensions_v1.ConversionResponse{
+		UID: req.UID,
+		Result: meta_v1.Status{
+			Status: meta_v1.StatusSuccess,
+		},
+	}
+
+	for _, obj := range req.Objects {
+		converted, err := convertObject(obj.Raw, req.DesiredAPIVersion)
+		if err != nil {
+			glog.V(3).Infof("Failed to convert an object to %v: %v", req.DesiredAPIVersion, err)
+			// the API server ignores the converted objects of a failed conversion
+			resp.ConvertedObjects = nil
+			resp.Result = meta_v1.Status{
+				Status:  meta_v1.StatusFailure,
+				Message: err.Error(),
+			}
+			return resp
+		}
+
+		resp.ConvertedObjects = append(resp.ConvertedObjects, runtime.RawExtension{Raw: converted})
+	}
+
+	return resp
+}
+
+func convertObject(raw []byte, desiredAPIVersion string) ([]byte, error) {
+	var typeMeta meta_v1.TypeMeta
+	err := json.Unmarshal(raw, &typeMeta)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding the object: %w", err)
+	}
+
+	if typeMeta.APIVersion == desiredAPIVersion {
+		return raw, nil
+	}
+
+	v1alpha1Version := conf_v1alpha1.SchemeGroupVersion.String()
+	v1Version := conf_v1.SchemeGroupVersion.String()
+
+	switch {
+	case typeMeta.Kind == "TransportServer" && typeMeta.APIVersion == v1alpha1Version && desiredAPIVersion == v1Version:
+		var ts conf_v1alpha1.TransportServer
+		if err := json.Unmarshal(raw, &ts); err != nil {
+			return nil, fmt.Errorf("error decoding %v %v: %w", typeMeta.Kind, typeMeta.APIVersion, err)
+		}
+		return json.Marshal(conf_v1alpha1.ConvertTransportServerToV1(&ts))
+	case typeMeta.Kind == "TransportServer" && typeMeta.APIVersion == v1Version && desiredAPIVersion == v1alpha1Version:
+		var ts conf_v1.TransportServer
+		if err := json.Unmarshal(raw, &ts); err != nil {
+			return nil, fmt.Errorf("error decoding %v %v: %w", typeMeta.Kind, typeMeta.APIVersion, err)
+		}
+		return json.Marshal(conf_v1alpha1.ConvertTransportServerFromV1(&ts))
+	case typeMeta.Kind == "GlobalConfiguration" && typeMeta.APIVersion == v1alpha1Version && desiredAPIVersion == v1Version:
+		var gc conf_v1alpha1.GlobalConfiguration
+		if err := json.Unmarshal(raw, &gc); err != nil {
+			return nil, fmt.Errorf("error decoding %v %v: %w", typeMeta.Kind, typeMeta.APIVersion, err)
+		}
+		return json.Marshal(conf_v1alpha1.ConvertGlobalConfigurationToV1(&gc))
+	case typeMeta.Kind == "GlobalConfiguration" && typeMeta.APIVersion == v1Version && desiredAPIVersion == v1alpha1Version:
+		var gc conf_v1.GlobalConfiguration
+		if err := json.Unmarshal(raw, &gc); err != nil {
+			return nil, fmt.Errorf("error decoding %v %v: %w", typeMeta.Kind, typeMeta.APIVersion, err)
+		}
+		return json.Marshal(conf_v1alpha1.ConvertGlobalConfigurationFromV1(&gc))
+	}
+
+	return nil, fmt.Errorf("conversion of %v %v to %v is not supported", typeMeta.Kind, typeMeta.APIVersion, desiredAPIVersion)
+}
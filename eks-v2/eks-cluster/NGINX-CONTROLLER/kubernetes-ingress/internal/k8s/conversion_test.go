@@ -0,0 +1,356 @@
+package k8s
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
+	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
+	apiextensions_v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func createTestV1alpha1TransportServer() *conf_v1alpha1.TransportServer {
+	maxFails := 3
+	maxConns := 100
+	verifyDepth := 2
+
+	return &conf_v1alpha1.TransportServer{
+		TypeMeta: meta_v1.TypeMeta{
+			APIVersion: "k8s.nginx.org/v1alpha1",
+			Kind:       "TransportServer",
+		},
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "secure-app",
+			Namespace: "default",
+			Labels: map[string]string{
+				"app": "secure-app",
+			},
+		},
+		Spec: conf_v1alpha1.TransportServerSpec{
+			IngressClass: "nginx",
+			Listener: conf_v1alpha1.TransportServerListener{
+				Name:     "tcp-7777",
+				Protocol: "TCP",
+			},
+			ServerSnippets: "deny 192.168.1.1;",
+			StreamSnippets: "limit_conn_zone $binary_remote_addr zone=addr:10m;",
+			Host:           "app.example.com",
+			TLS: &conf_v1alpha1.TransportServerTLS{
+				Secret: "app-secret",
+			},
+			Upstreams: []conf_v1alpha1.Upstream{
+				{
+					Name:                "app-v1",
+					Service:             "app-v1-svc",
+					Port:                8443,
+					FailTimeout:         "10s",
+					MaxFails:            &maxFails,
+					MaxConns:            &maxConns,
+					LoadBalancingMethod: "least_conn",
+					TLS: &conf_v1alpha1.UpstreamTLS{
+						Enable:            true,
+						VerifyServer:      true,
+						TrustedCertSecret: "app-ca",
+						VerifyDepth:       &verifyDepth,
+					},
+				},
+				{
+					Name:    "app-v2",
+					Service: "app-v2-svc",
+					Port:    8443,
+					HealthCheck: &conf_v1alpha1.HealthCheck{
+						Enabled:  true,
+						Interval: "5s",
+						Passes:   2,
+						Fails:    3,
+						Match: &conf_v1alpha1.Match{
+							Send:   "GET / HTTP/1.0\r\n",
+							Expect: "~200 OK",
+						},
+					},
+				},
+			},
+			UpstreamParameters: &conf_v1alpha1.UpstreamParameters{
+				ConnectTimeout:      "30s",
+				NextUpstream:        true,
+				NextUpstreamTimeout: "10s",
+				NextUpstreamTries:   2,
+			},
+			SessionParameters: &conf_v1alpha1.SessionParameters{
+				Timeout: "50s",
+			},
+			Action: &conf_v1alpha1.Action{
+				Splits: []conf_v1alpha1.Split{
+					{
+						Weight: 80,
+						Pass:   "app-v1",
+					},
+					{
+						Weight: 20,
+						Pass:   "app-v2",
+					},
+				},
+				Matches: []conf_v1alpha1.SNIMatch{
+					{
+						SNI:  "v2.example.com",
+						Pass: "app-v2",
+					},
+				},
+			},
+			Policies: []conf_v1.PolicyReference{
+				{
+					Name: "allow-list",
+				},
+			},
+		},
+		Status: conf_v1alpha1.TransportServerStatus{
+			State:   "Valid",
+			Reason:  "AddedOrUpdated",
+			Message: "Configuration for default/secure-app was added or updated",
+		},
+	}
+}
+
+func createTestV1alpha1GlobalConfiguration() *conf_v1alpha1.GlobalConfiguration {
+	return &conf_v1alpha1.GlobalConfiguration{
+		TypeMeta: meta_v1.TypeMeta{
+			APIVersion: "k8s.nginx.org/v1alpha1",
+			Kind:       "GlobalConfiguration",
+		},
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "nginx-configuration",
+			Namespace: "nginx-ingress",
+		},
+		Spec: conf_v1alpha1.GlobalConfigurationSpec{
+			Listeners: []conf_v1alpha1.Listener{
+				{
+					Name:     "tcp-7777",
+					Port:     7777,
+					Protocol: "TCP",
+				},
+				{
+					Name:     "udp-5353",
+					Port:     5353,
+					Protocol: "UDP",
+				},
+			},
+		},
+	}
+}
+
+func TestConvertTransportServerRoundTrip(t *testing.T) {
+	ts := createTestV1alpha1TransportServer()
+
+	tsV1 := conf_v1alpha1.ConvertTransportServerToV1(ts)
+	if tsV1.APIVersion != "k8s.nginx.org/v1" {
+		t.Errorf("ConvertTransportServerToV1() returned apiVersion %q but expected %q", tsV1.APIVersion, "k8s.nginx.org/v1")
+	}
+
+	result := conf_v1alpha1.ConvertTransportServerFromV1(tsV1)
+	if diff := cmp.Diff(ts, result); diff != "" {
+		t.Errorf("TransportServer round trip through v1 returned unexpected result (-want +got):\n%s", diff)
+	}
+}
+
+func TestConvertGlobalConfigurationRoundTrip(t *testing.T) {
+	gc := createTestV1alpha1GlobalConfiguration()
+
+	gcV1 := conf_v1alpha1.ConvertGlobalConfigurationToV1(gc)
+	if gcV1.APIVersion != "k8s.nginx.org/v1" {
+		t.Errorf("ConvertGlobalConfigurationToV1() returned apiVersion %q but expected %q", gcV1.APIVersion, "k8s.nginx.org/v1")
+	}
+
+	result := conf_v1alpha1.ConvertGlobalConfigurationFromV1(gcV1)
+	if diff := cmp.Diff(gc, result); diff != "" {
+		t.Errorf("GlobalConfiguration round trip through v1 returned unexpected result (-want +got):\n%s", diff)
+	}
+}
+
+func TestConvertObject(t *testing.T) {
+	ts, err := json.Marshal(createTestV1alpha1TransportServer())
+	if err != nil {
+		t.Fatalf("Failed to marshal the TransportServer: %v", err)
+	}
+	gc, err := json.Marshal(createTestV1alpha1GlobalConfiguration())
+	if err != nil {
+		t.Fatalf("Failed to marshal the GlobalConfiguration: %v", err)
+	}
+
+	tests := []struct {
+		raw []byte
+		msg string
+	}{
+		{
+			raw: ts,
+			msg: "TransportServer",
+		},
+		{
+			raw: gc,
+			msg: "GlobalConfiguration",
+		},
+	}
+
+	for _, test := range tests {
+		converted, err := convertObject(test.raw, "k8s.nginx.org/v1")
+		if err != nil {
+			t.Fatalf("convertObject() returned an unexpected error %v for %s to v1", err, test.msg)
+		}
+
+		var typeMeta meta_v1.TypeMeta
+		if err := json.Unmarshal(converted, &typeMeta); err != nil {
+			t.Fatalf("Failed to unmarshal the converted object for %s: %v", test.msg, err)
+		}
+		if typeMeta.APIVersion != "k8s.nginx.org/v1" {
+			t.Errorf("convertObject() returned apiVersion %q but expected %q for %s", typeMeta.APIVersion, "k8s.nginx.org/v1", test.msg)
+		}
+
+		result, err := convertObject(converted, "k8s.nginx.org/v1alpha1")
+		if err != nil {
+			t.Fatalf("convertObject() returned an unexpected error %v for %s to v1alpha1", err, test.msg)
+		}
+		if !bytes.Equal(test.raw, result) {
+			t.Errorf("convertObject() round trip returned %s but expected %s for %s", result, test.raw, test.msg)
+		}
+	}
+}
+
+func TestConvertObjectFails(t *testing.T) {
+	tests := []struct {
+		raw               []byte
+		desiredAPIVersion string
+		msg               string
+	}{
+		{
+			raw:               []byte(`{"apiVersion":"k8s.nginx.org/v1alpha1","kind":"Policy"}`),
+			desiredAPIVersion: "k8s.nginx.org/v1",
+			msg:               "unsupported kind",
+		},
+		{
+			raw:               []byte(`{"apiVersion":"k8s.nginx.org/v1alpha1","kind":"TransportServer"}`),
+			desiredAPIVersion: "k8s.nginx.org/v2",
+			msg:               "unsupported version",
+		},
+		{
+			raw:               []byte(`{"apiVersion":"k8s.nginx.org/v1alpha1","kind":"TransportServer","spec":[]}`),
+			desiredAPIVersion: "k8s.nginx.org/v1",
+			msg:               "invalid spec",
+		},
+		{
+			raw:               []byte(`not-json`),
+			desiredAPIVersion: "k8s.nginx.org/v1",
+			msg:               "invalid object",
+		},
+	}
+
+	for _, test := range tests {
+		_, err := convertObject(test.raw, test.desiredAPIVersion)
+		if err == nil {
+			t.Errorf("convertObject() returned no error for the case of %s", test.msg)
+		}
+	}
+}
+
+func TestReviewConversionRequestFails(t *testing.T) {
+	req := &apiextensions_v1.ConversionRequest{
+		UID:               types.UID("test-uid"),
+		DesiredAPIVersion: "k8s.nginx.org/v1",
+		Objects: []runtime.RawExtension{
+			{
+				Raw: []byte(`{"apiVersion":"k8s.nginx.org/v1alpha1","kind":"GlobalConfiguration"}`),
+			},
+			{
+				Raw: []byte(`{"apiVersion":"k8s.nginx.org/v1alpha1","kind":"Policy"}`),
+			},
+		},
+	}
+
+	resp := reviewConversionRequest(req)
+
+	if resp.UID != req.UID {
+		t.Errorf("reviewConversionRequest() returned UID %q but expected %q", resp.UID, req.UID)
+	}
+	if resp.Result.Status != meta_v1.StatusFailure {
+		t.Errorf("reviewConversionRequest() returned status %q but expected %q", resp.Result.Status, meta_v1.StatusFailure)
+	}
+	if resp.ConvertedObjects != nil {
+		t.Errorf("reviewConversionRequest() returned converted objects %v but expected none", resp.ConvertedObjects)
+	}
+}
+
+func TestServeConversionReview(t *testing.T) {
+	gc, err := json.Marshal(createTestV1alpha1GlobalConfiguration())
+	if err != nil {
+		t.Fatalf("Failed to marshal the GlobalConfiguration: %v", err)
+	}
+
+	review := apiextensions_v1.ConversionReview{
+		TypeMeta: meta_v1.TypeMeta{
+			APIVersion: "apiextensions.k8s.io/v1",
+			Kind:       "ConversionReview",
+		},
+		Request: &apiextensions_v1.ConversionRequest{
+			UID:               types.UID("test-uid"),
+			DesiredAPIVersion: "k8s.nginx.org/v1",
+			Objects: []runtime.RawExtension{
+				{
+					Raw: gc,
+				},
+			},
+		},
+	}
+
+	body, err := json.Marshal(review)
+	if err != nil {
+		t.Fatalf("Failed to marshal the ConversionReview: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	ServeConversionReview(rec, httptest.NewRequest(http.MethodPost, "/convert", bytes.NewReader(body)))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("ServeConversionReview() returned status %v but expected %v", rec.Code, http.StatusOK)
+	}
+
+	var result apiextensions_v1.ConversionReview
+	err = json.Unmarshal(rec.Body.Bytes(), &result)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal the response: %v", err)
+	}
+
+	if result.Kind != "ConversionReview" || result.APIVersion != "apiextensions.k8s.io/v1" {
+		t.Errorf("ServeConversionReview() returned %v/%v but expected apiextensions.k8s.io/v1/ConversionReview", result.APIVersion, result.Kind)
+	}
+	if result.Response == nil {
+		t.Fatalf("ServeConversionReview() returned no response")
+	}
+	if result.Response.UID != "test-uid" || result.Response.Result.Status != meta_v1.StatusSuccess {
+		t.Errorf("ServeConversionReview() returned response %v but expected a successful response for test-uid", result.Response)
+	}
+	if len(result.Response.ConvertedObjects) != 1 {
+		t.Fatalf("ServeConversionReview() returned %d converted objects but expected 1", len(result.Response.ConvertedObjects))
+	}
+
+	var gcV1 conf_v1.GlobalConfiguration
+	err = json.Unmarshal(result.Response.ConvertedObjects[0].Raw, &gcV1)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal the converted object: %v", err)
+	}
+	expected := conf_v1alpha1.ConvertGlobalConfigurationToV1(createTestV1alpha1GlobalConfiguration())
+	if diff := cmp.Diff(expected, &gcV1); diff != "" {
+		t.Errorf("ServeConversionReview() returned unexpected converted object (-want +got):\n%s", diff)
+	}
+
+	rec = httptest.NewRecorder()
+	ServeConversionReview(rec, httptest.NewRequest(http.MethodPost, "/convert", bytes.NewReader([]byte("{}"))))
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("ServeConversionReview() returned status %v but expected %v for a review without a request", rec.Code, http.StatusBadRequest)
+	}
+}
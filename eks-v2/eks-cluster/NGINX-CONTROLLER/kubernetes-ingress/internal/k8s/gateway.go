@@ -0,0 +1,1624 @@
+package k8s
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
+	"github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/validation"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// GatewayControllerName holds the name of the controller that must be referenced in the controllerName field
+// of a GatewayClass for the Ingress Controller to handle the Gateways of that class.
+const GatewayControllerName = "nginx.org/gateway-controller"
+
+const gatewayAPIGroup = "gateway.networking.k8s.io"
+
+var (
+	gatewayClassGVR = schema.GroupVersionResource{
+		Group:    gatewayAPIGroup,
+		Version:  "v1alpha2",
+		Resource: "gatewayclasses",
+	}
+	gatewayClassGVK = schema.GroupVersionKind{
+		Group:   gatewayAPIGroup,
+		Version: "v1alpha2",
+		Kind:    "GatewayClass",
+	}
+	gatewayGVR = schema.GroupVersionResource{
+		Group:    gatewayAPIGroup,
+		Version:  "v1alpha2",
+		Resource: "gateways",
+	}
+	gatewayGVK = schema.GroupVersionKind{
+		Group:   gatewayAPIGroup,
+		Version: "v1alpha2",
+		Kind:    "Gateway",
+	}
+	httpRouteGVR = schema.GroupVersionResource{
+		Group:    gatewayAPIGroup,
+		Version:  "v1alpha2",
+		Resource: "httproutes",
+	}
+	httpRouteGVK = schema.GroupVersionKind{
+		Group:   gatewayAPIGroup,
+		Version: "v1alpha2",
+		Kind:    "HTTPRoute",
+	}
+	tcpRouteGVR = schema.GroupVersionResource{
+		Group:    gatewayAPIGroup,
+		Version:  "v1alpha2",
+		Resource: "tcproutes",
+	}
+	tcpRouteGVK = schema.GroupVersionKind{
+		Group:   gatewayAPIGroup,
+		Version: "v1alpha2",
+		Kind:    "TCPRoute",
+	}
+	udpRouteGVR = schema.GroupVersionResource{
+		Group:    gatewayAPIGroup,
+		Version:  "v1alpha2",
+		Resource: "udproutes",
+	}
+	udpRouteGVK = schema.GroupVersionKind{
+		Group:   gatewayAPIGroup,
+		Version: "v1alpha2",
+		Kind:    "UDPRoute",
+	}
+	tlsRouteGVR = schema.GroupVersionResource{
+		Group:    gatewayAPIGroup,
+		Version:  "v1alpha2",
+		Resource: "tlsroutes",
+	}
+	tlsRouteGVK = schema.GroupVersionKind{
+		Group:   gatewayAPIGroup,
+		Version: "v1alpha2",
+		Kind:    "TLSRoute",
+	}
+)
+
+// gatewayResourceNamePrefix is the prefix of the names of the VirtualServers and TransportServers generated
+// from the Gateway API resources. Kubernetes names can't include underscores, so the generated names
+// never clash with the names of VirtualServer and TransportServer resources.
+const gatewayResourceNamePrefix = "gateway_"
+
+// The types below mirror the subset of the Gateway API (v1alpha2) that the Ingress Controller supports.
+// The Gateway API resources are watched as unstructured objects and converted into these types.
+
+type gatewayClass struct {
+	meta_v1.ObjectMeta `json:"metadata"`
+	Spec               gatewayClassSpec `json:"spec"`
+}
+
+type gatewayClassSpec struct {
+	ControllerName string `json:"controllerName"`
+}
+
+type gateway struct {
+	meta_v1.ObjectMeta `json:"metadata"`
+	Spec               gatewaySpec `json:"spec"`
+}
+
+type gatewaySpec struct {
+	GatewayClassName string            `json:"gatewayClassName"`
+	Listeners        []gatewayListener `json:"listeners"`
+}
+
+type gatewayListener struct {
+	Name          string                `json:"name"`
+	Hostname      string                `json:"hostname,omitempty"`
+	Port          int32                 `json:"port"`
+	Protocol      string                `json:"protocol"`
+	TLS           *gatewayTLSConfig     `json:"tls,omitempty"`
+	AllowedRoutes *gatewayAllowedRoutes `json:"allowedRoutes,omitempty"`
+}
+
+type gatewayTLSConfig struct {
+	Mode            string                   `json:"mode,omitempty"`
+	CertificateRefs []gatewayObjectReference `json:"certificateRefs,omitempty"`
+}
+
+type gatewayObjectReference struct {
+	Group     string `json:"group,omitempty"`
+	Kind      string `json:"kind,omitempty"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+type gatewayAllowedRoutes struct {
+	Namespaces *gatewayRouteNamespaces `json:"namespaces,omitempty"`
+}
+
+type gatewayRouteNamespaces struct {
+	From string `json:"from,omitempty"`
+}
+
+type httpRoute struct {
+	meta_v1.ObjectMeta `json:"metadata"`
+	Spec               httpRouteSpec `json:"spec"`
+}
+
+type httpRouteSpec struct {
+	ParentRefs []gatewayParentReference `json:"parentRefs,omitempty"`
+	Hostnames  []string                 `json:"hostnames,omitempty"`
+	Rules      []httpRouteRule          `json:"rules,omitempty"`
+}
+
+type gatewayParentReference struct {
+	Group       string `json:"group,omitempty"`
+	Kind        string `json:"kind,omitempty"`
+	Namespace   string `json:"namespace,omitempty"`
+	Name        string `json:"name"`
+	SectionName string `json:"sectionName,omitempty"`
+}
+
+type httpRouteRule struct {
+	Matches     []httpRouteMatch  `json:"matches,omitempty"`
+	Filters     []httpRouteFilter `json:"filters,omitempty"`
+	BackendRefs []httpBackendRef  `json:"backendRefs,omitempty"`
+}
+
+type httpRouteMatch struct {
+	Path        *httpPathMatch   `json:"path,omitempty"`
+	Headers     []httpValueMatch `json:"headers,omitempty"`
+	QueryParams []httpValueMatch `json:"queryParams,omitempty"`
+	Method      string           `json:"method,omitempty"`
+}
+
+type httpPathMatch struct {
+	Type  string `json:"type,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+type httpValueMatch struct {
+	Type  string `json:"type,omitempty"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type httpRouteFilter struct {
+	Type                  string               `json:"type"`
+	RequestHeaderModifier *httpHeaderFilter    `json:"requestHeaderModifier,omitempty"`
+	RequestRedirect       *httpRequestRedirect `json:"requestRedirect,omitempty"`
+}
+
+type httpHeaderFilter struct {
+	Set    []httpHeader `json:"set,omitempty"`
+	Add    []httpHeader `json:"add,omitempty"`
+	Remove []string     `json:"remove,omitempty"`
+}
+
+type httpHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type httpRequestRedirect struct {
+	Scheme     string `json:"scheme,omitempty"`
+	Hostname   string `json:"hostname,omitempty"`
+	Port       int32  `json:"port,omitempty"`
+	StatusCode int    `json:"statusCode,omitempty"`
+}
+
+// l4Route mirrors TCPRoute, UDPRoute and TLSRoute, which share the same structure.
+// Hostnames are only defined for TLSRoutes.
+type l4Route struct {
+	meta_v1.ObjectMeta `json:"metadata"`
+	Spec               l4RouteSpec `json:"spec"`
+	// Kind is the kind of the route. It is not part of the spec and is set by the Ingress Controller.
+	Kind string `json:"-"`
+}
+
+type l4RouteSpec struct {
+	ParentRefs []gatewayParentReference `json:"parentRefs,omitempty"`
+	Hostnames  []string                 `json:"hostnames,omitempty"`
+	Rules      []l4RouteRule            `json:"rules,omitempty"`
+}
+
+type l4RouteRule struct {
+	BackendRefs []httpBackendRef `json:"backendRefs,omitempty"`
+}
+
+type httpBackendRef struct {
+	Group     string `json:"group,omitempty"`
+	Kind      string `json:"kind,omitempty"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	Port      int32  `json:"port,omitempty"`
+	Weight    *int32 `json:"weight,omitempty"`
+}
+
+// The types below mirror the status of the Gateway API resources.
+
+type gatewayClassStatus struct {
+	Conditions []meta_v1.Condition `json:"conditions,omitempty"`
+}
+
+type gatewayStatus struct {
+	Addresses  []gatewayAddress        `json:"addresses,omitempty"`
+	Conditions []meta_v1.Condition     `json:"conditions,omitempty"`
+	Listeners  []gatewayListenerStatus `json:"listeners,omitempty"`
+}
+
+type gatewayAddress struct {
+	Type  string `json:"type,omitempty"`
+	Value string `json:"value"`
+}
+
+type gatewayListenerStatus struct {
+	Name           string              `json:"name"`
+	SupportedKinds []gatewayRouteKind  `json:"supportedKinds"`
+	AttachedRoutes int32               `json:"attachedRoutes"`
+	Conditions     []meta_v1.Condition `json:"conditions"`
+}
+
+type gatewayRouteKind struct {
+	Group string `json:"group,omitempty"`
+	Kind  string `json:"kind"`
+}
+
+type routeStatus struct {
+	Parents []routeParentStatus `json:"parents"`
+}
+
+type routeParentStatus struct {
+	ParentRef      gatewayParentReference `json:"parentRef"`
+	ControllerName string                 `json:"controllerName"`
+	Conditions     []meta_v1.Condition    `json:"conditions,omitempty"`
+}
+
+// gatewayVirtualServer is a VirtualServer generated from the HTTPRoutes attached to a Gateway for a single host.
+type gatewayVirtualServer struct {
+	VirtualServer *conf_v1.VirtualServer
+	// TLSSecret is the namespace/name key of the Secret referenced in the HTTPS listener of the Gateway.
+	TLSSecret string
+	// Routes holds the namespace/name keys of the HTTPRoutes of the VirtualServer.
+	Routes []string
+}
+
+// gatewayTransportServer is a TransportServer generated from a TCPRoute, UDPRoute or TLSRoute.
+type gatewayTransportServer struct {
+	TransportServer *conf_v1.TransportServer
+	// Route is the kind/namespace/name key of the route of the TransportServer.
+	Route string
+}
+
+// gatewayTranslation holds the result of the translation of the Gateway API resources.
+type gatewayTranslation struct {
+	// VirtualServers is keyed by the namespace/name key of the generated VirtualServer.
+	VirtualServers map[string]*gatewayVirtualServer
+	// TransportServers is keyed by the namespace/name key of the generated TransportServer.
+	TransportServers map[string]*gatewayTransportServer
+	// Listeners holds the TCP and UDP listeners of the Gateways referenced by the generated TransportServers.
+	// They play the same role as the listeners of the GlobalConfiguration.
+	Listeners []conf_v1.Listener
+	// ClassStatuses is keyed by the name of a GatewayClass.
+	ClassStatuses map[string]*gatewayClassStatus
+	// GatewayStatuses is keyed by the namespace/name key of a Gateway.
+	GatewayStatuses map[string]*gatewayStatus
+	// RouteStatuses is keyed by the namespace/name key of an HTTPRoute.
+	RouteStatuses map[string][]routeParentStatus
+	// L4RouteStatuses is keyed by the kind/namespace/name key of a TCPRoute, UDPRoute or TLSRoute.
+	L4RouteStatuses map[string][]routeParentStatus
+}
+
+// l4ListenerValidator validates the TCP, UDP and TLS listeners of Gateways.
+// If the validator is nil, such listeners are not supported.
+type l4ListenerValidator struct {
+	globalConfigurationValidator *validation.GlobalConfigurationValidator
+	isTLSPassthroughEnabled      bool
+}
+
+func convertUnstructured(obj *unstructured.Unstructured, into interface{}) error {
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), into)
+}
+
+// attachedListener is a listener of a Gateway to which a route is attached.
+type attachedListener struct {
+	gateway  *gateway
+	listener *gatewayListener
+	// tlsSecret is set for HTTPS listeners.
+	tlsSecret string
+}
+
+// hostConfig holds the routes from a single namespace attached to listeners for the same host.
+type hostConfig struct {
+	namespace string
+	host      string
+	tlsSecret string
+	routes    []*httpRoute
+}
+
+func getGatewayResourceKey(meta *meta_v1.ObjectMeta) string {
+	if meta.Namespace == "" {
+		return meta.Name
+	}
+	return fmt.Sprintf("%s/%s", meta.Namespace, meta.Name)
+}
+
+// translateGatewayAPI translates GatewayClasses, Gateways and HTTPRoutes into VirtualServers, TCPRoutes, UDPRoutes and TLSRoutes
+// into TransportServers and computes the status of every Gateway API resource handled by the Ingress Controller.
+func translateGatewayAPI(classes []*gatewayClass, gateways []*gateway, routes []*httpRoute, l4Routes []*l4Route,
+	vsv *validation.VirtualServerValidator, lv *l4ListenerValidator,
+) *gatewayTranslation {
+	result := &gatewayTranslation{
+		VirtualServers:   make(map[string]*gatewayVirtualServer),
+		TransportServers: make(map[string]*gatewayTransportServer),
+		ClassStatuses:    make(map[string]*gatewayClassStatus),
+		GatewayStatuses:  make(map[string]*gatewayStatus),
+		RouteStatuses:    make(map[string][]routeParentStatus),
+		L4RouteStatuses:  make(map[string][]routeParentStatus),
+	}
+
+	acceptedClasses := make(map[string]bool)
+	for _, gc := range classes {
+		if gc.Spec.ControllerName != GatewayControllerName {
+			continue
+		}
+		acceptedClasses[gc.Name] = true
+		result.ClassStatuses[gc.Name] = &gatewayClassStatus{
+			Conditions: []meta_v1.Condition{
+				newGatewayCondition("Accepted", meta_v1.ConditionTrue, "Accepted", "GatewayClass is accepted", gc.Generation),
+			},
+		}
+	}
+
+	sortGateways(gateways)
+
+	gatewaysByKey := make(map[string]*gateway)
+	listenerSecrets := make(map[string]map[string]string)
+	for _, gw := range gateways {
+		if !acceptedClasses[gw.Spec.GatewayClassName] {
+			continue
+		}
+		key := getGatewayResourceKey(&gw.ObjectMeta)
+		gatewaysByKey[key] = gw
+		status, secrets := generateGatewayStatus(gw, lv)
+		result.GatewayStatuses[key] = status
+		listenerSecrets[key] = secrets
+	}
+
+	l4Listeners := buildGatewayL4Listeners(gateways, result.GatewayStatuses)
+
+	sortHTTPRoutes(routes)
+
+	hosts := make(map[string]*hostConfig)
+	var hostOrder []string
+
+	for _, route := range routes {
+		routeKey := getGatewayResourceKey(&route.ObjectMeta)
+
+		var parentStatuses []routeParentStatus
+		var attachments []attachedListener
+		for _, ref := range route.Spec.ParentRefs {
+			gwKey, ok := getGatewayKeyForParentRef(ref, route.Namespace)
+			if !ok {
+				continue
+			}
+			gw, exists := gatewaysByKey[gwKey]
+			if !exists {
+				continue
+			}
+
+			listeners, reason, msg := findListenersForRoute(gw, ref, route, listenerSecrets[gwKey])
+			status := routeParentStatus{
+				ParentRef:      ref,
+				ControllerName: GatewayControllerName,
+			}
+			if len(listeners) == 0 {
+				status.Conditions = []meta_v1.Condition{
+					newGatewayCondition("Accepted", meta_v1.ConditionFalse, reason, msg, route.Generation),
+				}
+			}
+			parentStatuses = append(parentStatuses, status)
+			attachments = append(attachments, listeners...)
+		}
+
+		if len(parentStatuses) == 0 {
+			// the route is not attached to any Gateway handled by the Ingress Controller
+			continue
+		}
+
+		resolvedRefs := newGatewayCondition("ResolvedRefs", meta_v1.ConditionTrue, "ResolvedRefs", "All references are resolved", route.Generation)
+		if reason, msg := validateHTTPRouteBackendRefs(route); reason != "" {
+			resolvedRefs = newGatewayCondition("ResolvedRefs", meta_v1.ConditionFalse, reason, msg, route.Generation)
+		}
+
+		accepted := newGatewayCondition("Accepted", meta_v1.ConditionTrue, "Accepted", "Route is accepted", route.Generation)
+		if err := validateHTTPRouteTranslation(route, vsv); err != nil {
+			accepted = newGatewayCondition("Accepted", meta_v1.ConditionFalse, "UnsupportedValue", err.Error(), route.Generation)
+			attachments = nil
+		}
+
+		routeHosts := make(map[string]bool)
+		routeListeners := make(map[*gatewayListener]bool)
+		for _, a := range attachments {
+			for _, host := range intersectGatewayHostnames(a.listener.Hostname, route.Spec.Hostnames) {
+				hc, exists := hosts[host]
+				if !exists {
+					hc = &hostConfig{
+						namespace: route.Namespace,
+						host:      host,
+					}
+					hosts[host] = hc
+					hostOrder = append(hostOrder, host)
+				} else if hc.namespace != route.Namespace {
+					// routes from different namespaces can't share the same host, because
+					// the upstreams of a VirtualServer must belong to its namespace
+					continue
+				}
+
+				if a.tlsSecret != "" && hc.tlsSecret == "" {
+					hc.tlsSecret = a.tlsSecret
+				}
+
+				if !routeHosts[host] {
+					hc.routes = append(hc.routes, route)
+					routeHosts[host] = true
+				}
+
+				if !routeListeners[a.listener] {
+					incrementAttachedRoutes(result.GatewayStatuses[getGatewayResourceKey(&a.gateway.ObjectMeta)], a.listener.Name)
+					routeListeners[a.listener] = true
+				}
+			}
+		}
+
+		for i := range parentStatuses {
+			if len(parentStatuses[i].Conditions) > 0 {
+				continue
+			}
+			cond := accepted
+			if accepted.Status == meta_v1.ConditionTrue && len(routeHosts) == 0 {
+				cond = newGatewayCondition("Accepted", meta_v1.ConditionFalse, "NoMatchingListenerHostname",
+					"No hostname of the route matches a hostname of the listeners or the hostname is used by a route in a different namespace",
+					route.Generation)
+			}
+			parentStatuses[i].Conditions = []meta_v1.Condition{cond, resolvedRefs}
+		}
+
+		result.RouteStatuses[routeKey] = parentStatuses
+	}
+
+	for _, host := range hostOrder {
+		hc := hosts[host]
+
+		builder := newGatewayVirtualServerBuilder()
+		for _, route := range hc.routes {
+			builder.addRoute(route)
+		}
+
+		vs := builder.build(hc.namespace, getGatewayVirtualServerName(host), host)
+		// the VirtualServer is as old as its oldest route, which decides whether it wins a host over other resources
+		vs.CreationTimestamp = hc.routes[0].CreationTimestamp
+
+		var routeKeys []string
+		for _, route := range hc.routes {
+			routeKeys = append(routeKeys, getGatewayResourceKey(&route.ObjectMeta))
+		}
+
+		if err := vsv.ValidateVirtualServer(vs); err != nil {
+			for _, key := range routeKeys {
+				rejectRouteParents(result.RouteStatuses[key], "UnsupportedValue",
+					fmt.Sprintf("Configuration for host %s is invalid: %v", host, err))
+			}
+			continue
+		}
+
+		result.VirtualServers[getGatewayResourceKey(&vs.ObjectMeta)] = &gatewayVirtualServer{
+			VirtualServer: vs,
+			TLSSecret:     hc.tlsSecret,
+			Routes:        routeKeys,
+		}
+	}
+
+	translateL4Routes(result, gatewaysByKey, l4Routes, l4Listeners)
+
+	return result
+}
+
+func newGatewayCondition(condType string, status meta_v1.ConditionStatus, reason string, message string, generation int64) meta_v1.Condition {
+	return meta_v1.Condition{
+		Type:               condType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: generation,
+	}
+}
+
+func rejectRouteParents(parents []routeParentStatus, reason string, message string) {
+	for i := range parents {
+		for j := range parents[i].Conditions {
+			if parents[i].Conditions[j].Type == "Accepted" {
+				parents[i].Conditions[j].Status = meta_v1.ConditionFalse
+				parents[i].Conditions[j].Reason = reason
+				parents[i].Conditions[j].Message = message
+			}
+		}
+	}
+}
+
+// sortHTTPRoutes sorts routes by the creation timestamp and then by the namespace/name key,
+// so that the oldest route wins when routes conflict.
+func sortHTTPRoutes(routes []*httpRoute) {
+	sort.SliceStable(routes, func(i, j int) bool {
+		if !routes[i].CreationTimestamp.Equal(&routes[j].CreationTimestamp) {
+			return routes[i].CreationTimestamp.Before(&routes[j].CreationTimestamp)
+		}
+		return getGatewayResourceKey(&routes[i].ObjectMeta) < getGatewayResourceKey(&routes[j].ObjectMeta)
+	})
+}
+
+// getGatewayVirtualServerName returns the name of the VirtualServer generated for a host.
+// The name is not a valid Kubernetes name, so it never clashes with a VirtualServer resource.
+// Dots are replaced with double underscores, because a host can't include underscores
+// and NGINX variable names generated from the name can't include dots.
+func getGatewayVirtualServerName(host string) string {
+	return gatewayResourceNamePrefix + strings.ReplaceAll(host, ".", "__")
+}
+
+func getGatewayKeyForParentRef(ref gatewayParentReference, routeNamespace string) (string, bool) {
+	if ref.Group != "" && ref.Group != gatewayAPIGroup {
+		return "", false
+	}
+	if ref.Kind != "" && ref.Kind != gatewayGVK.Kind {
+		return "", false
+	}
+
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = routeNamespace
+	}
+
+	return fmt.Sprintf("%s/%s", namespace, ref.Name), true
+}
+
+func isHTTPListener(l *gatewayListener) bool {
+	return l.Protocol == "HTTP" || l.Protocol == "HTTPS"
+}
+
+// generateGatewayStatus generates the status of a Gateway and returns the keys of TLS Secrets of its valid HTTPS listeners.
+func generateGatewayStatus(gw *gateway, lv *l4ListenerValidator) (*gatewayStatus, map[string]string) {
+	status := &gatewayStatus{}
+	secrets := make(map[string]string)
+	allValid := true
+
+	for i := range gw.Spec.Listeners {
+		l := &gw.Spec.Listeners[i]
+
+		ls := gatewayListenerStatus{
+			Name:           l.Name,
+			SupportedKinds: []gatewayRouteKind{},
+		}
+
+		secret, condType, reason, msg := validateGatewayListener(gw.Namespace, l, lv)
+		if reason == "" {
+			ls.SupportedKinds = append(ls.SupportedKinds, gatewayRouteKind{Group: gatewayAPIGroup, Kind: getRouteKindForListener(l)})
+			ls.Conditions = []meta_v1.Condition{
+				newGatewayCondition("Detached", meta_v1.ConditionFalse, "Attached", "Listener is attached", gw.Generation),
+				newGatewayCondition("ResolvedRefs", meta_v1.ConditionTrue, "ResolvedRefs", "All references are resolved", gw.Generation),
+				newGatewayCondition("Ready", meta_v1.ConditionTrue, "Ready", "Listener is ready", gw.Generation),
+			}
+			if secret != "" {
+				secrets[l.Name] = secret
+			}
+		} else {
+			allValid = false
+			ls.Conditions = []meta_v1.Condition{
+				newGatewayCondition(condType, meta_v1.ConditionTrue, reason, msg, gw.Generation),
+				newGatewayCondition("Ready", meta_v1.ConditionFalse, "Invalid", msg, gw.Generation),
+			}
+			if condType == "ResolvedRefs" {
+				ls.Conditions[0].Status = meta_v1.ConditionFalse
+			}
+		}
+
+		status.Listeners = append(status.Listeners, ls)
+	}
+
+	status.Conditions = []meta_v1.Condition{
+		newGatewayCondition("Scheduled", meta_v1.ConditionTrue, "Scheduled", "Gateway is scheduled", gw.Generation),
+	}
+	if allValid {
+		status.Conditions = append(status.Conditions, newGatewayCondition("Ready", meta_v1.ConditionTrue, "Ready", "Gateway is ready", gw.Generation))
+	} else {
+		status.Conditions = append(status.Conditions, newGatewayCondition("Ready", meta_v1.ConditionFalse, "ListenersNotValid", "Some listeners are invalid", gw.Generation))
+	}
+
+	return status, secrets
+}
+
+// validateGatewayListener validates a listener of a Gateway. For a valid HTTPS listener, it returns the key of the TLS Secret.
+// For an invalid listener, it returns the type of the condition, the reason and the message that explain the problem.
+// TCP, UDP and TLS listeners are only supported if the l4ListenerValidator is not nil.
+func validateGatewayListener(namespace string, l *gatewayListener, lv *l4ListenerValidator) (secret string, condType string, reason string, msg string) {
+	if lv != nil && isL4Listener(l) {
+		condType, reason, msg := lv.validateListener(l)
+		return "", condType, reason, msg
+	}
+
+	switch l.Protocol {
+	case "HTTP":
+		if l.Port != 80 {
+			return "", "Detached", "PortUnavailable", fmt.Sprintf("Port %d is not supported for HTTP listeners, only port 80 is supported", l.Port)
+		}
+		return "", "", "", ""
+	case "HTTPS":
+		if l.Port != 443 {
+			return "", "Detached", "PortUnavailable", fmt.Sprintf("Port %d is not supported for HTTPS listeners, only port 443 is supported", l.Port)
+		}
+		if l.TLS == nil || len(l.TLS.CertificateRefs) == 0 {
+			return "", "ResolvedRefs", "InvalidCertificateRef", "HTTPS listener must reference a TLS Secret"
+		}
+		if l.TLS.Mode != "" && l.TLS.Mode != "Terminate" {
+			return "", "Detached", "UnsupportedProtocol", fmt.Sprintf("TLS mode %s is not supported for HTTPS listeners", l.TLS.Mode)
+		}
+		ref := l.TLS.CertificateRefs[0]
+		if (ref.Group != "" && ref.Group != "core") || (ref.Kind != "" && ref.Kind != "Secret") {
+			return "", "ResolvedRefs", "InvalidCertificateRef", fmt.Sprintf("Certificate reference of kind %s is not supported", ref.Kind)
+		}
+		if ref.Namespace != "" && ref.Namespace != namespace {
+			return "", "ResolvedRefs", "InvalidCertificateRef", "Certificate reference to a Secret in a different namespace is not permitted"
+		}
+		return fmt.Sprintf("%s/%s", namespace, ref.Name), "", "", ""
+	default:
+		return "", "Detached", "UnsupportedProtocol", fmt.Sprintf("Protocol %s is not supported", l.Protocol)
+	}
+}
+
+func incrementAttachedRoutes(status *gatewayStatus, listenerName string) {
+	for i := range status.Listeners {
+		if status.Listeners[i].Name == listenerName {
+			status.Listeners[i].AttachedRoutes++
+			return
+		}
+	}
+}
+
+// findListenersForRoute finds the listeners of the Gateway that accept the route.
+// If no listener accepts the route, it returns the reason and the message for the status of the route.
+func findListenersForRoute(gw *gateway, ref gatewayParentReference, route *httpRoute, secrets map[string]string) ([]attachedListener, string, string) {
+	var result []attachedListener
+
+	reason := "NoMatchingParent"
+	msg := fmt.Sprintf("Gateway %s/%s has no listener for the route", gw.Namespace, gw.Name)
+
+	for i := range gw.Spec.Listeners {
+		l := &gw.Spec.Listeners[i]
+
+		if ref.SectionName != "" && ref.SectionName != l.Name {
+			continue
+		}
+		if !isHTTPListener(l) {
+			continue
+		}
+		if l.Protocol == "HTTPS" && secrets[l.Name] == "" {
+			// the listener is invalid
+			continue
+		}
+		if l.Protocol == "HTTP" {
+			if _, condType, _, _ := validateGatewayListener(gw.Namespace, l, nil); condType != "" {
+				continue
+			}
+		}
+
+		if !isRouteNamespaceAllowed(l, gw.Namespace, route.Namespace) {
+			reason = "NotAllowedByListeners"
+			msg = fmt.Sprintf("Listeners of Gateway %s/%s don't allow routes from namespace %s", gw.Namespace, gw.Name, route.Namespace)
+			continue
+		}
+
+		if len(intersectGatewayHostnames(l.Hostname, route.Spec.Hostnames)) == 0 {
+			reason = "NoMatchingListenerHostname"
+			msg = fmt.Sprintf("No hostname of the route matches a hostname of the listeners of Gateway %s/%s", gw.Namespace, gw.Name)
+			continue
+		}
+
+		result = append(result, attachedListener{
+			gateway:   gw,
+			listener:  l,
+			tlsSecret: secrets[l.Name],
+		})
+	}
+
+	return result, reason, msg
+}
+
+func isRouteNamespaceAllowed(l *gatewayListener, gatewayNamespace string, routeNamespace string) bool {
+	from := "Same"
+	if l.AllowedRoutes != nil && l.AllowedRoutes.Namespaces != nil && l.AllowedRoutes.Namespaces.From != "" {
+		from = l.AllowedRoutes.Namespaces.From
+	}
+
+	switch from {
+	case "All":
+		return true
+	case "Same":
+		return gatewayNamespace == routeNamespace
+	default:
+		// the namespace selector is not supported
+		return false
+	}
+}
+
+// intersectGatewayHostnames returns the hosts that match both the hostname of a listener and the hostnames of a route.
+// Wildcard hostnames can't be configured as a host of a VirtualServer, so they are ignored.
+func intersectGatewayHostnames(listenerHostname string, routeHostnames []string) []string {
+	var result []string
+
+	if len(routeHostnames) == 0 {
+		if listenerHostname != "" && !strings.HasPrefix(listenerHostname, "*") {
+			result = append(result, listenerHostname)
+		}
+		return result
+	}
+
+	for _, h := range routeHostnames {
+		host := ""
+		switch {
+		case listenerHostname == "":
+			host = h
+		case h == listenerHostname:
+			host = h
+		case matchesWildcardHostname(listenerHostname, h):
+			host = h
+		case matchesWildcardHostname(h, listenerHostname):
+			host = listenerHostname
+		}
+
+		if host != "" && !strings.HasPrefix(host, "*") {
+			result = append(result, host)
+		}
+	}
+
+	return result
+}
+
+func matchesWildcardHostname(pattern string, host string) bool {
+	if !strings.HasPrefix(pattern, "*.") || strings.HasPrefix(host, "*") {
+		return false
+	}
+	return strings.HasSuffix(host, pattern[1:]) && len(host) > len(pattern)-1
+}
+
+func isSupportedBackendRef(ref httpBackendRef, routeNamespace string) (string, string) {
+	if (ref.Group != "" && ref.Group != "core") || (ref.Kind != "" && ref.Kind != "Service") {
+		return "InvalidKind", fmt.Sprintf("Backend %s of kind %s is not supported", ref.Name, ref.Kind)
+	}
+	if ref.Namespace != "" && ref.Namespace != routeNamespace {
+		return "RefNotPermitted", fmt.Sprintf("Backend %s/%s in a different namespace is not permitted", ref.Namespace, ref.Name)
+	}
+	if ref.Port == 0 {
+		return "UnsupportedValue", fmt.Sprintf("Backend %s must specify a port", ref.Name)
+	}
+	return "", ""
+}
+
+// validateHTTPRouteBackendRefs returns the reason and the message for the first unsupported backend of a route.
+func validateHTTPRouteBackendRefs(route *httpRoute) (string, string) {
+	for _, rule := range route.Spec.Rules {
+		for _, ref := range rule.BackendRefs {
+			if reason, msg := isSupportedBackendRef(ref, route.Namespace); reason != "" {
+				return reason, msg
+			}
+		}
+	}
+	return "", ""
+}
+
+// validateHTTPRouteTranslation makes sure that the route can be translated into a valid VirtualServer on its own.
+func validateHTTPRouteTranslation(route *httpRoute, vsv *validation.VirtualServerValidator) error {
+	builder := newGatewayVirtualServerBuilder()
+	if err := builder.addRoute(route); err != nil {
+		return err
+	}
+
+	vs := builder.build(route.Namespace, getGatewayVirtualServerName("route.example.com"), "route.example.com")
+
+	return vsv.ValidateVirtualServer(vs)
+}
+
+// gatewayVirtualServerBuilder merges the rules of HTTPRoutes into routes of a VirtualServer.
+type gatewayVirtualServerBuilder struct {
+	upstreams     []conf_v1.Upstream
+	upstreamNames map[string]string
+	routes        []*conf_v1.Route
+	routesByPath  map[string]*conf_v1.Route
+}
+
+func newGatewayVirtualServerBuilder() *gatewayVirtualServerBuilder {
+	return &gatewayVirtualServerBuilder{
+		upstreamNames: make(map[string]string),
+		routesByPath:  make(map[string]*conf_v1.Route),
+	}
+}
+
+// addRoute adds the rules of an HTTPRoute. If the route uses an unsupported feature, the builder is not modified.
+func (b *gatewayVirtualServerBuilder) addRoute(route *httpRoute) error {
+	type ruleMatch struct {
+		path       string
+		conditions []conf_v1.Condition
+		action     *conf_v1.Action
+		splits     []conf_v1.Split
+	}
+
+	// validate the whole route before modifying the builder
+	var ruleMatches []ruleMatch
+	for i, rule := range route.Spec.Rules {
+		if err := validateHTTPRouteFilters(rule.Filters); err != nil {
+			return fmt.Errorf("rules[%d]: %w", i, err)
+		}
+
+		matches := rule.Matches
+		if len(matches) == 0 {
+			matches = []httpRouteMatch{{}}
+		}
+
+		for j, m := range matches {
+			path, err := generateVirtualServerPath(m.Path)
+			if err != nil {
+				return fmt.Errorf("rules[%d].matches[%d]: %w", i, j, err)
+			}
+
+			conditions, err := generateVirtualServerConditions(m)
+			if err != nil {
+				return fmt.Errorf("rules[%d].matches[%d]: %w", i, j, err)
+			}
+
+			ruleMatches = append(ruleMatches, ruleMatch{path: path, conditions: conditions})
+		}
+	}
+
+	idx := 0
+	for _, rule := range route.Spec.Rules {
+		action, splits := b.generateActionForRule(rule, route.Namespace)
+
+		count := len(rule.Matches)
+		if count == 0 {
+			count = 1
+		}
+
+		for ; count > 0; count-- {
+			rm := ruleMatches[idx]
+			idx++
+
+			r, exists := b.routesByPath[rm.path]
+			if !exists {
+				r = &conf_v1.Route{Path: rm.path}
+				b.routesByPath[rm.path] = r
+				b.routes = append(b.routes, r)
+			}
+
+			if len(rm.conditions) == 0 {
+				// the first rule wins if multiple rules match the same path without conditions
+				if r.Action == nil && len(r.Splits) == 0 {
+					r.Action = action
+					r.Splits = splits
+				}
+				continue
+			}
+
+			r.Matches = append(r.Matches, conf_v1.Match{
+				Conditions: rm.conditions,
+				Action:     action,
+				Splits:     splits,
+			})
+		}
+	}
+
+	return nil
+}
+
+func validateHTTPRouteFilters(filters []httpRouteFilter) error {
+	for _, f := range filters {
+		switch f.Type {
+		case "RequestHeaderModifier":
+			if f.RequestHeaderModifier == nil {
+				return fmt.Errorf("filter %s must include requestHeaderModifier", f.Type)
+			}
+			// NGINX can only replace a request header, while add must append the value to the existing ones
+			if len(f.RequestHeaderModifier.Add) > 0 {
+				return fmt.Errorf("filter %s with add is not supported", f.Type)
+			}
+		case "RequestRedirect":
+			if f.RequestRedirect == nil {
+				return fmt.Errorf("filter %s must include requestRedirect", f.Type)
+			}
+		default:
+			return fmt.Errorf("filter %s is not supported", f.Type)
+		}
+	}
+	return nil
+}
+
+func generateVirtualServerPath(m *httpPathMatch) (string, error) {
+	if m == nil {
+		return "/", nil
+	}
+
+	value := m.Value
+	if value == "" {
+		value = "/"
+	}
+
+	switch m.Type {
+	case "", "PathPrefix":
+		return value, nil
+	case "Exact":
+		return "=" + value, nil
+	case "RegularExpression":
+		return "~" + value, nil
+	default:
+		return "", fmt.Errorf("path match type %s is not supported", m.Type)
+	}
+}
+
+func generateVirtualServerConditions(m httpRouteMatch) ([]conf_v1.Condition, error) {
+	var conditions []conf_v1.Condition
+
+	for _, h := range m.Headers {
+		if h.Type != "" && h.Type != "Exact" {
+			return nil, fmt.Errorf("header match type %s is not supported", h.Type)
+		}
+		conditions = append(conditions, conf_v1.Condition{Header: h.Name, Value: h.Value})
+	}
+
+	for _, q := range m.QueryParams {
+		if q.Type != "" && q.Type != "Exact" {
+			return nil, fmt.Errorf("query parameter match type %s is not supported", q.Type)
+		}
+		conditions = append(conditions, conf_v1.Condition{Argument: q.Name, Value: q.Value})
+	}
+
+	if m.Method != "" {
+		conditions = append(conditions, conf_v1.Condition{Variable: "$request_method", Value: m.Method})
+	}
+
+	return conditions, nil
+}
+
+func (b *gatewayVirtualServerBuilder) getUpstreamName(service string, port int32) string {
+	key := fmt.Sprintf("%s:%d", service, port)
+	if name, exists := b.upstreamNames[key]; exists {
+		return name
+	}
+
+	name := fmt.Sprintf("backend-%d", len(b.upstreams))
+	b.upstreamNames[key] = name
+	b.upstreams = append(b.upstreams, conf_v1.Upstream{
+		Name:    name,
+		Service: service,
+		Port:    uint16(port),
+	})
+
+	return name
+}
+
+// generateActionForRule generates either an action or splits for the backends of a rule.
+// Requests for a rule without valid backends get the 500 response.
+func (b *gatewayVirtualServerBuilder) generateActionForRule(rule httpRouteRule, namespace string) (*conf_v1.Action, []conf_v1.Split) {
+	var headers *conf_v1.ProxyRequestHeaders
+
+	for _, f := range rule.Filters {
+		switch f.Type {
+		case "RequestRedirect":
+			return generateRedirectAction(f.RequestRedirect), nil
+		case "RequestHeaderModifier":
+			headers = generateProxyRequestHeaders(f.RequestHeaderModifier)
+		}
+	}
+
+	var upstreams []string
+	var weights []int
+	for _, ref := range rule.BackendRefs {
+		if reason, _ := isSupportedBackendRef(ref, namespace); reason != "" {
+			continue
+		}
+
+		weight := 1
+		if ref.Weight != nil {
+			weight = int(*ref.Weight)
+		}
+		if weight <= 0 {
+			continue
+		}
+
+		upstreams = append(upstreams, b.getUpstreamName(ref.Name, ref.Port))
+		weights = append(weights, weight)
+	}
+
+	if len(upstreams) == 0 || len(upstreams) > 100 {
+		return &conf_v1.Action{
+			Return: &conf_v1.ActionReturn{
+				Code: 500,
+				Type: "text/plain",
+				Body: "Internal Server Error",
+			},
+		}, nil
+	}
+
+	if len(upstreams) == 1 {
+		return generateProxyAction(upstreams[0], headers), nil
+	}
+
+	var splits []conf_v1.Split
+	for i, w := range generateSplitWeights(weights) {
+		splits = append(splits, conf_v1.Split{
+			Weight: w,
+			Action: generateProxyAction(upstreams[i], headers),
+		})
+	}
+
+	return nil, splits
+}
+
+func generateProxyAction(upstream string, headers *conf_v1.ProxyRequestHeaders) *conf_v1.Action {
+	if headers == nil {
+		return &conf_v1.Action{Pass: upstream}
+	}
+
+	return &conf_v1.Action{
+		Proxy: &conf_v1.ActionProxy{
+			Upstream:       upstream,
+			RequestHeaders: headers,
+		},
+	}
+}
+
+// generateProxyRequestHeaders translates a header filter. Removed headers are set to an empty value,
+// which makes NGINX not pass them to the upstream. Filters with added headers are rejected during validation.
+func generateProxyRequestHeaders(filter *httpHeaderFilter) *conf_v1.ProxyRequestHeaders {
+	headers := &conf_v1.ProxyRequestHeaders{}
+
+	for _, h := range filter.Set {
+		headers.Set = append(headers.Set, conf_v1.Header{Name: h.Name, Value: h.Value})
+	}
+	for _, name := range filter.Remove {
+		headers.Set = append(headers.Set, conf_v1.Header{Name: name})
+	}
+
+	return headers
+}
+
+func generateRedirectAction(redirect *httpRequestRedirect) *conf_v1.Action {
+	scheme := "${scheme}"
+	if redirect.Scheme != "" {
+		scheme = redirect.Scheme
+	}
+
+	host := "${host}"
+	if redirect.Hostname != "" {
+		host = redirect.Hostname
+	}
+
+	port := ""
+	if redirect.Port != 0 {
+		port = fmt.Sprintf(":%d", redirect.Port)
+	}
+
+	code := 302
+	if redirect.StatusCode != 0 {
+		code = redirect.StatusCode
+	}
+
+	return &conf_v1.Action{
+		Redirect: &conf_v1.ActionRedirect{
+			URL:  fmt.Sprintf("%s://%s%s${request_uri}", scheme, host, port),
+			Code: code,
+		},
+	}
+}
+
+// generateSplitWeights converts the weights of backends into percentages for splits of a VirtualServer.
+// Every percentage is between 1 and 99 and the sum of the percentages is 100.
+func generateSplitWeights(weights []int) []int {
+	total := 0
+	for _, w := range weights {
+		total += w
+	}
+
+	result := make([]int, len(weights))
+	remainders := make([]int, len(weights))
+	sum := 0
+
+	for i, w := range weights {
+		result[i] = w * 100 / total
+		remainders[i] = w * 100 % total
+		if result[i] == 0 {
+			result[i] = 1
+			remainders[i] = 0
+		}
+		sum += result[i]
+	}
+
+	indexes := make([]int, len(weights))
+	for i := range indexes {
+		indexes[i] = i
+	}
+
+	// distribute the missing percentages starting from the largest remainders
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return remainders[indexes[i]] > remainders[indexes[j]]
+	})
+	for i := 0; sum < 100; i = (i + 1) % len(indexes) {
+		result[indexes[i]]++
+		sum++
+	}
+
+	// take away the excess percentages starting from the largest weights
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return result[indexes[i]] > result[indexes[j]]
+	})
+	for i := 0; sum > 100; i = (i + 1) % len(indexes) {
+		if result[indexes[i]] > 1 {
+			result[indexes[i]]--
+			sum--
+		}
+	}
+
+	return result
+}
+
+// build generates a VirtualServer. Routes with matches but without a default action get the 404 response.
+// Matches with more conditions take precedence over matches with fewer conditions.
+func (b *gatewayVirtualServerBuilder) build(namespace string, name string, host string) *conf_v1.VirtualServer {
+	var routes []conf_v1.Route
+
+	for _, r := range b.routes {
+		route := *r
+
+		if route.Action == nil && len(route.Splits) == 0 {
+			route.Action = &conf_v1.Action{
+				Return: &conf_v1.ActionReturn{
+					Code: 404,
+					Type: "text/plain",
+					Body: "Not Found",
+				},
+			}
+		}
+
+		if len(route.Matches) > 0 {
+			matches := make([]conf_v1.Match, len(route.Matches))
+			copy(matches, route.Matches)
+			sort.SliceStable(matches, func(i, j int) bool {
+				return len(matches[i].Conditions) > len(matches[j].Conditions)
+			})
+			route.Matches = matches
+		}
+
+		routes = append(routes, route)
+	}
+
+	return &conf_v1.VirtualServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Spec: conf_v1.VirtualServerSpec{
+			Host:      host,
+			Upstreams: b.upstreams,
+			Routes:    routes,
+		},
+	}
+}
+
+func isL4Listener(l *gatewayListener) bool {
+	return l.Protocol == "TCP" || l.Protocol == "UDP" || l.Protocol == "TLS"
+}
+
+// getRouteKindForListener returns the kind of routes that can be attached to a listener.
+func getRouteKindForListener(l *gatewayListener) string {
+	switch l.Protocol {
+	case "TCP":
+		return tcpRouteGVK.Kind
+	case "UDP":
+		return udpRouteGVK.Kind
+	case "TLS":
+		return tlsRouteGVK.Kind
+	default:
+		return httpRouteGVK.Kind
+	}
+}
+
+// validateListener validates a TCP, UDP or TLS listener.
+// For an invalid listener, it returns the type of the condition, the reason and the message that explain the problem.
+func (lv *l4ListenerValidator) validateListener(l *gatewayListener) (condType string, reason string, msg string) {
+	if l.Protocol == "TLS" {
+		if l.TLS == nil || l.TLS.Mode != "Passthrough" {
+			return "Detached", "UnsupportedProtocol", "Only the Passthrough TLS mode is supported for TLS listeners"
+		}
+		if !lv.isTLSPassthroughEnabled {
+			return "Detached", "UnsupportedProtocol", "TLS Passthrough is not enabled"
+		}
+		if l.Port != 443 {
+			return "Detached", "PortUnavailable", fmt.Sprintf("Port %d is not supported for TLS listeners, only port 443 is supported", l.Port)
+		}
+		return "", "", ""
+	}
+
+	if err := lv.globalConfigurationValidator.ValidateListenerPort(int(l.Port)); err != nil {
+		return "Detached", "PortUnavailable", fmt.Sprintf("Port %d is not available: %v", l.Port, err)
+	}
+
+	return "", "", ""
+}
+
+// sortGateways sorts Gateways by the creation timestamp and then by the namespace/name key,
+// so that the oldest Gateway wins when the listeners of Gateways conflict.
+func sortGateways(gateways []*gateway) {
+	sort.SliceStable(gateways, func(i, j int) bool {
+		if !gateways[i].CreationTimestamp.Equal(&gateways[j].CreationTimestamp) {
+			return gateways[i].CreationTimestamp.Before(&gateways[j].CreationTimestamp)
+		}
+		return getGatewayResourceKey(&gateways[i].ObjectMeta) < getGatewayResourceKey(&gateways[j].ObjectMeta)
+	})
+}
+
+// getGatewayL4ListenerName returns the name of the listener generated for a TCP or UDP listener of a Gateway.
+func getGatewayL4ListenerName(gw *gateway, l *gatewayListener) string {
+	return gatewayResourceNamePrefix + strings.ReplaceAll(fmt.Sprintf("%s_%s_%s", gw.Namespace, gw.Name, l.Name), ".", "__")
+}
+
+// getGatewayTransportServerName returns the name of the TransportServer generated for a route and a listener (TCP and UDP)
+// or a host (TLS).
+func getGatewayTransportServerName(routeName string, suffix string) string {
+	return gatewayResourceNamePrefix + strings.ReplaceAll(fmt.Sprintf("%s_%s", routeName, suffix), ".", "__")
+}
+
+// isGatewayVirtualServer tells if the VirtualServer was generated from the Gateway API resources.
+func isGatewayVirtualServer(vs *conf_v1.VirtualServer) bool {
+	return strings.HasPrefix(vs.Name, gatewayResourceNamePrefix)
+}
+
+// isGatewayTransportServer tells if the TransportServer was generated from the Gateway API resources.
+func isGatewayTransportServer(ts *conf_v1.TransportServer) bool {
+	return strings.HasPrefix(ts.Name, gatewayResourceNamePrefix)
+}
+
+func isGatewayListenerReady(status *gatewayStatus, listenerName string) bool {
+	for _, ls := range status.Listeners {
+		if ls.Name != listenerName {
+			continue
+		}
+		for _, c := range ls.Conditions {
+			if c.Type == "Ready" {
+				return c.Status == meta_v1.ConditionTrue
+			}
+		}
+	}
+	return false
+}
+
+// detachGatewayListener marks a valid listener as detached.
+func detachGatewayListener(status *gatewayStatus, listenerName string, reason string, msg string, generation int64) {
+	invalidateGatewayListener(status, listenerName, "Detached", reason, msg, generation)
+}
+
+// invalidateGatewayListener sets the condition of the given type for a valid listener and marks the listener as not ready.
+func invalidateGatewayListener(status *gatewayStatus, listenerName string, condType string, reason string, msg string, generation int64) {
+	for i := range status.Listeners {
+		if status.Listeners[i].Name != listenerName {
+			continue
+		}
+		status.Listeners[i].SupportedKinds = []gatewayRouteKind{}
+		status.Listeners[i].Conditions = []meta_v1.Condition{
+			newGatewayCondition(condType, meta_v1.ConditionTrue, reason, msg, generation),
+			newGatewayCondition("Ready", meta_v1.ConditionFalse, "Invalid", msg, generation),
+		}
+	}
+
+	for i := range status.Conditions {
+		if status.Conditions[i].Type == "Ready" {
+			status.Conditions[i] = newGatewayCondition("Ready", meta_v1.ConditionFalse, "ListenersNotValid", "Some listeners are invalid", generation)
+		}
+	}
+}
+
+// conflictGatewayListeners marks the TCP and UDP listeners of the Gateways that conflict with the listeners of
+// the GlobalConfiguration as conflicted. conflicts maps the names of the generated listeners to the names of the listeners
+// of the GlobalConfiguration (see Configuration.GetConflictedGatewayListeners).
+func conflictGatewayListeners(gateways []*gateway, statuses map[string]*gatewayStatus, conflicts map[string]string) {
+	for _, gw := range gateways {
+		status, exists := statuses[getGatewayResourceKey(&gw.ObjectMeta)]
+		if !exists {
+			continue
+		}
+
+		for i := range gw.Spec.Listeners {
+			l := &gw.Spec.Listeners[i]
+
+			gcListener, conflicted := conflicts[getGatewayL4ListenerName(gw, l)]
+			if !conflicted || !isGatewayListenerReady(status, l.Name) {
+				continue
+			}
+
+			invalidateGatewayListener(status, l.Name, "Conflicted", "ProtocolConflict",
+				fmt.Sprintf("Port %d/%s is used by listener %s of the GlobalConfiguration", l.Port, l.Protocol, gcListener), gw.Generation)
+		}
+	}
+}
+
+// buildGatewayL4Listeners builds the listeners for the valid TCP and UDP listeners of the Gateways.
+// A port can only be used by one listener per protocol: the listener of the oldest Gateway wins and the others are detached.
+// The Gateways must be sorted. The result is keyed by the namespace/name key of a Gateway and the name of its listener.
+func buildGatewayL4Listeners(gateways []*gateway, statuses map[string]*gatewayStatus) map[string]map[string]conf_v1.Listener {
+	result := make(map[string]map[string]conf_v1.Listener)
+	ports := make(map[string]string)
+
+	for _, gw := range gateways {
+		key := getGatewayResourceKey(&gw.ObjectMeta)
+		status, exists := statuses[key]
+		if !exists {
+			continue
+		}
+
+		for i := range gw.Spec.Listeners {
+			l := &gw.Spec.Listeners[i]
+
+			if l.Protocol != "TCP" && l.Protocol != "UDP" {
+				continue
+			}
+			if !isGatewayListenerReady(status, l.Name) {
+				continue
+			}
+
+			portProtocol := fmt.Sprintf("%d/%s", l.Port, l.Protocol)
+			if holder, taken := ports[portProtocol]; taken {
+				detachGatewayListener(status, l.Name, "PortUnavailable",
+					fmt.Sprintf("Port %s is used by listener %s", portProtocol, holder), gw.Generation)
+				continue
+			}
+			ports[portProtocol] = fmt.Sprintf("%s of Gateway %s", l.Name, key)
+
+			if result[key] == nil {
+				result[key] = make(map[string]conf_v1.Listener)
+			}
+			result[key][l.Name] = conf_v1.Listener{
+				Name:     getGatewayL4ListenerName(gw, l),
+				Port:     int(l.Port),
+				Protocol: l.Protocol,
+			}
+		}
+	}
+
+	return result
+}
+
+// sortL4Routes sorts routes by the creation timestamp and then by the kind/namespace/name key,
+// so that the oldest route wins when routes conflict.
+func sortL4Routes(routes []*l4Route) {
+	sort.SliceStable(routes, func(i, j int) bool {
+		if !routes[i].CreationTimestamp.Equal(&routes[j].CreationTimestamp) {
+			return routes[i].CreationTimestamp.Before(&routes[j].CreationTimestamp)
+		}
+		return getL4RouteKey(routes[i]) < getL4RouteKey(routes[j])
+	})
+}
+
+// getL4RouteKey returns the kind/namespace/name key of a route. For example, TCPRoute/my-namespace/my-name.
+func getL4RouteKey(route *l4Route) string {
+	return fmt.Sprintf("%s/%s", route.Kind, getGatewayResourceKey(&route.ObjectMeta))
+}
+
+func getListenerProtocolForRouteKind(kind string) string {
+	switch kind {
+	case tcpRouteGVK.Kind:
+		return "TCP"
+	case udpRouteGVK.Kind:
+		return "UDP"
+	case tlsRouteGVK.Kind:
+		return "TLS"
+	default:
+		return ""
+	}
+}
+
+// translateL4Routes translates TCPRoutes, UDPRoutes and TLSRoutes into TransportServers.
+// A TCP or UDP listener and a TLS host can only be used by one route: the oldest route wins.
+func translateL4Routes(result *gatewayTranslation, gateways map[string]*gateway, routes []*l4Route, l4Listeners map[string]map[string]conf_v1.Listener) {
+	sortL4Routes(routes)
+
+	takenListeners := make(map[string]bool)
+	takenHosts := make(map[string]bool)
+	usedListeners := make(map[string]conf_v1.Listener)
+
+	for _, route := range routes {
+		routeKey := getL4RouteKey(route)
+
+		var parentStatuses []routeParentStatus
+		var attachments []attachedListener
+		for _, ref := range route.Spec.ParentRefs {
+			gwKey, ok := getGatewayKeyForParentRef(ref, route.Namespace)
+			if !ok {
+				continue
+			}
+			gw, exists := gateways[gwKey]
+			if !exists {
+				continue
+			}
+
+			listeners, reason, msg := findListenersForL4Route(gw, ref, route, result.GatewayStatuses[gwKey])
+			status := routeParentStatus{
+				ParentRef:      ref,
+				ControllerName: GatewayControllerName,
+			}
+			if len(listeners) == 0 {
+				status.Conditions = []meta_v1.Condition{
+					newGatewayCondition("Accepted", meta_v1.ConditionFalse, reason, msg, route.Generation),
+				}
+			}
+			parentStatuses = append(parentStatuses, status)
+			attachments = append(attachments, listeners...)
+		}
+
+		if len(parentStatuses) == 0 {
+			// the route is not attached to any Gateway handled by the Ingress Controller
+			continue
+		}
+
+		accepted := newGatewayCondition("Accepted", meta_v1.ConditionTrue, "Accepted", "Route is accepted", route.Generation)
+		resolvedRefs := newGatewayCondition("ResolvedRefs", meta_v1.ConditionTrue, "ResolvedRefs", "All references are resolved", route.Generation)
+
+		var backend *httpBackendRef
+		if len(route.Spec.Rules) != 1 || len(route.Spec.Rules[0].BackendRefs) != 1 {
+			accepted = newGatewayCondition("Accepted", meta_v1.ConditionFalse, "UnsupportedValue",
+				fmt.Sprintf("%s must have exactly one rule with one backend", route.Kind), route.Generation)
+			attachments = nil
+		} else if reason, msg := isSupportedBackendRef(route.Spec.Rules[0].BackendRefs[0], route.Namespace); reason != "" {
+			// the route is accepted, but the traffic is not forwarded anywhere
+			resolvedRefs = newGatewayCondition("ResolvedRefs", meta_v1.ConditionFalse, reason, msg, route.Generation)
+			attachments = nil
+		} else {
+			backend = &route.Spec.Rules[0].BackendRefs[0]
+		}
+
+		attached := false
+		for _, a := range attachments {
+			gwKey := getGatewayResourceKey(&a.gateway.ObjectMeta)
+
+			var transportServers []*conf_v1.TransportServer
+			if a.listener.Protocol == "TLS" {
+				for _, host := range intersectGatewayHostnames(a.listener.Hostname, route.Spec.Hostnames) {
+					if takenHosts[host] {
+						continue
+					}
+					takenHosts[host] = true
+
+					listener := conf_v1.TransportServerListener{
+						Name:     conf_v1.TLSPassthroughListenerName,
+						Protocol: conf_v1.TLSPassthroughListenerProtocol,
+					}
+					transportServers = append(transportServers, newGatewayTransportServer(route, host, listener, host, backend))
+				}
+			} else {
+				l4Listener, exists := l4Listeners[gwKey][a.listener.Name]
+				if !exists || takenListeners[l4Listener.Name] {
+					continue
+				}
+				takenListeners[l4Listener.Name] = true
+				usedListeners[l4Listener.Name] = l4Listener
+
+				listener := conf_v1.TransportServerListener{
+					Name:     l4Listener.Name,
+					Protocol: l4Listener.Protocol,
+				}
+				suffix := fmt.Sprintf("%s_%s_%s", a.gateway.Namespace, a.gateway.Name, a.listener.Name)
+				transportServers = append(transportServers, newGatewayTransportServer(route, suffix, listener, "", backend))
+			}
+
+			if len(transportServers) == 0 {
+				continue
+			}
+
+			for _, ts := range transportServers {
+				result.TransportServers[getResourceKey(&ts.ObjectMeta)] = &gatewayTransportServer{
+					TransportServer: ts,
+					Route:           routeKey,
+				}
+			}
+
+			incrementAttachedRoutes(result.GatewayStatuses[gwKey], a.listener.Name)
+			attached = true
+		}
+
+		for i := range parentStatuses {
+			if len(parentStatuses[i].Conditions) > 0 {
+				continue
+			}
+			cond := accepted
+			if accepted.Status == meta_v1.ConditionTrue && resolvedRefs.Status == meta_v1.ConditionTrue && !attached {
+				cond = newGatewayCondition("Accepted", meta_v1.ConditionFalse, "NotAllowedByListeners",
+					"The listeners or the hostnames that match the route are used by other routes", route.Generation)
+			}
+			parentStatuses[i].Conditions = []meta_v1.Condition{cond, resolvedRefs}
+		}
+
+		result.L4RouteStatuses[routeKey] = parentStatuses
+	}
+
+	var names []string
+	for name := range usedListeners {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		result.Listeners = append(result.Listeners, usedListeners[name])
+	}
+}
+
+// findListenersForL4Route finds the listeners of the Gateway that accept the route.
+// If no listener accepts the route, it returns the reason and the message for the status of the route.
+func findListenersForL4Route(gw *gateway, ref gatewayParentReference, route *l4Route, status *gatewayStatus) ([]attachedListener, string, string) {
+	var result []attachedListener
+
+	reason := "NoMatchingParent"
+	msg := fmt.Sprintf("Gateway %s/%s has no listener for the route", gw.Namespace, gw.Name)
+
+	protocol := getListenerProtocolForRouteKind(route.Kind)
+
+	for i := range gw.Spec.Listeners {
+		l := &gw.Spec.Listeners[i]
+
+		if ref.SectionName != "" && ref.SectionName != l.Name {
+			continue
+		}
+		if l.Protocol != protocol || !isGatewayListenerReady(status, l.Name) {
+			continue
+		}
+
+		if !isRouteNamespaceAllowed(l, gw.Namespace, route.Namespace) {
+			reason = "NotAllowedByListeners"
+			msg = fmt.Sprintf("Listeners of Gateway %s/%s don't allow routes from namespace %s", gw.Namespace, gw.Name, route.Namespace)
+			continue
+		}
+
+		if protocol == "TLS" && len(intersectGatewayHostnames(l.Hostname, route.Spec.Hostnames)) == 0 {
+			reason = "NoMatchingListenerHostname"
+			msg = fmt.Sprintf("No hostname of the route matches a hostname of the listeners of Gateway %s/%s", gw.Namespace, gw.Name)
+			continue
+		}
+
+		result = append(result, attachedListener{
+			gateway:  gw,
+			listener: l,
+		})
+	}
+
+	return result, reason, msg
+}
+
+// newGatewayTransportServer creates a TransportServer for a route. The TransportServer inherits the generation of the route,
+// so that any change of the route spec is detected as a change of the TransportServer.
+func newGatewayTransportServer(route *l4Route, nameSuffix string, listener conf_v1.TransportServerListener, host string,
+	backend *httpBackendRef,
+) *conf_v1.TransportServer {
+	return &conf_v1.TransportServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:              getGatewayTransportServerName(route.Name, nameSuffix),
+			Namespace:         route.Namespace,
+			Generation:        route.Generation,
+			CreationTimestamp: route.CreationTimestamp,
+		},
+		Spec: conf_v1.TransportServerSpec{
+			Listener: listener,
+			Host:     host,
+			Upstreams: []conf_v1.TransportServerUpstream{
+				{
+					Name:    "backend",
+					Service: backend.Name,
+					Port:    int(backend.Port),
+				},
+			},
+			Action: &conf_v1.TransportServerAction{
+				Pass: "backend",
+			},
+		},
+	}
+}
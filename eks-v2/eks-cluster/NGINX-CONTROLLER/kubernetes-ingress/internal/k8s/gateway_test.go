@@ -0,0 +1,803 @@
+package k8s
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
+	"github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/validation"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func createTestGatewayClass(controllerName string) *gatewayClass {
+	return &gatewayClass{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name: "nginx",
+		},
+		Spec: gatewayClassSpec{
+			ControllerName: controllerName,
+		},
+	}
+}
+
+func createTestGateway() *gateway {
+	return &gateway{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "gateway",
+			Namespace: "default",
+		},
+		Spec: gatewaySpec{
+			GatewayClassName: "nginx",
+			Listeners: []gatewayListener{
+				{
+					Name:     "http",
+					Port:     80,
+					Protocol: "HTTP",
+				},
+				{
+					Name:     "https",
+					Port:     443,
+					Protocol: "HTTPS",
+					TLS: &gatewayTLSConfig{
+						CertificateRefs: []gatewayObjectReference{
+							{
+								Name: "cafe-secret",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func createTestHTTPRoute() *httpRoute {
+	weight80 := int32(80)
+	weight20 := int32(20)
+
+	return &httpRoute{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "cafe",
+			Namespace: "default",
+		},
+		Spec: httpRouteSpec{
+			ParentRefs: []gatewayParentReference{
+				{
+					Name: "gateway",
+				},
+			},
+			Hostnames: []string{"cafe.example.com"},
+			Rules: []httpRouteRule{
+				{
+					Matches: []httpRouteMatch{
+						{
+							Path: &httpPathMatch{
+								Type:  "PathPrefix",
+								Value: "/coffee",
+							},
+						},
+					},
+					BackendRefs: []httpBackendRef{
+						{
+							Name:   "coffee-v1",
+							Port:   80,
+							Weight: &weight80,
+						},
+						{
+							Name:   "coffee-v2",
+							Port:   80,
+							Weight: &weight20,
+						},
+					},
+				},
+				{
+					Matches: []httpRouteMatch{
+						{
+							Path: &httpPathMatch{
+								Type:  "PathPrefix",
+								Value: "/coffee",
+							},
+							Headers: []httpValueMatch{
+								{
+									Name:  "version",
+									Value: "v2",
+								},
+							},
+						},
+					},
+					BackendRefs: []httpBackendRef{
+						{
+							Name: "coffee-v2",
+							Port: 80,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestTranslateGatewayAPI(t *testing.T) {
+	vsv := validation.NewVirtualServerValidator(false, false, false)
+
+	result := translateGatewayAPI(
+		[]*gatewayClass{createTestGatewayClass(GatewayControllerName)},
+		[]*gateway{createTestGateway()},
+		[]*httpRoute{createTestHTTPRoute()},
+		nil,
+		vsv,
+		nil)
+
+	expectedVS := &conf_v1.VirtualServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "gateway_cafe__example__com",
+			Namespace: "default",
+		},
+		Spec: conf_v1.VirtualServerSpec{
+			Host: "cafe.example.com",
+			Upstreams: []conf_v1.Upstream{
+				{
+					Name:    "backend-0",
+					Service: "coffee-v1",
+					Port:    80,
+				},
+				{
+					Name:    "backend-1",
+					Service: "coffee-v2",
+					Port:    80,
+				},
+			},
+			Routes: []conf_v1.Route{
+				{
+					Path: "/coffee",
+					Splits: []conf_v1.Split{
+						{
+							Weight: 80,
+							Action: &conf_v1.Action{
+								Pass: "backend-0",
+							},
+						},
+						{
+							Weight: 20,
+							Action: &conf_v1.Action{
+								Pass: "backend-1",
+							},
+						},
+					},
+					Matches: []conf_v1.Match{
+						{
+							Conditions: []conf_v1.Condition{
+								{
+									Header: "version",
+									Value:  "v2",
+								},
+							},
+							Action: &conf_v1.Action{
+								Pass: "backend-1",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	gvs, exists := result.VirtualServers["default/gateway_cafe__example__com"]
+	if !exists {
+		t.Fatalf("translateGatewayAPI() didn't generate a VirtualServer for cafe.example.com")
+	}
+	if diff := cmp.Diff(expectedVS, gvs.VirtualServer); diff != "" {
+		t.Errorf("translateGatewayAPI() returned unexpected VirtualServer (-want +got):\n%s", diff)
+	}
+	if gvs.TLSSecret != "default/cafe-secret" {
+		t.Errorf("translateGatewayAPI() returned TLSSecret %q but expected %q", gvs.TLSSecret, "default/cafe-secret")
+	}
+	if diff := cmp.Diff([]string{"default/cafe"}, gvs.Routes); diff != "" {
+		t.Errorf("translateGatewayAPI() returned unexpected routes (-want +got):\n%s", diff)
+	}
+
+	classStatus := result.ClassStatuses["nginx"]
+	if classStatus == nil || classStatus.Conditions[0].Status != meta_v1.ConditionTrue {
+		t.Errorf("translateGatewayAPI() didn't accept the GatewayClass: %+v", classStatus)
+	}
+
+	gwStatus := result.GatewayStatuses["default/gateway"]
+	if gwStatus == nil {
+		t.Fatalf("translateGatewayAPI() didn't generate the status for the Gateway")
+	}
+	for _, ls := range gwStatus.Listeners {
+		if ls.AttachedRoutes != 1 {
+			t.Errorf("translateGatewayAPI() returned %d attached routes for listener %s but expected 1", ls.AttachedRoutes, ls.Name)
+		}
+	}
+
+	parents := result.RouteStatuses["default/cafe"]
+	if len(parents) != 1 {
+		t.Fatalf("translateGatewayAPI() returned %d parent statuses for the HTTPRoute but expected 1", len(parents))
+	}
+	for _, c := range parents[0].Conditions {
+		if c.Status != meta_v1.ConditionTrue {
+			t.Errorf("translateGatewayAPI() returned unexpected condition %+v for the HTTPRoute", c)
+		}
+	}
+}
+
+func TestTranslateGatewayAPIIgnoresOtherControllers(t *testing.T) {
+	vsv := validation.NewVirtualServerValidator(false, false, false)
+
+	result := translateGatewayAPI(
+		[]*gatewayClass{createTestGatewayClass("example.com/other-controller")},
+		[]*gateway{createTestGateway()},
+		[]*httpRoute{createTestHTTPRoute()},
+		nil,
+		vsv,
+		nil)
+
+	if len(result.VirtualServers) != 0 {
+		t.Errorf("translateGatewayAPI() generated %d VirtualServers but expected 0", len(result.VirtualServers))
+	}
+	if len(result.ClassStatuses) != 0 || len(result.GatewayStatuses) != 0 || len(result.RouteStatuses) != 0 {
+		t.Errorf("translateGatewayAPI() generated statuses for resources of another controller")
+	}
+}
+
+func TestTranslateGatewayAPIRejectsRoutes(t *testing.T) {
+	unsupportedFilter := createTestHTTPRoute()
+	unsupportedFilter.Spec.Rules[0].Filters = []httpRouteFilter{
+		{
+			Type: "RequestMirror",
+		},
+	}
+
+	addHeaderFilter := createTestHTTPRoute()
+	addHeaderFilter.Spec.Rules[0].Filters = []httpRouteFilter{
+		{
+			Type: "RequestHeaderModifier",
+			RequestHeaderModifier: &httpHeaderFilter{
+				Add: []httpHeader{{Name: "X-Env", Value: "prod"}},
+			},
+		},
+	}
+
+	otherHost := createTestHTTPRoute()
+	otherHost.Spec.Hostnames = []string{"tea.example.com"}
+	otherHost.Spec.ParentRefs[0].SectionName = "https"
+	gw := createTestGateway()
+	gw.Spec.Listeners[1].Hostname = "cafe.example.com"
+
+	otherNamespace := createTestHTTPRoute()
+	otherNamespace.Namespace = "other"
+	otherNamespace.Spec.ParentRefs[0].Namespace = "default"
+
+	tests := []struct {
+		route    *httpRoute
+		gateway  *gateway
+		expected string
+		msg      string
+	}{
+		{
+			route:    unsupportedFilter,
+			gateway:  createTestGateway(),
+			expected: "UnsupportedValue",
+			msg:      "unsupported filter",
+		},
+		{
+			route:    addHeaderFilter,
+			gateway:  createTestGateway(),
+			expected: "UnsupportedValue",
+			msg:      "header filter with add",
+		},
+		{
+			route:    otherHost,
+			gateway:  gw,
+			expected: "NoMatchingListenerHostname",
+			msg:      "no matching hostname",
+		},
+		{
+			route:    otherNamespace,
+			gateway:  createTestGateway(),
+			expected: "NotAllowedByListeners",
+			msg:      "route from a different namespace",
+		},
+	}
+
+	vsv := validation.NewVirtualServerValidator(false, false, false)
+
+	for _, test := range tests {
+		result := translateGatewayAPI(
+			[]*gatewayClass{createTestGatewayClass(GatewayControllerName)},
+			[]*gateway{test.gateway},
+			[]*httpRoute{test.route},
+			nil,
+			vsv,
+			nil)
+
+		if len(result.VirtualServers) != 0 {
+			t.Errorf("translateGatewayAPI() generated %d VirtualServers for the case of %s but expected 0", len(result.VirtualServers), test.msg)
+		}
+
+		parents := result.RouteStatuses[getGatewayResourceKey(&test.route.ObjectMeta)]
+		if len(parents) != 1 {
+			t.Errorf("translateGatewayAPI() returned %d parent statuses for the case of %s but expected 1", len(parents), test.msg)
+			continue
+		}
+
+		accepted := parents[0].Conditions[0]
+		if accepted.Type != "Accepted" || accepted.Status != meta_v1.ConditionFalse || accepted.Reason != test.expected {
+			t.Errorf("translateGatewayAPI() returned condition %+v for the case of %s but expected reason %s", accepted, test.msg, test.expected)
+		}
+	}
+}
+
+func TestValidateGatewayListener(t *testing.T) {
+	tests := []struct {
+		listener       gatewayListener
+		expectedSecret string
+		expectedReason string
+	}{
+		{
+			listener: gatewayListener{
+				Protocol: "HTTP",
+				Port:     80,
+			},
+		},
+		{
+			listener: gatewayListener{
+				Protocol: "HTTP",
+				Port:     8080,
+			},
+			expectedReason: "PortUnavailable",
+		},
+		{
+			listener: gatewayListener{
+				Protocol: "HTTPS",
+				Port:     443,
+				TLS: &gatewayTLSConfig{
+					CertificateRefs: []gatewayObjectReference{{Name: "secret"}},
+				},
+			},
+			expectedSecret: "default/secret",
+		},
+		{
+			listener: gatewayListener{
+				Protocol: "HTTPS",
+				Port:     443,
+			},
+			expectedReason: "InvalidCertificateRef",
+		},
+		{
+			listener: gatewayListener{
+				Protocol: "HTTPS",
+				Port:     443,
+				TLS: &gatewayTLSConfig{
+					CertificateRefs: []gatewayObjectReference{{Name: "secret", Namespace: "other"}},
+				},
+			},
+			expectedReason: "InvalidCertificateRef",
+		},
+		{
+			listener: gatewayListener{
+				Protocol: "UDP",
+				Port:     53,
+			},
+			expectedReason: "UnsupportedProtocol",
+		},
+	}
+
+	for _, test := range tests {
+		secret, _, reason, _ := validateGatewayListener("default", &test.listener, nil)
+		if secret != test.expectedSecret || reason != test.expectedReason {
+			t.Errorf("validateGatewayListener(%+v) returned %q, %q but expected %q, %q", test.listener, secret, reason, test.expectedSecret, test.expectedReason)
+		}
+	}
+}
+
+func createTestL4ListenerValidator() *l4ListenerValidator {
+	return &l4ListenerValidator{
+		globalConfigurationValidator: validation.NewGlobalConfigurationValidator(map[int]bool{
+			80:  true,
+			443: true,
+		}),
+		isTLSPassthroughEnabled: true,
+	}
+}
+
+func createTestL4Gateway(name string) *gateway {
+	return &gateway{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: gatewaySpec{
+			GatewayClassName: "nginx",
+			Listeners: []gatewayListener{
+				{
+					Name:     "tcp",
+					Port:     5432,
+					Protocol: "TCP",
+				},
+				{
+					Name:     "tls",
+					Hostname: "*.example.com",
+					Port:     443,
+					Protocol: "TLS",
+					TLS: &gatewayTLSConfig{
+						Mode: "Passthrough",
+					},
+				},
+			},
+		},
+	}
+}
+
+func createTestL4Route(kind string, name string, created meta_v1.Time, hostnames []string, service string, port int32) *l4Route {
+	return &l4Route{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:              name,
+			Namespace:         "default",
+			Generation:        1,
+			CreationTimestamp: created,
+		},
+		Spec: l4RouteSpec{
+			ParentRefs: []gatewayParentReference{
+				{
+					Name: "gateway",
+				},
+			},
+			Hostnames: hostnames,
+			Rules: []l4RouteRule{
+				{
+					BackendRefs: []httpBackendRef{
+						{
+							Name: service,
+							Port: port,
+						},
+					},
+				},
+			},
+		},
+		Kind: kind,
+	}
+}
+
+func TestTranslateGatewayAPIL4Routes(t *testing.T) {
+	vsv := validation.NewVirtualServerValidator(true, false, false)
+
+	older := meta_v1.Unix(1000, 0)
+	newer := meta_v1.Unix(2000, 0)
+
+	db := createTestL4Route(tcpRouteGVK.Kind, "db", older, nil, "db-svc", 5432)
+	conflictingDB := createTestL4Route(tcpRouteGVK.Kind, "db-2", newer, nil, "db-2-svc", 5432)
+	app := createTestL4Route(tlsRouteGVK.Kind, "app", older, []string{"app.example.com"}, "app-svc", 8443)
+
+	result := translateGatewayAPI(
+		[]*gatewayClass{createTestGatewayClass(GatewayControllerName)},
+		[]*gateway{createTestL4Gateway("gateway")},
+		nil,
+		[]*l4Route{conflictingDB, app, db},
+		vsv,
+		createTestL4ListenerValidator())
+
+	expectedTransportServers := map[string]*gatewayTransportServer{
+		"default/gateway_db_default_gateway_tcp": {
+			TransportServer: newGatewayTransportServer(db, "default_gateway_tcp", conf_v1.TransportServerListener{
+				Name:     "gateway_default_gateway_tcp",
+				Protocol: "TCP",
+			}, "", &db.Spec.Rules[0].BackendRefs[0]),
+			Route: "TCPRoute/default/db",
+		},
+		"default/gateway_app_app__example__com": {
+			TransportServer: newGatewayTransportServer(app, "app.example.com", conf_v1.TransportServerListener{
+				Name:     conf_v1.TLSPassthroughListenerName,
+				Protocol: conf_v1.TLSPassthroughListenerProtocol,
+			}, "app.example.com", &app.Spec.Rules[0].BackendRefs[0]),
+			Route: "TLSRoute/default/app",
+		},
+	}
+	expectedListeners := []conf_v1.Listener{
+		{
+			Name:     "gateway_default_gateway_tcp",
+			Port:     5432,
+			Protocol: "TCP",
+		},
+	}
+
+	if diff := cmp.Diff(expectedTransportServers, result.TransportServers); diff != "" {
+		t.Errorf("translateGatewayAPI() returned unexpected TransportServers (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedListeners, result.Listeners); diff != "" {
+		t.Errorf("translateGatewayAPI() returned unexpected listeners (-want +got):\n%s", diff)
+	}
+
+	for _, ls := range result.GatewayStatuses["default/gateway"].Listeners {
+		if ls.AttachedRoutes != 1 {
+			t.Errorf("translateGatewayAPI() returned %d attached routes for listener %s but expected 1", ls.AttachedRoutes, ls.Name)
+		}
+	}
+
+	parents := result.L4RouteStatuses["TCPRoute/default/db-2"]
+	if len(parents) != 1 {
+		t.Fatalf("translateGatewayAPI() returned %d parent statuses for the conflicting TCPRoute but expected 1", len(parents))
+	}
+	if c := parents[0].Conditions[0]; c.Status != meta_v1.ConditionFalse || c.Reason != "NotAllowedByListeners" {
+		t.Errorf("translateGatewayAPI() returned unexpected condition %+v for the conflicting TCPRoute", c)
+	}
+}
+
+func TestTranslateGatewayAPIL4RoutesWithoutL4Support(t *testing.T) {
+	vsv := validation.NewVirtualServerValidator(true, false, false)
+
+	result := translateGatewayAPI(
+		[]*gatewayClass{createTestGatewayClass(GatewayControllerName)},
+		[]*gateway{createTestL4Gateway("gateway")},
+		nil,
+		[]*l4Route{createTestL4Route(tcpRouteGVK.Kind, "db", meta_v1.Unix(1000, 0), nil, "db-svc", 5432)},
+		vsv,
+		nil)
+
+	if len(result.TransportServers) != 0 {
+		t.Errorf("translateGatewayAPI() generated %d TransportServers but expected 0", len(result.TransportServers))
+	}
+
+	parents := result.L4RouteStatuses["TCPRoute/default/db"]
+	if len(parents) != 1 || parents[0].Conditions[0].Reason != "NoMatchingParent" {
+		t.Errorf("translateGatewayAPI() returned unexpected parent statuses %+v for the TCPRoute", parents)
+	}
+}
+
+func TestBuildGatewayL4ListenersDetachesConflictingListeners(t *testing.T) {
+	first := createTestL4Gateway("first")
+	second := createTestL4Gateway("second")
+	gateways := []*gateway{first, second}
+
+	lv := createTestL4ListenerValidator()
+	statuses := make(map[string]*gatewayStatus)
+	for _, gw := range gateways {
+		statuses[getGatewayResourceKey(&gw.ObjectMeta)], _ = generateGatewayStatus(gw, lv)
+	}
+
+	expected := map[string]map[string]conf_v1.Listener{
+		"default/first": {
+			"tcp": {
+				Name:     "gateway_default_first_tcp",
+				Port:     5432,
+				Protocol: "TCP",
+			},
+		},
+	}
+
+	result := buildGatewayL4Listeners(gateways, statuses)
+	if diff := cmp.Diff(expected, result); diff != "" {
+		t.Errorf("buildGatewayL4Listeners() returned unexpected result (-want +got):\n%s", diff)
+	}
+
+	if isGatewayListenerReady(statuses["default/second"], "tcp") {
+		t.Errorf("buildGatewayL4Listeners() didn't detach the conflicting listener")
+	}
+	if !isGatewayListenerReady(statuses["default/second"], "tls") {
+		t.Errorf("buildGatewayL4Listeners() detached the TLS listener that doesn't conflict")
+	}
+}
+
+func TestConflictGatewayListeners(t *testing.T) {
+	gw := createTestL4Gateway("first")
+	gateways := []*gateway{gw}
+
+	status, _ := generateGatewayStatus(gw, createTestL4ListenerValidator())
+	statuses := map[string]*gatewayStatus{
+		"default/first": status,
+	}
+
+	conflicts := map[string]string{
+		"gateway_default_first_tcp": "tcp-5432",
+	}
+
+	conflictGatewayListeners(gateways, statuses, conflicts)
+
+	if isGatewayListenerReady(status, "tcp") {
+		t.Errorf("conflictGatewayListeners() didn't invalidate the conflicting listener")
+	}
+	if !isGatewayListenerReady(status, "tls") {
+		t.Errorf("conflictGatewayListeners() invalidated the TLS listener that doesn't conflict")
+	}
+
+	for _, ls := range status.Listeners {
+		if ls.Name != "tcp" {
+			continue
+		}
+		if len(ls.Conditions) == 0 || ls.Conditions[0].Type != "Conflicted" || ls.Conditions[0].Reason != "ProtocolConflict" {
+			t.Errorf("conflictGatewayListeners() set conditions %+v but expected the Conflicted condition", ls.Conditions)
+		}
+	}
+}
+
+func TestValidateL4GatewayListener(t *testing.T) {
+	tests := []struct {
+		listener       gatewayListener
+		expectedReason string
+	}{
+		{
+			listener: gatewayListener{
+				Protocol: "TCP",
+				Port:     5432,
+			},
+		},
+		{
+			listener: gatewayListener{
+				Protocol: "UDP",
+				Port:     80,
+			},
+			expectedReason: "PortUnavailable",
+		},
+		{
+			listener: gatewayListener{
+				Protocol: "TLS",
+				Port:     443,
+				TLS: &gatewayTLSConfig{
+					Mode: "Passthrough",
+				},
+			},
+		},
+		{
+			listener: gatewayListener{
+				Protocol: "TLS",
+				Port:     8443,
+				TLS: &gatewayTLSConfig{
+					Mode: "Passthrough",
+				},
+			},
+			expectedReason: "PortUnavailable",
+		},
+		{
+			listener: gatewayListener{
+				Protocol: "TLS",
+				Port:     443,
+			},
+			expectedReason: "UnsupportedProtocol",
+		},
+	}
+
+	lv := createTestL4ListenerValidator()
+
+	for _, test := range tests {
+		_, _, reason, _ := validateGatewayListener("default", &test.listener, lv)
+		if reason != test.expectedReason {
+			t.Errorf("validateGatewayListener(%+v) returned %q but expected %q", test.listener, reason, test.expectedReason)
+		}
+	}
+}
+
+func TestIntersectGatewayHostnames(t *testing.T) {
+	tests := []struct {
+		listenerHostname string
+		routeHostnames   []string
+		expected         []string
+	}{
+		{
+			listenerHostname: "",
+			routeHostnames:   nil,
+			expected:         nil,
+		},
+		{
+			listenerHostname: "cafe.example.com",
+			routeHostnames:   nil,
+			expected:         []string{"cafe.example.com"},
+		},
+		{
+			listenerHostname: "",
+			routeHostnames:   []string{"cafe.example.com", "*.example.com"},
+			expected:         []string{"cafe.example.com"},
+		},
+		{
+			listenerHostname: "*.example.com",
+			routeHostnames:   []string{"cafe.example.com", "example.com", "tea.example.org"},
+			expected:         []string{"cafe.example.com"},
+		},
+		{
+			listenerHostname: "cafe.example.com",
+			routeHostnames:   []string{"*.example.com", "tea.example.com"},
+			expected:         []string{"cafe.example.com"},
+		},
+	}
+
+	for _, test := range tests {
+		result := intersectGatewayHostnames(test.listenerHostname, test.routeHostnames)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("intersectGatewayHostnames(%q, %v) returned unexpected result (-want +got):\n%s", test.listenerHostname, test.routeHostnames, diff)
+		}
+	}
+}
+
+func TestGenerateSplitWeights(t *testing.T) {
+	tests := []struct {
+		weights  []int
+		expected []int
+	}{
+		{
+			weights:  []int{1, 1},
+			expected: []int{50, 50},
+		},
+		{
+			weights:  []int{1, 1, 1},
+			expected: []int{34, 33, 33},
+		},
+		{
+			weights:  []int{8, 2},
+			expected: []int{80, 20},
+		},
+		{
+			weights:  []int{1000, 1},
+			expected: []int{99, 1},
+		},
+		{
+			weights:  []int{1000, 1, 1},
+			expected: []int{98, 1, 1},
+		},
+	}
+
+	for _, test := range tests {
+		result := generateSplitWeights(test.weights)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("generateSplitWeights(%v) returned unexpected result (-want +got):\n%s", test.weights, diff)
+		}
+	}
+}
+
+func TestGenerateVirtualServerPath(t *testing.T) {
+	tests := []struct {
+		match    *httpPathMatch
+		expected string
+	}{
+		{
+			match:    nil,
+			expected: "/",
+		},
+		{
+			match:    &httpPathMatch{Type: "PathPrefix", Value: "/tea"},
+			expected: "/tea",
+		},
+		{
+			match:    &httpPathMatch{Type: "Exact", Value: "/tea"},
+			expected: "=/tea",
+		},
+		{
+			match:    &httpPathMatch{Type: "RegularExpression", Value: "^/tea/[a-z]+$"},
+			expected: "~^/tea/[a-z]+$",
+		},
+	}
+
+	for _, test := range tests {
+		result, err := generateVirtualServerPath(test.match)
+		if err != nil {
+			t.Errorf("generateVirtualServerPath(%+v) returned unexpected error: %v", test.match, err)
+		}
+		if result != test.expected {
+			t.Errorf("generateVirtualServerPath(%+v) returned %q but expected %q", test.match, result, test.expected)
+		}
+	}
+
+	_, err := generateVirtualServerPath(&httpPathMatch{Type: "Unknown", Value: "/tea"})
+	if err == nil {
+		t.Errorf("generateVirtualServerPath() returned no error for an unknown path match type")
+	}
+}
+
+func TestGenerateRedirectAction(t *testing.T) {
+	redirect := &httpRequestRedirect{
+		Scheme:     "https",
+		Port:       8443,
+		StatusCode: 301,
+	}
+
+	expected := &conf_v1.Action{
+		Redirect: &conf_v1.ActionRedirect{
+			URL:  "https://${host}:8443${request_uri}",
+			Code: 301,
+		},
+	}
+
+	result := generateRedirectAction(redirect)
+	if diff := cmp.Diff(expected, result); diff != "" {
+		t.Errorf("generateRedirectAction() returned unexpected result (-want +got):\n%s", diff)
+	}
+}
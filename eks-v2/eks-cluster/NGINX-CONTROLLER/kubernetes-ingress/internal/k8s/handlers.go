@@ -10,11 +10,11 @@ import (
 	"github.com/golang/glog"
 	"github.com/nginxinc/kubernetes-ingress/internal/k8s/secrets"
 	v1 "k8s.io/api/core/v1"
+	discovery_v1 "k8s.io/api/discovery/v1"
 	networking "k8s.io/api/networking/v1"
 	"k8s.io/client-go/tools/cache"
 
 	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
-	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
@@ -94,6 +94,41 @@ func createEndpointHandlers(lbc *LoadBalancerController) cache.ResourceEventHand
 	}
 }
 
+// createEndpointSliceHandlers builds the handler funcs for EndpointSlices
+func createEndpointSliceHandlers(lbc *LoadBalancerController) cache.ResourceEventHandlerFuncs {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			slice := obj.(*discovery_v1.EndpointSlice)
+			glog.V(3).Infof("Adding EndpointSlice: %v", slice.Name)
+			lbc.AddSyncQueue(obj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			slice, isSlice := obj.(*discovery_v1.EndpointSlice)
+			if !isSlice {
+				deletedState, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					glog.V(3).Infof("Error received unexpected object: %v", obj)
+					return
+				}
+				slice, ok = deletedState.Obj.(*discovery_v1.EndpointSlice)
+				if !ok {
+					glog.V(3).Infof("Error DeletedFinalStateUnknown contained non-EndpointSlice object: %v", deletedState.Obj)
+					return
+				}
+			}
+			glog.V(3).Infof("Removing EndpointSlice: %v", slice.Name)
+			// enqueue the EndpointSlice rather than the DeletedFinalStateUnknown, as the task needs the Service name label
+			lbc.AddSyncQueue(slice)
+		},
+		UpdateFunc: func(old, cur interface{}) {
+			if !reflect.DeepEqual(old, cur) {
+				glog.V(3).Infof("EndpointSlice %v changed, syncing", cur.(*discovery_v1.EndpointSlice).Name)
+				lbc.AddSyncQueue(cur)
+			}
+		},
+	}
+}
+
 // createIngressHandlers builds the handler funcs for ingresses
 func createIngressHandlers(lbc *LoadBalancerController) cache.ResourceEventHandlerFuncs {
 	return cache.ResourceEventHandlerFuncs{
@@ -313,6 +348,12 @@ func createVirtualServerHandlers(lbc *LoadBalancerController) cache.ResourceEven
 			if !reflect.DeepEqual(oldVs.Spec, curVs.Spec) {
 				glog.V(3).Infof("VirtualServer %v changed, syncing", curVs.Name)
 				lbc.AddSyncQueue(curVs)
+				return
+			}
+			// the pods other than the leader follow the progress of the canaries reported by the leader
+			if lbc.canaryManager != nil && !lbc.canaryManager.isLeader() && !reflect.DeepEqual(oldVs.Status.Canaries, curVs.Status.Canaries) {
+				glog.V(3).Infof("Progress of the canaries of VirtualServer %v changed, syncing", curVs.Name)
+				lbc.syncQueue.EnqueueTask(task{Kind: canaryProgress, Key: getResourceKey(&curVs.ObjectMeta)})
 			}
 		},
 	}
@@ -356,19 +397,19 @@ func createVirtualServerRouteHandlers(lbc *LoadBalancerController) cache.Resourc
 func createGlobalConfigurationHandlers(lbc *LoadBalancerController) cache.ResourceEventHandlerFuncs {
 	return cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
-			gc := obj.(*conf_v1alpha1.GlobalConfiguration)
+			gc := obj.(*conf_v1.GlobalConfiguration)
 			glog.V(3).Infof("Adding GlobalConfiguration: %v", gc.Name)
 			lbc.AddSyncQueue(gc)
 		},
 		DeleteFunc: func(obj interface{}) {
-			gc, isGc := obj.(*conf_v1alpha1.GlobalConfiguration)
+			gc, isGc := obj.(*conf_v1.GlobalConfiguration)
 			if !isGc {
 				deletedState, ok := obj.(cache.DeletedFinalStateUnknown)
 				if !ok {
 					glog.V(3).Infof("Error received unexpected object: %v", obj)
 					return
 				}
-				gc, ok = deletedState.Obj.(*conf_v1alpha1.GlobalConfiguration)
+				gc, ok = deletedState.Obj.(*conf_v1.GlobalConfiguration)
 				if !ok {
 					glog.V(3).Infof("Error DeletedFinalStateUnknown contained non-GlobalConfiguration object: %v", deletedState.Obj)
 					return
@@ -378,7 +419,7 @@ func createGlobalConfigurationHandlers(lbc *LoadBalancerController) cache.Resour
 			lbc.AddSyncQueue(gc)
 		},
 		UpdateFunc: func(old, cur interface{}) {
-			curGc := cur.(*conf_v1alpha1.GlobalConfiguration)
+			curGc := cur.(*conf_v1.GlobalConfiguration)
 			if !reflect.DeepEqual(old, cur) {
 				glog.V(3).Infof("GlobalConfiguration %v changed, syncing", curGc.Name)
 				lbc.AddSyncQueue(curGc)
@@ -390,19 +431,19 @@ func createGlobalConfigurationHandlers(lbc *LoadBalancerController) cache.Resour
 func createTransportServerHandlers(lbc *LoadBalancerController) cache.ResourceEventHandlerFuncs {
 	return cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
-			ts := obj.(*conf_v1alpha1.TransportServer)
+			ts := obj.(*conf_v1.TransportServer)
 			glog.V(3).Infof("Adding TransportServer: %v", ts.Name)
 			lbc.AddSyncQueue(ts)
 		},
 		DeleteFunc: func(obj interface{}) {
-			ts, isTs := obj.(*conf_v1alpha1.TransportServer)
+			ts, isTs := obj.(*conf_v1.TransportServer)
 			if !isTs {
 				deletedState, ok := obj.(cache.DeletedFinalStateUnknown)
 				if !ok {
 					glog.V(3).Infof("Error received unexpected object: %v", obj)
 					return
 				}
-				ts, ok = deletedState.Obj.(*conf_v1alpha1.TransportServer)
+				ts, ok = deletedState.Obj.(*conf_v1.TransportServer)
 				if !ok {
 					glog.V(3).Infof("Error DeletedFinalStateUnknown contained non-TransportServer object: %v", deletedState.Obj)
 					return
@@ -412,7 +453,7 @@ func createTransportServerHandlers(lbc *LoadBalancerController) cache.ResourceEv
 			lbc.AddSyncQueue(ts)
 		},
 		UpdateFunc: func(old, cur interface{}) {
-			curTs := cur.(*conf_v1alpha1.TransportServer)
+			curTs := cur.(*conf_v1.TransportServer)
 			if !reflect.DeepEqual(old, cur) {
 				glog.V(3).Infof("TransportServer %v changed, syncing", curTs.Name)
 				lbc.AddSyncQueue(curTs)
@@ -498,6 +539,49 @@ func createIngressLinkHandlers(lbc *LoadBalancerController) cache.ResourceEventH
 	}
 }
 
+// createGatewayAPIHandlers creates the handlers for GatewayClass, Gateway and HTTPRoute resources.
+func createGatewayAPIHandlers(lbc *LoadBalancerController) cache.ResourceEventHandlerFuncs {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			res := obj.(*unstructured.Unstructured)
+			glog.V(3).Infof("Adding %v: %v", res.GetKind(), res.GetName())
+			lbc.AddSyncQueue(res)
+		},
+		DeleteFunc: func(obj interface{}) {
+			res, isUnstructured := obj.(*unstructured.Unstructured)
+
+			if !isUnstructured {
+				deletedState, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					glog.V(3).Infof("Error received unexpected object: %v", obj)
+					return
+				}
+				res, ok = deletedState.Obj.(*unstructured.Unstructured)
+				if !ok {
+					glog.V(3).Infof("Error DeletedFinalStateUnknown contained non-Unstructured object: %v", deletedState.Obj)
+					return
+				}
+			}
+
+			glog.V(3).Infof("Removing %v: %v", res.GetKind(), res.GetName())
+			lbc.AddSyncQueue(res)
+		},
+		UpdateFunc: func(old, cur interface{}) {
+			oldRes := old.(*unstructured.Unstructured)
+			curRes := cur.(*unstructured.Unstructured)
+			different, err := areResourcesDifferent(oldRes, curRes)
+			if err != nil {
+				glog.V(3).Infof("Error when comparing %v resources: %v", curRes.GetKind(), err)
+				lbc.AddSyncQueue(curRes)
+			}
+			if different {
+				glog.V(3).Infof("%v %v changed, syncing", curRes.GetKind(), oldRes.GetName())
+				lbc.AddSyncQueue(curRes)
+			}
+		},
+	}
+}
+
 func createAppProtectPolicyHandlers(lbc *LoadBalancerController) cache.ResourceEventHandlerFuncs {
 	handlers := cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
@@ -678,3 +762,32 @@ func createAppProtectDosProtectedResourceHandlers(lbc *LoadBalancerController) c
 	}
 	return handlers
 }
+
+// createNamespaceHandlers builds the handler funcs for the namespaces that match the namespace label selector.
+// A namespace that stops matching the selector is reported as deleted by the informer.
+func createNamespaceHandlers(lbc *LoadBalancerController) cache.ResourceEventHandlerFuncs {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			ns := obj.(*v1.Namespace)
+			glog.V(3).Infof("Adding Namespace: %v", ns.Name)
+			lbc.AddSyncQueue(obj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			ns, isNs := obj.(*v1.Namespace)
+			if !isNs {
+				deletedState, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					glog.V(3).Infof("Error received unexpected object: %v", obj)
+					return
+				}
+				ns, ok = deletedState.Obj.(*v1.Namespace)
+				if !ok {
+					glog.V(3).Infof("Error DeletedFinalStateUnknown contained non-Namespace object: %v", deletedState.Obj)
+					return
+				}
+			}
+			glog.V(3).Infof("Removing Namespace: %v", ns.Name)
+			lbc.AddSyncQueue(ns)
+		},
+	}
+}
@@ -84,6 +84,11 @@ func createLeaderHandler(lbc *LoadBalancerController) leaderelection.LeaderCallb
 					glog.V(3).Infof("error updating TransportServers status when starting leading: %v", err)
 				}
 			}
+
+			if lbc.watchGatewayAPI && lbc.gatewayTranslation != nil {
+				glog.V(3).Info("updating Gateway API resources status")
+				lbc.updateGatewayAPIStatuses(lbc.gatewayTranslation)
+			}
 		},
 		OnStoppedLeading: func() {
 			glog.V(3).Info("stopped leading")
@@ -0,0 +1,484 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	k8s_nginx_informers "github.com/nginxinc/kubernetes-ingress/pkg/client/informers/externalversions"
+	api_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+)
+
+// namespaceSyncCheckInterval is the interval of the checks whether the caches of a newly watched namespace have synced.
+const namespaceSyncCheckInterval = 500 * time.Millisecond
+
+// namespacedInformer holds the informers of the resources of a watched namespace.
+// If all namespaces are watched, a single namespacedInformer for api_v1.NamespaceAll is used.
+// The informers of the selected factories watch only the resources that match the resource selector of the controller.
+type namespacedInformer struct {
+	namespace                         string
+	sharedInformerFactory             informers.SharedInformerFactory
+	selectedSharedInformerFactory     informers.SharedInformerFactory
+	confSharedInformerFactory         k8s_nginx_informers.SharedInformerFactory
+	selectedConfSharedInformerFactory k8s_nginx_informers.SharedInformerFactory
+	dynInformerFactory                dynamicinformer.DynamicSharedInformerFactory
+	gatewayInformerFactory            dynamicinformer.DynamicSharedInformerFactory
+	// stores are the stores of the informers with handlers, which resources are synced by the controller.
+	stores     []cache.Store
+	cacheSyncs []cache.InformerSynced
+	cancel     context.CancelFunc
+	// synced is true once the caches of the informers have synced and the resources of the namespace can be synced.
+	synced bool
+}
+
+// addInformer adds the informer of a resource of the namespace. The lister of the resource, which must be
+// created by newNamespacedIndexer, gets the store of the informer.
+func (nsi *namespacedInformer) addInformer(lister cache.Store, informer cache.SharedIndexInformer, handlers cache.ResourceEventHandlerFuncs) {
+	informer.AddEventHandler(handlers)
+	lister.(*namespacedIndexer).addNamespace(nsi.namespace, informer.GetIndexer())
+
+	nsi.stores = append(nsi.stores, informer.GetStore())
+	nsi.cacheSyncs = append(nsi.cacheSyncs, informer.HasSynced)
+}
+
+// newNamespacedIndexer creates a lister for a namespaced resource, which combines the stores of
+// the informers of the resource in the watched namespaces.
+func (lbc *LoadBalancerController) newNamespacedIndexer() *namespacedIndexer {
+	ni := &namespacedIndexer{
+		indexers: make(map[string]cache.Indexer),
+	}
+
+	lbc.namespacedIndexers = append(lbc.namespacedIndexers, ni)
+
+	return ni
+}
+
+// newNamespacedInformer creates the informers of the resources of the namespace.
+func (lbc *LoadBalancerController) newNamespacedInformer(namespace string) *namespacedInformer {
+	nsi := &namespacedInformer{
+		namespace:             namespace,
+		sharedInformerFactory: informers.NewSharedInformerFactoryWithOptions(lbc.client, lbc.resync, informers.WithNamespace(namespace)),
+	}
+
+	nsi.selectedSharedInformerFactory = nsi.sharedInformerFactory
+	if lbc.resourceSelector != nil {
+		nsi.selectedSharedInformerFactory = informers.NewSharedInformerFactoryWithOptions(lbc.client, lbc.resync, informers.WithNamespace(namespace),
+			informers.WithTweakListOptions(lbc.addResourceSelector))
+	}
+
+	lbc.addSecretHandler(nsi, createSecretHandlers(lbc))
+	lbc.addIngressHandler(nsi, createIngressHandlers(lbc))
+	lbc.addServiceHandler(nsi, createServiceHandlers(lbc))
+	if lbc.areEndpointSlicesEnabled {
+		lbc.addEndpointSliceHandler(nsi, createEndpointSliceHandlers(lbc))
+	} else {
+		lbc.addEndpointHandler(nsi, createEndpointHandlers(lbc))
+	}
+	lbc.addPodHandler(nsi)
+
+	if lbc.areCustomResourcesEnabled {
+		nsi.confSharedInformerFactory = k8s_nginx_informers.NewSharedInformerFactoryWithOptions(lbc.confClient, lbc.resync, k8s_nginx_informers.WithNamespace(namespace))
+
+		nsi.selectedConfSharedInformerFactory = nsi.confSharedInformerFactory
+		if lbc.resourceSelector != nil {
+			nsi.selectedConfSharedInformerFactory = k8s_nginx_informers.NewSharedInformerFactoryWithOptions(lbc.confClient, lbc.resync, k8s_nginx_informers.WithNamespace(namespace),
+				k8s_nginx_informers.WithTweakListOptions(lbc.addResourceSelector))
+		}
+
+		lbc.addVirtualServerHandler(nsi, createVirtualServerHandlers(lbc))
+		lbc.addVirtualServerRouteHandler(nsi, createVirtualServerRouteHandlers(lbc))
+		lbc.addTransportServerHandler(nsi, createTransportServerHandlers(lbc))
+		lbc.addPolicyHandler(nsi, createPolicyHandlers(lbc))
+	}
+
+	if lbc.appProtectEnabled || lbc.appProtectDosEnabled {
+		nsi.dynInformerFactory = dynamicinformer.NewFilteredDynamicSharedInformerFactory(lbc.dynClient, 0, namespace, nil)
+
+		if lbc.appProtectEnabled {
+			lbc.addAppProtectPolicyHandler(nsi, createAppProtectPolicyHandlers(lbc))
+			lbc.addAppProtectLogConfHandler(nsi, createAppProtectLogConfHandlers(lbc))
+			lbc.addAppProtectUserSigHandler(nsi, createAppProtectUserSigHandlers(lbc))
+		}
+
+		if lbc.appProtectDosEnabled {
+			lbc.addAppProtectDosPolicyHandler(nsi, createAppProtectDosPolicyHandlers(lbc))
+			lbc.addAppProtectDosLogConfHandler(nsi, createAppProtectDosLogConfHandlers(lbc))
+			lbc.addAppProtectDosProtectedResourceHandler(nsi, createAppProtectDosProtectedResourceHandlers(lbc))
+		}
+	}
+
+	if lbc.watchGatewayAPI {
+		nsi.gatewayInformerFactory = dynamicinformer.NewFilteredDynamicSharedInformerFactory(lbc.dynClient, lbc.resync, namespace, nil)
+
+		lbc.addGatewayAPIHandlers(nsi, createGatewayAPIHandlers(lbc))
+	}
+
+	lbc.namespacedInformers[namespace] = nsi
+
+	return nsi
+}
+
+// startNamespacedInformer starts the informers of the namespace. They are stopped when the controller stops
+// or when the namespace is no longer watched.
+func (lbc *LoadBalancerController) startNamespacedInformer(nsi *namespacedInformer) {
+	var ctx context.Context
+	ctx, nsi.cancel = context.WithCancel(lbc.ctx)
+
+	// the selected factories can be the same as the other factories, but starting a factory twice is safe
+	go nsi.sharedInformerFactory.Start(ctx.Done())
+	go nsi.selectedSharedInformerFactory.Start(ctx.Done())
+	if nsi.confSharedInformerFactory != nil {
+		go nsi.confSharedInformerFactory.Start(ctx.Done())
+		go nsi.selectedConfSharedInformerFactory.Start(ctx.Done())
+	}
+	if nsi.dynInformerFactory != nil {
+		go nsi.dynInformerFactory.Start(ctx.Done())
+	}
+	if nsi.gatewayInformerFactory != nil {
+		go nsi.gatewayInformerFactory.Start(ctx.Done())
+	}
+}
+
+// addResourceSelector restricts the listed resources to the resources that match the resource selector of the controller.
+func (lbc *LoadBalancerController) addResourceSelector(options *meta_v1.ListOptions) {
+	options.LabelSelector = lbc.resourceSelector.String()
+}
+
+// isSelectedResource returns true if the resource matches the resource selector of the controller. The selector restricts
+// the Ingress, VirtualServer, VirtualServerRoute, TransportServer and Policy resources that the controller handles, so that
+// several controllers of the same class can share the resources.
+func (lbc *LoadBalancerController) isSelectedResource(meta *meta_v1.ObjectMeta) bool {
+	return lbc.resourceSelector == nil || lbc.resourceSelector.Matches(labels.Set(meta.Labels))
+}
+
+// addNamespaceHandler adds the handler for the namespaces that match the selector to the controller.
+func (lbc *LoadBalancerController) addNamespaceHandler(handlers cache.ResourceEventHandlerFuncs, selector labels.Selector) {
+	optionsModifier := func(options *meta_v1.ListOptions) {
+		options.LabelSelector = selector.String()
+	}
+
+	lbc.namespaceLister, lbc.namespaceController = cache.NewInformer(
+		cache.NewFilteredListWatchFromClient(
+			lbc.client.CoreV1().RESTClient(),
+			"namespaces",
+			api_v1.NamespaceAll,
+			optionsModifier),
+		&api_v1.Namespace{},
+		lbc.resync,
+		handlers,
+	)
+	lbc.cacheSyncs = append(lbc.cacheSyncs, lbc.namespaceController.HasSynced)
+}
+
+// syncNamespace starts watching the resources of a namespace that matches the namespace label selector,
+// or stops watching the resources of a namespace that no longer matches the selector or was deleted.
+func (lbc *LoadBalancerController) syncNamespace(task task) {
+	key := task.Key
+	glog.V(3).Infof("Syncing Namespace %v", key)
+
+	_, exists, err := lbc.namespaceLister.GetByKey(key)
+	if err != nil {
+		lbc.syncQueue.Requeue(task, err)
+		return
+	}
+
+	nsi, isWatched := lbc.namespacedInformers[key]
+
+	if exists && !isWatched {
+		nsi = lbc.watchNamespace(key)
+	} else if !exists && isWatched {
+		lbc.unwatchNamespace(key)
+		return
+	}
+
+	if exists && !nsi.synced {
+		lbc.syncNamespacedInformer(task, nsi)
+	}
+}
+
+// watchNamespace starts watching the resources of the namespace. The informers of the namespace add its resources
+// to the sync queue.
+func (lbc *LoadBalancerController) watchNamespace(namespace string) *namespacedInformer {
+	glog.V(2).Infof("Starting watching namespace %v", namespace)
+
+	nsi := lbc.newNamespacedInformer(namespace)
+	lbc.startNamespacedInformer(nsi)
+
+	return nsi
+}
+
+// syncNamespacedInformer marks the informers of a newly watched namespace as synced once their caches have synced.
+// Waiting for the caches would block the sync queue, so the task of the namespace is requeued until then.
+// The tasks of the resources of the namespace are skipped until the caches have synced, so all the resources
+// are added to the sync queue afterwards.
+func (lbc *LoadBalancerController) syncNamespacedInformer(task task, nsi *namespacedInformer) {
+	for _, hasSynced := range nsi.cacheSyncs {
+		if !hasSynced() {
+			lbc.syncQueue.EnqueueTaskAfter(task, namespaceSyncCheckInterval)
+			return
+		}
+	}
+
+	glog.V(3).Infof("The caches of namespace %v have synced", nsi.namespace)
+
+	nsi.synced = true
+	lbc.preSyncSecrets(nsi.namespace)
+
+	for _, store := range nsi.stores {
+		for _, obj := range store.List() {
+			lbc.AddSyncQueue(obj)
+		}
+	}
+}
+
+// isNamespaceSynced returns false if the namespace is watched but the caches of its informers haven't synced yet.
+func (lbc *LoadBalancerController) isNamespaceSynced(namespace string) bool {
+	nsi, exists := lbc.namespacedInformers[namespace]
+	return !exists || nsi.synced
+}
+
+// areNamespacesSynced returns true if the caches of the informers of all watched namespaces have synced.
+func (lbc *LoadBalancerController) areNamespacesSynced() bool {
+	for _, nsi := range lbc.namespacedInformers {
+		if !nsi.synced {
+			return false
+		}
+	}
+	return true
+}
+
+// unwatchNamespace stops watching the resources of the namespace and removes them from the configuration.
+func (lbc *LoadBalancerController) unwatchNamespace(namespace string) {
+	glog.V(2).Infof("Stopping watching namespace %v", namespace)
+
+	nsi := lbc.namespacedInformers[namespace]
+	nsi.cancel()
+
+	var objects []interface{}
+	for _, store := range nsi.stores {
+		objects = append(objects, store.List()...)
+	}
+
+	for _, ni := range lbc.namespacedIndexers {
+		ni.removeNamespace(namespace)
+	}
+	delete(lbc.namespacedInformers, namespace)
+
+	// the listers no longer have the resources of the namespace, so syncing them deletes them like deleted resources
+	for _, obj := range objects {
+		lbc.AddSyncQueue(obj)
+	}
+}
+
+// namespacedIndexer is a cache.Indexer that combines the indexers of the informers of a resource
+// in the watched namespaces. This way, the controller uses one lister for a resource regardless of
+// how many namespaces it watches.
+type namespacedIndexer struct {
+	lock     sync.RWMutex
+	indexers map[string]cache.Indexer
+}
+
+func (ni *namespacedIndexer) addNamespace(namespace string, indexer cache.Indexer) {
+	ni.lock.Lock()
+	defer ni.lock.Unlock()
+
+	ni.indexers[namespace] = indexer
+}
+
+func (ni *namespacedIndexer) removeNamespace(namespace string) {
+	ni.lock.Lock()
+	defer ni.lock.Unlock()
+
+	delete(ni.indexers, namespace)
+}
+
+// getIndexer returns the indexer of the namespace. If all namespaces are watched, the indexer of all namespaces is returned.
+func (ni *namespacedIndexer) getIndexer(namespace string) (cache.Indexer, bool) {
+	ni.lock.RLock()
+	defer ni.lock.RUnlock()
+
+	if indexer, exists := ni.indexers[namespace]; exists {
+		return indexer, true
+	}
+
+	indexer, exists := ni.indexers[api_v1.NamespaceAll]
+	return indexer, exists
+}
+
+func (ni *namespacedIndexer) getIndexerForObject(obj interface{}) (cache.Indexer, error) {
+	key, err := keyFunc(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace, _, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	indexer, exists := ni.getIndexer(namespace)
+	if !exists {
+		return nil, fmt.Errorf("namespace %q is not watched", namespace)
+	}
+
+	return indexer, nil
+}
+
+func (ni *namespacedIndexer) getAllIndexers() []cache.Indexer {
+	ni.lock.RLock()
+	defer ni.lock.RUnlock()
+
+	var indexers []cache.Indexer
+	for _, indexer := range ni.indexers {
+		indexers = append(indexers, indexer)
+	}
+
+	return indexers
+}
+
+// Add adds the object to the indexer of its namespace.
+func (ni *namespacedIndexer) Add(obj interface{}) error {
+	indexer, err := ni.getIndexerForObject(obj)
+	if err != nil {
+		return err
+	}
+	return indexer.Add(obj)
+}
+
+// Update updates the object in the indexer of its namespace.
+func (ni *namespacedIndexer) Update(obj interface{}) error {
+	indexer, err := ni.getIndexerForObject(obj)
+	if err != nil {
+		return err
+	}
+	return indexer.Update(obj)
+}
+
+// Delete deletes the object from the indexer of its namespace.
+func (ni *namespacedIndexer) Delete(obj interface{}) error {
+	indexer, err := ni.getIndexerForObject(obj)
+	if err != nil {
+		return err
+	}
+	return indexer.Delete(obj)
+}
+
+// List lists the objects of all watched namespaces.
+func (ni *namespacedIndexer) List() []interface{} {
+	var objects []interface{}
+	for _, indexer := range ni.getAllIndexers() {
+		objects = append(objects, indexer.List()...)
+	}
+	return objects
+}
+
+// ListKeys lists the keys of the objects of all watched namespaces.
+func (ni *namespacedIndexer) ListKeys() []string {
+	var keys []string
+	for _, indexer := range ni.getAllIndexers() {
+		keys = append(keys, indexer.ListKeys()...)
+	}
+	return keys
+}
+
+// Get gets the object from the indexer of its namespace.
+func (ni *namespacedIndexer) Get(obj interface{}) (item interface{}, exists bool, err error) {
+	key, err := keyFunc(obj)
+	if err != nil {
+		return nil, false, err
+	}
+	return ni.GetByKey(key)
+}
+
+// GetByKey gets the object by its key from the indexer of its namespace.
+// If the namespace is not watched, the object doesn't exist.
+func (ni *namespacedIndexer) GetByKey(key string) (item interface{}, exists bool, err error) {
+	namespace, _, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return nil, false, err
+	}
+
+	indexer, watched := ni.getIndexer(namespace)
+	if !watched {
+		return nil, false, nil
+	}
+
+	return indexer.GetByKey(key)
+}
+
+// Replace is not supported, because the informers of the namespaces own the indexers.
+func (ni *namespacedIndexer) Replace(_ []interface{}, _ string) error {
+	return fmt.Errorf("replace is not supported by the namespaced indexer")
+}
+
+// Resync resyncs the indexers of all watched namespaces.
+func (ni *namespacedIndexer) Resync() error {
+	for _, indexer := range ni.getAllIndexers() {
+		err := indexer.Resync()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Index returns the objects from the indexer of the namespace of the object that match the object for the index.
+func (ni *namespacedIndexer) Index(indexName string, obj interface{}) ([]interface{}, error) {
+	indexer, err := ni.getIndexerForObject(obj)
+	if err != nil {
+		return nil, err
+	}
+	return indexer.Index(indexName, obj)
+}
+
+// IndexKeys returns the keys of the objects of all watched namespaces with the indexed value.
+func (ni *namespacedIndexer) IndexKeys(indexName, indexedValue string) ([]string, error) {
+	var keys []string
+	for _, indexer := range ni.getAllIndexers() {
+		indexerKeys, err := indexer.IndexKeys(indexName, indexedValue)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, indexerKeys...)
+	}
+	return keys, nil
+}
+
+// ListIndexFuncValues returns the indexed values of the index of all watched namespaces.
+func (ni *namespacedIndexer) ListIndexFuncValues(indexName string) []string {
+	var values []string
+	for _, indexer := range ni.getAllIndexers() {
+		values = append(values, indexer.ListIndexFuncValues(indexName)...)
+	}
+	return values
+}
+
+// ByIndex returns the objects of all watched namespaces with the indexed value.
+func (ni *namespacedIndexer) ByIndex(indexName, indexedValue string) ([]interface{}, error) {
+	var objects []interface{}
+	for _, indexer := range ni.getAllIndexers() {
+		indexerObjects, err := indexer.ByIndex(indexName, indexedValue)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, indexerObjects...)
+	}
+	return objects, nil
+}
+
+// GetIndexers returns the indexers of the namespace index, which all the informers have.
+func (ni *namespacedIndexer) GetIndexers() cache.Indexers {
+	return cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}
+}
+
+// AddIndexers is not supported, because the informers of the namespaces own the indexers.
+func (ni *namespacedIndexer) AddIndexers(_ cache.Indexers) error {
+	return fmt.Errorf("adding indexers is not supported by the namespaced indexer")
+}
@@ -0,0 +1,146 @@
+package k8s
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	api_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+func createTestNamespacedIndexer(t *testing.T, namespaces ...string) *namespacedIndexer {
+	t.Helper()
+
+	lbc := &LoadBalancerController{}
+	ni := lbc.newNamespacedIndexer()
+
+	for _, ns := range namespaces {
+		indexer := cache.NewIndexer(keyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+		err := indexer.Add(&api_v1.Pod{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "pod",
+				Namespace: ns,
+				Labels:    map[string]string{"app": "test"},
+			},
+		})
+		if err != nil {
+			t.Fatalf("Failed to add a pod: %v", err)
+		}
+		ni.addNamespace(ns, indexer)
+	}
+
+	return ni
+}
+
+func TestNamespacedIndexer(t *testing.T) {
+	ni := createTestNamespacedIndexer(t, "ns-1", "ns-2")
+
+	keys := ni.ListKeys()
+	sort.Strings(keys)
+	expectedKeys := []string{"ns-1/pod", "ns-2/pod"}
+	if diff := cmp.Diff(expectedKeys, keys); diff != "" {
+		t.Errorf("ListKeys() returned unexpected result (-want +got):\n%s", diff)
+	}
+
+	if _, exists, err := ni.GetByKey("ns-2/pod"); !exists || err != nil {
+		t.Errorf("GetByKey(%q) returned exists %v and error %v, but expected an existing object", "ns-2/pod", exists, err)
+	}
+
+	if _, exists, err := ni.GetByKey("ns-3/pod"); exists || err != nil {
+		t.Errorf("GetByKey(%q) returned exists %v and error %v for a namespace that is not watched", "ns-3/pod", exists, err)
+	}
+
+	pods, err := indexerToPodLister{Indexer: ni}.ListByNamespace("ns-1", labels.Set{"app": "test"}.AsSelector())
+	if err != nil || len(pods) != 1 || pods[0].Namespace != "ns-1" {
+		t.Errorf("ListByNamespace(%q) returned %v and error %v, but expected the pod of the namespace", "ns-1", pods, err)
+	}
+
+	ni.removeNamespace("ns-1")
+
+	if _, exists, _ := ni.GetByKey("ns-1/pod"); exists {
+		t.Errorf("GetByKey(%q) returned an object of a removed namespace", "ns-1/pod")
+	}
+	if objects := ni.List(); len(objects) != 1 {
+		t.Errorf("List() returned %d objects after removing a namespace, but expected 1", len(objects))
+	}
+}
+
+func TestNamespacedIndexerAllNamespaces(t *testing.T) {
+	ni := createTestNamespacedIndexer(t, api_v1.NamespaceAll)
+
+	pod := &api_v1.Pod{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "pod",
+			Namespace: "default",
+		},
+	}
+
+	err := ni.Add(pod)
+	if err != nil {
+		t.Fatalf("Add() returned an unexpected error: %v", err)
+	}
+
+	if _, exists, err := ni.GetByKey("default/pod"); !exists || err != nil {
+		t.Errorf("GetByKey(%q) returned exists %v and error %v, but expected an existing object", "default/pod", exists, err)
+	}
+}
+
+func TestSyncNamespacedInformer(t *testing.T) {
+	lbc := &LoadBalancerController{
+		syncQueue:           newTaskQueue(func(task) {}),
+		namespacedInformers: make(map[string]*namespacedInformer),
+	}
+	lbc.secretLister = lbc.newNamespacedIndexer()
+
+	store := cache.NewStore(keyFunc)
+	err := store.Add(&api_v1.Service{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "svc",
+			Namespace: "ns-1",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to add a service: %v", err)
+	}
+
+	hasSynced := false
+	nsi := &namespacedInformer{
+		namespace:  "ns-1",
+		stores:     []cache.Store{store},
+		cacheSyncs: []cache.InformerSynced{func() bool { return hasSynced }},
+	}
+	lbc.namespacedInformers["ns-1"] = nsi
+
+	namespaceTask := task{Kind: namespaceResource, Key: "ns-1"}
+
+	lbc.syncNamespacedInformer(namespaceTask, nsi)
+
+	if nsi.synced {
+		t.Errorf("syncNamespacedInformer() marked the namespace as synced before its caches have synced")
+	}
+	if lbc.isNamespaceSynced("ns-1") || lbc.areNamespacesSynced() {
+		t.Errorf("the namespace is reported as synced before its caches have synced")
+	}
+	if lbc.syncQueue.Len() != 0 {
+		t.Errorf("syncNamespacedInformer() added the resources of the namespace before its caches have synced")
+	}
+
+	hasSynced = true
+	lbc.syncNamespacedInformer(namespaceTask, nsi)
+
+	if !nsi.synced {
+		t.Errorf("syncNamespacedInformer() didn't mark the namespace as synced")
+	}
+	if !lbc.isNamespaceSynced("ns-1") || !lbc.areNamespacesSynced() {
+		t.Errorf("the namespace is not reported as synced after its caches have synced")
+	}
+	if !lbc.isNamespaceSynced("ns-2") {
+		t.Errorf("a namespace that is not watched is reported as not synced")
+	}
+	if lbc.syncQueue.Len() != 1 {
+		t.Errorf("syncNamespacedInformer() added %d resources of the namespace but expected 1", lbc.syncQueue.Len())
+	}
+}
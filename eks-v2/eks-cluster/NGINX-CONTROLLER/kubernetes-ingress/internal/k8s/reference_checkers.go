@@ -5,7 +5,6 @@ import (
 
 	"github.com/nginxinc/kubernetes-ingress/internal/configs"
 	v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
-	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
 	networking "k8s.io/api/networking/v1"
 )
 
@@ -14,7 +13,7 @@ type resourceReferenceChecker interface {
 	IsReferencedByMinion(namespace string, name string, ing *networking.Ingress) bool
 	IsReferencedByVirtualServer(namespace string, name string, vs *v1.VirtualServer) bool
 	IsReferencedByVirtualServerRoute(namespace string, name string, vsr *v1.VirtualServerRoute) bool
-	IsReferencedByTransportServer(namespace string, name string, ts *conf_v1alpha1.TransportServer) bool
+	IsReferencedByTransportServer(namespace string, name string, ts *v1.TransportServer) bool
 }
 
 type secretReferenceChecker struct {
@@ -79,7 +78,21 @@ func (rc *secretReferenceChecker) IsReferencedByVirtualServerRoute(_ string, _ s
 	return false
 }
 
-func (rc *secretReferenceChecker) IsReferencedByTransportServer(_ string, _ string, _ *conf_v1alpha1.TransportServer) bool {
+func (rc *secretReferenceChecker) IsReferencedByTransportServer(secretNamespace string, secretName string, ts *v1.TransportServer) bool {
+	if ts.Namespace != secretNamespace {
+		return false
+	}
+
+	if ts.Spec.TLS != nil && ts.Spec.TLS.Secret == secretName {
+		return true
+	}
+
+	for _, u := range ts.Spec.Upstreams {
+		if u.TLS != nil && u.TLS.TrustedCertSecret == secretName {
+			return true
+		}
+	}
+
 	return false
 }
 
@@ -153,7 +166,7 @@ func (rc *serviceReferenceChecker) IsReferencedByVirtualServerRoute(svcNamespace
 	return false
 }
 
-func (rc *serviceReferenceChecker) IsReferencedByTransportServer(svcNamespace string, svcName string, ts *conf_v1alpha1.TransportServer) bool {
+func (rc *serviceReferenceChecker) IsReferencedByTransportServer(svcNamespace string, svcName string, ts *v1.TransportServer) bool {
 	if ts.Namespace != svcNamespace {
 		return false
 	}
@@ -205,8 +218,8 @@ func (rc *policyReferenceChecker) IsReferencedByVirtualServerRoute(policyNamespa
 	return false
 }
 
-func (rc *policyReferenceChecker) IsReferencedByTransportServer(_ string, _ string, _ *conf_v1alpha1.TransportServer) bool {
-	return false
+func (rc *policyReferenceChecker) IsReferencedByTransportServer(policyNamespace string, policyName string, ts *v1.TransportServer) bool {
+	return isPolicyReferenced(ts.Spec.Policies, ts.Namespace, policyNamespace, policyName)
 }
 
 // appProtectResourceReferenceChecker is a reference checker for AppProtect related resources.
@@ -243,7 +256,7 @@ func (rc *appProtectResourceReferenceChecker) IsReferencedByVirtualServerRoute(_
 	return false
 }
 
-func (rc *appProtectResourceReferenceChecker) IsReferencedByTransportServer(_ string, _ string, _ *conf_v1alpha1.TransportServer) bool {
+func (rc *appProtectResourceReferenceChecker) IsReferencedByTransportServer(_ string, _ string, _ *v1.TransportServer) bool {
 	return false
 }
 
@@ -303,6 +316,6 @@ func (rc *dosResourceReferenceChecker) IsReferencedByVirtualServerRoute(namespac
 	return false
 }
 
-func (rc *dosResourceReferenceChecker) IsReferencedByTransportServer(_ string, _ string, _ *conf_v1alpha1.TransportServer) bool {
+func (rc *dosResourceReferenceChecker) IsReferencedByTransportServer(_ string, _ string, _ *v1.TransportServer) bool {
 	return false
 }
@@ -5,7 +5,6 @@ import (
 
 	"github.com/nginxinc/kubernetes-ingress/internal/configs"
 	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
-	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
 	networking "k8s.io/api/networking/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -309,13 +308,93 @@ func TestSecretIsReferencedByVirtualServerRoute(t *testing.T) {
 }
 
 func TestSecretIsReferencedByTransportServer(t *testing.T) {
-	isPlus := false // doesn't matter for TransportServer
-	rc := newSecretReferenceChecker(isPlus)
+	tests := []struct {
+		ts              *conf_v1.TransportServer
+		secretNamespace string
+		secretName      string
+		expected        bool
+		msg             string
+	}{
+		{
+			ts: &conf_v1.TransportServer{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "default",
+				},
+				Spec: conf_v1.TransportServerSpec{
+					TLS: &conf_v1.TransportServerTLS{
+						Secret: "test-secret",
+					},
+				},
+			},
+			secretNamespace: "default",
+			secretName:      "test-secret",
+			expected:        true,
+			msg:             "tls secret is referenced",
+		},
+		{
+			ts: &conf_v1.TransportServer{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "default",
+				},
+				Spec: conf_v1.TransportServerSpec{
+					Upstreams: []conf_v1.TransportServerUpstream{
+						{
+							Name: "tcp-app",
+							TLS: &conf_v1.TransportServerUpstreamTLS{
+								Enable:            true,
+								TrustedCertSecret: "test-secret",
+							},
+						},
+					},
+				},
+			},
+			secretNamespace: "default",
+			secretName:      "test-secret",
+			expected:        true,
+			msg:             "upstream trusted cert secret is referenced",
+		},
+		{
+			ts: &conf_v1.TransportServer{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "default",
+				},
+				Spec: conf_v1.TransportServerSpec{
+					TLS: &conf_v1.TransportServerTLS{
+						Secret: "test-secret",
+					},
+				},
+			},
+			secretNamespace: "default",
+			secretName:      "some-secret",
+			expected:        false,
+			msg:             "wrong name for tls secret",
+		},
+		{
+			ts: &conf_v1.TransportServer{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "default",
+				},
+				Spec: conf_v1.TransportServerSpec{
+					TLS: &conf_v1.TransportServerTLS{
+						Secret: "test-secret",
+					},
+				},
+			},
+			secretNamespace: "some-namespace",
+			secretName:      "test-secret",
+			expected:        false,
+			msg:             "wrong namespace for tls secret",
+		},
+	}
 
-	// always returns false
-	result := rc.IsReferencedByTransportServer("", "", nil)
-	if result != false {
-		t.Error("IsReferencedByTransportServer() returned true but expected false")
+	for _, test := range tests {
+		isPlus := false // doesn't matter for TransportServer
+		rc := newSecretReferenceChecker(isPlus)
+
+		result := rc.IsReferencedByTransportServer(test.secretNamespace, test.secretName, test.ts)
+		if result != test.expected {
+			t.Errorf("IsReferencedByTransportServer() returned %v but expected %v for the case of %s", result, test.expected, test.msg)
+		}
 	}
 }
 
@@ -571,19 +650,19 @@ func TestServiceIsReferencedByVirtualServerAndVirtualServerRoutes(t *testing.T)
 
 func TestIsServiceReferencedByTransportServer(t *testing.T) {
 	tests := []struct {
-		ts               *conf_v1alpha1.TransportServer
+		ts               *conf_v1.TransportServer
 		serviceNamespace string
 		serviceName      string
 		expected         bool
 		msg              string
 	}{
 		{
-			ts: &conf_v1alpha1.TransportServer{
+			ts: &conf_v1.TransportServer{
 				ObjectMeta: v1.ObjectMeta{
 					Namespace: "default",
 				},
-				Spec: conf_v1alpha1.TransportServerSpec{
-					Upstreams: []conf_v1alpha1.Upstream{
+				Spec: conf_v1.TransportServerSpec{
+					Upstreams: []conf_v1.TransportServerUpstream{
 						{
 							Service: "test-service",
 						},
@@ -596,12 +675,12 @@ func TestIsServiceReferencedByTransportServer(t *testing.T) {
 			msg:              "service is referenced in an upstream",
 		},
 		{
-			ts: &conf_v1alpha1.TransportServer{
+			ts: &conf_v1.TransportServer{
 				ObjectMeta: v1.ObjectMeta{
 					Namespace: "default",
 				},
-				Spec: conf_v1alpha1.TransportServerSpec{
-					Upstreams: []conf_v1alpha1.Upstream{
+				Spec: conf_v1.TransportServerSpec{
+					Upstreams: []conf_v1.TransportServerUpstream{
 						{
 							Service: "test-service",
 						},
@@ -614,12 +693,12 @@ func TestIsServiceReferencedByTransportServer(t *testing.T) {
 			msg:              "wrong namespace for service in an upstream",
 		},
 		{
-			ts: &conf_v1alpha1.TransportServer{
+			ts: &conf_v1.TransportServer{
 				ObjectMeta: v1.ObjectMeta{
 					Namespace: "default",
 				},
-				Spec: conf_v1alpha1.TransportServerSpec{
-					Upstreams: []conf_v1alpha1.Upstream{
+				Spec: conf_v1.TransportServerSpec{
+					Upstreams: []conf_v1.TransportServerUpstream{
 						{
 							Service: "test-service",
 						},
@@ -643,7 +722,7 @@ func TestIsServiceReferencedByTransportServer(t *testing.T) {
 	}
 }
 
-func TestPolicyIsReferencedByIngressesAndTransportServers(t *testing.T) {
+func TestPolicyIsReferencedByIngresses(t *testing.T) {
 	rc := newPolicyReferenceChecker()
 
 	result := rc.IsReferencedByIngress("", "", nil)
@@ -655,10 +734,73 @@ func TestPolicyIsReferencedByIngressesAndTransportServers(t *testing.T) {
 	if result != false {
 		t.Error("IsReferencedByMinion() returned true but expected false")
 	}
+}
 
-	result = rc.IsReferencedByTransportServer("", "", nil)
-	if result != false {
-		t.Error("IsReferencedByTransportServer() returned true but expected false")
+func TestPolicyIsReferencedByTransportServer(t *testing.T) {
+	tests := []struct {
+		ts              *conf_v1.TransportServer
+		policyNamespace string
+		policyName      string
+		expected        bool
+		msg             string
+	}{
+		{
+			ts: &conf_v1.TransportServer{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "default",
+				},
+				Spec: conf_v1.TransportServerSpec{
+					Policies: []conf_v1.PolicyReference{
+						{
+							Name: "test-policy",
+						},
+					},
+				},
+			},
+			policyNamespace: "default",
+			policyName:      "test-policy",
+			expected:        true,
+			msg:             "policy is referenced",
+		},
+		{
+			ts: &conf_v1.TransportServer{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "default",
+				},
+				Spec: conf_v1.TransportServerSpec{
+					Policies: []conf_v1.PolicyReference{
+						{
+							Name:      "test-policy",
+							Namespace: "nginx-ingress",
+						},
+					},
+				},
+			},
+			policyNamespace: "default",
+			policyName:      "test-policy",
+			expected:        false,
+			msg:             "policy in another namespace is referenced",
+		},
+		{
+			ts: &conf_v1.TransportServer{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "default",
+				},
+			},
+			policyNamespace: "default",
+			policyName:      "test-policy",
+			expected:        false,
+			msg:             "no policies",
+		},
+	}
+
+	rc := newPolicyReferenceChecker()
+
+	for _, test := range tests {
+		result := rc.IsReferencedByTransportServer(test.policyNamespace, test.policyName, test.ts)
+		if result != test.expected {
+			t.Errorf("IsReferencedByTransportServer() returned %v but expected %v for the case of %s", result, test.expected, test.msg)
+		}
 	}
 }
 
@@ -0,0 +1,226 @@
+package k8s
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/nginxinc/kubernetes-ingress/internal/configs"
+	"github.com/nginxinc/kubernetes-ingress/internal/k8s/appprotect"
+	"github.com/nginxinc/kubernetes-ingress/internal/k8s/appprotectdos"
+	"github.com/nginxinc/kubernetes-ingress/internal/k8s/secrets"
+	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
+	"github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/validation"
+	api_v1 "k8s.io/api/core/v1"
+	discovery_v1 "k8s.io/api/discovery/v1"
+	networking "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+)
+
+// RendererInput holds the input needed to call NewRenderer.
+type RendererInput struct {
+	NginxConfigurator            *configs.Configurator
+	IsNginxPlus                  bool
+	IngressClass                 string
+	EnablePreviewPolicies        bool
+	IsTLSPassthroughEnabled      bool
+	SnippetsEnabled              bool
+	GlobalConfigurationValidator *validation.GlobalConfigurationValidator
+	TransportServerValidator     *validation.TransportServerValidator
+	VirtualServerValidator       *validation.VirtualServerValidator
+}
+
+// Renderer generates the NGINX configuration for a set of resources without a Kubernetes cluster.
+// It uses the same code as the LoadBalancerController, but the resources come from the caller rather than
+// from the Kubernetes API, and the statuses and events of the resources are not reported.
+type Renderer struct {
+	lbc     *LoadBalancerController
+	objects []runtime.Object
+}
+
+// NewRenderer creates a Renderer.
+func NewRenderer(input RendererInput) *Renderer {
+	lbc := &LoadBalancerController{
+		configurator:                 input.NginxConfigurator,
+		isNginxPlus:                  input.IsNginxPlus,
+		ingressClass:                 input.IngressClass,
+		enablePreviewPolicies:        input.EnablePreviewPolicies,
+		globalConfigurationValidator: input.GlobalConfigurationValidator,
+		transportServerValidator:     input.TransportServerValidator,
+		virtualServerValidator:       input.VirtualServerValidator,
+		svcLister:                    cache.NewStore(keyFunc),
+		endpointLister:               storeToEndpointLister{Store: cache.NewStore(keyFunc)},
+		endpointSliceLister:          storeToEndpointSliceLister{Store: cache.NewStore(keyFunc)},
+		podLister:                    indexerToPodLister{Indexer: cache.NewIndexer(keyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})},
+		secretLister:                 cache.NewStore(keyFunc),
+		policyLister:                 cache.NewStore(keyFunc),
+	}
+
+	lbc.configuration = NewConfiguration(
+		lbc.HasCorrectIngressClass,
+		input.IsNginxPlus,
+		false,
+		false,
+		false,
+		input.VirtualServerValidator,
+		input.GlobalConfigurationValidator,
+		input.TransportServerValidator,
+		input.IsTLSPassthroughEnabled,
+		input.SnippetsEnabled)
+
+	lbc.appProtectConfiguration = appprotect.NewConfiguration()
+	lbc.dosConfiguration = appprotectdos.NewConfiguration(false)
+	lbc.secretStore = secrets.NewLocalSecretStore(lbc.configurator)
+
+	return &Renderer{
+		lbc: lbc,
+	}
+}
+
+// AddObject adds a resource to the Renderer. The supported resources are Ingresses, VirtualServers, VirtualServerRoutes,
+// TransportServers, GlobalConfigurations, Policies, Secrets, Services, Endpoints, EndpointSlices and Pods.
+// If any EndpointSlices are added, the endpoints of the services are discovered from the EndpointSlices rather than
+// from the Endpoints.
+func (r *Renderer) AddObject(obj runtime.Object) error {
+	var err error
+
+	switch o := obj.(type) {
+	case *api_v1.Service:
+		err = r.lbc.svcLister.Add(o)
+	case *api_v1.Endpoints:
+		err = r.lbc.endpointLister.Add(o)
+	case *discovery_v1.EndpointSlice:
+		r.lbc.areEndpointSlicesEnabled = true
+		err = r.lbc.endpointSliceLister.Add(o)
+	case *api_v1.Pod:
+		err = r.lbc.podLister.Add(o)
+	case *api_v1.Secret:
+		err = r.lbc.secretLister.Add(o)
+		if err == nil {
+			r.lbc.secretStore.AddOrUpdateSecret(o)
+		}
+	case *conf_v1.Policy:
+		err = r.lbc.policyLister.Add(o)
+	case *networking.Ingress, *conf_v1.VirtualServer, *conf_v1.VirtualServerRoute, *conf_v1.TransportServer, *conf_v1.GlobalConfiguration:
+		r.objects = append(r.objects, obj)
+	default:
+		return fmt.Errorf("unsupported resource %T", obj)
+	}
+
+	return err
+}
+
+// Render generates the NGINX configuration for the added resources through the Configurator.
+// It returns the warnings about the resources, including the validation errors of the rejected resources.
+func (r *Renderer) Render() ([]string, error) {
+	var warnings []string
+	var problems []ConfigurationProblem
+
+	// the GlobalConfiguration must be added first so that the TransportServers find their listeners
+	for _, obj := range r.objects {
+		if gc, ok := obj.(*conf_v1.GlobalConfiguration); ok {
+			_, _, err := r.lbc.configuration.AddOrUpdateGlobalConfiguration(gc)
+			if err != nil {
+				warnings = append(warnings, fmt.Sprintf("GlobalConfiguration %v: %v", getResourceKey(&gc.ObjectMeta), err))
+			}
+		}
+	}
+
+	for _, obj := range r.objects {
+		var p []ConfigurationProblem
+
+		switch o := obj.(type) {
+		case *networking.Ingress:
+			_, p = r.lbc.configuration.AddOrUpdateIngress(o)
+		case *conf_v1.VirtualServer:
+			_, p = r.lbc.configuration.AddOrUpdateVirtualServer(o)
+		case *conf_v1.VirtualServerRoute:
+			_, p = r.lbc.configuration.AddOrUpdateVirtualServerRoute(o)
+		case *conf_v1.TransportServer:
+			_, p = r.lbc.configuration.AddOrUpdateTransportServer(o)
+		}
+
+		// the problems about collisions and references can be resolved by the resources added later,
+		// so only the validation errors are reported here
+		for _, problem := range p {
+			if problem.Reason == "Rejected" {
+				problems = append(problems, problem)
+			}
+		}
+	}
+
+	problems = append(problems, r.lbc.configuration.GetProblems()...)
+	for _, p := range problems {
+		warnings = append(warnings, fmt.Sprintf("%v: %v", getObjectDescription(p.Object), p.Message))
+	}
+
+	resources := r.lbc.configuration.GetResources()
+	for _, res := range resources {
+		var obj runtime.Object
+		var resWarnings []string
+
+		switch impl := res.(type) {
+		case *IngressConfiguration:
+			obj = impl.Ingress
+			resWarnings = impl.Warnings
+			for minion, childWarnings := range impl.ChildWarnings {
+				for _, w := range childWarnings {
+					warnings = append(warnings, fmt.Sprintf("Ingress %v: %v", minion, w))
+				}
+			}
+		case *VirtualServerConfiguration:
+			obj = impl.VirtualServer
+			resWarnings = impl.Warnings
+		case *TransportServerConfiguration:
+			obj = impl.TransportServer
+			resWarnings = impl.Warnings
+		}
+
+		for _, w := range resWarnings {
+			warnings = append(warnings, fmt.Sprintf("%v: %v", getObjectDescription(obj), w))
+		}
+	}
+
+	resourceExes := r.lbc.createExtendedResources(resources)
+
+	configuratorWarnings, err := r.lbc.configurator.AddOrUpdateResources(resourceExes)
+	for obj, objWarnings := range configuratorWarnings {
+		for _, w := range objWarnings {
+			warnings = append(warnings, fmt.Sprintf("%v: %v", getObjectDescription(obj), w))
+		}
+	}
+
+	sort.Strings(warnings)
+
+	return warnings, err
+}
+
+// getObjectDescription returns the kind and the namespace/name of a resource, like "VirtualServer default/cafe".
+func getObjectDescription(obj interface{}) string {
+	var kind string
+
+	switch obj.(type) {
+	case *networking.Ingress:
+		kind = ingressKind
+	case *conf_v1.VirtualServer:
+		kind = virtualServerKind
+	case *conf_v1.VirtualServerRoute:
+		kind = virtualServerRouteKind
+	case *conf_v1.TransportServer:
+		kind = transportServerKind
+	case *conf_v1.Policy:
+		kind = "Policy"
+	}
+
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return fmt.Sprintf("%T", obj)
+	}
+
+	if kind == "" {
+		return fmt.Sprintf("%v/%v", accessor.GetNamespace(), accessor.GetName())
+	}
+
+	return fmt.Sprintf("%v %v/%v", kind, accessor.GetNamespace(), accessor.GetName())
+}
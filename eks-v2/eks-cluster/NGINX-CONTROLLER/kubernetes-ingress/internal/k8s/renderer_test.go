@@ -0,0 +1,148 @@
+package k8s
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nginxinc/kubernetes-ingress/internal/configs"
+	"github.com/nginxinc/kubernetes-ingress/internal/configs/version1"
+	"github.com/nginxinc/kubernetes-ingress/internal/configs/version2"
+	"github.com/nginxinc/kubernetes-ingress/internal/nginx"
+	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
+	"github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/validation"
+	api_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+type recordingManager struct {
+	*nginx.FakeManager
+	configs map[string]string
+}
+
+func (m *recordingManager) CreateConfig(name string, content []byte) {
+	m.configs[name] = string(content)
+}
+
+func createTestRenderer(t *testing.T) (*Renderer, *recordingManager) {
+	t.Helper()
+
+	templateExecutor, err := version1.NewTemplateExecutor("../configs/version1/nginx.tmpl", "../configs/version1/nginx.ingress.tmpl")
+	if err != nil {
+		t.Fatalf("Failed to create the TemplateExecutor: %v", err)
+	}
+	templateExecutorV2, err := version2.NewTemplateExecutor("../configs/version2/nginx.virtualserver.tmpl", "../configs/version2/nginx.transportserver.tmpl")
+	if err != nil {
+		t.Fatalf("Failed to create the TemplateExecutorV2: %v", err)
+	}
+
+	manager := &recordingManager{
+		FakeManager: nginx.NewFakeManager("/etc/nginx"),
+		configs:     make(map[string]string),
+	}
+
+	cnf := configs.NewConfigurator(manager, &configs.StaticConfigParams{}, configs.NewDefaultConfigParams(false), templateExecutor, templateExecutorV2, false, false, nil, false, nil, false)
+
+	renderer := NewRenderer(RendererInput{
+		NginxConfigurator:            cnf,
+		IngressClass:                 "nginx",
+		GlobalConfigurationValidator: validation.NewGlobalConfigurationValidator(map[int]bool{80: true, 443: true}),
+		TransportServerValidator:     validation.NewTransportServerValidator(false, false, false),
+		VirtualServerValidator:       validation.NewVirtualServerValidator(false, false, false),
+	})
+
+	return renderer, manager
+}
+
+func TestRendererRender(t *testing.T) {
+	renderer, manager := createTestRenderer(t)
+
+	vs := createTestVirtualServer("cafe", "cafe.example.com")
+	vs.Spec.Upstreams = []conf_v1.Upstream{
+		{
+			Name:    "tea",
+			Service: "tea-svc",
+			Port:    80,
+		},
+	}
+	vs.Spec.Routes = []conf_v1.Route{
+		{
+			Path: "/",
+			Action: &conf_v1.Action{
+				Pass: "tea",
+			},
+		},
+	}
+
+	invalidVS := createTestVirtualServer("invalid", "")
+
+	svc := &api_v1.Service{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "tea-svc",
+			Namespace: "default",
+		},
+		Spec: api_v1.ServiceSpec{
+			Ports: []api_v1.ServicePort{
+				{
+					Port:       80,
+					TargetPort: intstr.FromInt(8080),
+				},
+			},
+		},
+	}
+
+	endpoints := &api_v1.Endpoints{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "tea-svc",
+			Namespace: "default",
+		},
+		Subsets: []api_v1.EndpointSubset{
+			{
+				Addresses: []api_v1.EndpointAddress{
+					{
+						IP: "10.0.0.1",
+					},
+				},
+				Ports: []api_v1.EndpointPort{
+					{
+						Port: 8080,
+					},
+				},
+			},
+		},
+	}
+
+	for _, obj := range []runtime.Object{vs, invalidVS, svc, endpoints} {
+		err := renderer.AddObject(obj)
+		if err != nil {
+			t.Fatalf("AddObject() returned an unexpected error: %v", err)
+		}
+	}
+
+	warnings, err := renderer.Render()
+	if err != nil {
+		t.Fatalf("Render() returned an unexpected error: %v", err)
+	}
+
+	if len(warnings) != 1 || !strings.HasPrefix(warnings[0], "VirtualServer default/invalid:") {
+		t.Errorf("Render() returned warnings %v but expected one warning for VirtualServer default/invalid", warnings)
+	}
+
+	content, exists := manager.configs["vs_default_cafe"]
+	if !exists {
+		t.Fatalf("Render() didn't create the config for VirtualServer default/cafe, created configs: %v", manager.configs)
+	}
+	if !strings.Contains(content, "server 10.0.0.1:8080") {
+		t.Errorf("Render() created the config for VirtualServer default/cafe without the endpoint 10.0.0.1:8080:\n%s", content)
+	}
+}
+
+func TestRendererAddObjectUnsupported(t *testing.T) {
+	renderer, _ := createTestRenderer(t)
+
+	err := renderer.AddObject(&api_v1.ConfigMap{})
+	if err == nil {
+		t.Error("AddObject() returned no error for a ConfigMap")
+	}
+}
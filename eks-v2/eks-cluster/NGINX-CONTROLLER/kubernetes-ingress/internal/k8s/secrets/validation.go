@@ -13,6 +13,9 @@ import (
 // JWTKeyKey is the key of the data field of a Secret where the JWK must be stored.
 const JWTKeyKey = "jwk"
 
+// HtpasswdFileKey is the key of the data field of a Secret where the htpasswd file must be stored.
+const HtpasswdFileKey = "htpasswd"
+
 // CAKey is the key of the data field of a Secret where the certificate authority must be stored.
 const CAKey = "ca.crt"
 
@@ -25,6 +28,9 @@ const SecretTypeCA api_v1.SecretType = "nginx.org/ca"
 // SecretTypeJWK contains a JWK (JSON Web Key) for validating JWTs (JSON Web Tokens). #nosec G101
 const SecretTypeJWK api_v1.SecretType = "nginx.org/jwk"
 
+// SecretTypeHtpasswd contains an htpasswd file for use in HTTP Basic authorization. #nosec G101
+const SecretTypeHtpasswd api_v1.SecretType = "nginx.org/htpasswd"
+
 // SecretTypeOIDC contains an OIDC client secret for use in oauth flows. #nosec G101
 const SecretTypeOIDC api_v1.SecretType = "nginx.org/oidc"
 
@@ -103,12 +109,29 @@ func ValidateOIDCSecret(secret *api_v1.Secret) error {
 	return nil
 }
 
+// ValidateHtpasswdSecret validates the secret. If it is valid, the function returns nil.
+func ValidateHtpasswdSecret(secret *api_v1.Secret) error {
+	if secret.Type != SecretTypeHtpasswd {
+		return fmt.Errorf("htpasswd secret must be of the type %v", SecretTypeHtpasswd)
+	}
+
+	if _, exists := secret.Data[HtpasswdFileKey]; !exists {
+		return fmt.Errorf("htpasswd secret must have the data field %v", HtpasswdFileKey)
+	}
+
+	// we don't validate the contents of secret.Data[HtpasswdFileKey], because invalid contents will not make NGINX
+	// fail to reload: NGINX will return 500 responses for the affected URLs.
+
+	return nil
+}
+
 // IsSupportedSecretType checks if the secret type is supported.
 func IsSupportedSecretType(secretType api_v1.SecretType) bool {
 	return secretType == api_v1.SecretTypeTLS ||
 		secretType == SecretTypeCA ||
 		secretType == SecretTypeJWK ||
-		secretType == SecretTypeOIDC
+		secretType == SecretTypeOIDC ||
+		secretType == SecretTypeHtpasswd
 }
 
 // ValidateSecret validates the secret. If it is valid, the function returns nil.
@@ -122,6 +145,8 @@ func ValidateSecret(secret *api_v1.Secret) error {
 		return ValidateCASecret(secret)
 	case SecretTypeOIDC:
 		return ValidateOIDCSecret(secret)
+	case SecretTypeHtpasswd:
+		return ValidateHtpasswdSecret(secret)
 	}
 
 	return fmt.Errorf("Secret is of the unsupported type %v", secret.Type)
@@ -312,6 +312,62 @@ func TestValidateOIDCSecretFails(t *testing.T) {
 	}
 }
 
+func TestValidateHtpasswdSecret(t *testing.T) {
+	secret := &v1.Secret{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "htpasswd-secret",
+			Namespace: "default",
+		},
+		Type: SecretTypeHtpasswd,
+		Data: map[string][]byte{
+			"htpasswd": nil,
+		},
+	}
+
+	err := ValidateHtpasswdSecret(secret)
+	if err != nil {
+		t.Errorf("ValidateHtpasswdSecret() returned error %v", err)
+	}
+}
+
+func TestValidateHtpasswdSecretFails(t *testing.T) {
+	tests := []struct {
+		secret *v1.Secret
+		msg    string
+	}{
+		{
+			secret: &v1.Secret{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "htpasswd-secret",
+					Namespace: "default",
+				},
+				Type: "some-type",
+				Data: map[string][]byte{
+					"htpasswd": nil,
+				},
+			},
+			msg: "Incorrect type for htpasswd secret",
+		},
+		{
+			secret: &v1.Secret{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "htpasswd-secret",
+					Namespace: "default",
+				},
+				Type: SecretTypeHtpasswd,
+			},
+			msg: "Missing htpasswd for htpasswd secret",
+		},
+	}
+
+	for _, test := range tests {
+		err := ValidateHtpasswdSecret(test.secret)
+		if err == nil {
+			t.Errorf("ValidateHtpasswdSecret() returned no error for the case of %s", test.msg)
+		}
+	}
+}
+
 func TestValidateSecret(t *testing.T) {
 	tests := []struct {
 		secret *v1.Secret
@@ -370,6 +426,19 @@ func TestValidateSecret(t *testing.T) {
 			},
 			msg: "Valid OIDC secret",
 		},
+		{
+			secret: &v1.Secret{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "htpasswd-secret",
+					Namespace: "default",
+				},
+				Type: SecretTypeHtpasswd,
+				Data: map[string][]byte{
+					"htpasswd": nil,
+				},
+			},
+			msg: "Valid htpasswd secret",
+		},
 	}
 
 	for _, test := range tests {
@@ -449,6 +518,10 @@ func TestHasCorrectSecretType(t *testing.T) {
 			secretType: SecretTypeOIDC,
 			expected:   true,
 		},
+		{
+			secretType: SecretTypeHtpasswd,
+			expected:   true,
+		},
 		{
 			secretType: "some-type",
 			expected:   false,
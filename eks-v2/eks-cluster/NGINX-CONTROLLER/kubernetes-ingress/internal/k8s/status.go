@@ -11,11 +11,14 @@ import (
 	"github.com/golang/glog"
 	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
 	v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
-	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
 	k8s_nginx "github.com/nginxinc/kubernetes-ingress/pkg/client/clientset/versioned"
 	api_v1 "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
 	typednetworking "k8s.io/client-go/kubernetes/typed/networking/v1"
 
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -43,7 +46,14 @@ type statusUpdater struct {
 	virtualServerRouteLister cache.Store
 	transportServerLister    cache.Store
 	policyLister             cache.Store
+	gatewayClassLister       cache.Store
+	gatewayLister            cache.Store
+	httpRouteLister          cache.Store
+	tcpRouteLister           cache.Store
+	udpRouteLister           cache.Store
+	tlsRouteLister           cache.Store
 	confClient               k8s_nginx.Interface
+	dynClient                dynamic.Interface
 	hasCorrectIngressClass   func(interface{}) bool
 }
 
@@ -337,14 +347,14 @@ func (su *statusUpdater) ClearStatusFromIngressLink() {
 	su.externalEndpoints = su.generateExternalEndpointsFromStatus(su.status)
 }
 
-func (su *statusUpdater) retryUpdateTransportServerStatus(tsCopy *conf_v1alpha1.TransportServer) error {
-	ts, err := su.confClient.K8sV1alpha1().TransportServers(tsCopy.Namespace).Get(context.TODO(), tsCopy.Name, metav1.GetOptions{})
+func (su *statusUpdater) retryUpdateTransportServerStatus(tsCopy *conf_v1.TransportServer) error {
+	ts, err := su.confClient.K8sV1().TransportServers(tsCopy.Namespace).Get(context.TODO(), tsCopy.Name, metav1.GetOptions{})
 	if err != nil {
 		return err
 	}
 
 	ts.Status = tsCopy.Status
-	_, err = su.confClient.K8sV1alpha1().TransportServers(ts.Namespace).UpdateStatus(context.TODO(), ts, metav1.UpdateOptions{})
+	_, err = su.confClient.K8sV1().TransportServers(ts.Namespace).UpdateStatus(context.TODO(), ts, metav1.UpdateOptions{})
 	if err != nil {
 		return err
 	}
@@ -399,7 +409,7 @@ func hasVsStatusChanged(vs *conf_v1.VirtualServer, state string, reason string,
 }
 
 // UpdateTransportServerStatus updates the status of a TransportServer.
-func (su *statusUpdater) UpdateTransportServerStatus(ts *conf_v1alpha1.TransportServer, state string, reason string, message string) error {
+func (su *statusUpdater) UpdateTransportServerStatus(ts *conf_v1.TransportServer, state string, reason string, message string) error {
 	tsLatest, exists, err := su.transportServerLister.Get(ts)
 	if err != nil {
 		glog.V(3).Infof("error getting TransportServer from Store: %v", err)
@@ -410,16 +420,16 @@ func (su *statusUpdater) UpdateTransportServerStatus(ts *conf_v1alpha1.Transport
 		return nil
 	}
 
-	if !hasTsStatusChanged(tsLatest.(*conf_v1alpha1.TransportServer), state, reason, message) {
+	if !hasTsStatusChanged(tsLatest.(*conf_v1.TransportServer), state, reason, message) {
 		return nil
 	}
 
-	tsCopy := tsLatest.(*conf_v1alpha1.TransportServer).DeepCopy()
+	tsCopy := tsLatest.(*conf_v1.TransportServer).DeepCopy()
 	tsCopy.Status.State = state
 	tsCopy.Status.Reason = reason
 	tsCopy.Status.Message = message
 
-	_, err = su.confClient.K8sV1alpha1().TransportServers(tsCopy.Namespace).UpdateStatus(context.TODO(), tsCopy, metav1.UpdateOptions{})
+	_, err = su.confClient.K8sV1().TransportServers(tsCopy.Namespace).UpdateStatus(context.TODO(), tsCopy, metav1.UpdateOptions{})
 	if err != nil {
 		glog.V(3).Infof("error setting TransportServer %v/%v status, retrying: %v", tsCopy.Namespace, tsCopy.Name, err)
 		return su.retryUpdateTransportServerStatus(tsCopy)
@@ -427,7 +437,7 @@ func (su *statusUpdater) UpdateTransportServerStatus(ts *conf_v1alpha1.Transport
 	return err
 }
 
-func hasTsStatusChanged(ts *conf_v1alpha1.TransportServer, state string, reason string, message string) bool {
+func hasTsStatusChanged(ts *conf_v1.TransportServer, state string, reason string, message string) bool {
 	if ts.Status.State != state {
 		return true
 	}
@@ -441,7 +451,8 @@ func hasTsStatusChanged(ts *conf_v1alpha1.TransportServer, state string, reason
 }
 
 // UpdateVirtualServerStatus updates the status of a VirtualServer.
-func (su *statusUpdater) UpdateVirtualServerStatus(vs *conf_v1.VirtualServer, state string, reason string, message string) error {
+// If canaries is not nil, it replaces the reported progress of the canaries of the VirtualServer.
+func (su *statusUpdater) UpdateVirtualServerStatus(vs *conf_v1.VirtualServer, state string, reason string, message string, canaries []conf_v1.CanaryStatus) error {
 	// Get an up-to-date VirtualServer from the Store
 	vsLatest, exists, err := su.virtualServerLister.Get(vs)
 	if err != nil {
@@ -455,7 +466,7 @@ func (su *statusUpdater) UpdateVirtualServerStatus(vs *conf_v1.VirtualServer, st
 
 	vsCopy := vsLatest.(*conf_v1.VirtualServer).DeepCopy()
 
-	if !hasVsStatusChanged(vsCopy, state, reason, message) {
+	if !hasVsStatusChanged(vsCopy, state, reason, message) && !hasVsCanariesStatusChanged(vsCopy, canaries) {
 		return nil
 	}
 
@@ -464,6 +475,13 @@ func (su *statusUpdater) UpdateVirtualServerStatus(vs *conf_v1.VirtualServer, st
 	vsCopy.Status.Message = message
 	vsCopy.Status.ExternalEndpoints = su.externalEndpoints
 
+	if canaries != nil {
+		vsCopy.Status.Canaries = nil
+		if len(canaries) > 0 {
+			vsCopy.Status.Canaries = canaries
+		}
+	}
+
 	_, err = su.confClient.K8sV1().VirtualServers(vsCopy.Namespace).UpdateStatus(context.TODO(), vsCopy, metav1.UpdateOptions{})
 	if err != nil {
 		glog.V(3).Infof("error setting VirtualServer %v/%v status, retrying: %v", vsCopy.Namespace, vsCopy.Name, err)
@@ -472,6 +490,16 @@ func (su *statusUpdater) UpdateVirtualServerStatus(vs *conf_v1.VirtualServer, st
 	return err
 }
 
+func hasVsCanariesStatusChanged(vs *conf_v1.VirtualServer, canaries []conf_v1.CanaryStatus) bool {
+	if canaries == nil {
+		return false
+	}
+	if len(vs.Status.Canaries) == 0 && len(canaries) == 0 {
+		return false
+	}
+	return !reflect.DeepEqual(vs.Status.Canaries, canaries)
+}
+
 func hasVsrStatusChanged(vsr *conf_v1.VirtualServerRoute, state string, reason string, message string, referencedByString string) bool {
 	if vsr.Status.State != state {
 		return true
@@ -681,3 +709,225 @@ func (su *statusUpdater) retryUpdatePolicyStatus(polCopy *v1.Policy) error {
 
 	return nil
 }
+
+// mergeGatewayConditions keeps the last transition time of the current conditions that didn't change their status.
+func mergeGatewayConditions(current []metav1.Condition, desired []metav1.Condition) []metav1.Condition {
+	now := metav1.Now()
+	var result []metav1.Condition
+
+	for _, d := range desired {
+		d.LastTransitionTime = now
+		for _, c := range current {
+			if c.Type == d.Type && c.Status == d.Status {
+				d.LastTransitionTime = c.LastTransitionTime
+				break
+			}
+		}
+		result = append(result, d)
+	}
+
+	return result
+}
+
+func getGatewayAPIResourceStatus(obj *unstructured.Unstructured, status interface{}) error {
+	content, found, err := unstructured.NestedMap(obj.Object, "status")
+	if err != nil || !found {
+		return err
+	}
+
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(content, status)
+}
+
+func (su *statusUpdater) getLatestGatewayAPIResource(lister cache.Store, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	latest, exists, err := lister.Get(obj)
+	if err != nil {
+		glog.V(3).Infof("error getting %v from Store: %v", obj.GetKind(), err)
+		return nil, err
+	}
+	if !exists {
+		glog.V(3).Infof("%v doesn't exist in Store", obj.GetKind())
+		return nil, nil
+	}
+
+	return latest.(*unstructured.Unstructured), nil
+}
+
+func (su *statusUpdater) updateGatewayAPIResourceStatus(gvr schema.GroupVersionResource, obj *unstructured.Unstructured, status interface{}) error {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(status)
+	if err != nil {
+		return err
+	}
+
+	objCopy := obj.DeepCopy()
+	objCopy.Object["status"] = content
+
+	client := su.dynClient.Resource(gvr).Namespace(objCopy.GetNamespace())
+
+	_, err = client.UpdateStatus(context.TODO(), objCopy, metav1.UpdateOptions{})
+	if err != nil {
+		glog.V(3).Infof("error setting %v %v status, retrying: %v", objCopy.GetKind(), getGatewayAPIResourceKeyFromObject(objCopy), err)
+		return su.retryUpdateGatewayAPIResourceStatus(gvr, objCopy)
+	}
+
+	return nil
+}
+
+func (su *statusUpdater) retryUpdateGatewayAPIResourceStatus(gvr schema.GroupVersionResource, objCopy *unstructured.Unstructured) error {
+	client := su.dynClient.Resource(gvr).Namespace(objCopy.GetNamespace())
+
+	obj, err := client.Get(context.TODO(), objCopy.GetName(), metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	obj.Object["status"] = objCopy.Object["status"]
+	_, err = client.UpdateStatus(context.TODO(), obj, metav1.UpdateOptions{})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func getGatewayAPIResourceKeyFromObject(obj *unstructured.Unstructured) string {
+	if obj.GetNamespace() == "" {
+		return obj.GetName()
+	}
+	return fmt.Sprintf("%s/%s", obj.GetNamespace(), obj.GetName())
+}
+
+// UpdateGatewayClassStatus updates the status of a GatewayClass.
+func (su *statusUpdater) UpdateGatewayClassStatus(gc *unstructured.Unstructured, status *gatewayClassStatus) error {
+	latest, err := su.getLatestGatewayAPIResource(su.gatewayClassLister, gc)
+	if latest == nil {
+		return err
+	}
+
+	var current gatewayClassStatus
+	err = getGatewayAPIResourceStatus(latest, &current)
+	if err != nil {
+		return err
+	}
+
+	desired := gatewayClassStatus{
+		Conditions: mergeGatewayConditions(current.Conditions, status.Conditions),
+	}
+
+	if reflect.DeepEqual(current, desired) {
+		return nil
+	}
+
+	return su.updateGatewayAPIResourceStatus(gatewayClassGVR, latest, &desired)
+}
+
+// UpdateGatewayStatus updates the status of a Gateway. The addresses of the Gateway are the same as
+// the external endpoints reported for VirtualServers.
+func (su *statusUpdater) UpdateGatewayStatus(gw *unstructured.Unstructured, status *gatewayStatus) error {
+	latest, err := su.getLatestGatewayAPIResource(su.gatewayLister, gw)
+	if latest == nil {
+		return err
+	}
+
+	var current gatewayStatus
+	err = getGatewayAPIResourceStatus(latest, &current)
+	if err != nil {
+		return err
+	}
+
+	desired := gatewayStatus{
+		Conditions: mergeGatewayConditions(current.Conditions, status.Conditions),
+	}
+
+	for _, lbIngress := range su.status {
+		if lbIngress.IP != "" {
+			desired.Addresses = append(desired.Addresses, gatewayAddress{Type: "IPAddress", Value: lbIngress.IP})
+		} else {
+			desired.Addresses = append(desired.Addresses, gatewayAddress{Type: "Hostname", Value: lbIngress.Hostname})
+		}
+	}
+
+	for _, l := range status.Listeners {
+		var currentConditions []metav1.Condition
+		for _, cl := range current.Listeners {
+			if cl.Name == l.Name {
+				currentConditions = cl.Conditions
+				break
+			}
+		}
+		l.Conditions = mergeGatewayConditions(currentConditions, l.Conditions)
+		desired.Listeners = append(desired.Listeners, l)
+	}
+
+	if reflect.DeepEqual(current, desired) {
+		return nil
+	}
+
+	return su.updateGatewayAPIResourceStatus(gatewayGVR, latest, &desired)
+}
+
+// UpdateHTTPRouteStatus updates the statuses of the parents of an HTTPRoute that are handled by the Ingress Controller.
+// The statuses of the parents handled by other controllers are preserved.
+func (su *statusUpdater) UpdateHTTPRouteStatus(route *unstructured.Unstructured, parents []routeParentStatus) error {
+	return su.updateRouteStatus(su.httpRouteLister, httpRouteGVR, route, parents)
+}
+
+// UpdateL4RouteStatus updates the statuses of the parents of a TCPRoute, UDPRoute or TLSRoute that are handled by the Ingress Controller.
+// The statuses of the parents handled by other controllers are preserved.
+func (su *statusUpdater) UpdateL4RouteStatus(route *unstructured.Unstructured, parents []routeParentStatus) error {
+	switch route.GetKind() {
+	case tcpRouteGVK.Kind:
+		return su.updateRouteStatus(su.tcpRouteLister, tcpRouteGVR, route, parents)
+	case udpRouteGVK.Kind:
+		return su.updateRouteStatus(su.udpRouteLister, udpRouteGVR, route, parents)
+	case tlsRouteGVK.Kind:
+		return su.updateRouteStatus(su.tlsRouteLister, tlsRouteGVR, route, parents)
+	default:
+		return fmt.Errorf("unknown route kind %v", route.GetKind())
+	}
+}
+
+func (su *statusUpdater) updateRouteStatus(lister cache.Store, gvr schema.GroupVersionResource, route *unstructured.Unstructured, parents []routeParentStatus) error {
+	latest, err := su.getLatestGatewayAPIResource(lister, route)
+	if latest == nil {
+		return err
+	}
+
+	var current routeStatus
+	err = getGatewayAPIResourceStatus(latest, &current)
+	if err != nil {
+		return err
+	}
+
+	var desired routeStatus
+	for _, p := range current.Parents {
+		if p.ControllerName != GatewayControllerName {
+			desired.Parents = append(desired.Parents, p)
+		}
+	}
+
+	for _, p := range parents {
+		var currentConditions []metav1.Condition
+		for _, cp := range current.Parents {
+			if cp.ControllerName == GatewayControllerName && reflect.DeepEqual(cp.ParentRef, p.ParentRef) {
+				currentConditions = cp.Conditions
+				break
+			}
+		}
+		p.Conditions = mergeGatewayConditions(currentConditions, p.Conditions)
+		desired.Parents = append(desired.Parents, p)
+	}
+
+	if len(current.Parents) == 0 && len(desired.Parents) == 0 {
+		return nil
+	}
+
+	if reflect.DeepEqual(current, desired) {
+		return nil
+	}
+
+	if desired.Parents == nil {
+		desired.Parents = []routeParentStatus{}
+	}
+
+	return su.updateGatewayAPIResourceStatus(gvr, latest, &desired)
+}
@@ -4,27 +4,31 @@ import (
 	"context"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
-	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
 	fake_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/client/clientset/versioned/fake"
 	v1 "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	fake_dynamic "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/tools/cache"
 )
 
 func TestUpdateTransportServerStatus(t *testing.T) {
-	ts := &conf_v1alpha1.TransportServer{
+	ts := &conf_v1.TransportServer{
 		ObjectMeta: meta_v1.ObjectMeta{
 			Name:      "ts-1",
 			Namespace: "default",
 		},
-		Status: conf_v1alpha1.TransportServerStatus{
+		Status: conf_v1.TransportServerStatus{
 			State:   "before status",
 			Reason:  "before reason",
 			Message: "before message",
@@ -32,8 +36,8 @@ func TestUpdateTransportServerStatus(t *testing.T) {
 	}
 
 	fakeClient := fake_v1alpha1.NewSimpleClientset(
-		&conf_v1alpha1.TransportServerList{
-			Items: []conf_v1alpha1.TransportServer{
+		&conf_v1.TransportServerList{
+			Items: []conf_v1.TransportServer{
 				*ts,
 			},
 		})
@@ -54,9 +58,9 @@ func TestUpdateTransportServerStatus(t *testing.T) {
 	if err != nil {
 		t.Errorf("error updating transportserver status: %v", err)
 	}
-	updatedTs, _ := fakeClient.K8sV1alpha1().TransportServers(ts.Namespace).Get(context.TODO(), ts.Name, meta_v1.GetOptions{})
+	updatedTs, _ := fakeClient.K8sV1().TransportServers(ts.Namespace).Get(context.TODO(), ts.Name, meta_v1.GetOptions{})
 
-	expectedStatus := conf_v1alpha1.TransportServerStatus{
+	expectedStatus := conf_v1.TransportServerStatus{
 		State:   "after status",
 		Reason:  "after reason",
 		Message: "after message",
@@ -68,12 +72,12 @@ func TestUpdateTransportServerStatus(t *testing.T) {
 }
 
 func TestUpdateTransportServerStatusIgnoreNoChange(t *testing.T) {
-	ts := &conf_v1alpha1.TransportServer{
+	ts := &conf_v1.TransportServer{
 		ObjectMeta: meta_v1.ObjectMeta{
 			Name:      "ts-1",
 			Namespace: "default",
 		},
-		Status: conf_v1alpha1.TransportServerStatus{
+		Status: conf_v1.TransportServerStatus{
 			State:   "same status",
 			Reason:  "same reason",
 			Message: "same message",
@@ -81,20 +85,20 @@ func TestUpdateTransportServerStatusIgnoreNoChange(t *testing.T) {
 	}
 
 	fakeClient := fake_v1alpha1.NewSimpleClientset(
-		&conf_v1alpha1.TransportServerList{
-			Items: []conf_v1alpha1.TransportServer{
+		&conf_v1.TransportServerList{
+			Items: []conf_v1.TransportServer{
 				*ts,
 			},
 		})
 
 	tsLister, _ := cache.NewInformer(
 		cache.NewListWatchFromClient(
-			fakeClient.K8sV1alpha1().RESTClient(),
+			fakeClient.K8sV1().RESTClient(),
 			"transportservers",
 			"nginx-ingress",
 			fields.Everything(),
 		),
-		&conf_v1alpha1.TransportServer{},
+		&conf_v1.TransportServer{},
 		2,
 		nil,
 	)
@@ -113,7 +117,7 @@ func TestUpdateTransportServerStatusIgnoreNoChange(t *testing.T) {
 	if err != nil {
 		t.Errorf("error updating transportserver status: %v", err)
 	}
-	updatedTs, _ := fakeClient.K8sV1alpha1().TransportServers(ts.Namespace).Get(context.TODO(), ts.Name, meta_v1.GetOptions{})
+	updatedTs, _ := fakeClient.K8sV1().TransportServers(ts.Namespace).Get(context.TODO(), ts.Name, meta_v1.GetOptions{})
 
 	if updatedTs.Status.State != "same status" {
 		t.Errorf("expected: %v actual: %v", "same status", updatedTs.Status.State)
@@ -127,12 +131,12 @@ func TestUpdateTransportServerStatusIgnoreNoChange(t *testing.T) {
 }
 
 func TestUpdateTransportServerStatusMissingTransportServer(t *testing.T) {
-	ts := &conf_v1alpha1.TransportServer{
+	ts := &conf_v1.TransportServer{
 		ObjectMeta: meta_v1.ObjectMeta{
 			Name:      "ts-1",
 			Namespace: "default",
 		},
-		Status: conf_v1alpha1.TransportServerStatus{
+		Status: conf_v1.TransportServerStatus{
 			State:   "before status",
 			Reason:  "before reason",
 			Message: "before message",
@@ -140,18 +144,18 @@ func TestUpdateTransportServerStatusMissingTransportServer(t *testing.T) {
 	}
 
 	fakeClient := fake_v1alpha1.NewSimpleClientset(
-		&conf_v1alpha1.TransportServerList{
-			Items: []conf_v1alpha1.TransportServer{},
+		&conf_v1.TransportServerList{
+			Items: []conf_v1.TransportServer{},
 		})
 
 	tsLister, _ := cache.NewInformer(
 		cache.NewListWatchFromClient(
-			fakeClient.K8sV1alpha1().RESTClient(),
+			fakeClient.K8sV1().RESTClient(),
 			"transportservers",
 			"nginx-ingress",
 			fields.Everything(),
 		),
-		&conf_v1alpha1.TransportServer{},
+		&conf_v1.TransportServer{},
 		2,
 		nil,
 	)
@@ -173,12 +177,96 @@ func TestUpdateTransportServerStatusMissingTransportServer(t *testing.T) {
 		t.Errorf("unexpected error: %v, result should be empty as no matching TransportServer is present", err)
 	}
 
-	updatedTs, _ := fakeClient.K8sV1alpha1().TransportServers(ts.Namespace).Get(context.TODO(), ts.Name, meta_v1.GetOptions{})
+	updatedTs, _ := fakeClient.K8sV1().TransportServers(ts.Namespace).Get(context.TODO(), ts.Name, meta_v1.GetOptions{})
 	if updatedTs != nil {
 		t.Errorf("expected TransportServer Store would be empty as provided TransportServer was not found. Unexpected updated TransportServer: %v", updatedTs)
 	}
 }
 
+func TestUpdateVirtualServerStatusWithCanaries(t *testing.T) {
+	vs := &conf_v1.VirtualServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "cafe",
+			Namespace: "default",
+		},
+		Status: conf_v1.VirtualServerStatus{
+			State:   "Valid",
+			Reason:  "AddedOrUpdated",
+			Message: "Configuration for default/cafe was added or updated",
+		},
+	}
+
+	fakeClient := fake_v1alpha1.NewSimpleClientset(
+		&conf_v1.VirtualServerList{
+			Items: []conf_v1.VirtualServer{
+				*vs,
+			},
+		})
+
+	vsLister := cache.NewStore(cache.DeletionHandlingMetaNamespaceKeyFunc)
+
+	err := vsLister.Add(vs)
+	if err != nil {
+		t.Errorf("Error adding VirtualServer to the virtualserver lister: %v", err)
+	}
+	su := statusUpdater{
+		virtualServerLister: vsLister,
+		confClient:          fakeClient,
+		keyFunc:             cache.DeletionHandlingMetaNamespaceKeyFunc,
+	}
+
+	canaries := []conf_v1.CanaryStatus{
+		{
+			Path:    "/coffee",
+			Phase:   canaryPhaseProgressing,
+			Weight:  20,
+			Step:    1,
+			Message: "Moved to step 1 with weight 20%",
+		},
+	}
+
+	err = su.UpdateVirtualServerStatus(vs, vs.Status.State, vs.Status.Reason, vs.Status.Message, canaries)
+	if err != nil {
+		t.Errorf("error updating virtualserver status: %v", err)
+	}
+	updatedVs, _ := fakeClient.K8sV1().VirtualServers(vs.Namespace).Get(context.TODO(), vs.Name, meta_v1.GetOptions{})
+
+	expectedStatus := conf_v1.VirtualServerStatus{
+		State:    "Valid",
+		Reason:   "AddedOrUpdated",
+		Message:  "Configuration for default/cafe was added or updated",
+		Canaries: canaries,
+	}
+
+	if diff := cmp.Diff(expectedStatus, updatedVs.Status); diff != "" {
+		t.Errorf("Unexpected status (-want +got):\n%s", diff)
+	}
+
+	// nil canaries keep the reported progress of the canaries
+
+	err = vsLister.Update(updatedVs)
+	if err != nil {
+		t.Errorf("Error updating VirtualServer in the virtualserver lister: %v", err)
+	}
+
+	err = su.UpdateVirtualServerStatus(vs, "Warning", "Rejected", "Host is taken by another resource", nil)
+	if err != nil {
+		t.Errorf("error updating virtualserver status: %v", err)
+	}
+	updatedVs, _ = fakeClient.K8sV1().VirtualServers(vs.Namespace).Get(context.TODO(), vs.Name, meta_v1.GetOptions{})
+
+	expectedStatus = conf_v1.VirtualServerStatus{
+		State:    "Warning",
+		Reason:   "Rejected",
+		Message:  "Host is taken by another resource",
+		Canaries: canaries,
+	}
+
+	if diff := cmp.Diff(expectedStatus, updatedVs.Status); diff != "" {
+		t.Errorf("Unexpected status (-want +got):\n%s", diff)
+	}
+}
+
 func TestStatusUpdateWithExternalStatusAndExternalService(t *testing.T) {
 	ing := networking.Ingress{
 		ObjectMeta: meta_v1.ObjectMeta{
@@ -692,3 +780,114 @@ func TestHasPolicyStatusChanged(t *testing.T) {
 		}
 	}
 }
+
+func TestUpdateHTTPRouteStatus(t *testing.T) {
+	route := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "gateway.networking.k8s.io/v1alpha2",
+			"kind":       "HTTPRoute",
+			"metadata": map[string]interface{}{
+				"name":      "cafe",
+				"namespace": "default",
+			},
+			"spec": map[string]interface{}{},
+			"status": map[string]interface{}{
+				"parents": []interface{}{
+					map[string]interface{}{
+						"parentRef": map[string]interface{}{
+							"name": "other-gateway",
+						},
+						"controllerName": "example.com/other-controller",
+					},
+				},
+			},
+		},
+	}
+
+	dynClient := fake_dynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{httpRouteGVR: "HTTPRouteList"}, route)
+
+	routeLister := cache.NewStore(cache.DeletionHandlingMetaNamespaceKeyFunc)
+	err := routeLister.Add(route)
+	if err != nil {
+		t.Errorf("Error adding HTTPRoute to the httproute lister: %v", err)
+	}
+
+	su := statusUpdater{
+		httpRouteLister: routeLister,
+		dynClient:       dynClient,
+		keyFunc:         cache.DeletionHandlingMetaNamespaceKeyFunc,
+	}
+
+	parents := []routeParentStatus{
+		{
+			ParentRef:      gatewayParentReference{Name: "gateway"},
+			ControllerName: GatewayControllerName,
+			Conditions: []meta_v1.Condition{
+				newGatewayCondition("Accepted", meta_v1.ConditionTrue, "Accepted", "Route is accepted", 0),
+			},
+		},
+	}
+
+	err = su.UpdateHTTPRouteStatus(route, parents)
+	if err != nil {
+		t.Errorf("error updating HTTPRoute status: %v", err)
+	}
+
+	updatedRoute, err := dynClient.Resource(httpRouteGVR).Namespace("default").Get(context.TODO(), "cafe", meta_v1.GetOptions{})
+	if err != nil {
+		t.Fatalf("error getting HTTPRoute: %v", err)
+	}
+
+	var status routeStatus
+	err = getGatewayAPIResourceStatus(updatedRoute, &status)
+	if err != nil {
+		t.Fatalf("error getting HTTPRoute status: %v", err)
+	}
+
+	if len(status.Parents) != 2 {
+		t.Fatalf("HTTPRoute has %d parents in the status but expected 2", len(status.Parents))
+	}
+	if status.Parents[0].ControllerName != "example.com/other-controller" {
+		t.Errorf("the status of the parent of another controller was not preserved: %+v", status.Parents[0])
+	}
+	if status.Parents[1].ControllerName != GatewayControllerName || status.Parents[1].Conditions[0].Reason != "Accepted" {
+		t.Errorf("unexpected status of the parent: %+v", status.Parents[1])
+	}
+}
+
+func TestMergeGatewayConditions(t *testing.T) {
+	before := meta_v1.NewTime(meta_v1.Now().Add(-time.Hour))
+
+	current := []meta_v1.Condition{
+		{
+			Type:               "Accepted",
+			Status:             meta_v1.ConditionTrue,
+			LastTransitionTime: before,
+		},
+		{
+			Type:               "ResolvedRefs",
+			Status:             meta_v1.ConditionTrue,
+			LastTransitionTime: before,
+		},
+	}
+	desired := []meta_v1.Condition{
+		{
+			Type:   "Accepted",
+			Status: meta_v1.ConditionTrue,
+		},
+		{
+			Type:   "ResolvedRefs",
+			Status: meta_v1.ConditionFalse,
+		},
+	}
+
+	result := mergeGatewayConditions(current, desired)
+
+	if !result[0].LastTransitionTime.Equal(&before) {
+		t.Errorf("mergeGatewayConditions() changed the last transition time of the unchanged condition")
+	}
+	if result[1].LastTransitionTime.Equal(&before) {
+		t.Errorf("mergeGatewayConditions() didn't change the last transition time of the changed condition")
+	}
+}
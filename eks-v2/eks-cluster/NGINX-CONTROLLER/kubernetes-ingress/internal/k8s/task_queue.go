@@ -10,8 +10,8 @@ import (
 	"github.com/nginxinc/kubernetes-ingress/internal/k8s/appprotect"
 	"github.com/nginxinc/kubernetes-ingress/internal/k8s/appprotectdos"
 	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
-	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
 	v1 "k8s.io/api/core/v1"
+	discovery_v1 "k8s.io/api/discovery/v1"
 	networking "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -62,6 +62,12 @@ func (tq *taskQueue) Enqueue(obj interface{}) {
 	tq.queue.Add(task)
 }
 
+// EnqueueTask enqueues the given task, which is not tied to an api object.
+func (tq *taskQueue) EnqueueTask(t task) {
+	glog.V(3).Infof("Adding an element with a key: %v", t.Key)
+	tq.queue.Add(t)
+}
+
 // Requeue adds the task to the queue again and logs the given error
 func (tq *taskQueue) Requeue(task task, err error) {
 	glog.Errorf("Requeuing %v, err %v", task.Key, err)
@@ -83,6 +89,15 @@ func (tq *taskQueue) RequeueAfter(t task, err error, after time.Duration) {
 	}(t, after)
 }
 
+// EnqueueTaskAfter adds the task to the queue after the given duration
+func (tq *taskQueue) EnqueueTaskAfter(t task, after time.Duration) {
+	glog.V(3).Infof("Adding an element with a key %v after %s", t.Key, after.String())
+	go func(t task, after time.Duration) {
+		time.Sleep(after)
+		tq.queue.Add(t)
+	}(t, after)
+}
+
 // Worker processes work in the queue through sync.
 func (tq *taskQueue) worker() {
 	for {
@@ -125,6 +140,18 @@ const (
 	appProtectDosLogConf
 	appProtectDosProtectedResource
 	ingressLink
+	gatewayClassResource
+	gatewayResource
+	httpRouteResource
+	tcpRouteResource
+	udpRouteResource
+	tlsRouteResource
+	endpointSlice
+	namespaceResource
+	// pendingReload is not a resource: it is the task to perform the pending reload of NGINX.
+	pendingReload
+	// canaryProgress is not a resource: it is the task to apply the progress of the canaries of a VirtualServer.
+	canaryProgress
 )
 
 // task is an element of a taskQueue
@@ -141,8 +168,19 @@ func newTask(key string, obj interface{}) (task, error) {
 		k = ingress
 	case *v1.Endpoints:
 		k = endpoints
+	case *discovery_v1.EndpointSlice:
+		// a Service can have multiple EndpointSlices, so we sync the Service rather than the individual EndpointSlice.
+		// This way, the changes of several EndpointSlices of the same Service result in a single task in the queue.
+		svcName, exists := t.Labels[discovery_v1.LabelServiceName]
+		if !exists {
+			return task{}, fmt.Errorf("EndpointSlice %v has no %v label", key, discovery_v1.LabelServiceName)
+		}
+		k = endpointSlice
+		key = t.Namespace + "/" + svcName
 	case *v1.ConfigMap:
 		k = configMap
+	case *v1.Namespace:
+		k = namespaceResource
 	case *v1.Secret:
 		k = secret
 	case *v1.Service:
@@ -153,9 +191,9 @@ func newTask(key string, obj interface{}) (task, error) {
 		k = virtualServerRoute
 	case *conf_v1.Policy:
 		k = policy
-	case *conf_v1alpha1.GlobalConfiguration:
+	case *conf_v1.GlobalConfiguration:
 		k = globalConfiguration
-	case *conf_v1alpha1.TransportServer:
+	case *conf_v1.TransportServer:
 		k = transportserver
 	case *v1beta1.DosProtectedResource:
 		k = appProtectDosProtectedResource
@@ -172,6 +210,18 @@ func newTask(key string, obj interface{}) (task, error) {
 			k = appProtectDosPolicy
 		} else if objectKind == appprotectdos.DosLogConfGVK.Kind {
 			k = appProtectDosLogConf
+		} else if objectKind == gatewayClassGVK.Kind {
+			k = gatewayClassResource
+		} else if objectKind == gatewayGVK.Kind {
+			k = gatewayResource
+		} else if objectKind == httpRouteGVK.Kind {
+			k = httpRouteResource
+		} else if objectKind == tcpRouteGVK.Kind {
+			k = tcpRouteResource
+		} else if objectKind == udpRouteGVK.Kind {
+			k = udpRouteResource
+		} else if objectKind == tlsRouteGVK.Kind {
+			k = tlsRouteResource
 		} else {
 			return task{}, fmt.Errorf("Unknown unstructured kind: %v", objectKind)
 		}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/golang/glog"
 	v1 "k8s.io/api/core/v1"
+	discovery_v1 "k8s.io/api/discovery/v1"
 	networking "k8s.io/api/networking/v1"
 
 	"k8s.io/apimachinery/pkg/labels"
@@ -99,6 +100,26 @@ func (s *storeToEndpointLister) GetServiceEndpoints(svc *v1.Service) (ep v1.Endp
 	return ep, fmt.Errorf("could not find endpoints for service: %v", svc.Name)
 }
 
+// storeToEndpointSliceLister makes a Store that lists EndpointSlices
+type storeToEndpointSliceLister struct {
+	cache.Store
+}
+
+// GetServiceEndpointSlices returns the EndpointSlices of a service, matched on the service name label.
+func (s *storeToEndpointSliceLister) GetServiceEndpointSlices(svc *v1.Service) ([]discovery_v1.EndpointSlice, error) {
+	var slices []discovery_v1.EndpointSlice
+	for _, m := range s.Store.List() {
+		slice := *m.(*discovery_v1.EndpointSlice)
+		if svc.Namespace == slice.Namespace && svc.Name == slice.Labels[discovery_v1.LabelServiceName] {
+			slices = append(slices, slice)
+		}
+	}
+	if len(slices) == 0 {
+		return nil, fmt.Errorf("could not find endpoint slices for service: %v", svc.Name)
+	}
+	return slices, nil
+}
+
 // findPort locates the container port for the given pod and portName.  If the
 // targetPort is a number, use that.  If the targetPort is a string, look that
 // string up in all named ports in all containers in the target pod.  If no
@@ -3,6 +3,7 @@ package collectors
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 	"sync"
@@ -50,6 +51,58 @@ type LatencyCollector interface {
 	DeleteUpstreamServerPeerLabels([]string)
 	DeleteMetrics([]string)
 	Register(*prometheus.Registry) error
+	GetUpstreamStats(string) UpstreamStats
+}
+
+// UpstreamStats holds the cumulative number of responses, server errors and the latency distribution
+// recorded for an upstream since it was first seen by the collector.
+type UpstreamStats struct {
+	Requests     uint64
+	ServerErrors uint64
+	// LatencyBuckets holds the number of responses per latency bucket. The buckets follow latencyBucketsMilliSeconds
+	// with one extra bucket at the end for the responses slower than the last bound.
+	LatencyBuckets []uint64
+}
+
+// Sub returns the stats recorded since prev was taken.
+func (s UpstreamStats) Sub(prev UpstreamStats) UpstreamStats {
+	if prev.Requests > s.Requests {
+		// the stats were reset in between, for example, because the upstream was recreated
+		return s
+	}
+
+	diff := UpstreamStats{
+		Requests:       s.Requests - prev.Requests,
+		ServerErrors:   s.ServerErrors - prev.ServerErrors,
+		LatencyBuckets: make([]uint64, len(s.LatencyBuckets)),
+	}
+	for i := range s.LatencyBuckets {
+		diff.LatencyBuckets[i] = s.LatencyBuckets[i]
+		if i < len(prev.LatencyBuckets) {
+			diff.LatencyBuckets[i] -= prev.LatencyBuckets[i]
+		}
+	}
+
+	return diff
+}
+
+// Percentile returns the upper bound in milliseconds of the latency bucket which contains the given percentile (0-100).
+// It returns +Inf if the percentile falls beyond the last bucket and 0 if no responses were recorded.
+func (s UpstreamStats) Percentile(p float64) float64 {
+	if s.Requests == 0 {
+		return 0
+	}
+
+	rank := uint64(math.Ceil(float64(s.Requests) * p / 100))
+	var count uint64
+	for i, c := range s.LatencyBuckets {
+		count += c
+		if count >= rank && i < len(latencyBucketsMilliSeconds) {
+			return latencyBucketsMilliSeconds[i]
+		}
+	}
+
+	return math.Inf(1)
 }
 
 // metricsPublishedMap is a map of upstream server peers (upstream/server) to a metricsSet.
@@ -73,6 +126,8 @@ type LatencyMetricsCollector struct {
 	metricsPublishedMap          metricsPublishedMap
 	metricsPublishedMutex        sync.Mutex
 	variableLabelsMutex          sync.RWMutex
+	upstreamStats                map[string]*UpstreamStats
+	upstreamStatsMutex           sync.Mutex
 }
 
 // NewLatencyMetricsCollector creates a new LatencyMetricsCollector
@@ -94,6 +149,7 @@ func NewLatencyMetricsCollector(
 		upstreamServerLabels:         make(map[string][]string),
 		upstreamServerPeerLabels:     make(map[string][]string),
 		metricsPublishedMap:          make(metricsPublishedMap),
+		upstreamStats:                make(map[string]*UpstreamStats),
 		upstreamServerLabelNames:     upstreamServerLabelNames,
 		upstreamServerPeerLabelNames: upstreamServerPeerLabelNames,
 	}
@@ -133,6 +189,12 @@ func (l *LatencyMetricsCollector) DeleteUpstreamServerLabels(upstreamNames []str
 		delete(l.upstreamServerLabels, k)
 	}
 	l.variableLabelsMutex.Unlock()
+
+	l.upstreamStatsMutex.Lock()
+	for _, k := range upstreamNames {
+		delete(l.upstreamStats, k)
+	}
+	l.upstreamStatsMutex.Unlock()
 }
 
 // DeleteMetrics deletes all metrics published associated with the given upstream server peer names.
@@ -188,6 +250,52 @@ func (l *LatencyMetricsCollector) RecordLatency(syslogMsg string) {
 	}
 	l.httpLatency.WithLabelValues(labelValues...).Observe(lm.Latency * 1000)
 	l.updateMetricsPublished(lm.Upstream, lm.Server, labelValues)
+	l.updateUpstreamStats(lm)
+}
+
+func (l *LatencyMetricsCollector) updateUpstreamStats(lm latencyMetric) {
+	l.upstreamStatsMutex.Lock()
+	defer l.upstreamStatsMutex.Unlock()
+
+	stats, ok := l.upstreamStats[lm.Upstream]
+	if !ok {
+		stats = &UpstreamStats{
+			LatencyBuckets: make([]uint64, len(latencyBucketsMilliSeconds)+1),
+		}
+		l.upstreamStats[lm.Upstream] = stats
+	}
+
+	stats.Requests++
+	if strings.HasPrefix(lm.Code, "5") {
+		stats.ServerErrors++
+	}
+
+	latency := lm.Latency * 1000
+	bucket := len(latencyBucketsMilliSeconds)
+	for i, bound := range latencyBucketsMilliSeconds {
+		if latency <= bound {
+			bucket = i
+			break
+		}
+	}
+	stats.LatencyBuckets[bucket]++
+}
+
+// GetUpstreamStats returns a copy of the stats recorded for the given upstream.
+func (l *LatencyMetricsCollector) GetUpstreamStats(upstreamName string) UpstreamStats {
+	l.upstreamStatsMutex.Lock()
+	defer l.upstreamStatsMutex.Unlock()
+
+	stats, ok := l.upstreamStats[upstreamName]
+	if !ok {
+		return UpstreamStats{}
+	}
+
+	return UpstreamStats{
+		Requests:       stats.Requests,
+		ServerErrors:   stats.ServerErrors,
+		LatencyBuckets: append([]uint64(nil), stats.LatencyBuckets...),
+	}
 }
 
 func (l *LatencyMetricsCollector) updateMetricsPublished(upstreamName, server string, labelValues []string) {
@@ -313,3 +421,6 @@ func (l *LatencyFakeCollector) Register(_ *prometheus.Registry) error { return n
 
 // RecordLatency implements a fake RecordLatency
 func (l *LatencyFakeCollector) RecordLatency(_ string) {}
+
+// GetUpstreamStats implements a fake GetUpstreamStats
+func (l *LatencyFakeCollector) GetUpstreamStats(_ string) UpstreamStats { return UpstreamStats{} }
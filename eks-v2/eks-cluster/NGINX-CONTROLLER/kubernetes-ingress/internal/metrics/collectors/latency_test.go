@@ -1,6 +1,7 @@
 package collectors
 
 import (
+	"math"
 	"reflect"
 	"testing"
 )
@@ -10,6 +11,7 @@ func newTestLatencyMetricsCollector() *LatencyMetricsCollector {
 		upstreamServerLabels:         make(map[string][]string),
 		upstreamServerPeerLabels:     make(map[string][]string),
 		metricsPublishedMap:          make(metricsPublishedMap),
+		upstreamStats:                make(map[string]*UpstreamStats),
 		upstreamServerLabelNames:     []string{"service", "resource_type", "resource_name", "resource_namespace"},
 		upstreamServerPeerLabelNames: []string{"pod_name"},
 	}
@@ -232,3 +234,116 @@ func contains(x []string, y [][]string) bool {
 	}
 	return false
 }
+
+func TestUpstreamStats(t *testing.T) {
+	collector := newTestLatencyMetricsCollector()
+
+	collector.updateUpstreamStats(latencyMetric{Upstream: "upstream-1", Code: "200", Latency: 0.0005})
+	collector.updateUpstreamStats(latencyMetric{Upstream: "upstream-1", Code: "503", Latency: 0.045})
+	collector.updateUpstreamStats(latencyMetric{Upstream: "upstream-1", Code: "200", Latency: 60})
+	collector.updateUpstreamStats(latencyMetric{Upstream: "upstream-2", Code: "200", Latency: 0.001})
+
+	stats := collector.GetUpstreamStats("upstream-1")
+
+	expectedBuckets := make([]uint64, len(latencyBucketsMilliSeconds)+1)
+	expectedBuckets[0] = 1
+	expectedBuckets[9] = 1
+	expectedBuckets[len(latencyBucketsMilliSeconds)] = 1
+	expected := UpstreamStats{
+		Requests:       3,
+		ServerErrors:   1,
+		LatencyBuckets: expectedBuckets,
+	}
+	if !reflect.DeepEqual(stats, expected) {
+		t.Errorf("GetUpstreamStats() returned %+v but expected %+v", stats, expected)
+	}
+
+	collector.DeleteUpstreamServerLabels([]string{"upstream-1"})
+
+	stats = collector.GetUpstreamStats("upstream-1")
+	if !reflect.DeepEqual(stats, UpstreamStats{}) {
+		t.Errorf("GetUpstreamStats() returned %+v for a deleted upstream but expected empty stats", stats)
+	}
+
+	stats = collector.GetUpstreamStats("upstream-2")
+	if stats.Requests != 1 {
+		t.Errorf("GetUpstreamStats() returned %d requests for upstream-2 but expected 1", stats.Requests)
+	}
+}
+
+func TestUpstreamStatsSub(t *testing.T) {
+	prev := UpstreamStats{
+		Requests:       3,
+		ServerErrors:   1,
+		LatencyBuckets: []uint64{1, 2, 0},
+	}
+	current := UpstreamStats{
+		Requests:       10,
+		ServerErrors:   2,
+		LatencyBuckets: []uint64{4, 5, 1},
+	}
+	expected := UpstreamStats{
+		Requests:       7,
+		ServerErrors:   1,
+		LatencyBuckets: []uint64{3, 3, 1},
+	}
+
+	result := current.Sub(prev)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Sub() returned %+v but expected %+v", result, expected)
+	}
+
+	result = prev.Sub(current)
+	if !reflect.DeepEqual(result, prev) {
+		t.Errorf("Sub() returned %+v for reset stats but expected %+v", result, prev)
+	}
+
+	result = current.Sub(UpstreamStats{})
+	if !reflect.DeepEqual(result, current) {
+		t.Errorf("Sub() returned %+v for empty previous stats but expected %+v", result, current)
+	}
+}
+
+func TestUpstreamStatsPercentile(t *testing.T) {
+	buckets := make([]uint64, len(latencyBucketsMilliSeconds)+1)
+	buckets[0] = 90
+	buckets[10] = 9
+	buckets[len(latencyBucketsMilliSeconds)] = 1
+	stats := UpstreamStats{
+		Requests:       100,
+		LatencyBuckets: buckets,
+	}
+
+	tests := []struct {
+		percentile float64
+		expected   float64
+	}{
+		{
+			percentile: 50,
+			expected:   1,
+		},
+		{
+			percentile: 90,
+			expected:   1,
+		},
+		{
+			percentile: 99,
+			expected:   100,
+		},
+		{
+			percentile: 100,
+			expected:   math.Inf(1),
+		},
+	}
+
+	for _, test := range tests {
+		result := stats.Percentile(test.percentile)
+		if result != test.expected {
+			t.Errorf("Percentile(%v) returned %v but expected %v", test.percentile, result, test.expected)
+		}
+	}
+
+	if result := (UpstreamStats{}).Percentile(99); result != 0 {
+		t.Errorf("Percentile(99) returned %v for empty stats but expected 0", result)
+	}
+}
@@ -11,6 +11,8 @@ type ManagerCollector interface {
 	IncNginxReloadCount(isEndPointUpdate bool)
 	IncNginxReloadErrors()
 	UpdateLastReloadTime(ms time.Duration)
+	IncNginxBatchedReloadCount()
+	IncNginxSkippedReloadCount()
 	Register(registry *prometheus.Registry) error
 }
 
@@ -21,6 +23,8 @@ type LocalManagerMetricsCollector struct {
 	reloadsError     prometheus.Counter
 	lastReloadStatus prometheus.Gauge
 	lastReloadTime   prometheus.Gauge
+	batchedReloads   prometheus.Counter
+	skippedReloads   prometheus.Counter
 }
 
 // NewLocalManagerMetricsCollector creates a new LocalManagerMetricsCollector
@@ -59,6 +63,22 @@ func NewLocalManagerMetricsCollector(constLabels map[string]string) *LocalManage
 				ConstLabels: constLabels,
 			},
 		),
+		batchedReloads: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name:        "nginx_batched_reloads_total",
+				Namespace:   metricsNamespace,
+				Help:        "Number of NGINX reloads that applied batched configuration changes",
+				ConstLabels: constLabels,
+			},
+		),
+		skippedReloads: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name:        "nginx_skipped_reloads_total",
+				Namespace:   metricsNamespace,
+				Help:        "Number of NGINX reloads that were skipped because the configuration changes were batched into a pending reload",
+				ConstLabels: constLabels,
+			},
+		),
 	}
 	nc.reloadsTotal.WithLabelValues("other")
 	nc.reloadsTotal.WithLabelValues("endpoints")
@@ -97,12 +117,24 @@ func (nc *LocalManagerMetricsCollector) UpdateLastReloadTime(duration time.Durat
 	nc.lastReloadTime.Set(float64(duration / time.Millisecond))
 }
 
+// IncNginxBatchedReloadCount increments the counter of NGINX reloads that applied batched configuration changes
+func (nc *LocalManagerMetricsCollector) IncNginxBatchedReloadCount() {
+	nc.batchedReloads.Inc()
+}
+
+// IncNginxSkippedReloadCount increments the counter of skipped NGINX reloads
+func (nc *LocalManagerMetricsCollector) IncNginxSkippedReloadCount() {
+	nc.skippedReloads.Inc()
+}
+
 // Describe implements prometheus.Collector interface Describe method
 func (nc *LocalManagerMetricsCollector) Describe(ch chan<- *prometheus.Desc) {
 	nc.reloadsTotal.Describe(ch)
 	nc.reloadsError.Describe(ch)
 	nc.lastReloadStatus.Describe(ch)
 	nc.lastReloadTime.Describe(ch)
+	nc.batchedReloads.Describe(ch)
+	nc.skippedReloads.Describe(ch)
 }
 
 // Collect implements the prometheus.Collector interface Collect method
@@ -111,6 +143,8 @@ func (nc *LocalManagerMetricsCollector) Collect(ch chan<- prometheus.Metric) {
 	nc.reloadsError.Collect(ch)
 	nc.lastReloadStatus.Collect(ch)
 	nc.lastReloadTime.Collect(ch)
+	nc.batchedReloads.Collect(ch)
+	nc.skippedReloads.Collect(ch)
 }
 
 // Register registers all the metrics of the collector
@@ -137,3 +171,9 @@ func (nc *ManagerFakeCollector) IncNginxReloadErrors() {}
 
 // UpdateLastReloadTime implements a fake UpdateLastReloadTime
 func (nc *ManagerFakeCollector) UpdateLastReloadTime(_ time.Duration) {}
+
+// IncNginxBatchedReloadCount implements a fake IncNginxBatchedReloadCount
+func (nc *ManagerFakeCollector) IncNginxBatchedReloadCount() {}
+
+// IncNginxSkippedReloadCount implements a fake IncNginxSkippedReloadCount
+func (nc *ManagerFakeCollector) IncNginxSkippedReloadCount() {}
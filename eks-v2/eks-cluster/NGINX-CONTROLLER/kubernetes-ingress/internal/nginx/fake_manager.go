@@ -58,6 +58,12 @@ func (*FakeManager) DeleteConfig(name string) {
 	glog.V(3).Infof("Deleting config %v", name)
 }
 
+// RestoreConfig provides a fake implementation of RestoreConfig.
+func (*FakeManager) RestoreConfig(name string) bool {
+	glog.V(3).Infof("Restoring config %v", name)
+	return false
+}
+
 // CreateStreamConfig provides a fake implementation of CreateStreamConfig.
 func (*FakeManager) CreateStreamConfig(name string, content []byte) {
 	glog.V(3).Infof("Writing stream config %v", name)
@@ -69,6 +75,12 @@ func (*FakeManager) DeleteStreamConfig(name string) {
 	glog.V(3).Infof("Deleting stream config %v", name)
 }
 
+// RestoreStreamConfig provides a fake implementation of RestoreStreamConfig.
+func (*FakeManager) RestoreStreamConfig(name string) bool {
+	glog.V(3).Infof("Restoring stream config %v", name)
+	return false
+}
+
 // CreateTLSPassthroughHostsConfig provides a fake implementation of CreateTLSPassthroughHostsConfig.
 func (*FakeManager) CreateTLSPassthroughHostsConfig(_ []byte) {
 	glog.V(3).Infof("Writing TLS Passthrough Hosts config file")
@@ -113,6 +125,11 @@ func (*FakeManager) Reload(_ bool) error {
 	return nil
 }
 
+// DiscardStagedConfig provides a fake implementation of DiscardStagedConfig.
+func (*FakeManager) DiscardStagedConfig() {
+	glog.V(3).Info("Discarding the staged config")
+}
+
 // Quit provides a fake implementation of Quit.
 func (*FakeManager) Quit() {
 	glog.V(3).Info("Quitting nginx")
@@ -24,6 +24,8 @@ const (
 	ReloadForOtherUpdate = false
 	// TLSSecretFileMode defines the default filemode for files with TLS Secrets.
 	TLSSecretFileMode = 0o600
+	// HtpasswdSecretFileMode defines the default filemode for files with htpasswd Secrets.
+	HtpasswdSecretFileMode = 0o644
 	// JWKSecretFileMode defines the default filemode for files with JWK Secrets.
 	JWKSecretFileMode            = 0o644
 	configFileMode               = 0o644
@@ -62,8 +64,10 @@ type Manager interface {
 	CreateMainConfig(content []byte)
 	CreateConfig(name string, content []byte)
 	DeleteConfig(name string)
+	RestoreConfig(name string) bool
 	CreateStreamConfig(name string, content []byte)
 	DeleteStreamConfig(name string)
+	RestoreStreamConfig(name string) bool
 	CreateTLSPassthroughHostsConfig(content []byte)
 	CreateSecret(name string, content []byte, mode os.FileMode) string
 	DeleteSecret(name string)
@@ -76,6 +80,7 @@ type Manager interface {
 	Start(done chan error)
 	Version() string
 	Reload(isEndpointsUpdate bool) error
+	DiscardStagedConfig()
 	Quit()
 	UpdateConfigVersionFile(openTracing bool)
 	SetPlusClients(plusClient *client.NginxClient, plusConfigVersionCheckClient *http.Client)
@@ -101,6 +106,7 @@ type LocalManager struct {
 	debug                        bool
 	dhparamFilename              string
 	tlsPassthroughHostsFilename  string
+	staging                      configPaths
 	verifyConfigGenerator        *verifyConfigGenerator
 	verifyClient                 *verifyClient
 	configVersion                int
@@ -128,6 +134,7 @@ func NewLocalManager(confPath string, debug bool, mc collectors.ManagerCollector
 		mainConfFilename:            path.Join(confPath, "nginx.conf"),
 		configVersionFilename:       path.Join(confPath, "config-version.conf"),
 		tlsPassthroughHostsFilename: path.Join(confPath, "tls-passthrough-hosts.conf"),
+		staging:                     newConfigPaths(path.Join(confPath, stagingDirName)),
 		debug:                       debug,
 		verifyConfigGenerator:       verifyConfigGenerator,
 		configVersion:               0,
@@ -135,21 +142,25 @@ func NewLocalManager(confPath string, debug bool, mc collectors.ManagerCollector
 		metricsCollector:            mc,
 	}
 
+	manager.initStaging()
+
 	return &manager
 }
 
 // CreateMainConfig creates the main NGINX configuration file. If the file already exists, it will be overridden.
+// The file is staged until the next start or reload of NGINX.
 func (lm *LocalManager) CreateMainConfig(content []byte) {
-	glog.V(3).Infof("Writing main config to %v", lm.mainConfFilename)
+	glog.V(3).Infof("Writing main config to %v", lm.staging.mainConfFilename)
 	glog.V(3).Infof(string(content))
 
-	err := createFileAndWrite(lm.mainConfFilename, content)
+	err := createFileAndWrite(lm.staging.mainConfFilename, content)
 	if err != nil {
 		glog.Fatalf("Failed to write main config: %v", err)
 	}
 }
 
 // CreateConfig creates a configuration file. If the file already exists, it will be overridden.
+// The file is staged until the next start or reload of NGINX.
 func (lm *LocalManager) CreateConfig(name string, content []byte) {
 	createConfig(lm.getFilenameForConfig(name), content)
 }
@@ -177,8 +188,14 @@ func deleteConfig(filename string) {
 	}
 }
 
+// RestoreConfig replaces the staged configuration file with the live one, so that the last configuration that passed
+// the NGINX configuration test is kept. It returns false if there is no live file, in which case the staged file is deleted.
+func (lm *LocalManager) RestoreConfig(name string) bool {
+	return restoreConfig(path.Join(lm.confdPath, name+".conf"), lm.getFilenameForConfig(name))
+}
+
 func (lm *LocalManager) getFilenameForConfig(name string) string {
-	return path.Join(lm.confdPath, name+".conf")
+	return path.Join(lm.staging.confdPath, name+".conf")
 }
 
 // CreateStreamConfig creates a configuration file for stream module.
@@ -192,16 +209,22 @@ func (lm *LocalManager) DeleteStreamConfig(name string) {
 	deleteConfig(lm.getFilenameForStreamConfig(name))
 }
 
+// RestoreStreamConfig replaces the staged configuration file for stream module with the live one.
+// It returns false if there is no live file, in which case the staged file is deleted.
+func (lm *LocalManager) RestoreStreamConfig(name string) bool {
+	return restoreConfig(path.Join(lm.streamConfdPath, name+".conf"), lm.getFilenameForStreamConfig(name))
+}
+
 func (lm *LocalManager) getFilenameForStreamConfig(name string) string {
-	return path.Join(lm.streamConfdPath, name+".conf")
+	return path.Join(lm.staging.streamConfdPath, name+".conf")
 }
 
 // CreateTLSPassthroughHostsConfig creates a configuration file with mapping between TLS Passthrough hosts and
 // the corresponding unix sockets.
 // If the file already exists, it will be overridden.
 func (lm *LocalManager) CreateTLSPassthroughHostsConfig(content []byte) {
-	glog.V(3).Infof("Writing TLS Passthrough Hosts config file to %v", lm.tlsPassthroughHostsFilename)
-	createConfig(lm.tlsPassthroughHostsFilename, content)
+	glog.V(3).Infof("Writing TLS Passthrough Hosts config file to %v", lm.staging.tlsPassthroughHostsFilename)
+	createConfig(lm.staging.tlsPassthroughHostsFilename, content)
 }
 
 // CreateSecret creates a secret file with the specified name, content and mode. If the file already exists,
@@ -274,10 +297,12 @@ func (lm *LocalManager) ClearAppProtectFolder(name string) {
 	}
 }
 
-// Start starts NGINX.
+// Start starts NGINX with the staged configuration.
 func (lm *LocalManager) Start(done chan error) {
 	glog.V(3).Info("Starting nginx")
 
+	lm.commitStagedConfig()
+
 	binaryFilename := getBinaryFileName(lm.debug)
 	cmd := exec.Command(binaryFilename)
 	cmd.Stdout = os.Stdout
@@ -295,8 +320,18 @@ func (lm *LocalManager) Start(done chan error) {
 	}
 }
 
-// Reload reloads NGINX.
+// Reload reloads NGINX with the staged configuration.
+// The staged configuration is tested with nginx -t first. If the test fails, NGINX keeps running with the previous
+// configuration and a *ConfigTestError is returned. The staged changes are kept, so that the caller can fix them
+// and reload again, or discard them with DiscardStagedConfig.
 func (lm *LocalManager) Reload(isEndpointsUpdate bool) error {
+	if err := lm.testStagedConfig(); err != nil {
+		lm.metricsCollector.IncNginxReloadErrors()
+		return err
+	}
+
+	lm.commitStagedConfig()
+
 	// write a new config version
 	lm.configVersion++
 	lm.UpdateConfigVersionFile(lm.OpenTracing)
@@ -323,6 +358,13 @@ func (lm *LocalManager) Reload(isEndpointsUpdate bool) error {
 	return nil
 }
 
+// DiscardStagedConfig discards the staged changes that were not applied by a reload.
+func (lm *LocalManager) DiscardStagedConfig() {
+	glog.V(3).Info("Discarding the staged config")
+
+	lm.resetStagedConfig()
+}
+
 // Quit shutdowns NGINX gracefully.
 func (lm *LocalManager) Quit() {
 	glog.V(3).Info("Quitting nginx")
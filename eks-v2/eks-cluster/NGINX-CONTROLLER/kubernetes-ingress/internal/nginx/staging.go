@@ -0,0 +1,293 @@
+package nginx
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/golang/glog"
+)
+
+const (
+	stagingDirName     = "staging"
+	testConfigFilename = "nginx-test.conf"
+)
+
+// configErrorRe matches the file of a config error reported by nginx -t, like
+// "nginx: [emerg] unknown directive "foo" in /etc/nginx/conf.d/vs_default_cafe.conf:12".
+var configErrorRe = regexp.MustCompile(`in (\S+\.conf):\d+`)
+
+// ConfigTestError is returned by Reload when the new configuration fails the nginx -t test.
+// In that case, NGINX keeps running with the previous configuration.
+type ConfigTestError struct {
+	// Output is the output of nginx -t.
+	Output string
+	// ConfigErrors maps the names of the configs (as passed to CreateConfig) to the errors reported for them.
+	ConfigErrors map[string][]string
+	// StreamConfigErrors maps the names of the stream configs (as passed to CreateStreamConfig) to the errors reported for them.
+	StreamConfigErrors map[string][]string
+}
+
+func (e *ConfigTestError) Error() string {
+	return fmt.Sprintf("NGINX configuration test failed: %v", e.Output)
+}
+
+// configPaths holds the locations of the NGINX configuration files that the Manager creates.
+type configPaths struct {
+	mainConfFilename            string
+	confdPath                   string
+	streamConfdPath             string
+	tlsPassthroughHostsFilename string
+}
+
+func newConfigPaths(confPath string) configPaths {
+	return configPaths{
+		mainConfFilename:            path.Join(confPath, "nginx.conf"),
+		confdPath:                   path.Join(confPath, "conf.d"),
+		streamConfdPath:             path.Join(confPath, "stream-conf.d"),
+		tlsPassthroughHostsFilename: path.Join(confPath, "tls-passthrough-hosts.conf"),
+	}
+}
+
+// initStaging creates the staging directory with a copy of the current configuration.
+func (lm *LocalManager) initStaging() {
+	for _, dir := range []string{lm.staging.confdPath, lm.staging.streamConfdPath} {
+		err := os.MkdirAll(dir, 0o755)
+		if err != nil {
+			glog.Fatalf("Failed to create the staging directory %v: %v", dir, err)
+		}
+	}
+
+	lm.resetStagedConfig()
+}
+
+// testStagedConfig runs nginx -t for the staged configuration.
+// The main config file of the staging directory includes the files from the live directories, so it is tested
+// through a copy that includes the staged files instead.
+func (lm *LocalManager) testStagedConfig() error {
+	content, err := os.ReadFile(lm.staging.mainConfFilename)
+	if err != nil {
+		return fmt.Errorf("failed to read the staged main config: %w", err)
+	}
+
+	live := lm.getLiveConfigPaths()
+	replacer := strings.NewReplacer(
+		live.confdPath+"/", lm.staging.confdPath+"/",
+		live.streamConfdPath+"/", lm.staging.streamConfdPath+"/",
+		live.tlsPassthroughHostsFilename, lm.staging.tlsPassthroughHostsFilename,
+	)
+
+	testFilename := path.Join(path.Dir(lm.staging.mainConfFilename), testConfigFilename)
+	err = createFileAndWrite(testFilename, []byte(replacer.Replace(string(content))))
+	if err != nil {
+		return fmt.Errorf("failed to write the test main config: %w", err)
+	}
+
+	binaryFilename := getBinaryFileName(lm.debug)
+
+	glog.V(3).Infof("Testing the staged config with %v -t -c %v", binaryFilename, testFilename)
+
+	out, err := exec.Command(binaryFilename, "-t", "-c", testFilename).CombinedOutput()
+	if err == nil {
+		return nil
+	}
+
+	// report the errors with the live file names, which the users know
+	output := strings.NewReplacer(
+		lm.staging.confdPath+"/", live.confdPath+"/",
+		lm.staging.streamConfdPath+"/", live.streamConfdPath+"/",
+		lm.staging.tlsPassthroughHostsFilename, live.tlsPassthroughHostsFilename,
+		testFilename, live.mainConfFilename,
+	).Replace(string(out))
+
+	return newConfigTestError(output, live)
+}
+
+func newConfigTestError(output string, live configPaths) *ConfigTestError {
+	testErr := &ConfigTestError{
+		ConfigErrors:       make(map[string][]string),
+		StreamConfigErrors: make(map[string][]string),
+	}
+
+	var messages []string
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "nginx:"))
+		if line == "" || strings.HasSuffix(line, "test failed") {
+			continue
+		}
+
+		messages = append(messages, line)
+
+		match := configErrorRe.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		dir, file := path.Split(match[1])
+		name := strings.TrimSuffix(file, ".conf")
+
+		switch path.Clean(dir) {
+		case live.confdPath:
+			testErr.ConfigErrors[name] = append(testErr.ConfigErrors[name], line)
+		case live.streamConfdPath:
+			testErr.StreamConfigErrors[name] = append(testErr.StreamConfigErrors[name], line)
+		}
+	}
+
+	testErr.Output = strings.Join(messages, "; ")
+
+	return testErr
+}
+
+// commitStagedConfig makes the staged configuration live.
+func (lm *LocalManager) commitStagedConfig() {
+	err := syncConfigFiles(lm.staging, lm.getLiveConfigPaths())
+	if err != nil {
+		glog.Fatalf("Failed to commit the staged config: %v", err)
+	}
+}
+
+// resetStagedConfig discards the staged changes, so that the staged configuration is the same as the live one.
+func (lm *LocalManager) resetStagedConfig() {
+	err := syncConfigFiles(lm.getLiveConfigPaths(), lm.staging)
+	if err != nil {
+		glog.Fatalf("Failed to reset the staged config: %v", err)
+	}
+}
+
+// restoreConfig replaces the staged config file with the live one. It returns false if the live file doesn't exist.
+func restoreConfig(liveFilename string, stagedFilename string) bool {
+	glog.V(3).Infof("Restoring config %v from %v", stagedFilename, liveFilename)
+
+	_, err := os.Stat(liveFilename)
+	exists := err == nil
+
+	err = syncConfigFile(liveFilename, stagedFilename)
+	if err != nil {
+		glog.Errorf("Failed to restore config %v: %v", stagedFilename, err)
+	}
+
+	return exists
+}
+
+func (lm *LocalManager) getLiveConfigPaths() configPaths {
+	return configPaths{
+		mainConfFilename:            lm.mainConfFilename,
+		confdPath:                   lm.confdPath,
+		streamConfdPath:             lm.streamConfdPath,
+		tlsPassthroughHostsFilename: lm.tlsPassthroughHostsFilename,
+	}
+}
+
+// syncConfigFiles makes the config files of the destination the same as the files of the source.
+// Only the changed files are written.
+func syncConfigFiles(src configPaths, dst configPaths) error {
+	err := syncConfigFile(src.mainConfFilename, dst.mainConfFilename)
+	if err != nil {
+		return err
+	}
+
+	err = syncConfigFile(src.tlsPassthroughHostsFilename, dst.tlsPassthroughHostsFilename)
+	if err != nil {
+		return err
+	}
+
+	err = syncConfigDir(src.confdPath, dst.confdPath)
+	if err != nil {
+		return err
+	}
+
+	return syncConfigDir(src.streamConfdPath, dst.streamConfdPath)
+}
+
+func syncConfigDir(src string, dst string) error {
+	srcNames, err := getConfigFilenames(src)
+	if err != nil {
+		return err
+	}
+
+	dstNames, err := getConfigFilenames(dst)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range dstNames {
+		if !containsString(srcNames, name) {
+			err := os.Remove(path.Join(dst, name))
+			if err != nil {
+				return fmt.Errorf("failed to delete %v: %w", path.Join(dst, name), err)
+			}
+		}
+	}
+
+	for _, name := range srcNames {
+		err := syncConfigFile(path.Join(src, name), path.Join(dst, name))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// syncConfigFile copies the file if its content differs from the content of the destination file.
+// If the file doesn't exist, the destination file is deleted.
+func syncConfigFile(src string, dst string) error {
+	content, err := os.ReadFile(src)
+	if os.IsNotExist(err) {
+		err = os.Remove(dst)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to delete %v: %w", dst, err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %v: %w", src, err)
+	}
+
+	existing, err := os.ReadFile(dst)
+	if err == nil && bytes.Equal(content, existing) {
+		return nil
+	}
+
+	glog.V(3).Infof("Copying %v to %v", src, dst)
+
+	return createFileAndWrite(dst, content)
+}
+
+// getConfigFilenames returns the sorted names of the .conf files of a directory.
+func getConfigFilenames(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %v: %w", dir, err)
+	}
+
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".conf") {
+			names = append(names, e.Name())
+		}
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,132 @@
+package nginx
+
+import (
+	"os"
+	"path"
+	"reflect"
+	"testing"
+)
+
+func TestNewConfigTestError(t *testing.T) {
+	live := newConfigPaths("/etc/nginx")
+
+	output := `nginx: [emerg] unknown directive "foo" in /etc/nginx/conf.d/vs_default_cafe.conf:12
+nginx: [emerg] invalid number of arguments in "proxy_pass" directive in /etc/nginx/stream-conf.d/ts_default_tcp.conf:3
+nginx: configuration file /etc/nginx/nginx.conf test failed
+`
+
+	testErr := newConfigTestError(output, live)
+
+	expectedOutput := `[emerg] unknown directive "foo" in /etc/nginx/conf.d/vs_default_cafe.conf:12; ` +
+		`[emerg] invalid number of arguments in "proxy_pass" directive in /etc/nginx/stream-conf.d/ts_default_tcp.conf:3`
+	if testErr.Output != expectedOutput {
+		t.Errorf("newConfigTestError() returned output %q but expected %q", testErr.Output, expectedOutput)
+	}
+
+	expectedConfigErrors := map[string][]string{
+		"vs_default_cafe": {`[emerg] unknown directive "foo" in /etc/nginx/conf.d/vs_default_cafe.conf:12`},
+	}
+	if !reflect.DeepEqual(testErr.ConfigErrors, expectedConfigErrors) {
+		t.Errorf("newConfigTestError() returned config errors %v but expected %v", testErr.ConfigErrors, expectedConfigErrors)
+	}
+
+	expectedStreamConfigErrors := map[string][]string{
+		"ts_default_tcp": {`[emerg] invalid number of arguments in "proxy_pass" directive in /etc/nginx/stream-conf.d/ts_default_tcp.conf:3`},
+	}
+	if !reflect.DeepEqual(testErr.StreamConfigErrors, expectedStreamConfigErrors) {
+		t.Errorf("newConfigTestError() returned stream config errors %v but expected %v", testErr.StreamConfigErrors, expectedStreamConfigErrors)
+	}
+}
+
+func writeTestFile(t *testing.T, name string, content string) {
+	t.Helper()
+
+	err := os.MkdirAll(path.Dir(name), 0o755)
+	if err != nil {
+		t.Fatalf("Failed to create the directory for %v: %v", name, err)
+	}
+
+	err = os.WriteFile(name, []byte(content), 0o644)
+	if err != nil {
+		t.Fatalf("Failed to write %v: %v", name, err)
+	}
+}
+
+func TestSyncConfigFiles(t *testing.T) {
+	dir := t.TempDir()
+	src := newConfigPaths(path.Join(dir, "src"))
+	dst := newConfigPaths(path.Join(dir, "dst"))
+
+	writeTestFile(t, src.mainConfFilename, "main")
+	writeTestFile(t, path.Join(src.confdPath, "new.conf"), "new")
+	writeTestFile(t, path.Join(src.confdPath, "changed.conf"), "changed")
+	writeTestFile(t, path.Join(src.streamConfdPath, "ts.conf"), "ts")
+
+	writeTestFile(t, dst.mainConfFilename, "old main")
+	writeTestFile(t, path.Join(dst.confdPath, "changed.conf"), "old")
+	writeTestFile(t, path.Join(dst.confdPath, "deleted.conf"), "deleted")
+	writeTestFile(t, dst.tlsPassthroughHostsFilename, "deleted")
+
+	err := os.MkdirAll(dst.streamConfdPath, 0o755)
+	if err != nil {
+		t.Fatalf("Failed to create %v: %v", dst.streamConfdPath, err)
+	}
+
+	err = syncConfigFiles(src, dst)
+	if err != nil {
+		t.Fatalf("syncConfigFiles() returned an unexpected error: %v", err)
+	}
+
+	expected := map[string]string{
+		dst.mainConfFilename:                      "main",
+		path.Join(dst.confdPath, "new.conf"):      "new",
+		path.Join(dst.confdPath, "changed.conf"):  "changed",
+		path.Join(dst.streamConfdPath, "ts.conf"): "ts",
+	}
+	for name, content := range expected {
+		result, err := os.ReadFile(name)
+		if err != nil {
+			t.Errorf("syncConfigFiles() didn't create %v: %v", name, err)
+			continue
+		}
+		if string(result) != content {
+			t.Errorf("syncConfigFiles() created %v with %q but expected %q", name, result, content)
+		}
+	}
+
+	for _, name := range []string{path.Join(dst.confdPath, "deleted.conf"), dst.tlsPassthroughHostsFilename} {
+		if _, err := os.Stat(name); !os.IsNotExist(err) {
+			t.Errorf("syncConfigFiles() didn't delete %v", name)
+		}
+	}
+}
+
+func TestRestoreConfig(t *testing.T) {
+	dir := t.TempDir()
+	live := path.Join(dir, "live.conf")
+	staged := path.Join(dir, "staged.conf")
+
+	writeTestFile(t, live, "valid")
+	writeTestFile(t, staged, "invalid")
+
+	if !restoreConfig(live, staged) {
+		t.Errorf("restoreConfig() returned false for an existing live config")
+	}
+
+	result, err := os.ReadFile(staged)
+	if err != nil {
+		t.Fatalf("restoreConfig() deleted the staged config: %v", err)
+	}
+	if string(result) != "valid" {
+		t.Errorf("restoreConfig() restored %q but expected %q", result, "valid")
+	}
+
+	missing := path.Join(dir, "missing.conf")
+
+	if restoreConfig(missing, staged) {
+		t.Errorf("restoreConfig() returned true for a missing live config")
+	}
+	if _, err := os.Stat(staged); !os.IsNotExist(err) {
+		t.Errorf("restoreConfig() didn't delete the staged config")
+	}
+}
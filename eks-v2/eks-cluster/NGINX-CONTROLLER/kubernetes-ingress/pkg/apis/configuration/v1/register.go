@@ -36,6 +36,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&VirtualServerRouteList{},
 		&Policy{},
 		&PolicyList{},
+		&TransportServer{},
+		&TransportServerList{},
+		&GlobalConfiguration{},
+		&GlobalConfigurationList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
@@ -11,6 +11,10 @@ const (
 	StateValid = "Valid"
 	// StateInvalid is used when the resource failed validation or NGINX failed to reload the corresponding config.
 	StateInvalid = "Invalid"
+	// TLSPassthroughListenerName is the name of a built-in TLS Passthrough listener.
+	TLSPassthroughListenerName = "tls-passthrough"
+	// TLSPassthroughListenerProtocol is the protocol of a built-in TLS Passthrough listener.
+	TLSPassthroughListenerProtocol = "TLS_PASSTHROUGH"
 )
 
 // +genclient
@@ -139,6 +143,7 @@ type Route struct {
 	Route            string            `json:"route"`
 	Action           *Action           `json:"action"`
 	Splits           []Split           `json:"splits"`
+	Canary           *Canary           `json:"canary"`
 	Matches          []Match           `json:"matches"`
 	ErrorPages       []ErrorPage       `json:"errorPages"`
 	LocationSnippets string            `json:"location-snippets"`
@@ -151,6 +156,7 @@ type Action struct {
 	Redirect *ActionRedirect `json:"redirect"`
 	Return   *ActionReturn   `json:"return"`
 	Proxy    *ActionProxy    `json:"proxy"`
+	Fault    *ActionFault    `json:"fault"`
 }
 
 // ActionRedirect defines a redirect in an Action.
@@ -172,6 +178,33 @@ type ActionProxy struct {
 	RewritePath     string                `json:"rewritePath"`
 	RequestHeaders  *ProxyRequestHeaders  `json:"requestHeaders"`
 	ResponseHeaders *ProxyResponseHeaders `json:"responseHeaders"`
+	Mirror          *ProxyMirror          `json:"mirror"`
+}
+
+// ProxyMirror defines the mirroring of requests to an upstream in an ActionProxy.
+type ProxyMirror struct {
+	Upstream    string `json:"upstream"`
+	Percentage  *int   `json:"percentage"`
+	RequestBody *bool  `json:"requestBody"`
+}
+
+// ActionFault defines the injection of a delay or an abort into a percentage of the requests of an Action.
+type ActionFault struct {
+	Delay      string       `json:"delay"`
+	Abort      *FaultAbort  `json:"abort"`
+	Percentage *int         `json:"percentage"`
+	Header     *FaultHeader `json:"header"`
+}
+
+// FaultAbort defines the status code returned to the requests with an injected abort.
+type FaultAbort struct {
+	Code int `json:"code"`
+}
+
+// FaultHeader restricts the injection of faults to the requests with a header value.
+type FaultHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
 }
 
 // ProxyRequestHeaders defines the request headers manipulation in an ActionProxy.
@@ -200,20 +233,39 @@ type Split struct {
 	Action *Action `json:"action"`
 }
 
+// Canary defines a progressive rollout of the second split of a route. The weight of the split goes through
+// the steps and the canary is promoted or rolled back based on the error rate and latency of its upstream.
+type Canary struct {
+	Steps         []int  `json:"steps"`
+	Interval      string `json:"interval"`
+	MaxErrorRate  *int   `json:"maxErrorRate"`
+	MaxLatencyP99 string `json:"maxLatencyP99"`
+	MinRequests   int    `json:"minRequests"`
+}
+
 // Condition defines a condition in a MatchRule.
 type Condition struct {
-	Header   string `json:"header"`
-	Cookie   string `json:"cookie"`
-	Argument string `json:"argument"`
-	Variable string `json:"variable"`
-	Value    string `json:"value"`
+	Header     string   `json:"header"`
+	Cookie     string   `json:"cookie"`
+	Argument   string   `json:"argument"`
+	Variable   string   `json:"variable"`
+	Method     []string `json:"method"`
+	Value      string   `json:"value"`
+	Operator   string   `json:"operator"`
+	IgnoreCase bool     `json:"ignoreCase"`
 }
 
 // Match defines a match.
 type Match struct {
+	Conditions []Condition      `json:"conditions"`
+	AnyOf      []ConditionGroup `json:"anyOf"`
+	Action     *Action          `json:"action"`
+	Splits     []Split          `json:"splits"`
+}
+
+// ConditionGroup defines a group of conditions in a Match. The group is satisfied if any of its conditions is satisfied.
+type ConditionGroup struct {
 	Conditions []Condition `json:"conditions"`
-	Action     *Action     `json:"action"`
-	Splits     []Split     `json:"splits"`
 }
 
 // ErrorPage defines an ErrorPage in a Route.
@@ -253,6 +305,18 @@ type VirtualServerStatus struct {
 	Reason            string             `json:"reason"`
 	Message           string             `json:"message"`
 	ExternalEndpoints []ExternalEndpoint `json:"externalEndpoints,omitempty"`
+	Canaries          []CanaryStatus     `json:"canaries,omitempty"`
+}
+
+// CanaryStatus defines the progress of the canary of a route of a VirtualServer or one of its VirtualServerRoutes.
+type CanaryStatus struct {
+	Path    string `json:"path"`
+	Phase   string `json:"phase"`
+	Weight  int    `json:"weight"`
+	Step    int    `json:"step"`
+	Message string `json:"message"`
+	// Fingerprint identifies the canary and the splits of the route the progress belongs to.
+	Fingerprint string `json:"fingerprint"`
 }
 
 // ExternalEndpoint defines the IP and ports used to connect to this resource.
@@ -353,14 +417,20 @@ type PolicyStatus struct {
 // The spec includes multiple fields, where each field represents a different policy.
 // Only one policy (field) is allowed.
 type PolicySpec struct {
-	IngressClass  string         `json:"ingressClassName"`
-	AccessControl *AccessControl `json:"accessControl"`
-	RateLimit     *RateLimit     `json:"rateLimit"`
-	JWTAuth       *JWTAuth       `json:"jwt"`
-	IngressMTLS   *IngressMTLS   `json:"ingressMTLS"`
-	EgressMTLS    *EgressMTLS    `json:"egressMTLS"`
-	OIDC          *OIDC          `json:"oidc"`
-	WAF           *WAF           `json:"waf"`
+	IngressClass    string           `json:"ingressClassName"`
+	AccessControl   *AccessControl   `json:"accessControl"`
+	RateLimit       *RateLimit       `json:"rateLimit"`
+	ConnectionLimit *ConnectionLimit `json:"connectionLimit"`
+	JWTAuth         *JWTAuth         `json:"jwt"`
+	IngressMTLS     *IngressMTLS     `json:"ingressMTLS"`
+	EgressMTLS      *EgressMTLS      `json:"egressMTLS"`
+	OIDC            *OIDC            `json:"oidc"`
+	WAF             *WAF             `json:"waf"`
+	BasicAuth       *BasicAuth       `json:"basicAuth"`
+	ExternalAuth    *ExternalAuth    `json:"externalAuth"`
+	CORS            *CORS            `json:"cors"`
+	Cache           *Cache           `json:"cache"`
+	Retry           *Retry           `json:"retry"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -394,6 +464,19 @@ type RateLimit struct {
 	RejectCode *int   `json:"rejectCode"`
 }
 
+// ConnectionLimit defines a connection limit policy.
+// The policy is applied with limit_conn of the http module for VirtualServers
+// and of the stream module for TransportServers.
+// policy status: preview
+type ConnectionLimit struct {
+	Key            string `json:"key"`
+	MaxConnections int    `json:"maxConnections"`
+	ZoneSize       string `json:"zoneSize"`
+	DryRun         *bool  `json:"dryRun"`
+	LogLevel       string `json:"logLevel"`
+	RejectCode     *int   `json:"rejectCode"`
+}
+
 // JWTAuth holds JWT authentication configuration.
 // policy status: preview
 type JWTAuth struct {
@@ -402,6 +485,74 @@ type JWTAuth struct {
 	Token  string `json:"token"`
 }
 
+// BasicAuth holds HTTP Basic authentication configuration.
+// policy status: preview
+type BasicAuth struct {
+	Realm  string `json:"realm"`
+	Secret string `json:"secret"`
+}
+
+// ExternalAuth defines an external authorization policy.
+// Before proxying a request, NGINX sends a subrequest to the authorization service.
+// policy status: preview
+type ExternalAuth struct {
+	AuthURL         string   `json:"authURL"`
+	AuthServiceName string   `json:"authServiceName"`
+	AuthServicePort int      `json:"authServicePort"`
+	AuthURI         string   `json:"authURI"`
+	ForwardHeaders  []string `json:"forwardHeaders"`
+	ResponseHeaders []string `json:"responseHeaders"`
+	SigninURL       string   `json:"signinURL"`
+	CacheTTL        string   `json:"cacheTTL"`
+}
+
+// CORS defines a Cross-Origin Resource Sharing policy.
+// policy status: preview
+type CORS struct {
+	AllowOrigins     []string `json:"allowOrigins"`
+	AllowMethods     []string `json:"allowMethods"`
+	AllowHeaders     []string `json:"allowHeaders"`
+	ExposeHeaders    []string `json:"exposeHeaders"`
+	AllowCredentials bool     `json:"allowCredentials"`
+	MaxAge           *int     `json:"maxAge"`
+}
+
+// Cache defines a response caching policy.
+// policy status: preview
+type Cache struct {
+	ZoneSize             string       `json:"zoneSize"`
+	MaxSize              string       `json:"maxSize"`
+	Valid                []CacheValid `json:"valid"`
+	Key                  string       `json:"key"`
+	Methods              []string     `json:"methods"`
+	Bypass               []string     `json:"bypass"`
+	Lock                 bool         `json:"lock"`
+	StaleWhileRevalidate bool         `json:"staleWhileRevalidate"`
+}
+
+// CacheValid defines the caching time for responses with the status codes.
+type CacheValid struct {
+	Codes []int  `json:"codes"`
+	Time  string `json:"time"`
+}
+
+// Retry defines a retry policy.
+// policy status: preview
+type Retry struct {
+	Conditions    []string     `json:"conditions"`
+	Methods       []string     `json:"methods"`
+	Attempts      *int         `json:"attempts"`
+	PerTryTimeout string       `json:"perTryTimeout"`
+	Timeout       string       `json:"timeout"`
+	Sample        *RetrySample `json:"sample"`
+}
+
+// RetrySample limits the retries to a random sample of the requests.
+// Unlike a retry budget, it doesn't depend on the share of the retries in the traffic.
+type RetrySample struct {
+	Percentage int `json:"percentage"`
+}
+
 // IngressMTLS defines an Ingress MTLS policy.
 // policy status: preview
 type IngressMTLS struct {
@@ -449,3 +600,183 @@ type SecurityLog struct {
 	ApLogConf string `json:"apLogConf"`
 	LogDest   string `json:"logDest"`
 }
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:validation:Optional
+// +kubebuilder:resource:shortName=gc
+// +kubebuilder:storageversion
+
+// GlobalConfiguration defines the GlobalConfiguration resource.
+type GlobalConfiguration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec GlobalConfigurationSpec `json:"spec"`
+}
+
+// GlobalConfigurationSpec is the spec of the GlobalConfiguration resource.
+type GlobalConfigurationSpec struct {
+	Listeners []Listener `json:"listeners"`
+}
+
+// Listener defines a listener.
+type Listener struct {
+	Name     string `json:"name"`
+	Port     int    `json:"port"`
+	Protocol string `json:"protocol"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// GlobalConfigurationList is a list of the GlobalConfiguration resources.
+type GlobalConfigurationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []GlobalConfiguration `json:"items"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:validation:Optional
+// +kubebuilder:resource:shortName=ts
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`,description="Current state of the TransportServer. If the resource has a valid status, it means it has been validated and accepted by the Ingress Controller."
+// +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.reason`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// TransportServer defines the TransportServer resource.
+type TransportServer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TransportServerSpec   `json:"spec"`
+	Status TransportServerStatus `json:"status"`
+}
+
+// TransportServerSpec is the spec of the TransportServer resource.
+type TransportServerSpec struct {
+	IngressClass       string                    `json:"ingressClassName"`
+	Listener           TransportServerListener   `json:"listener"`
+	ServerSnippets     string                    `json:"serverSnippets"`
+	StreamSnippets     string                    `json:"streamSnippets"`
+	Host               string                    `json:"host"`
+	Upstreams          []TransportServerUpstream `json:"upstreams"`
+	UpstreamParameters *UpstreamParameters       `json:"upstreamParameters"`
+	SessionParameters  *SessionParameters        `json:"sessionParameters"`
+	Action             *TransportServerAction    `json:"action"`
+	Policies           []PolicyReference         `json:"policies"`
+	TLS                *TransportServerTLS       `json:"tls"`
+}
+
+// TransportServerTLS defines TLS termination for a TransportServer.
+type TransportServerTLS struct {
+	Secret    string `json:"secret"`
+	Protocols string `json:"protocols"`
+	Ciphers   string `json:"ciphers"`
+}
+
+// TransportServerListener defines a listener for a TransportServer.
+type TransportServerListener struct {
+	Name     string `json:"name"`
+	Protocol string `json:"protocol"`
+}
+
+// TransportServerUpstream defines an upstream of a TransportServer.
+type TransportServerUpstream struct {
+	Name                string                      `json:"name"`
+	Service             string                      `json:"service"`
+	Port                int                         `json:"port"`
+	FailTimeout         string                      `json:"failTimeout"`
+	MaxFails            *int                        `json:"maxFails"`
+	MaxConns            *int                        `json:"maxConns"`
+	HealthCheck         *TransportServerHealthCheck `json:"healthCheck"`
+	LoadBalancingMethod string                      `json:"loadBalancingMethod"`
+	TLS                 *TransportServerUpstreamTLS `json:"tls"`
+}
+
+// TransportServerUpstreamTLS defines a TLS configuration for connections to a TransportServerUpstream.
+type TransportServerUpstreamTLS struct {
+	Enable            bool   `json:"enable"`
+	VerifyServer      bool   `json:"verifyServer"`
+	TrustedCertSecret string `json:"trustedCertSecret"`
+	VerifyDepth       *int   `json:"verifyDepth"`
+	Protocols         string `json:"protocols"`
+	Ciphers           string `json:"ciphers"`
+	ServerName        bool   `json:"serverName"`
+	SSLName           string `json:"sslName"`
+}
+
+// TransportServerHealthCheck defines the parameters for active health checks of a TransportServerUpstream.
+type TransportServerHealthCheck struct {
+	Enabled  bool                  `json:"enable"`
+	Timeout  string                `json:"timeout"`
+	Jitter   string                `json:"jitter"`
+	Port     int                   `json:"port"`
+	Interval string                `json:"interval"`
+	Passes   int                   `json:"passes"`
+	Fails    int                   `json:"fails"`
+	Match    *TransportServerMatch `json:"match"`
+}
+
+// TransportServerMatch defines the parameters of a custom health check.
+type TransportServerMatch struct {
+	Send   string `json:"send"`
+	Expect string `json:"expect"`
+}
+
+// UpstreamParameters defines parameters for the upstreams of a TransportServer.
+type UpstreamParameters struct {
+	UDPRequests  *int `json:"udpRequests"`
+	UDPResponses *int `json:"udpResponses"`
+
+	ConnectTimeout      string `json:"connectTimeout"`
+	NextUpstream        bool   `json:"nextUpstream"`
+	NextUpstreamTimeout string `json:"nextUpstreamTimeout"`
+	NextUpstreamTries   int    `json:"nextUpstreamTries"`
+
+	ProxyProtocol bool `json:"proxyProtocol"`
+}
+
+// SessionParameters defines session parameters.
+type SessionParameters struct {
+	Timeout string `json:"timeout"`
+}
+
+// TransportServerAction defines an action of a TransportServer.
+type TransportServerAction struct {
+	Pass    string                 `json:"pass"`
+	Splits  []TransportServerSplit `json:"splits"`
+	Matches []SNIMatch             `json:"matches"`
+}
+
+// TransportServerSplit defines a weight of the connections passed to an upstream.
+type TransportServerSplit struct {
+	Weight int    `json:"weight"`
+	Pass   string `json:"pass"`
+}
+
+// SNIMatch defines an upstream for the TLS connections with the SNI server name.
+type SNIMatch struct {
+	SNI  string `json:"sni"`
+	Pass string `json:"pass"`
+}
+
+// TransportServerStatus defines the status for the TransportServer resource.
+type TransportServerStatus struct {
+	State   string `json:"state"`
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TransportServerList is a list of the TransportServer resources.
+type TransportServerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []TransportServer `json:"items"`
+}
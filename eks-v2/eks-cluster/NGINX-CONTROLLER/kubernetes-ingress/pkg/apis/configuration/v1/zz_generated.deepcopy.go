@@ -53,6 +53,11 @@ func (in *Action) DeepCopyInto(out *Action) {
 		*out = new(ActionProxy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Fault != nil {
+		in, out := &in.Fault, &out.Fault
+		*out = new(ActionFault)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -66,6 +71,37 @@ func (in *Action) DeepCopy() *Action {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActionFault) DeepCopyInto(out *ActionFault) {
+	*out = *in
+	if in.Abort != nil {
+		in, out := &in.Abort, &out.Abort
+		*out = new(FaultAbort)
+		**out = **in
+	}
+	if in.Percentage != nil {
+		in, out := &in.Percentage, &out.Percentage
+		*out = new(int)
+		**out = **in
+	}
+	if in.Header != nil {
+		in, out := &in.Header, &out.Header
+		*out = new(FaultHeader)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActionFault.
+func (in *ActionFault) DeepCopy() *ActionFault {
+	if in == nil {
+		return nil
+	}
+	out := new(ActionFault)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ActionProxy) DeepCopyInto(out *ActionProxy) {
 	*out = *in
@@ -79,6 +115,11 @@ func (in *ActionProxy) DeepCopyInto(out *ActionProxy) {
 		*out = new(ProxyResponseHeaders)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Mirror != nil {
+		in, out := &in.Mirror, &out.Mirror
+		*out = new(ProxyMirror)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -141,9 +182,167 @@ func (in *AddHeader) DeepCopy() *AddHeader {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BasicAuth) DeepCopyInto(out *BasicAuth) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BasicAuth.
+func (in *BasicAuth) DeepCopy() *BasicAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(BasicAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CORS) DeepCopyInto(out *CORS) {
+	*out = *in
+	if in.AllowOrigins != nil {
+		in, out := &in.AllowOrigins, &out.AllowOrigins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowMethods != nil {
+		in, out := &in.AllowMethods, &out.AllowMethods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowHeaders != nil {
+		in, out := &in.AllowHeaders, &out.AllowHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExposeHeaders != nil {
+		in, out := &in.ExposeHeaders, &out.ExposeHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CORS.
+func (in *CORS) DeepCopy() *CORS {
+	if in == nil {
+		return nil
+	}
+	out := new(CORS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Cache) DeepCopyInto(out *Cache) {
+	*out = *in
+	if in.Valid != nil {
+		in, out := &in.Valid, &out.Valid
+		*out = make([]CacheValid, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Methods != nil {
+		in, out := &in.Methods, &out.Methods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Bypass != nil {
+		in, out := &in.Bypass, &out.Bypass
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Cache.
+func (in *Cache) DeepCopy() *Cache {
+	if in == nil {
+		return nil
+	}
+	out := new(Cache)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CacheValid) DeepCopyInto(out *CacheValid) {
+	*out = *in
+	if in.Codes != nil {
+		in, out := &in.Codes, &out.Codes
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CacheValid.
+func (in *CacheValid) DeepCopy() *CacheValid {
+	if in == nil {
+		return nil
+	}
+	out := new(CacheValid)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Canary) DeepCopyInto(out *Canary) {
+	*out = *in
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxErrorRate != nil {
+		in, out := &in.MaxErrorRate, &out.MaxErrorRate
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Canary.
+func (in *Canary) DeepCopy() *Canary {
+	if in == nil {
+		return nil
+	}
+	out := new(Canary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryStatus) DeepCopyInto(out *CanaryStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryStatus.
+func (in *CanaryStatus) DeepCopy() *CanaryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Condition) DeepCopyInto(out *Condition) {
 	*out = *in
+	if in.Method != nil {
+		in, out := &in.Method, &out.Method
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -157,6 +356,55 @@ func (in *Condition) DeepCopy() *Condition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConditionGroup) DeepCopyInto(out *ConditionGroup) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConditionGroup.
+func (in *ConditionGroup) DeepCopy() *ConditionGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(ConditionGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectionLimit) DeepCopyInto(out *ConnectionLimit) {
+	*out = *in
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RejectCode != nil {
+		in, out := &in.RejectCode, &out.RejectCode
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionLimit.
+func (in *ConnectionLimit) DeepCopy() *ConnectionLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectionLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EgressMTLS) DeepCopyInto(out *EgressMTLS) {
 	*out = *in
@@ -254,121 +502,114 @@ func (in *ErrorPageReturn) DeepCopy() *ErrorPageReturn {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ExternalEndpoint) DeepCopyInto(out *ExternalEndpoint) {
+func (in *ExternalAuth) DeepCopyInto(out *ExternalAuth) {
 	*out = *in
+	if in.ForwardHeaders != nil {
+		in, out := &in.ForwardHeaders, &out.ForwardHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResponseHeaders != nil {
+		in, out := &in.ResponseHeaders, &out.ResponseHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalEndpoint.
-func (in *ExternalEndpoint) DeepCopy() *ExternalEndpoint {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalAuth.
+func (in *ExternalAuth) DeepCopy() *ExternalAuth {
 	if in == nil {
 		return nil
 	}
-	out := new(ExternalEndpoint)
+	out := new(ExternalAuth)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Header) DeepCopyInto(out *Header) {
+func (in *ExternalEndpoint) DeepCopyInto(out *ExternalEndpoint) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Header.
-func (in *Header) DeepCopy() *Header {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalEndpoint.
+func (in *ExternalEndpoint) DeepCopy() *ExternalEndpoint {
 	if in == nil {
 		return nil
 	}
-	out := new(Header)
+	out := new(ExternalEndpoint)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HealthCheck) DeepCopyInto(out *HealthCheck) {
+func (in *FaultAbort) DeepCopyInto(out *FaultAbort) {
 	*out = *in
-	if in.TLS != nil {
-		in, out := &in.TLS, &out.TLS
-		*out = new(UpstreamTLS)
-		**out = **in
-	}
-	if in.Headers != nil {
-		in, out := &in.Headers, &out.Headers
-		*out = make([]Header, len(*in))
-		copy(*out, *in)
-	}
-	if in.GRPCStatus != nil {
-		in, out := &in.GRPCStatus, &out.GRPCStatus
-		*out = new(int)
-		**out = **in
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheck.
-func (in *HealthCheck) DeepCopy() *HealthCheck {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FaultAbort.
+func (in *FaultAbort) DeepCopy() *FaultAbort {
 	if in == nil {
 		return nil
 	}
-	out := new(HealthCheck)
+	out := new(FaultAbort)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IngressMTLS) DeepCopyInto(out *IngressMTLS) {
+func (in *FaultHeader) DeepCopyInto(out *FaultHeader) {
 	*out = *in
-	if in.VerifyDepth != nil {
-		in, out := &in.VerifyDepth, &out.VerifyDepth
-		*out = new(int)
-		**out = **in
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressMTLS.
-func (in *IngressMTLS) DeepCopy() *IngressMTLS {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FaultHeader.
+func (in *FaultHeader) DeepCopy() *FaultHeader {
 	if in == nil {
 		return nil
 	}
-	out := new(IngressMTLS)
+	out := new(FaultHeader)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JWTAuth) DeepCopyInto(out *JWTAuth) {
+func (in *GlobalConfiguration) DeepCopyInto(out *GlobalConfiguration) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTAuth.
-func (in *JWTAuth) DeepCopy() *JWTAuth {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalConfiguration.
+func (in *GlobalConfiguration) DeepCopy() *GlobalConfiguration {
 	if in == nil {
 		return nil
 	}
-	out := new(JWTAuth)
+	out := new(GlobalConfiguration)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GlobalConfiguration) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Match) DeepCopyInto(out *Match) {
+func (in *GlobalConfigurationList) DeepCopyInto(out *GlobalConfigurationList) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]Condition, len(*in))
-		copy(*out, *in)
-	}
-	if in.Action != nil {
-		in, out := &in.Action, &out.Action
-		*out = new(Action)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Splits != nil {
-		in, out := &in.Splits, &out.Splits
-		*out = make([]Split, len(*in))
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GlobalConfiguration, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -376,7 +617,178 @@ func (in *Match) DeepCopyInto(out *Match) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Match.
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalConfigurationList.
+func (in *GlobalConfigurationList) DeepCopy() *GlobalConfigurationList {
+	if in == nil {
+		return nil
+	}
+	out := new(GlobalConfigurationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GlobalConfigurationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GlobalConfigurationSpec) DeepCopyInto(out *GlobalConfigurationSpec) {
+	*out = *in
+	if in.Listeners != nil {
+		in, out := &in.Listeners, &out.Listeners
+		*out = make([]Listener, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalConfigurationSpec.
+func (in *GlobalConfigurationSpec) DeepCopy() *GlobalConfigurationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GlobalConfigurationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Header) DeepCopyInto(out *Header) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Header.
+func (in *Header) DeepCopy() *Header {
+	if in == nil {
+		return nil
+	}
+	out := new(Header)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthCheck) DeepCopyInto(out *HealthCheck) {
+	*out = *in
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(UpstreamTLS)
+		**out = **in
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]Header, len(*in))
+		copy(*out, *in)
+	}
+	if in.GRPCStatus != nil {
+		in, out := &in.GRPCStatus, &out.GRPCStatus
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheck.
+func (in *HealthCheck) DeepCopy() *HealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressMTLS) DeepCopyInto(out *IngressMTLS) {
+	*out = *in
+	if in.VerifyDepth != nil {
+		in, out := &in.VerifyDepth, &out.VerifyDepth
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressMTLS.
+func (in *IngressMTLS) DeepCopy() *IngressMTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressMTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTAuth) DeepCopyInto(out *JWTAuth) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTAuth.
+func (in *JWTAuth) DeepCopy() *JWTAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Listener) DeepCopyInto(out *Listener) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Listener.
+func (in *Listener) DeepCopy() *Listener {
+	if in == nil {
+		return nil
+	}
+	out := new(Listener)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Match) DeepCopyInto(out *Match) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AnyOf != nil {
+		in, out := &in.AnyOf, &out.AnyOf
+		*out = make([]ConditionGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Action != nil {
+		in, out := &in.Action, &out.Action
+		*out = new(Action)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Splits != nil {
+		in, out := &in.Splits, &out.Splits
+		*out = make([]Split, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Match.
 func (in *Match) DeepCopy() *Match {
 	if in == nil {
 		return nil
@@ -445,18 +857,519 @@ func (in *PolicyList) DeepCopyInto(out *PolicyList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyList.
-func (in *PolicyList) DeepCopy() *PolicyList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyList.
+func (in *PolicyList) DeepCopy() *PolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyReference) DeepCopyInto(out *PolicyReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyReference.
+func (in *PolicyReference) DeepCopy() *PolicyReference {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicySpec) DeepCopyInto(out *PolicySpec) {
+	*out = *in
+	if in.AccessControl != nil {
+		in, out := &in.AccessControl, &out.AccessControl
+		*out = new(AccessControl)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(RateLimit)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConnectionLimit != nil {
+		in, out := &in.ConnectionLimit, &out.ConnectionLimit
+		*out = new(ConnectionLimit)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JWTAuth != nil {
+		in, out := &in.JWTAuth, &out.JWTAuth
+		*out = new(JWTAuth)
+		**out = **in
+	}
+	if in.IngressMTLS != nil {
+		in, out := &in.IngressMTLS, &out.IngressMTLS
+		*out = new(IngressMTLS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EgressMTLS != nil {
+		in, out := &in.EgressMTLS, &out.EgressMTLS
+		*out = new(EgressMTLS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OIDC != nil {
+		in, out := &in.OIDC, &out.OIDC
+		*out = new(OIDC)
+		**out = **in
+	}
+	if in.WAF != nil {
+		in, out := &in.WAF, &out.WAF
+		*out = new(WAF)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BasicAuth != nil {
+		in, out := &in.BasicAuth, &out.BasicAuth
+		*out = new(BasicAuth)
+		**out = **in
+	}
+	if in.ExternalAuth != nil {
+		in, out := &in.ExternalAuth, &out.ExternalAuth
+		*out = new(ExternalAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CORS != nil {
+		in, out := &in.CORS, &out.CORS
+		*out = new(CORS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cache != nil {
+		in, out := &in.Cache, &out.Cache
+		*out = new(Cache)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(Retry)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicySpec.
+func (in *PolicySpec) DeepCopy() *PolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyStatus) DeepCopyInto(out *PolicyStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyStatus.
+func (in *PolicyStatus) DeepCopy() *PolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyMirror) DeepCopyInto(out *ProxyMirror) {
+	*out = *in
+	if in.Percentage != nil {
+		in, out := &in.Percentage, &out.Percentage
+		*out = new(int)
+		**out = **in
+	}
+	if in.RequestBody != nil {
+		in, out := &in.RequestBody, &out.RequestBody
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyMirror.
+func (in *ProxyMirror) DeepCopy() *ProxyMirror {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyMirror)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyRequestHeaders) DeepCopyInto(out *ProxyRequestHeaders) {
+	*out = *in
+	if in.Pass != nil {
+		in, out := &in.Pass, &out.Pass
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Set != nil {
+		in, out := &in.Set, &out.Set
+		*out = make([]Header, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyRequestHeaders.
+func (in *ProxyRequestHeaders) DeepCopy() *ProxyRequestHeaders {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyRequestHeaders)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyResponseHeaders) DeepCopyInto(out *ProxyResponseHeaders) {
+	*out = *in
+	if in.Hide != nil {
+		in, out := &in.Hide, &out.Hide
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Pass != nil {
+		in, out := &in.Pass, &out.Pass
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Ignore != nil {
+		in, out := &in.Ignore, &out.Ignore
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Add != nil {
+		in, out := &in.Add, &out.Add
+		*out = make([]AddHeader, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyResponseHeaders.
+func (in *ProxyResponseHeaders) DeepCopy() *ProxyResponseHeaders {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyResponseHeaders)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimit) DeepCopyInto(out *RateLimit) {
+	*out = *in
+	if in.Delay != nil {
+		in, out := &in.Delay, &out.Delay
+		*out = new(int)
+		**out = **in
+	}
+	if in.NoDelay != nil {
+		in, out := &in.NoDelay, &out.NoDelay
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Burst != nil {
+		in, out := &in.Burst, &out.Burst
+		*out = new(int)
+		**out = **in
+	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RejectCode != nil {
+		in, out := &in.RejectCode, &out.RejectCode
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimit.
+func (in *RateLimit) DeepCopy() *RateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Retry) DeepCopyInto(out *Retry) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Methods != nil {
+		in, out := &in.Methods, &out.Methods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Attempts != nil {
+		in, out := &in.Attempts, &out.Attempts
+		*out = new(int)
+		**out = **in
+	}
+	if in.Sample != nil {
+		in, out := &in.Sample, &out.Sample
+		*out = new(RetrySample)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Retry.
+func (in *Retry) DeepCopy() *Retry {
+	if in == nil {
+		return nil
+	}
+	out := new(Retry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetrySample) DeepCopyInto(out *RetrySample) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetrySample.
+func (in *RetrySample) DeepCopy() *RetrySample {
+	if in == nil {
+		return nil
+	}
+	out := new(RetrySample)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Route) DeepCopyInto(out *Route) {
+	*out = *in
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]PolicyReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Action != nil {
+		in, out := &in.Action, &out.Action
+		*out = new(Action)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Splits != nil {
+		in, out := &in.Splits, &out.Splits
+		*out = make([]Split, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(Canary)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Matches != nil {
+		in, out := &in.Matches, &out.Matches
+		*out = make([]Match, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ErrorPages != nil {
+		in, out := &in.ErrorPages, &out.ErrorPages
+		*out = make([]ErrorPage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Route.
+func (in *Route) DeepCopy() *Route {
+	if in == nil {
+		return nil
+	}
+	out := new(Route)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SNIMatch) DeepCopyInto(out *SNIMatch) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SNIMatch.
+func (in *SNIMatch) DeepCopy() *SNIMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(SNIMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityLog) DeepCopyInto(out *SecurityLog) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityLog.
+func (in *SecurityLog) DeepCopy() *SecurityLog {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityLog)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SessionCookie) DeepCopyInto(out *SessionCookie) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SessionCookie.
+func (in *SessionCookie) DeepCopy() *SessionCookie {
+	if in == nil {
+		return nil
+	}
+	out := new(SessionCookie)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SessionParameters) DeepCopyInto(out *SessionParameters) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SessionParameters.
+func (in *SessionParameters) DeepCopy() *SessionParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(SessionParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Split) DeepCopyInto(out *Split) {
+	*out = *in
+	if in.Action != nil {
+		in, out := &in.Action, &out.Action
+		*out = new(Action)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Split.
+func (in *Split) DeepCopy() *Split {
+	if in == nil {
+		return nil
+	}
+	out := new(Split)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLS) DeepCopyInto(out *TLS) {
+	*out = *in
+	if in.Redirect != nil {
+		in, out := &in.Redirect, &out.Redirect
+		*out = new(TLSRedirect)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLS.
+func (in *TLS) DeepCopy() *TLS {
+	if in == nil {
+		return nil
+	}
+	out := new(TLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSRedirect) DeepCopyInto(out *TLSRedirect) {
+	*out = *in
+	if in.Code != nil {
+		in, out := &in.Code, &out.Code
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSRedirect.
+func (in *TLSRedirect) DeepCopy() *TLSRedirect {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSRedirect)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransportServer) DeepCopyInto(out *TransportServer) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransportServer.
+func (in *TransportServer) DeepCopy() *TransportServer {
 	if in == nil {
 		return nil
 	}
-	out := new(PolicyList)
+	out := new(TransportServer)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PolicyList) DeepCopyObject() runtime.Object {
+func (in *TransportServer) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -464,329 +1377,267 @@ func (in *PolicyList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PolicyReference) DeepCopyInto(out *PolicyReference) {
+func (in *TransportServerAction) DeepCopyInto(out *TransportServerAction) {
 	*out = *in
+	if in.Splits != nil {
+		in, out := &in.Splits, &out.Splits
+		*out = make([]TransportServerSplit, len(*in))
+		copy(*out, *in)
+	}
+	if in.Matches != nil {
+		in, out := &in.Matches, &out.Matches
+		*out = make([]SNIMatch, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyReference.
-func (in *PolicyReference) DeepCopy() *PolicyReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransportServerAction.
+func (in *TransportServerAction) DeepCopy() *TransportServerAction {
 	if in == nil {
 		return nil
 	}
-	out := new(PolicyReference)
+	out := new(TransportServerAction)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PolicySpec) DeepCopyInto(out *PolicySpec) {
+func (in *TransportServerHealthCheck) DeepCopyInto(out *TransportServerHealthCheck) {
 	*out = *in
-	if in.AccessControl != nil {
-		in, out := &in.AccessControl, &out.AccessControl
-		*out = new(AccessControl)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.RateLimit != nil {
-		in, out := &in.RateLimit, &out.RateLimit
-		*out = new(RateLimit)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.JWTAuth != nil {
-		in, out := &in.JWTAuth, &out.JWTAuth
-		*out = new(JWTAuth)
-		**out = **in
-	}
-	if in.IngressMTLS != nil {
-		in, out := &in.IngressMTLS, &out.IngressMTLS
-		*out = new(IngressMTLS)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.EgressMTLS != nil {
-		in, out := &in.EgressMTLS, &out.EgressMTLS
-		*out = new(EgressMTLS)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.OIDC != nil {
-		in, out := &in.OIDC, &out.OIDC
-		*out = new(OIDC)
+	if in.Match != nil {
+		in, out := &in.Match, &out.Match
+		*out = new(TransportServerMatch)
 		**out = **in
 	}
-	if in.WAF != nil {
-		in, out := &in.WAF, &out.WAF
-		*out = new(WAF)
-		(*in).DeepCopyInto(*out)
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicySpec.
-func (in *PolicySpec) DeepCopy() *PolicySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransportServerHealthCheck.
+func (in *TransportServerHealthCheck) DeepCopy() *TransportServerHealthCheck {
 	if in == nil {
 		return nil
 	}
-	out := new(PolicySpec)
+	out := new(TransportServerHealthCheck)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PolicyStatus) DeepCopyInto(out *PolicyStatus) {
+func (in *TransportServerList) DeepCopyInto(out *TransportServerList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TransportServer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyStatus.
-func (in *PolicyStatus) DeepCopy() *PolicyStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransportServerList.
+func (in *TransportServerList) DeepCopy() *TransportServerList {
 	if in == nil {
 		return nil
 	}
-	out := new(PolicyStatus)
+	out := new(TransportServerList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TransportServerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProxyRequestHeaders) DeepCopyInto(out *ProxyRequestHeaders) {
+func (in *TransportServerListener) DeepCopyInto(out *TransportServerListener) {
 	*out = *in
-	if in.Pass != nil {
-		in, out := &in.Pass, &out.Pass
-		*out = new(bool)
-		**out = **in
-	}
-	if in.Set != nil {
-		in, out := &in.Set, &out.Set
-		*out = make([]Header, len(*in))
-		copy(*out, *in)
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyRequestHeaders.
-func (in *ProxyRequestHeaders) DeepCopy() *ProxyRequestHeaders {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransportServerListener.
+func (in *TransportServerListener) DeepCopy() *TransportServerListener {
 	if in == nil {
 		return nil
 	}
-	out := new(ProxyRequestHeaders)
+	out := new(TransportServerListener)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProxyResponseHeaders) DeepCopyInto(out *ProxyResponseHeaders) {
+func (in *TransportServerMatch) DeepCopyInto(out *TransportServerMatch) {
 	*out = *in
-	if in.Hide != nil {
-		in, out := &in.Hide, &out.Hide
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Pass != nil {
-		in, out := &in.Pass, &out.Pass
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Ignore != nil {
-		in, out := &in.Ignore, &out.Ignore
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Add != nil {
-		in, out := &in.Add, &out.Add
-		*out = make([]AddHeader, len(*in))
-		copy(*out, *in)
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyResponseHeaders.
-func (in *ProxyResponseHeaders) DeepCopy() *ProxyResponseHeaders {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransportServerMatch.
+func (in *TransportServerMatch) DeepCopy() *TransportServerMatch {
 	if in == nil {
 		return nil
 	}
-	out := new(ProxyResponseHeaders)
+	out := new(TransportServerMatch)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RateLimit) DeepCopyInto(out *RateLimit) {
+func (in *TransportServerSpec) DeepCopyInto(out *TransportServerSpec) {
 	*out = *in
-	if in.Delay != nil {
-		in, out := &in.Delay, &out.Delay
-		*out = new(int)
-		**out = **in
-	}
-	if in.NoDelay != nil {
-		in, out := &in.NoDelay, &out.NoDelay
-		*out = new(bool)
-		**out = **in
-	}
-	if in.Burst != nil {
-		in, out := &in.Burst, &out.Burst
-		*out = new(int)
-		**out = **in
+	out.Listener = in.Listener
+	if in.Upstreams != nil {
+		in, out := &in.Upstreams, &out.Upstreams
+		*out = make([]TransportServerUpstream, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.DryRun != nil {
-		in, out := &in.DryRun, &out.DryRun
-		*out = new(bool)
-		**out = **in
+	if in.UpstreamParameters != nil {
+		in, out := &in.UpstreamParameters, &out.UpstreamParameters
+		*out = new(UpstreamParameters)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.RejectCode != nil {
-		in, out := &in.RejectCode, &out.RejectCode
-		*out = new(int)
+	if in.SessionParameters != nil {
+		in, out := &in.SessionParameters, &out.SessionParameters
+		*out = new(SessionParameters)
 		**out = **in
 	}
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimit.
-func (in *RateLimit) DeepCopy() *RateLimit {
-	if in == nil {
-		return nil
+	if in.Action != nil {
+		in, out := &in.Action, &out.Action
+		*out = new(TransportServerAction)
+		(*in).DeepCopyInto(*out)
 	}
-	out := new(RateLimit)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Route) DeepCopyInto(out *Route) {
-	*out = *in
 	if in.Policies != nil {
 		in, out := &in.Policies, &out.Policies
 		*out = make([]PolicyReference, len(*in))
 		copy(*out, *in)
 	}
-	if in.Action != nil {
-		in, out := &in.Action, &out.Action
-		*out = new(Action)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Splits != nil {
-		in, out := &in.Splits, &out.Splits
-		*out = make([]Split, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Matches != nil {
-		in, out := &in.Matches, &out.Matches
-		*out = make([]Match, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.ErrorPages != nil {
-		in, out := &in.ErrorPages, &out.ErrorPages
-		*out = make([]ErrorPage, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(TransportServerTLS)
+		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Route.
-func (in *Route) DeepCopy() *Route {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransportServerSpec.
+func (in *TransportServerSpec) DeepCopy() *TransportServerSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(Route)
+	out := new(TransportServerSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecurityLog) DeepCopyInto(out *SecurityLog) {
+func (in *TransportServerSplit) DeepCopyInto(out *TransportServerSplit) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityLog.
-func (in *SecurityLog) DeepCopy() *SecurityLog {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransportServerSplit.
+func (in *TransportServerSplit) DeepCopy() *TransportServerSplit {
 	if in == nil {
 		return nil
 	}
-	out := new(SecurityLog)
+	out := new(TransportServerSplit)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SessionCookie) DeepCopyInto(out *SessionCookie) {
+func (in *TransportServerStatus) DeepCopyInto(out *TransportServerStatus) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SessionCookie.
-func (in *SessionCookie) DeepCopy() *SessionCookie {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransportServerStatus.
+func (in *TransportServerStatus) DeepCopy() *TransportServerStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(SessionCookie)
+	out := new(TransportServerStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Split) DeepCopyInto(out *Split) {
+func (in *TransportServerTLS) DeepCopyInto(out *TransportServerTLS) {
 	*out = *in
-	if in.Action != nil {
-		in, out := &in.Action, &out.Action
-		*out = new(Action)
-		(*in).DeepCopyInto(*out)
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Split.
-func (in *Split) DeepCopy() *Split {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransportServerTLS.
+func (in *TransportServerTLS) DeepCopy() *TransportServerTLS {
 	if in == nil {
 		return nil
 	}
-	out := new(Split)
+	out := new(TransportServerTLS)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TLS) DeepCopyInto(out *TLS) {
+func (in *TransportServerUpstream) DeepCopyInto(out *TransportServerUpstream) {
 	*out = *in
-	if in.Redirect != nil {
-		in, out := &in.Redirect, &out.Redirect
-		*out = new(TLSRedirect)
+	if in.MaxFails != nil {
+		in, out := &in.MaxFails, &out.MaxFails
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxConns != nil {
+		in, out := &in.MaxConns, &out.MaxConns
+		*out = new(int)
+		**out = **in
+	}
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(TransportServerHealthCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(TransportServerUpstreamTLS)
 		(*in).DeepCopyInto(*out)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLS.
-func (in *TLS) DeepCopy() *TLS {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransportServerUpstream.
+func (in *TransportServerUpstream) DeepCopy() *TransportServerUpstream {
 	if in == nil {
 		return nil
 	}
-	out := new(TLS)
+	out := new(TransportServerUpstream)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TLSRedirect) DeepCopyInto(out *TLSRedirect) {
+func (in *TransportServerUpstreamTLS) DeepCopyInto(out *TransportServerUpstreamTLS) {
 	*out = *in
-	if in.Code != nil {
-		in, out := &in.Code, &out.Code
+	if in.VerifyDepth != nil {
+		in, out := &in.VerifyDepth, &out.VerifyDepth
 		*out = new(int)
 		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSRedirect.
-func (in *TLSRedirect) DeepCopy() *TLSRedirect {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransportServerUpstreamTLS.
+func (in *TransportServerUpstreamTLS) DeepCopy() *TransportServerUpstreamTLS {
 	if in == nil {
 		return nil
 	}
-	out := new(TLSRedirect)
+	out := new(TransportServerUpstreamTLS)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -871,6 +1722,32 @@ func (in *UpstreamBuffers) DeepCopy() *UpstreamBuffers {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpstreamParameters) DeepCopyInto(out *UpstreamParameters) {
+	*out = *in
+	if in.UDPRequests != nil {
+		in, out := &in.UDPRequests, &out.UDPRequests
+		*out = new(int)
+		**out = **in
+	}
+	if in.UDPResponses != nil {
+		in, out := &in.UDPResponses, &out.UDPResponses
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpstreamParameters.
+func (in *UpstreamParameters) DeepCopy() *UpstreamParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(UpstreamParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UpstreamQueue) DeepCopyInto(out *UpstreamQueue) {
 	*out = *in
@@ -1124,6 +2001,11 @@ func (in *VirtualServerStatus) DeepCopyInto(out *VirtualServerStatus) {
 		*out = make([]ExternalEndpoint, len(*in))
 		copy(*out, *in)
 	}
+	if in.Canaries != nil {
+		in, out := &in.Canaries, &out.Canaries
+		*out = make([]CanaryStatus, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -0,0 +1,217 @@
+package v1alpha1
+
+import (
+	v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
+)
+
+// The v1 version is the storage version of the TransportServer and GlobalConfiguration resources.
+// The functions below convert the resources between the v1alpha1 and v1 versions without any loss,
+// so that a resource survives a round trip through both versions.
+
+// ConvertTransportServerToV1 converts a v1alpha1 TransportServer to the v1 version.
+func ConvertTransportServerToV1(in *TransportServer) *v1.TransportServer {
+	out := &v1.TransportServer{
+		TypeMeta:   in.TypeMeta,
+		ObjectMeta: *in.ObjectMeta.DeepCopy(),
+		Spec: v1.TransportServerSpec{
+			IngressClass:   in.Spec.IngressClass,
+			Listener:       v1.TransportServerListener(in.Spec.Listener),
+			ServerSnippets: in.Spec.ServerSnippets,
+			StreamSnippets: in.Spec.StreamSnippets,
+			Host:           in.Spec.Host,
+		},
+		Status: v1.TransportServerStatus(in.Status),
+	}
+	out.APIVersion = v1.SchemeGroupVersion.String()
+
+	in = in.DeepCopy()
+
+	if in.Spec.Upstreams != nil {
+		out.Spec.Upstreams = make([]v1.TransportServerUpstream, len(in.Spec.Upstreams))
+	}
+	for i, u := range in.Spec.Upstreams {
+		upstream := v1.TransportServerUpstream{
+			Name:                u.Name,
+			Service:             u.Service,
+			Port:                u.Port,
+			FailTimeout:         u.FailTimeout,
+			MaxFails:            u.MaxFails,
+			MaxConns:            u.MaxConns,
+			LoadBalancingMethod: u.LoadBalancingMethod,
+			TLS:                 (*v1.TransportServerUpstreamTLS)(u.TLS),
+		}
+
+		if u.HealthCheck != nil {
+			upstream.HealthCheck = &v1.TransportServerHealthCheck{
+				Enabled:  u.HealthCheck.Enabled,
+				Timeout:  u.HealthCheck.Timeout,
+				Jitter:   u.HealthCheck.Jitter,
+				Port:     u.HealthCheck.Port,
+				Interval: u.HealthCheck.Interval,
+				Passes:   u.HealthCheck.Passes,
+				Fails:    u.HealthCheck.Fails,
+				Match:    (*v1.TransportServerMatch)(u.HealthCheck.Match),
+			}
+		}
+
+		out.Spec.Upstreams[i] = upstream
+	}
+
+	out.Spec.UpstreamParameters = (*v1.UpstreamParameters)(in.Spec.UpstreamParameters)
+	out.Spec.SessionParameters = (*v1.SessionParameters)(in.Spec.SessionParameters)
+
+	if in.Spec.Action != nil {
+		out.Spec.Action = &v1.TransportServerAction{
+			Pass: in.Spec.Action.Pass,
+		}
+		if in.Spec.Action.Splits != nil {
+			out.Spec.Action.Splits = make([]v1.TransportServerSplit, len(in.Spec.Action.Splits))
+		}
+		for i, s := range in.Spec.Action.Splits {
+			out.Spec.Action.Splits[i] = v1.TransportServerSplit(s)
+		}
+		if in.Spec.Action.Matches != nil {
+			out.Spec.Action.Matches = make([]v1.SNIMatch, len(in.Spec.Action.Matches))
+		}
+		for i, m := range in.Spec.Action.Matches {
+			out.Spec.Action.Matches[i] = v1.SNIMatch(m)
+		}
+	}
+
+	out.Spec.Policies = in.Spec.Policies
+	out.Spec.TLS = (*v1.TransportServerTLS)(in.Spec.TLS)
+
+	return out
+}
+
+// ConvertTransportServerFromV1 converts a v1 TransportServer to the v1alpha1 version.
+func ConvertTransportServerFromV1(in *v1.TransportServer) *TransportServer {
+	out := &TransportServer{
+		TypeMeta:   in.TypeMeta,
+		ObjectMeta: *in.ObjectMeta.DeepCopy(),
+		Spec: TransportServerSpec{
+			IngressClass:   in.Spec.IngressClass,
+			Listener:       TransportServerListener(in.Spec.Listener),
+			ServerSnippets: in.Spec.ServerSnippets,
+			StreamSnippets: in.Spec.StreamSnippets,
+			Host:           in.Spec.Host,
+		},
+		Status: TransportServerStatus(in.Status),
+	}
+	out.APIVersion = SchemeGroupVersion.String()
+
+	in = in.DeepCopy()
+
+	if in.Spec.Upstreams != nil {
+		out.Spec.Upstreams = make([]Upstream, len(in.Spec.Upstreams))
+	}
+	for i, u := range in.Spec.Upstreams {
+		upstream := Upstream{
+			Name:                u.Name,
+			Service:             u.Service,
+			Port:                u.Port,
+			FailTimeout:         u.FailTimeout,
+			MaxFails:            u.MaxFails,
+			MaxConns:            u.MaxConns,
+			LoadBalancingMethod: u.LoadBalancingMethod,
+			TLS:                 (*UpstreamTLS)(u.TLS),
+		}
+
+		if u.HealthCheck != nil {
+			upstream.HealthCheck = &HealthCheck{
+				Enabled:  u.HealthCheck.Enabled,
+				Timeout:  u.HealthCheck.Timeout,
+				Jitter:   u.HealthCheck.Jitter,
+				Port:     u.HealthCheck.Port,
+				Interval: u.HealthCheck.Interval,
+				Passes:   u.HealthCheck.Passes,
+				Fails:    u.HealthCheck.Fails,
+				Match:    (*Match)(u.HealthCheck.Match),
+			}
+		}
+
+		out.Spec.Upstreams[i] = upstream
+	}
+
+	out.Spec.UpstreamParameters = (*UpstreamParameters)(in.Spec.UpstreamParameters)
+	out.Spec.SessionParameters = (*SessionParameters)(in.Spec.SessionParameters)
+
+	if in.Spec.Action != nil {
+		out.Spec.Action = &Action{
+			Pass: in.Spec.Action.Pass,
+		}
+		if in.Spec.Action.Splits != nil {
+			out.Spec.Action.Splits = make([]Split, len(in.Spec.Action.Splits))
+		}
+		for i, s := range in.Spec.Action.Splits {
+			out.Spec.Action.Splits[i] = Split(s)
+		}
+		if in.Spec.Action.Matches != nil {
+			out.Spec.Action.Matches = make([]SNIMatch, len(in.Spec.Action.Matches))
+		}
+		for i, m := range in.Spec.Action.Matches {
+			out.Spec.Action.Matches[i] = SNIMatch(m)
+		}
+	}
+
+	out.Spec.Policies = in.Spec.Policies
+	out.Spec.TLS = (*TransportServerTLS)(in.Spec.TLS)
+
+	return out
+}
+
+// ConvertGlobalConfigurationToV1 converts a v1alpha1 GlobalConfiguration to the v1 version.
+func ConvertGlobalConfigurationToV1(in *GlobalConfiguration) *v1.GlobalConfiguration {
+	out := &v1.GlobalConfiguration{
+		TypeMeta:   in.TypeMeta,
+		ObjectMeta: *in.ObjectMeta.DeepCopy(),
+	}
+	out.APIVersion = v1.SchemeGroupVersion.String()
+
+	if in.Spec.Listeners != nil {
+		out.Spec.Listeners = make([]v1.Listener, len(in.Spec.Listeners))
+	}
+	for i, l := range in.Spec.Listeners {
+		out.Spec.Listeners[i] = v1.Listener(l)
+	}
+
+	return out
+}
+
+// ConvertGlobalConfigurationFromV1 converts a v1 GlobalConfiguration to the v1alpha1 version.
+func ConvertGlobalConfigurationFromV1(in *v1.GlobalConfiguration) *GlobalConfiguration {
+	out := &GlobalConfiguration{
+		TypeMeta:   in.TypeMeta,
+		ObjectMeta: *in.ObjectMeta.DeepCopy(),
+	}
+	out.APIVersion = SchemeGroupVersion.String()
+
+	if in.Spec.Listeners != nil {
+		out.Spec.Listeners = make([]Listener, len(in.Spec.Listeners))
+	}
+	for i, l := range in.Spec.Listeners {
+		out.Spec.Listeners[i] = Listener(l)
+	}
+
+	return out
+}
+
+// ConvertPolicyToV1 converts a v1alpha1 Policy to the v1 version, which is the storage version of the Policy resource.
+// The v1 version is a superset of the v1alpha1 version, so the conversion has no loss.
+func ConvertPolicyToV1(in *Policy) *v1.Policy {
+	out := &v1.Policy{
+		TypeMeta:   in.TypeMeta,
+		ObjectMeta: *in.ObjectMeta.DeepCopy(),
+	}
+	out.APIVersion = v1.SchemeGroupVersion.String()
+
+	in = in.DeepCopy()
+
+	out.Spec.AccessControl = (*v1.AccessControl)(in.Spec.AccessControl)
+	out.Spec.RateLimit = (*v1.RateLimit)(in.Spec.RateLimit)
+	out.Spec.JWTAuth = (*v1.JWTAuth)(in.Spec.JWTAuth)
+	out.Spec.IngressMTLS = (*v1.IngressMTLS)(in.Spec.IngressMTLS)
+	out.Spec.EgressMTLS = (*v1.EgressMTLS)(in.Spec.EgressMTLS)
+
+	return out
+}
@@ -1,6 +1,7 @@
 package v1alpha1
 
 import (
+	v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -75,6 +76,15 @@ type TransportServerSpec struct {
 	UpstreamParameters *UpstreamParameters     `json:"upstreamParameters"`
 	SessionParameters  *SessionParameters      `json:"sessionParameters"`
 	Action             *Action                 `json:"action"`
+	Policies           []v1.PolicyReference    `json:"policies"`
+	TLS                *TransportServerTLS     `json:"tls"`
+}
+
+// TransportServerTLS defines TLS termination for a TransportServer.
+type TransportServerTLS struct {
+	Secret    string `json:"secret"`
+	Protocols string `json:"protocols"`
+	Ciphers   string `json:"ciphers"`
 }
 
 // TransportServerListener defines a listener for a TransportServer.
@@ -93,6 +103,19 @@ type Upstream struct {
 	MaxConns            *int         `json:"maxConns"`
 	HealthCheck         *HealthCheck `json:"healthCheck"`
 	LoadBalancingMethod string       `json:"loadBalancingMethod"`
+	TLS                 *UpstreamTLS `json:"tls"`
+}
+
+// UpstreamTLS defines a TLS configuration for connections to an Upstream.
+type UpstreamTLS struct {
+	Enable            bool   `json:"enable"`
+	VerifyServer      bool   `json:"verifyServer"`
+	TrustedCertSecret string `json:"trustedCertSecret"`
+	VerifyDepth       *int   `json:"verifyDepth"`
+	Protocols         string `json:"protocols"`
+	Ciphers           string `json:"ciphers"`
+	ServerName        bool   `json:"serverName"`
+	SSLName           string `json:"sslName"`
 }
 
 // HealthCheck defines the parameters for active Upstream HealthChecks.
@@ -122,6 +145,8 @@ type UpstreamParameters struct {
 	NextUpstream        bool   `json:"nextUpstream"`
 	NextUpstreamTimeout string `json:"nextUpstreamTimeout"`
 	NextUpstreamTries   int    `json:"nextUpstreamTries"`
+
+	ProxyProtocol bool `json:"proxyProtocol"`
 }
 
 // SessionParameters defines session parameters.
@@ -131,6 +156,20 @@ type SessionParameters struct {
 
 // Action defines an action.
 type Action struct {
+	Pass    string     `json:"pass"`
+	Splits  []Split    `json:"splits"`
+	Matches []SNIMatch `json:"matches"`
+}
+
+// Split defines a weight of the connections passed to an upstream.
+type Split struct {
+	Weight int    `json:"weight"`
+	Pass   string `json:"pass"`
+}
+
+// SNIMatch defines an upstream for the TLS connections with the SNI server name.
+type SNIMatch struct {
+	SNI  string `json:"sni"`
 	Pass string `json:"pass"`
 }
 
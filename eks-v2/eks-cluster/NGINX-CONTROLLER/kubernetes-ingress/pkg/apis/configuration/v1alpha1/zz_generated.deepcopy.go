@@ -6,6 +6,7 @@
 package v1alpha1
 
 import (
+	v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -38,6 +39,16 @@ func (in *AccessControl) DeepCopy() *AccessControl {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Action) DeepCopyInto(out *Action) {
 	*out = *in
+	if in.Splits != nil {
+		in, out := &in.Splits, &out.Splits
+		*out = make([]Split, len(*in))
+		copy(*out, *in)
+	}
+	if in.Matches != nil {
+		in, out := &in.Matches, &out.Matches
+		*out = make([]SNIMatch, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -390,6 +401,22 @@ func (in *RateLimit) DeepCopy() *RateLimit {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SNIMatch) DeepCopyInto(out *SNIMatch) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SNIMatch.
+func (in *SNIMatch) DeepCopy() *SNIMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(SNIMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SessionParameters) DeepCopyInto(out *SessionParameters) {
 	*out = *in
@@ -406,6 +433,22 @@ func (in *SessionParameters) DeepCopy() *SessionParameters {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Split) DeepCopyInto(out *Split) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Split.
+func (in *Split) DeepCopy() *Split {
+	if in == nil {
+		return nil
+	}
+	out := new(Split)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TransportServer) DeepCopyInto(out *TransportServer) {
 	*out = *in
@@ -507,6 +550,16 @@ func (in *TransportServerSpec) DeepCopyInto(out *TransportServerSpec) {
 	if in.Action != nil {
 		in, out := &in.Action, &out.Action
 		*out = new(Action)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]v1.PolicyReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(TransportServerTLS)
 		**out = **in
 	}
 	return
@@ -538,6 +591,22 @@ func (in *TransportServerStatus) DeepCopy() *TransportServerStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransportServerTLS) DeepCopyInto(out *TransportServerTLS) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransportServerTLS.
+func (in *TransportServerTLS) DeepCopy() *TransportServerTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(TransportServerTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Upstream) DeepCopyInto(out *Upstream) {
 	*out = *in
@@ -556,6 +625,11 @@ func (in *Upstream) DeepCopyInto(out *Upstream) {
 		*out = new(HealthCheck)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(UpstreamTLS)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -594,3 +668,24 @@ func (in *UpstreamParameters) DeepCopy() *UpstreamParameters {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpstreamTLS) DeepCopyInto(out *UpstreamTLS) {
+	*out = *in
+	if in.VerifyDepth != nil {
+		in, out := &in.VerifyDepth, &out.VerifyDepth
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpstreamTLS.
+func (in *UpstreamTLS) DeepCopy() *UpstreamTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(UpstreamTLS)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -2,8 +2,8 @@ package validation
 
 import (
 	"fmt"
+	v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
 
-	"github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -22,16 +22,16 @@ func NewGlobalConfigurationValidator(forbiddenListenerPorts map[int]bool) *Globa
 }
 
 // ValidateGlobalConfiguration validates a GlobalConfiguration.
-func (gcv *GlobalConfigurationValidator) ValidateGlobalConfiguration(globalConfiguration *v1alpha1.GlobalConfiguration) error {
+func (gcv *GlobalConfigurationValidator) ValidateGlobalConfiguration(globalConfiguration *v1.GlobalConfiguration) error {
 	allErrs := gcv.validateGlobalConfigurationSpec(&globalConfiguration.Spec, field.NewPath("spec"))
 	return allErrs.ToAggregate()
 }
 
-func (gcv *GlobalConfigurationValidator) validateGlobalConfigurationSpec(spec *v1alpha1.GlobalConfigurationSpec, fieldPath *field.Path) field.ErrorList {
+func (gcv *GlobalConfigurationValidator) validateGlobalConfigurationSpec(spec *v1.GlobalConfigurationSpec, fieldPath *field.Path) field.ErrorList {
 	return gcv.validateListeners(spec.Listeners, fieldPath.Child("listeners"))
 }
 
-func (gcv *GlobalConfigurationValidator) validateListeners(listeners []v1alpha1.Listener, fieldPath *field.Path) field.ErrorList {
+func (gcv *GlobalConfigurationValidator) validateListeners(listeners []v1.Listener, fieldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	listenerNames := sets.String{}
@@ -59,29 +59,49 @@ func (gcv *GlobalConfigurationValidator) validateListeners(listeners []v1alpha1.
 }
 
 func generatePortProtocolKey(port int, protocol string) string {
+	// TLS Passthrough listeners accept TCP connections, so they can't share a port with TCP listeners.
+	if protocol == v1.TLSPassthroughListenerProtocol {
+		protocol = "TCP"
+	}
+
 	return fmt.Sprintf("%d/%s", port, protocol)
 }
 
-func (gcv *GlobalConfigurationValidator) validateListener(listener v1alpha1.Listener, fieldPath *field.Path) field.ErrorList {
+func (gcv *GlobalConfigurationValidator) validateListener(listener v1.Listener, fieldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	allErrs = append(allErrs, validateGlobalConfigurationListenerName(listener.Name, fieldPath.Child("name"))...)
 	allErrs = append(allErrs, gcv.validateListenerPort(listener.Port, fieldPath.Child("port"))...)
-	allErrs = append(allErrs, validateListenerProtocol(listener.Protocol, fieldPath.Child("protocol"))...)
+	allErrs = append(allErrs, validateGlobalConfigurationListenerProtocol(listener.Protocol, fieldPath.Child("protocol"))...)
 
 	return allErrs
 }
 
+func validateGlobalConfigurationListenerProtocol(protocol string, fieldPath *field.Path) field.ErrorList {
+	// custom TLS Passthrough listeners can be defined in addition to the built-in one
+	if protocol == v1.TLSPassthroughListenerProtocol {
+		return field.ErrorList{}
+	}
+
+	return validateListenerProtocol(protocol, fieldPath)
+}
+
 func validateGlobalConfigurationListenerName(name string, fieldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
-	if name == v1alpha1.TLSPassthroughListenerName {
+	if name == v1.TLSPassthroughListenerName {
 		return append(allErrs, field.Forbidden(fieldPath, "is the name of a built-in listener"))
 	}
 
 	return validateListenerName(name, fieldPath)
 }
 
+// ValidateListenerPort validates the port of a listener that is not defined in the GlobalConfiguration,
+// such as a TCP or UDP listener of a Gateway.
+func (gcv *GlobalConfigurationValidator) ValidateListenerPort(port int) error {
+	return gcv.validateListenerPort(port, field.NewPath("port")).ToAggregate()
+}
+
 func (gcv *GlobalConfigurationValidator) validateListenerPort(port int, fieldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
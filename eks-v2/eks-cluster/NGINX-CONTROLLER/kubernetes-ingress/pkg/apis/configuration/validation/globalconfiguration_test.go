@@ -1,9 +1,9 @@
 package validation
 
 import (
+	v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
 	"testing"
 
-	"github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
@@ -12,9 +12,9 @@ func createGlobalConfigurationValidator() *GlobalConfigurationValidator {
 }
 
 func TestValidateGlobalConfiguration(t *testing.T) {
-	globalConfiguration := v1alpha1.GlobalConfiguration{
-		Spec: v1alpha1.GlobalConfigurationSpec{
-			Listeners: []v1alpha1.Listener{
+	globalConfiguration := v1.GlobalConfiguration{
+		Spec: v1.GlobalConfigurationSpec{
+			Listeners: []v1.Listener{
 				{
 					Name:     "tcp-listener",
 					Port:     53,
@@ -58,7 +58,7 @@ func TestValidateListenerPort(t *testing.T) {
 }
 
 func TestValidateListeners(t *testing.T) {
-	listeners := []v1alpha1.Listener{
+	listeners := []v1.Listener{
 		{
 			Name:     "tcp-listener",
 			Port:     53,
@@ -69,6 +69,11 @@ func TestValidateListeners(t *testing.T) {
 			Port:     53,
 			Protocol: "UDP",
 		},
+		{
+			Name:     "tls-passthrough-8443",
+			Port:     8443,
+			Protocol: "TLS_PASSTHROUGH",
+		},
 	}
 
 	gcv := createGlobalConfigurationValidator()
@@ -81,11 +86,11 @@ func TestValidateListeners(t *testing.T) {
 
 func TestValidateListenersFails(t *testing.T) {
 	tests := []struct {
-		listeners []v1alpha1.Listener
+		listeners []v1.Listener
 		msg       string
 	}{
 		{
-			listeners: []v1alpha1.Listener{
+			listeners: []v1.Listener{
 				{
 					Name:     "tcp-listener",
 					Port:     2201,
@@ -100,7 +105,7 @@ func TestValidateListenersFails(t *testing.T) {
 			msg: "duplicated name",
 		},
 		{
-			listeners: []v1alpha1.Listener{
+			listeners: []v1.Listener{
 				{
 					Name:     "tcp-listener-1",
 					Port:     2201,
@@ -114,6 +119,21 @@ func TestValidateListenersFails(t *testing.T) {
 			},
 			msg: "duplicated port/protocol combination",
 		},
+		{
+			listeners: []v1.Listener{
+				{
+					Name:     "tcp-listener",
+					Port:     8443,
+					Protocol: "TCP",
+				},
+				{
+					Name:     "tls-passthrough-8443",
+					Port:     8443,
+					Protocol: "TLS_PASSTHROUGH",
+				},
+			},
+			msg: "TCP and TLS Passthrough listeners with the same port",
+		},
 	}
 
 	gcv := createGlobalConfigurationValidator()
@@ -127,7 +147,7 @@ func TestValidateListenersFails(t *testing.T) {
 }
 
 func TestValidateListener(t *testing.T) {
-	listener := v1alpha1.Listener{
+	listener := v1.Listener{
 		Name:     "tcp-listener",
 		Port:     53,
 		Protocol: "TCP",
@@ -143,11 +163,11 @@ func TestValidateListener(t *testing.T) {
 
 func TestValidateListenerFails(t *testing.T) {
 	tests := []struct {
-		Listener v1alpha1.Listener
+		Listener v1.Listener
 		msg      string
 	}{
 		{
-			Listener: v1alpha1.Listener{
+			Listener: v1.Listener{
 				Name:     "@",
 				Port:     2201,
 				Protocol: "TCP",
@@ -155,7 +175,7 @@ func TestValidateListenerFails(t *testing.T) {
 			msg: "invalid name",
 		},
 		{
-			Listener: v1alpha1.Listener{
+			Listener: v1.Listener{
 				Name:     "tcp-listener",
 				Port:     -1,
 				Protocol: "TCP",
@@ -163,7 +183,7 @@ func TestValidateListenerFails(t *testing.T) {
 			msg: "invalid port",
 		},
 		{
-			Listener: v1alpha1.Listener{
+			Listener: v1.Listener{
 				Name:     "name",
 				Port:     2201,
 				Protocol: "IP",
@@ -171,7 +191,7 @@ func TestValidateListenerFails(t *testing.T) {
 			msg: "invalid protocol",
 		},
 		{
-			Listener: v1alpha1.Listener{
+			Listener: v1.Listener{
 				Name:     "tls-passthrough",
 				Port:     2201,
 				Protocol: "TCP",
@@ -201,4 +221,15 @@ func TestGeneratePortProtocolKey(t *testing.T) {
 	if result != expected {
 		t.Errorf("generatePortProtocolKey(%d, %q) returned %q but expected %q", port, protocol, result, expected)
 	}
+
+	port = 8443
+	protocol = "TLS_PASSTHROUGH"
+
+	expected = "8443/TCP"
+
+	result = generatePortProtocolKey(port, protocol)
+
+	if result != expected {
+		t.Errorf("generatePortProtocolKey(%d, %q) returned %q but expected %q", port, protocol, result, expected)
+	}
 }
@@ -38,6 +38,16 @@ func validatePolicySpec(spec *v1.PolicySpec, fieldPath *field.Path, isPlus, enab
 		fieldCount++
 	}
 
+	if spec.ConnectionLimit != nil {
+		if !enablePreviewPolicies {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("connectionLimit"),
+				"connectionLimit is a preview policy. Preview policies must be enabled to use via cli argument -enable-preview-policies"))
+		}
+
+		allErrs = append(allErrs, validateConnectionLimit(spec.ConnectionLimit, fieldPath.Child("connectionLimit"), isPlus)...)
+		fieldCount++
+	}
+
 	if spec.JWTAuth != nil {
 		if !enablePreviewPolicies {
 			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("jwt"),
@@ -51,6 +61,56 @@ func validatePolicySpec(spec *v1.PolicySpec, fieldPath *field.Path, isPlus, enab
 		fieldCount++
 	}
 
+	if spec.BasicAuth != nil {
+		if !enablePreviewPolicies {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("basicAuth"),
+				"basicAuth is a preview policy. Preview policies must be enabled to use via cli argument -enable-preview-policies"))
+		}
+
+		allErrs = append(allErrs, validateBasic(spec.BasicAuth, fieldPath.Child("basicAuth"))...)
+		fieldCount++
+	}
+
+	if spec.ExternalAuth != nil {
+		if !enablePreviewPolicies {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("externalAuth"),
+				"externalAuth is a preview policy. Preview policies must be enabled to use via cli argument -enable-preview-policies"))
+		}
+
+		allErrs = append(allErrs, validateExternalAuth(spec.ExternalAuth, fieldPath.Child("externalAuth"))...)
+		fieldCount++
+	}
+
+	if spec.CORS != nil {
+		if !enablePreviewPolicies {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("cors"),
+				"cors is a preview policy. Preview policies must be enabled to use via cli argument -enable-preview-policies"))
+		}
+
+		allErrs = append(allErrs, validateCORS(spec.CORS, fieldPath.Child("cors"))...)
+		fieldCount++
+	}
+
+	if spec.Cache != nil {
+		if !enablePreviewPolicies {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("cache"),
+				"cache is a preview policy. Preview policies must be enabled to use via cli argument -enable-preview-policies"))
+		}
+
+		allErrs = append(allErrs, validateCache(spec.Cache, fieldPath.Child("cache"), isPlus)...)
+		fieldCount++
+	}
+
+	if spec.Retry != nil {
+		if !enablePreviewPolicies {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("retry"),
+				"retry is a preview policy. Preview policies must be enabled to use via cli argument -enable-preview-policies"))
+		}
+
+		allErrs = append(allErrs, validateRetry(spec.Retry, fieldPath.Child("retry"))...)
+		fieldCount++
+	}
+
 	if spec.IngressMTLS != nil {
 		if !enablePreviewPolicies {
 			return append(allErrs, field.Forbidden(fieldPath.Child("ingressMTLS"),
@@ -96,7 +156,7 @@ func validatePolicySpec(spec *v1.PolicySpec, fieldPath *field.Path, isPlus, enab
 	}
 
 	if fieldCount != 1 {
-		msg := "must specify exactly one of: `accessControl`, `rateLimit`, `ingressMTLS`, `egressMTLS`"
+		msg := "must specify exactly one of: `accessControl`, `rateLimit`, `connectionLimit`, `ingressMTLS`, `egressMTLS`, `basicAuth`, `externalAuth`, `cors`, `cache`, `retry`"
 		if isPlus {
 			msg = fmt.Sprint(msg, ", `jwt`, `oidc`, `waf`")
 		}
@@ -161,6 +221,27 @@ func validateRateLimit(rateLimit *v1.RateLimit, fieldPath *field.Path, isPlus bo
 	return allErrs
 }
 
+func validateConnectionLimit(connectionLimit *v1.ConnectionLimit, fieldPath *field.Path, isPlus bool) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, validateRateLimitZoneSize(connectionLimit.ZoneSize, fieldPath.Child("zoneSize"))...)
+	allErrs = append(allErrs, validateRateLimitKey(connectionLimit.Key, fieldPath.Child("key"), isPlus)...)
+	allErrs = append(allErrs, validatePositiveInt(connectionLimit.MaxConnections, fieldPath.Child("maxConnections"))...)
+
+	if connectionLimit.LogLevel != "" {
+		allErrs = append(allErrs, validateRateLimitLogLevel(connectionLimit.LogLevel, fieldPath.Child("logLevel"))...)
+	}
+
+	if connectionLimit.RejectCode != nil {
+		if *connectionLimit.RejectCode < 400 || *connectionLimit.RejectCode > 599 {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("rejectCode"), connectionLimit.RejectCode,
+				"must be within the range [400-599]"))
+		}
+	}
+
+	return allErrs
+}
+
 func validateJWT(jwt *v1.JWTAuth, fieldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -176,6 +257,395 @@ func validateJWT(jwt *v1.JWTAuth, fieldPath *field.Path) field.ErrorList {
 	return allErrs
 }
 
+func validateBasic(basic *v1.BasicAuth, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if basic.Realm != "" {
+		allErrs = append(allErrs, validateJWTRealm(basic.Realm, fieldPath.Child("realm"))...)
+	}
+	if basic.Realm == "off" {
+		// "off" disables the auth_basic directive
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("realm"), basic.Realm, "must not be 'off'"))
+	}
+
+	if basic.Secret == "" {
+		return append(allErrs, field.Required(fieldPath.Child("secret"), ""))
+	}
+	allErrs = append(allErrs, validateSecretName(basic.Secret, fieldPath.Child("secret"))...)
+
+	return allErrs
+}
+
+func validateExternalAuth(extAuth *v1.ExternalAuth, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if extAuth.AuthURL == "" && extAuth.AuthServiceName == "" {
+		return append(allErrs, field.Required(fieldPath, "must specify one of: `authURL` or `authServiceName`"))
+	}
+	if extAuth.AuthURL != "" && extAuth.AuthServiceName != "" {
+		return append(allErrs, field.Invalid(fieldPath, "", "must specify only one of: `authURL` or `authServiceName`"))
+	}
+
+	if extAuth.AuthURL != "" {
+		allErrs = append(allErrs, validateExternalAuthURL(extAuth.AuthURL, fieldPath.Child("authURL"))...)
+		if extAuth.AuthServicePort != 0 {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("authServicePort"), "must not be set together with `authURL`"))
+		}
+		if extAuth.AuthURI != "" {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("authURI"), "must not be set together with `authURL`"))
+		}
+	} else {
+		allErrs = append(allErrs, validateServiceName(extAuth.AuthServiceName, fieldPath.Child("authServiceName"))...)
+		for _, msg := range validation.IsValidPortNum(extAuth.AuthServicePort) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("authServicePort"), extAuth.AuthServicePort, msg))
+		}
+		if extAuth.AuthURI != "" {
+			allErrs = append(allErrs, validatePath(extAuth.AuthURI, fieldPath.Child("authURI"))...)
+			allErrs = append(allErrs, validateExternalAuthURLChars(extAuth.AuthURI, fieldPath.Child("authURI"))...)
+		}
+	}
+
+	allErrs = append(allErrs, validateHTTPHeaderNames(extAuth.ForwardHeaders, fieldPath.Child("forwardHeaders"))...)
+	allErrs = append(allErrs, validateHTTPHeaderNames(extAuth.ResponseHeaders, fieldPath.Child("responseHeaders"))...)
+
+	if extAuth.SigninURL != "" {
+		allErrs = append(allErrs, validateExternalAuthURL(extAuth.SigninURL, fieldPath.Child("signinURL"))...)
+	}
+
+	allErrs = append(allErrs, validateTime(extAuth.CacheTTL, fieldPath.Child("cacheTTL"))...)
+
+	return allErrs
+}
+
+func validateExternalAuthURL(u string, fieldPath *field.Path) field.ErrorList {
+	allErrs := validateURL(u, fieldPath)
+	return append(allErrs, validateExternalAuthURLChars(u, fieldPath)...)
+}
+
+const (
+	externalAuthURLFmt    = `[^\s{};$#"'\\]*`
+	externalAuthURLErrMsg = "must not include any whitespace character, `{`, `}`, `;`, `$`, `#`, `\\`, `\"` or `'`"
+)
+
+var externalAuthURLRegexp = regexp.MustCompile("^" + externalAuthURLFmt + "$")
+
+func validateExternalAuthURLChars(u string, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if !externalAuthURLRegexp.MatchString(u) {
+		msg := validation.RegexError(externalAuthURLErrMsg, externalAuthURLFmt, "https://auth.example.com/check", "/check")
+		allErrs = append(allErrs, field.Invalid(fieldPath, u, msg))
+	}
+
+	return allErrs
+}
+
+func validateHTTPHeaderNames(headers []string, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	seen := make(map[string]bool)
+	for i, h := range headers {
+		idxPath := fieldPath.Index(i)
+		for _, msg := range validation.IsHTTPHeaderName(h) {
+			allErrs = append(allErrs, field.Invalid(idxPath, h, msg))
+		}
+		if seen[strings.ToLower(h)] {
+			allErrs = append(allErrs, field.Duplicate(idxPath, h))
+		}
+		seen[strings.ToLower(h)] = true
+	}
+
+	return allErrs
+}
+
+func validateCORS(cors *v1.CORS, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(cors.AllowOrigins) == 0 {
+		allErrs = append(allErrs, field.Required(fieldPath.Child("allowOrigins"), ""))
+	}
+
+	seen := make(map[string]bool)
+	for i, origin := range cors.AllowOrigins {
+		idxPath := fieldPath.Child("allowOrigins").Index(i)
+		if origin == "*" && len(cors.AllowOrigins) > 1 {
+			allErrs = append(allErrs, field.Invalid(idxPath, origin, "must be the only origin"))
+			continue
+		}
+		if origin == "*" && cors.AllowCredentials {
+			allErrs = append(allErrs, field.Invalid(idxPath, origin, "is not allowed with allowCredentials"))
+			continue
+		}
+		allErrs = append(allErrs, validateCORSOrigin(origin, idxPath)...)
+		if seen[origin] {
+			allErrs = append(allErrs, field.Duplicate(idxPath, origin))
+		}
+		seen[origin] = true
+	}
+
+	allErrs = append(allErrs, validateCORSMethods(cors.AllowMethods, fieldPath.Child("allowMethods"))...)
+	allErrs = append(allErrs, validateHTTPHeaderNames(cors.AllowHeaders, fieldPath.Child("allowHeaders"))...)
+	allErrs = append(allErrs, validateHTTPHeaderNames(cors.ExposeHeaders, fieldPath.Child("exposeHeaders"))...)
+
+	if cors.MaxAge != nil && *cors.MaxAge < 0 {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("maxAge"), *cors.MaxAge, "must be non-negative"))
+	}
+
+	return allErrs
+}
+
+// validateCORSOrigin validates an origin of a CORS policy. An origin is either `*`, a regular expression that starts
+// with `~` (case-sensitive) or `~*` (case-insensitive), or a `scheme://host[:port]` origin,
+// where the host can start with a `*.` wildcard.
+func validateCORSOrigin(origin string, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if origin == "*" {
+		return allErrs
+	}
+
+	if strings.HasPrefix(origin, "~") {
+		if err := ValidateEscapedString(origin, `~^https://app[0-9]+\.example\.com$`); err != nil {
+			return append(allErrs, field.Invalid(fieldPath, origin, err.Error()))
+		}
+		if _, err := regexp.Compile(strings.TrimPrefix(strings.TrimPrefix(origin, "~"), "*")); err != nil {
+			return append(allErrs, field.Invalid(fieldPath, origin, fmt.Sprintf("must be a valid regular expression: %v", err)))
+		}
+		return allErrs
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil {
+		return append(allErrs, field.Invalid(fieldPath, origin, err.Error()))
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return append(allErrs, field.Invalid(fieldPath, origin, "scheme must be http or https"))
+	}
+	if u.User != nil || u.Path != "" || u.RawQuery != "" || u.Fragment != "" || u.ForceQuery {
+		return append(allErrs, field.Invalid(fieldPath, origin, "must be of the form scheme://host[:port]"))
+	}
+
+	host, port, err := net.SplitHostPort(u.Host)
+	if err != nil {
+		host = u.Host
+	}
+
+	// a wildcard matches one or more labels of the host
+	host = strings.TrimPrefix(host, "*.")
+	for _, msg := range validation.IsDNS1123Subdomain(host) {
+		allErrs = append(allErrs, field.Invalid(fieldPath, origin, msg))
+	}
+	if port != "" {
+		allErrs = append(allErrs, validatePortNumber(port, fieldPath)...)
+	}
+
+	return allErrs
+}
+
+const (
+	corsMethodFmt    = `[A-Z]+`
+	corsMethodErrMsg = "must consist of uppercase letters"
+)
+
+var corsMethodRegexp = regexp.MustCompile("^" + corsMethodFmt + "$")
+
+func validateCORSMethods(methods []string, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	seen := make(map[string]bool)
+	for i, m := range methods {
+		idxPath := fieldPath.Index(i)
+		if !corsMethodRegexp.MatchString(m) {
+			msg := validation.RegexError(corsMethodErrMsg, corsMethodFmt, "GET", "PATCH")
+			allErrs = append(allErrs, field.Invalid(idxPath, m, msg))
+		}
+		if seen[m] {
+			allErrs = append(allErrs, field.Duplicate(idxPath, m))
+		}
+		seen[m] = true
+	}
+
+	return allErrs
+}
+
+func validateCache(cache *v1.Cache, fieldPath *field.Path, isPlus bool) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, validateRateLimitZoneSize(cache.ZoneSize, fieldPath.Child("zoneSize"))...)
+	allErrs = append(allErrs, validateSize(cache.MaxSize, fieldPath.Child("maxSize"))...)
+
+	if len(cache.Valid) == 0 {
+		allErrs = append(allErrs, field.Required(fieldPath.Child("valid"), ""))
+	}
+	for i, v := range cache.Valid {
+		allErrs = append(allErrs, validateCacheValid(v, fieldPath.Child("valid").Index(i))...)
+	}
+
+	if cache.Key != "" {
+		allErrs = append(allErrs, validateCacheKey(cache.Key, fieldPath.Child("key"), isPlus)...)
+	}
+
+	seen := make(map[string]bool)
+	for i, m := range cache.Methods {
+		idxPath := fieldPath.Child("methods").Index(i)
+		if !cacheMethods[m] {
+			allErrs = append(allErrs, field.Invalid(idxPath, m, fmt.Sprintf("Accepted values: %s", mapToPrettyString(cacheMethods))))
+		}
+		if seen[m] {
+			allErrs = append(allErrs, field.Duplicate(idxPath, m))
+		}
+		seen[m] = true
+	}
+
+	for i, b := range cache.Bypass {
+		allErrs = append(allErrs, validateCacheBypass(b, fieldPath.Child("bypass").Index(i), isPlus)...)
+	}
+
+	return allErrs
+}
+
+// cacheMethods includes the methods that proxy_cache_methods accepts.
+var cacheMethods = map[string]bool{
+	"GET":  true,
+	"HEAD": true,
+	"POST": true,
+}
+
+func validateCacheValid(valid v1.CacheValid, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i, code := range valid.Codes {
+		if code < 100 || code > 599 {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("codes").Index(i), code, "must be within the range [100-599]"))
+		}
+	}
+
+	if valid.Time == "" {
+		return append(allErrs, field.Required(fieldPath.Child("time"), ""))
+	}
+	allErrs = append(allErrs, validateTime(valid.Time, fieldPath.Child("time"))...)
+
+	return allErrs
+}
+
+var cacheSpecialVariables = []string{"arg_", "http_", "cookie_"}
+
+// cacheKeyVariables includes NGINX variables allowed to be used in a cache policy key.
+var cacheKeyVariables = map[string]bool{
+	"scheme":         true,
+	"request_method": true,
+	"host":           true,
+	"request_uri":    true,
+	"uri":            true,
+	"args":           true,
+}
+
+func validateCacheKey(key string, fieldPath *field.Path, isPlus bool) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if err := ValidateEscapedString(key, `${scheme}${host}${request_uri}`); err != nil {
+		allErrs = append(allErrs, field.Invalid(fieldPath, key, err.Error()))
+	}
+
+	allErrs = append(allErrs, validateStringWithVariables(key, fieldPath, cacheSpecialVariables, cacheKeyVariables, isPlus)...)
+
+	return allErrs
+}
+
+func validateCacheBypass(bypass string, fieldPath *field.Path, isPlus bool) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if bypass == "" {
+		return append(allErrs, field.Required(fieldPath, ""))
+	}
+
+	if err := ValidateEscapedString(bypass, `${cookie_nocache}`, `${http_pragma}`); err != nil {
+		allErrs = append(allErrs, field.Invalid(fieldPath, bypass, err.Error()))
+	}
+
+	// only the special variables are allowed, because a condition without variables is either always or never true
+	allErrs = append(allErrs, validateStringWithVariables(bypass, fieldPath, cacheSpecialVariables, map[string]bool{}, isPlus)...)
+
+	return allErrs
+}
+
+// retryConditions includes the parameters of proxy_next_upstream that a retry policy accepts.
+// non_idempotent and off are derived from the methods and the attempts of the policy.
+var retryConditions = map[string]bool{
+	"error":          true,
+	"timeout":        true,
+	"invalid_header": true,
+	"http_500":       true,
+	"http_502":       true,
+	"http_503":       true,
+	"http_504":       true,
+	"http_403":       true,
+	"http_404":       true,
+	"http_429":       true,
+}
+
+// retryMethods includes the methods that a retry policy accepts.
+var retryMethods = map[string]bool{
+	"GET":     true,
+	"HEAD":    true,
+	"POST":    true,
+	"PUT":     true,
+	"DELETE":  true,
+	"OPTIONS": true,
+	"TRACE":   true,
+	"PATCH":   true,
+}
+
+func validateRetry(retry *v1.Retry, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, validateRetryValues(retry.Conditions, retryConditions, fieldPath.Child("conditions"))...)
+	allErrs = append(allErrs, validateRetryValues(retry.Methods, retryMethods, fieldPath.Child("methods"))...)
+
+	if retry.Attempts != nil {
+		allErrs = append(allErrs, validatePositiveInt(*retry.Attempts, fieldPath.Child("attempts"))...)
+	}
+
+	if retry.PerTryTimeout != "" {
+		allErrs = append(allErrs, validateTime(retry.PerTryTimeout, fieldPath.Child("perTryTimeout"))...)
+	}
+
+	if retry.Timeout != "" {
+		allErrs = append(allErrs, validateTime(retry.Timeout, fieldPath.Child("timeout"))...)
+	}
+
+	if retry.Sample != nil {
+		// without attempts, the number of retries of the sampled requests is not limited
+		if retry.Attempts == nil {
+			allErrs = append(allErrs, field.Required(fieldPath.Child("attempts"), "must be specified with `sample`"))
+		}
+		percentage := retry.Sample.Percentage
+		if percentage < 1 || percentage > 100 {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("sample").Child("percentage"), percentage, "must be within the range [1-100]"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateRetryValues(values []string, accepted map[string]bool, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	seen := make(map[string]bool)
+	for i, v := range values {
+		idxPath := fieldPath.Index(i)
+		if !accepted[v] {
+			allErrs = append(allErrs, field.Invalid(idxPath, v, fmt.Sprintf("Accepted values: %s", mapToPrettyString(accepted))))
+		}
+		if seen[v] {
+			allErrs = append(allErrs, field.Duplicate(idxPath, v))
+		}
+		seen[v] = true
+	}
+
+	return allErrs
+}
+
 func validateIngressMTLS(ingressMTLS *v1.IngressMTLS, fieldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
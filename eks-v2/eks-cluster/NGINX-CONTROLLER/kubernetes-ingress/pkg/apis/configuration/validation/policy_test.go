@@ -170,6 +170,21 @@ func TestValidatePolicyFails(t *testing.T) {
 			enableAppProtect:      false,
 			msg:                   "rateLimit policy with preview policies disabled",
 		},
+		{
+			policy: &v1.Policy{
+				Spec: v1.PolicySpec{
+					ConnectionLimit: &v1.ConnectionLimit{
+						MaxConnections: 10,
+						ZoneSize:       "10M",
+						Key:            "${binary_remote_addr}",
+					},
+				},
+			},
+			isPlus:                false,
+			enablePreviewPolicies: false,
+			enableAppProtect:      false,
+			msg:                   "connectionLimit policy with preview policies disabled",
+		},
 		{
 			policy: &v1.Policy{
 				Spec: v1.PolicySpec{
@@ -210,6 +225,50 @@ func TestValidatePolicyFails(t *testing.T) {
 			enableAppProtect:      false,
 			msg:                   "egressMTLS policy with preview policies disabled",
 		},
+		{
+			policy: &v1.Policy{
+				Spec: v1.PolicySpec{
+					CORS: &v1.CORS{
+						AllowOrigins: []string{"https://app.example.com"},
+					},
+				},
+			},
+			isPlus:                false,
+			enablePreviewPolicies: false,
+			enableAppProtect:      false,
+			msg:                   "cors policy with preview policies disabled",
+		},
+		{
+			policy: &v1.Policy{
+				Spec: v1.PolicySpec{
+					Cache: &v1.Cache{
+						ZoneSize: "10m",
+						Valid: []v1.CacheValid{
+							{
+								Time: "10m",
+							},
+						},
+					},
+				},
+			},
+			isPlus:                false,
+			enablePreviewPolicies: false,
+			enableAppProtect:      false,
+			msg:                   "cache policy with preview policies disabled",
+		},
+		{
+			policy: &v1.Policy{
+				Spec: v1.PolicySpec{
+					Retry: &v1.Retry{
+						Conditions: []string{"error", "timeout"},
+					},
+				},
+			},
+			isPlus:                false,
+			enablePreviewPolicies: false,
+			enableAppProtect:      false,
+			msg:                   "retry policy with preview policies disabled",
+		},
 		{
 			policy: &v1.Policy{
 				Spec: v1.PolicySpec{
@@ -442,6 +501,107 @@ func TestValidateRateLimitFails(t *testing.T) {
 	}
 }
 
+func TestValidateConnectionLimit(t *testing.T) {
+	dryRun := true
+
+	tests := []struct {
+		connectionLimit *v1.ConnectionLimit
+		msg             string
+	}{
+		{
+			connectionLimit: &v1.ConnectionLimit{
+				MaxConnections: 10,
+				ZoneSize:       "10M",
+				Key:            "${binary_remote_addr}",
+			},
+			msg: "only required fields are set",
+		},
+		{
+			connectionLimit: &v1.ConnectionLimit{
+				MaxConnections: 1,
+				ZoneSize:       "10M",
+				Key:            "${request_uri}",
+				DryRun:         &dryRun,
+				LogLevel:       "info",
+				RejectCode:     createPointerFromInt(429),
+			},
+			msg: "connectionLimit all fields set",
+		},
+	}
+
+	isPlus := false
+
+	for _, test := range tests {
+		allErrs := validateConnectionLimit(test.connectionLimit, field.NewPath("connectionLimit"), isPlus)
+		if len(allErrs) > 0 {
+			t.Errorf("validateConnectionLimit() returned errors %v for valid input for the case of %v", allErrs, test.msg)
+		}
+	}
+}
+
+func createInvalidConnectionLimit(f func(c *v1.ConnectionLimit)) *v1.ConnectionLimit {
+	validConnectionLimit := &v1.ConnectionLimit{
+		MaxConnections: 10,
+		ZoneSize:       "10M",
+		Key:            "${binary_remote_addr}",
+	}
+	f(validConnectionLimit)
+	return validConnectionLimit
+}
+
+func TestValidateConnectionLimitFails(t *testing.T) {
+	tests := []struct {
+		connectionLimit *v1.ConnectionLimit
+		msg             string
+	}{
+		{
+			connectionLimit: createInvalidConnectionLimit(func(c *v1.ConnectionLimit) {
+				c.MaxConnections = 0
+			}),
+			msg: "invalid connectionLimit maxConnections",
+		},
+		{
+			connectionLimit: createInvalidConnectionLimit(func(c *v1.ConnectionLimit) {
+				c.Key = ""
+			}),
+			msg: "missing connectionLimit key",
+		},
+		{
+			connectionLimit: createInvalidConnectionLimit(func(c *v1.ConnectionLimit) {
+				c.Key = "${fail}"
+			}),
+			msg: "invalid connectionLimit key variable use",
+		},
+		{
+			connectionLimit: createInvalidConnectionLimit(func(c *v1.ConnectionLimit) {
+				c.ZoneSize = "31k"
+			}),
+			msg: "invalid connectionLimit zoneSize",
+		},
+		{
+			connectionLimit: createInvalidConnectionLimit(func(c *v1.ConnectionLimit) {
+				c.RejectCode = createPointerFromInt(600)
+			}),
+			msg: "invalid connectionLimit rejectCode",
+		},
+		{
+			connectionLimit: createInvalidConnectionLimit(func(c *v1.ConnectionLimit) {
+				c.LogLevel = "invalid"
+			}),
+			msg: "invalid connectionLimit logLevel",
+		},
+	}
+
+	isPlus := false
+
+	for _, test := range tests {
+		allErrs := validateConnectionLimit(test.connectionLimit, field.NewPath("connectionLimit"), isPlus)
+		if len(allErrs) == 0 {
+			t.Errorf("validateConnectionLimit() returned no errors for invalid input for the case of %v", test.msg)
+		}
+	}
+}
+
 func TestValidateJWT(t *testing.T) {
 	tests := []struct {
 		jwt *v1.JWTAuth
@@ -533,6 +693,634 @@ func TestValidateJWTFails(t *testing.T) {
 	}
 }
 
+func TestValidateBasic(t *testing.T) {
+	tests := []struct {
+		basic *v1.BasicAuth
+		msg   string
+	}{
+		{
+			basic: &v1.BasicAuth{
+				Realm:  "My Dashboard",
+				Secret: "my-htpasswd",
+			},
+			msg: "basic auth with realm",
+		},
+		{
+			basic: &v1.BasicAuth{
+				Secret: "my-htpasswd",
+			},
+			msg: "basic auth without realm",
+		},
+	}
+	for _, test := range tests {
+		allErrs := validateBasic(test.basic, field.NewPath("basicAuth"))
+		if len(allErrs) != 0 {
+			t.Errorf("validateBasic() returned errors %v for valid input for the case of %v", allErrs, test.msg)
+		}
+	}
+}
+
+func TestValidateBasicFails(t *testing.T) {
+	tests := []struct {
+		basic *v1.BasicAuth
+		msg   string
+	}{
+		{
+			basic: &v1.BasicAuth{
+				Realm: "My Dashboard",
+			},
+			msg: "missing secret",
+		},
+		{
+			basic: &v1.BasicAuth{
+				Realm:  "My Dashboard",
+				Secret: "my-\"htpasswd",
+			},
+			msg: "invalid secret name",
+		},
+		{
+			basic: &v1.BasicAuth{
+				Realm:  "My \"Dashboard",
+				Secret: "my-htpasswd",
+			},
+			msg: "invalid realm due to escaped string",
+		},
+		{
+			basic: &v1.BasicAuth{
+				Realm:  "off",
+				Secret: "my-htpasswd",
+			},
+			msg: "realm that disables basic auth",
+		},
+	}
+	for _, test := range tests {
+		allErrs := validateBasic(test.basic, field.NewPath("basicAuth"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateBasic() returned no errors for invalid input for the case of %v", test.msg)
+		}
+	}
+}
+
+func TestValidateExternalAuth(t *testing.T) {
+	tests := []struct {
+		extAuth *v1.ExternalAuth
+		msg     string
+	}{
+		{
+			extAuth: &v1.ExternalAuth{
+				AuthURL: "https://auth.example.com/check",
+			},
+			msg: "auth URL",
+		},
+		{
+			extAuth: &v1.ExternalAuth{
+				AuthServiceName: "authz",
+				AuthServicePort: 8080,
+				AuthURI:         "/check",
+				ForwardHeaders:  []string{"Authorization", "Cookie"},
+				ResponseHeaders: []string{"X-User", "X-Groups"},
+				SigninURL:       "https://login.example.com/signin",
+				CacheTTL:        "30s",
+			},
+			msg: "auth service with all fields",
+		},
+	}
+	for _, test := range tests {
+		allErrs := validateExternalAuth(test.extAuth, field.NewPath("externalAuth"))
+		if len(allErrs) != 0 {
+			t.Errorf("validateExternalAuth() returned errors %v for valid input for the case of %v", allErrs, test.msg)
+		}
+	}
+}
+
+func TestValidateExternalAuthFails(t *testing.T) {
+	tests := []struct {
+		extAuth *v1.ExternalAuth
+		msg     string
+	}{
+		{
+			extAuth: &v1.ExternalAuth{},
+			msg:     "missing auth URL and service",
+		},
+		{
+			extAuth: &v1.ExternalAuth{
+				AuthURL:         "https://auth.example.com/check",
+				AuthServiceName: "authz",
+				AuthServicePort: 8080,
+			},
+			msg: "both auth URL and service",
+		},
+		{
+			extAuth: &v1.ExternalAuth{
+				AuthServiceName: "authz",
+			},
+			msg: "missing auth service port",
+		},
+		{
+			extAuth: &v1.ExternalAuth{
+				AuthURL: "https://auth.example.com/check;",
+			},
+			msg: "invalid character in auth URL",
+		},
+		{
+			extAuth: &v1.ExternalAuth{
+				AuthURL: "https://auth.example.com/$uri",
+			},
+			msg: "variable in auth URL",
+		},
+		{
+			extAuth: &v1.ExternalAuth{
+				AuthServiceName: "authz",
+				AuthServicePort: 8080,
+				AuthURI:         "check",
+			},
+			msg: "auth URI without leading slash",
+		},
+		{
+			extAuth: &v1.ExternalAuth{
+				AuthURL:        "https://auth.example.com/check",
+				ForwardHeaders: []string{"Authorization", "authorization"},
+			},
+			msg: "duplicate forward header",
+		},
+		{
+			extAuth: &v1.ExternalAuth{
+				AuthURL:         "https://auth.example.com/check",
+				ResponseHeaders: []string{"X User"},
+			},
+			msg: "invalid response header",
+		},
+		{
+			extAuth: &v1.ExternalAuth{
+				AuthURL:   "https://auth.example.com/check",
+				SigninURL: "/signin",
+			},
+			msg: "relative signin URL",
+		},
+		{
+			extAuth: &v1.ExternalAuth{
+				AuthURL:   "https://auth.example.com/check",
+				SigninURL: "https://login.example.com/signin#login",
+			},
+			msg: "signin URL with fragment",
+		},
+		{
+			extAuth: &v1.ExternalAuth{
+				AuthURL:  "https://auth.example.com/check",
+				CacheTTL: "1 minute",
+			},
+			msg: "invalid cache TTL",
+		},
+	}
+	for _, test := range tests {
+		allErrs := validateExternalAuth(test.extAuth, field.NewPath("externalAuth"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateExternalAuth() returned no errors for invalid input for the case of %v", test.msg)
+		}
+	}
+}
+
+func TestValidateCORS(t *testing.T) {
+	tests := []struct {
+		cors *v1.CORS
+		msg  string
+	}{
+		{
+			cors: &v1.CORS{
+				AllowOrigins: []string{"*"},
+			},
+			msg: "all origins",
+		},
+		{
+			cors: &v1.CORS{
+				AllowOrigins:     []string{"https://app.example.com", "https://*.example.org", "http://localhost:8080", `~^https://app[0-9]+\.example\.com$`},
+				AllowMethods:     []string{"GET", "POST", "DELETE"},
+				AllowHeaders:     []string{"Authorization", "Content-Type"},
+				ExposeHeaders:    []string{"X-Request-ID"},
+				AllowCredentials: true,
+				MaxAge:           createPointerFromInt(600),
+			},
+			msg: "all fields",
+		},
+		{
+			cors: &v1.CORS{
+				AllowOrigins: []string{"~*^https://.*\\.example\\.com$"},
+				MaxAge:       createPointerFromInt(0),
+			},
+			msg: "case-insensitive regex origin and zero max age",
+		},
+	}
+	for _, test := range tests {
+		allErrs := validateCORS(test.cors, field.NewPath("cors"))
+		if len(allErrs) != 0 {
+			t.Errorf("validateCORS() returned errors %v for valid input for the case of %v", allErrs, test.msg)
+		}
+	}
+}
+
+func TestValidateCORSFails(t *testing.T) {
+	tests := []struct {
+		cors *v1.CORS
+		msg  string
+	}{
+		{
+			cors: &v1.CORS{},
+			msg:  "missing origins",
+		},
+		{
+			cors: &v1.CORS{
+				AllowOrigins: []string{"*", "https://app.example.com"},
+			},
+			msg: "all origins with other origins",
+		},
+		{
+			cors: &v1.CORS{
+				AllowOrigins:     []string{"*"},
+				AllowCredentials: true,
+			},
+			msg: "all origins with credentials",
+		},
+		{
+			cors: &v1.CORS{
+				AllowOrigins: []string{"https://app.example.com", "https://app.example.com"},
+			},
+			msg: "duplicate origin",
+		},
+		{
+			cors: &v1.CORS{
+				AllowOrigins: []string{"app.example.com"},
+			},
+			msg: "origin without scheme",
+		},
+		{
+			cors: &v1.CORS{
+				AllowOrigins: []string{"ftp://app.example.com"},
+			},
+			msg: "origin with invalid scheme",
+		},
+		{
+			cors: &v1.CORS{
+				AllowOrigins: []string{"https://app.example.com/path"},
+			},
+			msg: "origin with path",
+		},
+		{
+			cors: &v1.CORS{
+				AllowOrigins: []string{"https://app.*.example.com"},
+			},
+			msg: "wildcard in the middle of the host",
+		},
+		{
+			cors: &v1.CORS{
+				AllowOrigins: []string{"https://app.example.com:99999"},
+			},
+			msg: "origin with invalid port",
+		},
+		{
+			cors: &v1.CORS{
+				AllowOrigins: []string{"~^https://(app"},
+			},
+			msg: "invalid regex origin",
+		},
+		{
+			cors: &v1.CORS{
+				AllowOrigins: []string{`~^https://"app"`},
+			},
+			msg: "regex origin with unescaped double quotes",
+		},
+		{
+			cors: &v1.CORS{
+				AllowOrigins: []string{"*"},
+				AllowMethods: []string{"get"},
+			},
+			msg: "lowercase method",
+		},
+		{
+			cors: &v1.CORS{
+				AllowOrigins: []string{"*"},
+				AllowMethods: []string{"GET", "GET"},
+			},
+			msg: "duplicate method",
+		},
+		{
+			cors: &v1.CORS{
+				AllowOrigins: []string{"*"},
+				AllowHeaders: []string{"Content Type"},
+			},
+			msg: "invalid allowed header",
+		},
+		{
+			cors: &v1.CORS{
+				AllowOrigins:  []string{"*"},
+				ExposeHeaders: []string{"X-Request-ID", "x-request-id"},
+			},
+			msg: "duplicate exposed header",
+		},
+		{
+			cors: &v1.CORS{
+				AllowOrigins: []string{"*"},
+				MaxAge:       createPointerFromInt(-1),
+			},
+			msg: "negative max age",
+		},
+	}
+	for _, test := range tests {
+		allErrs := validateCORS(test.cors, field.NewPath("cors"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateCORS() returned no errors for invalid input for the case of %v", test.msg)
+		}
+	}
+}
+
+func TestValidateCache(t *testing.T) {
+	tests := []struct {
+		cache *v1.Cache
+		msg   string
+	}{
+		{
+			cache: &v1.Cache{
+				ZoneSize: "10m",
+				Valid: []v1.CacheValid{
+					{
+						Time: "10m",
+					},
+				},
+			},
+			msg: "only required fields",
+		},
+		{
+			cache: &v1.Cache{
+				ZoneSize: "10m",
+				MaxSize:  "1024m",
+				Valid: []v1.CacheValid{
+					{
+						Codes: []int{200, 301, 302},
+						Time:  "10m",
+					},
+					{
+						Codes: []int{404},
+						Time:  "1m",
+					},
+				},
+				Key:                  "${scheme}${request_method}${host}${request_uri}${cookie_user}",
+				Methods:              []string{"GET", "HEAD", "POST"},
+				Bypass:               []string{"${cookie_nocache}", "${arg_nocache}${http_pragma}"},
+				Lock:                 true,
+				StaleWhileRevalidate: true,
+			},
+			msg: "all fields",
+		},
+	}
+	for _, test := range tests {
+		allErrs := validateCache(test.cache, field.NewPath("cache"), false)
+		if len(allErrs) != 0 {
+			t.Errorf("validateCache() returned errors %v for valid input for the case of %v", allErrs, test.msg)
+		}
+	}
+}
+
+func TestValidateCacheFails(t *testing.T) {
+	validValid := []v1.CacheValid{
+		{
+			Time: "10m",
+		},
+	}
+
+	tests := []struct {
+		cache *v1.Cache
+		msg   string
+	}{
+		{
+			cache: &v1.Cache{
+				Valid: validValid,
+			},
+			msg: "missing zone size",
+		},
+		{
+			cache: &v1.Cache{
+				ZoneSize: "10k",
+				Valid:    validValid,
+			},
+			msg: "too small zone size",
+		},
+		{
+			cache: &v1.Cache{
+				ZoneSize: "10m",
+				MaxSize:  "1 GB",
+				Valid:    validValid,
+			},
+			msg: "invalid max size",
+		},
+		{
+			cache: &v1.Cache{
+				ZoneSize: "10m",
+			},
+			msg: "missing valid",
+		},
+		{
+			cache: &v1.Cache{
+				ZoneSize: "10m",
+				Valid: []v1.CacheValid{
+					{
+						Codes: []int{200},
+					},
+				},
+			},
+			msg: "missing valid time",
+		},
+		{
+			cache: &v1.Cache{
+				ZoneSize: "10m",
+				Valid: []v1.CacheValid{
+					{
+						Codes: []int{600},
+						Time:  "10m",
+					},
+				},
+			},
+			msg: "invalid valid code",
+		},
+		{
+			cache: &v1.Cache{
+				ZoneSize: "10m",
+				Valid: []v1.CacheValid{
+					{
+						Time: "10 minutes",
+					},
+				},
+			},
+			msg: "invalid valid time",
+		},
+		{
+			cache: &v1.Cache{
+				ZoneSize: "10m",
+				Valid:    validValid,
+				Key:      "$request_uri",
+			},
+			msg: "variable without curly braces in key",
+		},
+		{
+			cache: &v1.Cache{
+				ZoneSize: "10m",
+				Valid:    validValid,
+				Key:      "${remote_addr}",
+			},
+			msg: "unsupported variable in key",
+		},
+		{
+			cache: &v1.Cache{
+				ZoneSize: "10m",
+				Valid:    validValid,
+				Methods:  []string{"PUT"},
+			},
+			msg: "unsupported method",
+		},
+		{
+			cache: &v1.Cache{
+				ZoneSize: "10m",
+				Valid:    validValid,
+				Methods:  []string{"GET", "GET"},
+			},
+			msg: "duplicate method",
+		},
+		{
+			cache: &v1.Cache{
+				ZoneSize: "10m",
+				Valid:    validValid,
+				Bypass:   []string{""},
+			},
+			msg: "empty bypass",
+		},
+		{
+			cache: &v1.Cache{
+				ZoneSize: "10m",
+				Valid:    validValid,
+				Bypass:   []string{"${request_uri}"},
+			},
+			msg: "unsupported variable in bypass",
+		},
+	}
+	for _, test := range tests {
+		allErrs := validateCache(test.cache, field.NewPath("cache"), false)
+		if len(allErrs) == 0 {
+			t.Errorf("validateCache() returned no errors for invalid input for the case of %v", test.msg)
+		}
+	}
+}
+
+func TestValidateRetry(t *testing.T) {
+	tests := []struct {
+		retry *v1.Retry
+		msg   string
+	}{
+		{
+			retry: &v1.Retry{},
+			msg:   "no fields",
+		},
+		{
+			retry: &v1.Retry{
+				Conditions:    []string{"error", "timeout", "http_503"},
+				Methods:       []string{"GET", "POST"},
+				Attempts:      createPointerFromInt(3),
+				PerTryTimeout: "2s",
+				Timeout:       "10s",
+				Sample: &v1.RetrySample{
+					Percentage: 20,
+				},
+			},
+			msg: "all fields",
+		},
+	}
+	for _, test := range tests {
+		allErrs := validateRetry(test.retry, field.NewPath("retry"))
+		if len(allErrs) != 0 {
+			t.Errorf("validateRetry() returned errors %v for valid input for the case of %v", allErrs, test.msg)
+		}
+	}
+}
+
+func TestValidateRetryFails(t *testing.T) {
+	tests := []struct {
+		retry *v1.Retry
+		msg   string
+	}{
+		{
+			retry: &v1.Retry{
+				Conditions: []string{"non_idempotent"},
+			},
+			msg: "unsupported condition",
+		},
+		{
+			retry: &v1.Retry{
+				Conditions: []string{"error", "error"},
+			},
+			msg: "duplicate condition",
+		},
+		{
+			retry: &v1.Retry{
+				Methods: []string{"get"},
+			},
+			msg: "unsupported method",
+		},
+		{
+			retry: &v1.Retry{
+				Methods: []string{"GET", "GET"},
+			},
+			msg: "duplicate method",
+		},
+		{
+			retry: &v1.Retry{
+				Attempts: createPointerFromInt(0),
+			},
+			msg: "zero attempts",
+		},
+		{
+			retry: &v1.Retry{
+				PerTryTimeout: "2 seconds",
+			},
+			msg: "invalid per try timeout",
+		},
+		{
+			retry: &v1.Retry{
+				Timeout: "10 seconds",
+			},
+			msg: "invalid timeout",
+		},
+		{
+			retry: &v1.Retry{
+				Attempts: createPointerFromInt(3),
+				Sample: &v1.RetrySample{
+					Percentage: 0,
+				},
+			},
+			msg: "zero sample percentage",
+		},
+		{
+			retry: &v1.Retry{
+				Attempts: createPointerFromInt(3),
+				Sample: &v1.RetrySample{
+					Percentage: 101,
+				},
+			},
+			msg: "too big sample percentage",
+		},
+		{
+			retry: &v1.Retry{
+				Sample: &v1.RetrySample{
+					Percentage: 20,
+				},
+			},
+			msg: "sample without attempts",
+		},
+	}
+	for _, test := range tests {
+		allErrs := validateRetry(test.retry, field.NewPath("retry"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateRetry() returned no errors for invalid input for the case of %v", test.msg)
+		}
+	}
+}
+
 func TestValidateIPorCIDR(t *testing.T) {
 	validInput := []string{
 		"192.168.1.1",
@@ -3,10 +3,11 @@ package validation
 import (
 	"encoding/hex"
 	"fmt"
+	v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
+	"reflect"
 	"regexp"
 	"strings"
 
-	"github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -29,12 +30,12 @@ func NewTransportServerValidator(tlsPassthrough bool, snippetsEnabled bool, isPl
 }
 
 // ValidateTransportServer validates a TransportServer.
-func (tsv *TransportServerValidator) ValidateTransportServer(transportServer *v1alpha1.TransportServer) error {
-	allErrs := tsv.validateTransportServerSpec(&transportServer.Spec, field.NewPath("spec"))
+func (tsv *TransportServerValidator) ValidateTransportServer(transportServer *v1.TransportServer) error {
+	allErrs := tsv.validateTransportServerSpec(&transportServer.Spec, field.NewPath("spec"), transportServer.Namespace)
 	return allErrs.ToAggregate()
 }
 
-func (tsv *TransportServerValidator) validateTransportServerSpec(spec *v1alpha1.TransportServerSpec, fieldPath *field.Path) field.ErrorList {
+func (tsv *TransportServerValidator) validateTransportServerSpec(spec *v1.TransportServerSpec, fieldPath *field.Path, namespace string) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	allErrs = append(allErrs, tsv.validateTransportListener(&spec.Listener, fieldPath.Child("listener"))...)
@@ -42,7 +43,9 @@ func (tsv *TransportServerValidator) validateTransportServerSpec(spec *v1alpha1.
 	isTLSPassthroughListener := isPotentialTLSPassthroughListener(&spec.Listener)
 	allErrs = append(allErrs, validateTransportServerHost(spec.Host, fieldPath.Child("host"), isTLSPassthroughListener)...)
 
-	upstreamErrs, upstreamNames := validateTransportServerUpstreams(spec.Upstreams, fieldPath.Child("upstreams"), tsv.isPlus)
+	allErrs = append(allErrs, validateTransportServerTLS(spec.TLS, fieldPath.Child("tls"), spec.Listener.Protocol)...)
+
+	upstreamErrs, upstreamNames := validateTransportServerUpstreams(spec.Upstreams, fieldPath.Child("upstreams"), tsv.isPlus, spec.Listener.Protocol)
 	allErrs = append(allErrs, upstreamErrs...)
 
 	allErrs = append(allErrs, validateTransportServerUpstreamParameters(spec.UpstreamParameters, fieldPath.Child("upstreamParameters"), spec.Listener.Protocol)...)
@@ -52,9 +55,12 @@ func (tsv *TransportServerValidator) validateTransportServerSpec(spec *v1alpha1.
 	if spec.Action == nil {
 		allErrs = append(allErrs, field.Required(fieldPath.Child("action"), "must specify action"))
 	} else {
-		allErrs = append(allErrs, validateTransportServerAction(spec.Action, fieldPath.Child("action"), upstreamNames)...)
+		allErrs = append(allErrs, validateTransportServerAction(spec.Action, fieldPath.Child("action"), upstreamNames, spec.Listener.Protocol)...)
+		allErrs = append(allErrs, validateTransportServerActionUpstreams(spec.Action, spec.Upstreams, fieldPath.Child("action"))...)
 	}
 
+	allErrs = append(allErrs, validatePolicies(spec.Policies, fieldPath.Child("policies"), namespace)...)
+
 	allErrs = append(allErrs, validateSnippets(spec.ServerSnippets, fieldPath.Child("serverSnippets"), tsv.snippetsEnabled)...)
 
 	allErrs = append(allErrs, validateSnippets(spec.StreamSnippets, fieldPath.Child("streamSnippets"), tsv.snippetsEnabled)...)
@@ -84,7 +90,7 @@ func validateTransportServerHost(host string, fieldPath *field.Path, isTLSPassth
 	return validateHost(host, fieldPath)
 }
 
-func (tsv *TransportServerValidator) validateTransportListener(listener *v1alpha1.TransportServerListener, fieldPath *field.Path) field.ErrorList {
+func (tsv *TransportServerValidator) validateTransportListener(listener *v1.TransportServerListener, fieldPath *field.Path) field.ErrorList {
 	if isPotentialTLSPassthroughListener(listener) {
 		return tsv.validateTLSPassthroughListener(listener, fieldPath)
 	}
@@ -92,7 +98,7 @@ func (tsv *TransportServerValidator) validateTransportListener(listener *v1alpha
 	return validateRegularListener(listener, fieldPath)
 }
 
-func validateRegularListener(listener *v1alpha1.TransportServerListener, fieldPath *field.Path) field.ErrorList {
+func validateRegularListener(listener *v1.TransportServerListener, fieldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	allErrs = append(allErrs, validateListenerName(listener.Name, fieldPath.Child("name"))...)
@@ -101,28 +107,28 @@ func validateRegularListener(listener *v1alpha1.TransportServerListener, fieldPa
 	return allErrs
 }
 
-func isPotentialTLSPassthroughListener(listener *v1alpha1.TransportServerListener) bool {
-	return listener.Name == v1alpha1.TLSPassthroughListenerName || listener.Protocol == v1alpha1.TLSPassthroughListenerProtocol
+func isPotentialTLSPassthroughListener(listener *v1.TransportServerListener) bool {
+	return listener.Name == v1.TLSPassthroughListenerName || listener.Protocol == v1.TLSPassthroughListenerProtocol
 }
 
-func (tsv *TransportServerValidator) validateTLSPassthroughListener(listener *v1alpha1.TransportServerListener, fieldPath *field.Path) field.ErrorList {
+func (tsv *TransportServerValidator) validateTLSPassthroughListener(listener *v1.TransportServerListener, fieldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	if !tsv.tlsPassthrough {
 		return append(allErrs, field.Forbidden(fieldPath, "TLS Passthrough is not enabled"))
 	}
 
-	if listener.Name == v1alpha1.TLSPassthroughListenerName && listener.Protocol != v1alpha1.TLSPassthroughListenerProtocol {
-		msg := fmt.Sprintf("must be '%s' for the built-in %s listener", v1alpha1.TLSPassthroughListenerProtocol, v1alpha1.TLSPassthroughListenerName)
+	if listener.Name == v1.TLSPassthroughListenerName && listener.Protocol != v1.TLSPassthroughListenerProtocol {
+		msg := fmt.Sprintf("must be '%s' for the built-in %s listener", v1.TLSPassthroughListenerProtocol, v1.TLSPassthroughListenerName)
 		return append(allErrs, field.Invalid(fieldPath.Child("protocol"), listener.Protocol, msg))
 	}
 
-	if listener.Protocol == v1alpha1.TLSPassthroughListenerProtocol && listener.Name != v1alpha1.TLSPassthroughListenerName {
-		msg := fmt.Sprintf("must be '%s' for a listener with the protocol %s", v1alpha1.TLSPassthroughListenerName, v1alpha1.TLSPassthroughListenerProtocol)
-		return append(allErrs, field.Invalid(fieldPath.Child("name"), listener.Name, msg))
+	if listener.Name == v1.TLSPassthroughListenerName {
+		return allErrs
 	}
 
-	return allErrs
+	// a custom TLS Passthrough listener is defined in the GlobalConfiguration
+	return validateListenerName(listener.Name, fieldPath.Child("name"))
 }
 
 func validateListenerName(name string, fieldPath *field.Path) field.ErrorList {
@@ -151,7 +157,94 @@ func validateListenerProtocol(protocol string, fieldPath *field.Path) field.Erro
 	return allErrs
 }
 
-func validateTransportServerUpstreams(upstreams []v1alpha1.Upstream, fieldPath *field.Path, isPlus bool) (allErrs field.ErrorList, upstreamNames sets.String) {
+func validateTransportServerTLS(tls *v1.TransportServerTLS, fieldPath *field.Path, protocol string) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if tls == nil {
+		return allErrs
+	}
+
+	if protocol != "TCP" {
+		return append(allErrs, field.Forbidden(fieldPath, "is not allowed for non-TCP TransportServers"))
+	}
+
+	if tls.Secret == "" {
+		allErrs = append(allErrs, field.Required(fieldPath.Child("secret"), ""))
+	} else {
+		allErrs = append(allErrs, validateSecretName(tls.Secret, fieldPath.Child("secret"))...)
+	}
+
+	allErrs = append(allErrs, validateSSLProtocols(tls.Protocols, fieldPath.Child("protocols"))...)
+	allErrs = append(allErrs, validateSSLCiphers(tls.Ciphers, fieldPath.Child("ciphers"))...)
+
+	return allErrs
+}
+
+func validateUpstreamTLS(tls *v1.TransportServerUpstreamTLS, fieldPath *field.Path, protocol string) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if tls == nil || !tls.Enable {
+		return allErrs
+	}
+
+	if protocol != "TCP" {
+		return append(allErrs, field.Forbidden(fieldPath, "is not allowed for non-TCP TransportServers"))
+	}
+
+	if tls.VerifyServer && tls.TrustedCertSecret == "" {
+		allErrs = append(allErrs, field.Required(fieldPath.Child("trustedCertSecret"), "must be set when verifyServer is 'true'"))
+	}
+	allErrs = append(allErrs, validateSecretName(tls.TrustedCertSecret, fieldPath.Child("trustedCertSecret"))...)
+
+	if tls.VerifyDepth != nil {
+		allErrs = append(allErrs, validatePositiveIntOrZero(*tls.VerifyDepth, fieldPath.Child("verifyDepth"))...)
+	}
+
+	allErrs = append(allErrs, validateSSLProtocols(tls.Protocols, fieldPath.Child("protocols"))...)
+	allErrs = append(allErrs, validateSSLCiphers(tls.Ciphers, fieldPath.Child("ciphers"))...)
+	allErrs = append(allErrs, validateSSLName(tls.SSLName, fieldPath.Child("sslName"))...)
+
+	return allErrs
+}
+
+// sslProtocols includes the protocols accepted by the ssl_protocols and proxy_ssl_protocols directives.
+var sslProtocols = map[string]bool{
+	"SSLv2":   true,
+	"SSLv3":   true,
+	"TLSv1":   true,
+	"TLSv1.1": true,
+	"TLSv1.2": true,
+	"TLSv1.3": true,
+}
+
+func validateSSLProtocols(protocols string, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for _, p := range strings.Fields(protocols) {
+		if !sslProtocols[p] {
+			allErrs = append(allErrs, field.Invalid(fieldPath, protocols, fmt.Sprintf("unsupported protocol %q. Accepted values: %s", p, mapToPrettyString(sslProtocols))))
+		}
+	}
+
+	return allErrs
+}
+
+const sslCiphersFmt = `[A-Za-z0-9!+@_.:-]+`
+
+var sslCiphersRegexp = regexp.MustCompile("^" + sslCiphersFmt + "$")
+
+func validateSSLCiphers(ciphers string, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if ciphers != "" && !sslCiphersRegexp.MatchString(ciphers) {
+		msg := validation.RegexError("must be a list of ciphers in the OpenSSL format", sslCiphersFmt, "HIGH:!aNULL:!MD5", "ECDHE-RSA-AES128-GCM-SHA256")
+		allErrs = append(allErrs, field.Invalid(fieldPath, ciphers, msg))
+	}
+
+	return allErrs
+}
+
+func validateTransportServerUpstreams(upstreams []v1.TransportServerUpstream, fieldPath *field.Path, isPlus bool, protocol string) (allErrs field.ErrorList, upstreamNames sets.String) {
 	allErrs = field.ErrorList{}
 	upstreamNames = sets.String{}
 
@@ -179,6 +272,8 @@ func validateTransportServerUpstreams(upstreams []v1alpha1.Upstream, fieldPath *
 		allErrs = append(allErrs, validateTSUpstreamHealthChecks(u.HealthCheck, idxPath.Child("healthChecks"))...)
 
 		allErrs = append(allErrs, validateLoadBalancingMethod(u.LoadBalancingMethod, idxPath.Child("loadBalancingMethod"), isPlus)...)
+
+		allErrs = append(allErrs, validateUpstreamTLS(u.TLS, idxPath.Child("tls"), protocol)...)
 	}
 
 	return allErrs, upstreamNames
@@ -257,7 +352,7 @@ func validateHashLoadBalancingMethod(method string, fieldPath *field.Path, isPlu
 	return allErrs
 }
 
-func validateTSUpstreamHealthChecks(hc *v1alpha1.HealthCheck, fieldPath *field.Path) field.ErrorList {
+func validateTSUpstreamHealthChecks(hc *v1.TransportServerHealthCheck, fieldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	if hc == nil {
@@ -281,7 +376,7 @@ func validateTSUpstreamHealthChecks(hc *v1alpha1.HealthCheck, fieldPath *field.P
 	return allErrs
 }
 
-func validateHealthCheckMatch(match *v1alpha1.Match, fieldPath *field.Path) field.ErrorList {
+func validateHealthCheckMatch(match *v1.TransportServerMatch, fieldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	if match == nil {
 		return allErrs
@@ -361,7 +456,7 @@ func validateHexString(s string) error {
 	return nil
 }
 
-func validateTransportServerUpstreamParameters(upstreamParameters *v1alpha1.UpstreamParameters, fieldPath *field.Path, protocol string) field.ErrorList {
+func validateTransportServerUpstreamParameters(upstreamParameters *v1.UpstreamParameters, fieldPath *field.Path, protocol string) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	if upstreamParameters == nil {
@@ -374,10 +469,14 @@ func validateTransportServerUpstreamParameters(upstreamParameters *v1alpha1.Upst
 	allErrs = append(allErrs, validateTime(upstreamParameters.NextUpstreamTimeout, fieldPath.Child("nextUpstreamTimeout"))...)
 	allErrs = append(allErrs, validatePositiveIntOrZero(upstreamParameters.NextUpstreamTries, fieldPath.Child("nextUpstreamTries"))...)
 
+	if upstreamParameters.ProxyProtocol && protocol == "UDP" {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("proxyProtocol"), "is not allowed for UDP TransportServers"))
+	}
+
 	return allErrs
 }
 
-func validateSessionParameters(sessionParameters *v1alpha1.SessionParameters, fieldPath *field.Path) field.ErrorList {
+func validateSessionParameters(sessionParameters *v1.SessionParameters, fieldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	if sessionParameters == nil {
@@ -399,12 +498,136 @@ func validateUDPUpstreamParameter(parameter *int, fieldPath *field.Path, protoco
 	return validatePositiveIntOrZeroFromPointer(parameter, fieldPath)
 }
 
-func validateTransportServerAction(action *v1alpha1.Action, fieldPath *field.Path, upstreamNames sets.String) field.ErrorList {
+func validateTransportServerAction(action *v1.TransportServerAction, fieldPath *field.Path, upstreamNames sets.String, protocol string) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if action.Pass != "" && len(action.Splits) > 0 {
+		return append(allErrs, field.Forbidden(fieldPath, "must specify exactly one of: pass or splits"))
+	}
+
+	if action.Pass != "" {
+		allErrs = append(allErrs, validateReferencedUpstream(action.Pass, fieldPath.Child("pass"), upstreamNames)...)
+	} else if len(action.Splits) > 0 {
+		allErrs = append(allErrs, validateTransportServerSplits(action.Splits, fieldPath.Child("splits"), upstreamNames)...)
+	} else {
+		return append(allErrs, field.Required(fieldPath, "must specify pass or splits"))
+	}
+
+	if len(action.Matches) > 0 {
+		allErrs = append(allErrs, validateTransportServerSNIMatches(action.Matches, fieldPath.Child("matches"), upstreamNames, protocol)...)
+	}
+
+	return allErrs
+}
+
+func validateTransportServerSplits(splits []v1.TransportServerSplit, fieldPath *field.Path, upstreamNames sets.String) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(splits) < 2 {
+		return append(allErrs, field.Invalid(fieldPath, "", "must include at least 2 splits"))
+	}
+
+	totalWeight := 0
+
+	for i, s := range splits {
+		idxPath := fieldPath.Index(i)
+
+		for _, msg := range validation.IsInRange(s.Weight, 1, 99) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("weight"), s.Weight, msg))
+		}
+
+		if s.Pass == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("pass"), ""))
+		} else {
+			allErrs = append(allErrs, validateReferencedUpstream(s.Pass, idxPath.Child("pass"), upstreamNames)...)
+		}
+
+		totalWeight += s.Weight
+	}
+
+	if totalWeight != 100 {
+		allErrs = append(allErrs, field.Invalid(fieldPath, "", "the sum of the weights of all splits must be equal to 100"))
+	}
+
+	return allErrs
+}
+
+func validateTransportServerSNIMatches(matches []v1.SNIMatch, fieldPath *field.Path, upstreamNames sets.String, protocol string) field.ErrorList {
 	allErrs := field.ErrorList{}
 
-	if action.Pass == "" {
-		return append(allErrs, field.Required(fieldPath, "must specify pass"))
+	// TLS Passthrough TransportServers are already selected by the SNI server name
+	if protocol != "TCP" {
+		return append(allErrs, field.Forbidden(fieldPath, "is not allowed for non-TCP TransportServers"))
 	}
 
-	return validateReferencedUpstream(action.Pass, fieldPath.Child("pass"), upstreamNames)
+	snis := sets.String{}
+
+	for i, m := range matches {
+		idxPath := fieldPath.Index(i)
+
+		sniErrs := validateHost(m.SNI, idxPath.Child("sni"))
+		if len(sniErrs) > 0 {
+			allErrs = append(allErrs, sniErrs...)
+		} else if snis.Has(m.SNI) {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("sni"), m.SNI))
+		} else {
+			snis.Insert(m.SNI)
+		}
+
+		if m.Pass == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("pass"), ""))
+		} else {
+			allErrs = append(allErrs, validateReferencedUpstream(m.Pass, idxPath.Child("pass"), upstreamNames)...)
+		}
+	}
+
+	return allErrs
+}
+
+// validateTransportServerActionUpstreams validates the upstreams referenced by the splits and matches of an action.
+// NGINX can't run health checks for the upstreams of a server that passes the connections to a variable.
+// The TLS configuration of the connections to the upstreams is defined in the server, so it must be the same for all of them.
+func validateTransportServerActionUpstreams(action *v1.TransportServerAction, upstreams []v1.TransportServerUpstream, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(action.Splits) == 0 && len(action.Matches) == 0 {
+		return allErrs
+	}
+
+	names := sets.NewString(action.Pass)
+	for _, s := range action.Splits {
+		names.Insert(s.Pass)
+	}
+	for _, m := range action.Matches {
+		names.Insert(m.Pass)
+	}
+
+	var tls *v1.TransportServerUpstreamTLS
+	tlsFound := false
+
+	for _, u := range upstreams {
+		if !names.Has(u.Name) {
+			continue
+		}
+
+		if u.HealthCheck != nil && u.HealthCheck.Enabled {
+			msg := fmt.Sprintf("health checks are not supported for upstreams of splits and matches, but upstream %s has health checks enabled", u.Name)
+			allErrs = append(allErrs, field.Forbidden(fieldPath, msg))
+		}
+
+		upstreamTLS := u.TLS
+		if upstreamTLS != nil && !upstreamTLS.Enable {
+			upstreamTLS = nil
+		}
+
+		if !tlsFound {
+			tls = upstreamTLS
+			tlsFound = true
+		} else if !reflect.DeepEqual(tls, upstreamTLS) {
+			msg := fmt.Sprintf("all upstreams of splits and matches must have the same tls, but upstream %s has a different tls", u.Name)
+			allErrs = append(allErrs, field.Forbidden(fieldPath, msg))
+		}
+	}
+
+	return allErrs
 }
@@ -3,7 +3,7 @@ package validation
 import (
 	"testing"
 
-	"github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
+	v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
@@ -13,22 +13,27 @@ func createTransportServerValidator() *TransportServerValidator {
 }
 
 func TestValidateTransportServer(t *testing.T) {
-	ts := v1alpha1.TransportServer{
-		Spec: v1alpha1.TransportServerSpec{
-			Listener: v1alpha1.TransportServerListener{
+	ts := v1.TransportServer{
+		Spec: v1.TransportServerSpec{
+			Listener: v1.TransportServerListener{
 				Name:     "tcp-listener",
 				Protocol: "TCP",
 			},
-			Upstreams: []v1alpha1.Upstream{
+			Upstreams: []v1.TransportServerUpstream{
 				{
 					Name:    "upstream1",
 					Service: "test-1",
 					Port:    5501,
 				},
 			},
-			Action: &v1alpha1.Action{
+			Action: &v1.TransportServerAction{
 				Pass: "upstream1",
 			},
+			Policies: []v1.PolicyReference{
+				{
+					Name: "allow-list",
+				},
+			},
 		},
 	}
 
@@ -41,13 +46,13 @@ func TestValidateTransportServer(t *testing.T) {
 }
 
 func TestValidateTransportServerFails(t *testing.T) {
-	ts := v1alpha1.TransportServer{
-		Spec: v1alpha1.TransportServerSpec{
-			Listener: v1alpha1.TransportServerListener{
+	ts := v1.TransportServer{
+		Spec: v1.TransportServerSpec{
+			Listener: v1.TransportServerListener{
 				Name:     "tcp-listener",
 				Protocol: "TCP",
 			},
-			Upstreams: []v1alpha1.Upstream{
+			Upstreams: []v1.TransportServerUpstream{
 				{
 					Name:    "upstream1",
 					Service: "test-1",
@@ -68,17 +73,17 @@ func TestValidateTransportServerFails(t *testing.T) {
 
 func TestValidateTransportServerUpstreams(t *testing.T) {
 	tests := []struct {
-		upstreams             []v1alpha1.Upstream
+		upstreams             []v1.TransportServerUpstream
 		expectedUpstreamNames sets.String
 		msg                   string
 	}{
 		{
-			upstreams:             []v1alpha1.Upstream{},
+			upstreams:             []v1.TransportServerUpstream{},
 			expectedUpstreamNames: sets.String{},
 			msg:                   "no upstreams",
 		},
 		{
-			upstreams: []v1alpha1.Upstream{
+			upstreams: []v1.TransportServerUpstream{
 				{
 					Name:    "upstream1",
 					Service: "test-1",
@@ -99,7 +104,7 @@ func TestValidateTransportServerUpstreams(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		allErrs, resultUpstreamNames := validateTransportServerUpstreams(test.upstreams, field.NewPath("upstreams"), true)
+		allErrs, resultUpstreamNames := validateTransportServerUpstreams(test.upstreams, field.NewPath("upstreams"), true, "TCP")
 		if len(allErrs) > 0 {
 			t.Errorf("validateTransportServerUpstreams() returned errors %v for valid input for the case of %s", allErrs, test.msg)
 		}
@@ -111,12 +116,12 @@ func TestValidateTransportServerUpstreams(t *testing.T) {
 
 func TestValidateTransportServerUpstreamsFails(t *testing.T) {
 	tests := []struct {
-		upstreams             []v1alpha1.Upstream
+		upstreams             []v1.TransportServerUpstream
 		expectedUpstreamNames sets.String
 		msg                   string
 	}{
 		{
-			upstreams: []v1alpha1.Upstream{
+			upstreams: []v1.TransportServerUpstream{
 				{
 					Name:    "@upstream1",
 					Service: "test-1",
@@ -127,7 +132,7 @@ func TestValidateTransportServerUpstreamsFails(t *testing.T) {
 			msg:                   "invalid upstream name",
 		},
 		{
-			upstreams: []v1alpha1.Upstream{
+			upstreams: []v1.TransportServerUpstream{
 				{
 					Name:    "upstream1",
 					Service: "@test-1",
@@ -140,7 +145,7 @@ func TestValidateTransportServerUpstreamsFails(t *testing.T) {
 			msg: "invalid service",
 		},
 		{
-			upstreams: []v1alpha1.Upstream{
+			upstreams: []v1.TransportServerUpstream{
 				{
 					Name:    "upstream1",
 					Service: "test-1",
@@ -153,7 +158,7 @@ func TestValidateTransportServerUpstreamsFails(t *testing.T) {
 			msg: "invalid port",
 		},
 		{
-			upstreams: []v1alpha1.Upstream{
+			upstreams: []v1.TransportServerUpstream{
 				{
 					Name:    "upstream1",
 					Service: "test-1",
@@ -173,7 +178,7 @@ func TestValidateTransportServerUpstreamsFails(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		allErrs, resultUpstreamNames := validateTransportServerUpstreams(test.upstreams, field.NewPath("upstreams"), true)
+		allErrs, resultUpstreamNames := validateTransportServerUpstreams(test.upstreams, field.NewPath("upstreams"), true, "TCP")
 		if len(allErrs) == 0 {
 			t.Errorf("validateTransportServerUpstreams() returned no errors for the case of %s", test.msg)
 		}
@@ -382,30 +387,37 @@ func TestValidateTransportServerHostFails(t *testing.T) {
 
 func TestValidateTransportListener(t *testing.T) {
 	tests := []struct {
-		listener       *v1alpha1.TransportServerListener
+		listener       *v1.TransportServerListener
 		tlsPassthrough bool
 	}{
 		{
-			listener: &v1alpha1.TransportServerListener{
+			listener: &v1.TransportServerListener{
 				Name:     "tcp-listener",
 				Protocol: "TCP",
 			},
 			tlsPassthrough: false,
 		},
 		{
-			listener: &v1alpha1.TransportServerListener{
+			listener: &v1.TransportServerListener{
 				Name:     "tcp-listener",
 				Protocol: "TCP",
 			},
 			tlsPassthrough: true,
 		},
 		{
-			listener: &v1alpha1.TransportServerListener{
+			listener: &v1.TransportServerListener{
 				Name:     "tls-passthrough",
 				Protocol: "TLS_PASSTHROUGH",
 			},
 			tlsPassthrough: true,
 		},
+		{
+			listener: &v1.TransportServerListener{
+				Name:     "tls-passthrough-8443",
+				Protocol: "TLS_PASSTHROUGH",
+			},
+			tlsPassthrough: true,
+		},
 	}
 
 	for _, test := range tests {
@@ -422,39 +434,39 @@ func TestValidateTransportListener(t *testing.T) {
 
 func TestValidateTransportListenerFails(t *testing.T) {
 	tests := []struct {
-		listener       *v1alpha1.TransportServerListener
+		listener       *v1.TransportServerListener
 		tlsPassthrough bool
 	}{
 		{
-			listener: &v1alpha1.TransportServerListener{
+			listener: &v1.TransportServerListener{
 				Name:     "tls-passthrough",
 				Protocol: "TLS_PASSTHROUGH",
 			},
 			tlsPassthrough: false,
 		},
 		{
-			listener: &v1alpha1.TransportServerListener{
+			listener: &v1.TransportServerListener{
 				Name:     "tls-passthrough",
 				Protocol: "abc",
 			},
 			tlsPassthrough: true,
 		},
 		{
-			listener: &v1alpha1.TransportServerListener{
+			listener: &v1.TransportServerListener{
 				Name:     "tls-passthrough",
 				Protocol: "abc",
 			},
 			tlsPassthrough: false,
 		},
 		{
-			listener: &v1alpha1.TransportServerListener{
-				Name:     "abc",
+			listener: &v1.TransportServerListener{
+				Name:     "-abc",
 				Protocol: "TLS_PASSTHROUGH",
 			},
 			tlsPassthrough: true,
 		},
 		{
-			listener: &v1alpha1.TransportServerListener{
+			listener: &v1.TransportServerListener{
 				Name:     "abc",
 				Protocol: "TLS_PASSTHROUGH",
 			},
@@ -476,25 +488,25 @@ func TestValidateTransportListenerFails(t *testing.T) {
 
 func TestValidateIsPotentialTLSPassthroughListener(t *testing.T) {
 	tests := []struct {
-		listener *v1alpha1.TransportServerListener
+		listener *v1.TransportServerListener
 		expected bool
 	}{
 		{
-			listener: &v1alpha1.TransportServerListener{
+			listener: &v1.TransportServerListener{
 				Name:     "tls-passthrough",
 				Protocol: "abc",
 			},
 			expected: true,
 		},
 		{
-			listener: &v1alpha1.TransportServerListener{
+			listener: &v1.TransportServerListener{
 				Name:     "abc",
 				Protocol: "TLS_PASSTHROUGH",
 			},
 			expected: true,
 		},
 		{
-			listener: &v1alpha1.TransportServerListener{
+			listener: &v1.TransportServerListener{
 				Name:     "tcp",
 				Protocol: "TCP",
 			},
@@ -540,7 +552,7 @@ func TestValidateListenerProtocol(t *testing.T) {
 
 func TestValidateTSUpstreamHealthChecks(t *testing.T) {
 	tests := []struct {
-		healthCheck *v1alpha1.HealthCheck
+		healthCheck *v1.TransportServerHealthCheck
 		msg         string
 	}{
 		{
@@ -548,11 +560,11 @@ func TestValidateTSUpstreamHealthChecks(t *testing.T) {
 			msg:         "nil health check",
 		},
 		{
-			healthCheck: &v1alpha1.HealthCheck{},
+			healthCheck: &v1.TransportServerHealthCheck{},
 			msg:         "non nil health check",
 		},
 		{
-			healthCheck: &v1alpha1.HealthCheck{
+			healthCheck: &v1.TransportServerHealthCheck{
 				Enabled:  true,
 				Timeout:  "30s",
 				Jitter:   "5s",
@@ -574,11 +586,11 @@ func TestValidateTSUpstreamHealthChecks(t *testing.T) {
 
 func TestValidateTSUpstreamHealthChecksFails(t *testing.T) {
 	tests := []struct {
-		healthCheck *v1alpha1.HealthCheck
+		healthCheck *v1.TransportServerHealthCheck
 		msg         string
 	}{
 		{
-			healthCheck: &v1alpha1.HealthCheck{
+			healthCheck: &v1.TransportServerHealthCheck{
 				Enabled:  true,
 				Timeout:  "-30s",
 				Jitter:   "5s",
@@ -590,7 +602,7 @@ func TestValidateTSUpstreamHealthChecksFails(t *testing.T) {
 			msg: "invalid timeout",
 		},
 		{
-			healthCheck: &v1alpha1.HealthCheck{
+			healthCheck: &v1.TransportServerHealthCheck{
 				Enabled:  true,
 				Timeout:  "30s",
 				Jitter:   "5s",
@@ -602,7 +614,7 @@ func TestValidateTSUpstreamHealthChecksFails(t *testing.T) {
 			msg: "invalid port number",
 		},
 		{
-			healthCheck: &v1alpha1.HealthCheck{
+			healthCheck: &v1.TransportServerHealthCheck{
 				Enabled:  true,
 				Timeout:  "30s",
 				Jitter:   "5s",
@@ -614,7 +626,7 @@ func TestValidateTSUpstreamHealthChecksFails(t *testing.T) {
 			msg: "invalid passes value",
 		},
 		{
-			healthCheck: &v1alpha1.HealthCheck{
+			healthCheck: &v1.TransportServerHealthCheck{
 				Enabled:  true,
 				Timeout:  "30s",
 				Jitter:   "5s",
@@ -626,7 +638,7 @@ func TestValidateTSUpstreamHealthChecksFails(t *testing.T) {
 			msg: "invalid fails value",
 		},
 		{
-			healthCheck: &v1alpha1.HealthCheck{
+			healthCheck: &v1.TransportServerHealthCheck{
 				Enabled:  true,
 				Timeout:  "30s",
 				Jitter:   "5s",
@@ -638,7 +650,7 @@ func TestValidateTSUpstreamHealthChecksFails(t *testing.T) {
 			msg: "invalid interval value",
 		},
 		{
-			healthCheck: &v1alpha1.HealthCheck{
+			healthCheck: &v1.TransportServerHealthCheck{
 				Enabled:  true,
 				Timeout:  "30s",
 				Jitter:   "5sec",
@@ -661,7 +673,7 @@ func TestValidateTSUpstreamHealthChecksFails(t *testing.T) {
 
 func TestValidateUpstreamParameters(t *testing.T) {
 	tests := []struct {
-		parameters *v1alpha1.UpstreamParameters
+		parameters *v1.UpstreamParameters
 		msg        string
 	}{
 		{
@@ -669,7 +681,7 @@ func TestValidateUpstreamParameters(t *testing.T) {
 			msg:        "nil parameters",
 		},
 		{
-			parameters: &v1alpha1.UpstreamParameters{},
+			parameters: &v1.UpstreamParameters{},
 			msg:        "Non-nil parameters",
 		},
 	}
@@ -682,9 +694,27 @@ func TestValidateUpstreamParameters(t *testing.T) {
 	}
 }
 
+func TestValidateUpstreamParametersProxyProtocol(t *testing.T) {
+	parameters := &v1.UpstreamParameters{
+		ProxyProtocol: true,
+	}
+
+	for _, protocol := range []string{"TCP", "TLS_PASSTHROUGH"} {
+		allErrs := validateTransportServerUpstreamParameters(parameters, field.NewPath("upstreamParameters"), protocol)
+		if len(allErrs) > 0 {
+			t.Errorf("validateTransportServerUpstreamParameters() returned errors %v for valid input for the protocol %s", allErrs, protocol)
+		}
+	}
+
+	allErrs := validateTransportServerUpstreamParameters(parameters, field.NewPath("upstreamParameters"), "UDP")
+	if len(allErrs) == 0 {
+		t.Error("validateTransportServerUpstreamParameters() returned no errors for invalid input for the protocol UDP")
+	}
+}
+
 func TestValidateSessionParameters(t *testing.T) {
 	tests := []struct {
-		parameters *v1alpha1.SessionParameters
+		parameters *v1.SessionParameters
 		msg        string
 	}{
 		{
@@ -692,11 +722,11 @@ func TestValidateSessionParameters(t *testing.T) {
 			msg:        "nil parameters",
 		},
 		{
-			parameters: &v1alpha1.SessionParameters{},
+			parameters: &v1.SessionParameters{},
 			msg:        "Non-nil parameters",
 		},
 		{
-			parameters: &v1alpha1.SessionParameters{
+			parameters: &v1.SessionParameters{
 				Timeout: "60s",
 			},
 			msg: "valid parameters",
@@ -713,11 +743,11 @@ func TestValidateSessionParameters(t *testing.T) {
 
 func TestValidateSessionParametersFails(t *testing.T) {
 	tests := []struct {
-		parameters *v1alpha1.SessionParameters
+		parameters *v1.SessionParameters
 		msg        string
 	}{
 		{
-			parameters: &v1alpha1.SessionParameters{
+			parameters: &v1.SessionParameters{
 				Timeout: "-1s",
 			},
 			msg: "invalid timeout",
@@ -786,50 +816,446 @@ func TestValidateUDPUpstreamParameterFails(t *testing.T) {
 	}
 }
 
+func TestValidateTransportServerTLS(t *testing.T) {
+	validInput := []struct {
+		tls      *v1.TransportServerTLS
+		protocol string
+	}{
+		{
+			tls:      nil,
+			protocol: "UDP",
+		},
+		{
+			tls: &v1.TransportServerTLS{
+				Secret: "postgres-secret",
+			},
+			protocol: "TCP",
+		},
+		{
+			tls: &v1.TransportServerTLS{
+				Secret:    "postgres-secret",
+				Protocols: "TLSv1.2 TLSv1.3",
+				Ciphers:   "HIGH:!aNULL:!MD5",
+			},
+			protocol: "TCP",
+		},
+	}
+
+	for _, input := range validInput {
+		allErrs := validateTransportServerTLS(input.tls, field.NewPath("tls"), input.protocol)
+		if len(allErrs) > 0 {
+			t.Errorf("validateTransportServerTLS(%+v, %q) returned errors %v for valid input", input.tls, input.protocol, allErrs)
+		}
+	}
+}
+
+func TestValidateTransportServerTLSFails(t *testing.T) {
+	invalidInput := []struct {
+		tls      *v1.TransportServerTLS
+		protocol string
+		msg      string
+	}{
+		{
+			tls: &v1.TransportServerTLS{
+				Secret: "postgres-secret",
+			},
+			protocol: "UDP",
+			msg:      "udp listener",
+		},
+		{
+			tls: &v1.TransportServerTLS{
+				Secret: "postgres-secret",
+			},
+			protocol: "TLS_PASSTHROUGH",
+			msg:      "tls passthrough listener",
+		},
+		{
+			tls:      &v1.TransportServerTLS{},
+			protocol: "TCP",
+			msg:      "missing secret",
+		},
+		{
+			tls: &v1.TransportServerTLS{
+				Secret: "-invalid",
+			},
+			protocol: "TCP",
+			msg:      "invalid secret name",
+		},
+		{
+			tls: &v1.TransportServerTLS{
+				Secret:    "postgres-secret",
+				Protocols: "TLSv1.2 TLSv2",
+			},
+			protocol: "TCP",
+			msg:      "invalid protocol",
+		},
+		{
+			tls: &v1.TransportServerTLS{
+				Secret:  "postgres-secret",
+				Ciphers: "HIGH; ssl_verify_client off",
+			},
+			protocol: "TCP",
+			msg:      "invalid ciphers",
+		},
+	}
+
+	for _, input := range invalidInput {
+		allErrs := validateTransportServerTLS(input.tls, field.NewPath("tls"), input.protocol)
+		if len(allErrs) == 0 {
+			t.Errorf("validateTransportServerTLS() returned no errors for invalid input for the case of %s", input.msg)
+		}
+	}
+}
+
+func TestValidateUpstreamTLS(t *testing.T) {
+	validInput := []struct {
+		tls      *v1.TransportServerUpstreamTLS
+		protocol string
+	}{
+		{
+			tls:      nil,
+			protocol: "UDP",
+		},
+		{
+			tls: &v1.TransportServerUpstreamTLS{
+				Enable: false,
+			},
+			protocol: "UDP",
+		},
+		{
+			tls: &v1.TransportServerUpstreamTLS{
+				Enable: true,
+			},
+			protocol: "TCP",
+		},
+		{
+			tls: &v1.TransportServerUpstreamTLS{
+				Enable:            true,
+				VerifyServer:      true,
+				TrustedCertSecret: "postgres-ca",
+				VerifyDepth:       createPointerFromInt(2),
+				Protocols:         "TLSv1.3",
+				Ciphers:           "ECDHE-RSA-AES128-GCM-SHA256",
+				ServerName:        true,
+				SSLName:           "postgres.example.com",
+			},
+			protocol: "TCP",
+		},
+	}
+
+	for _, input := range validInput {
+		allErrs := validateUpstreamTLS(input.tls, field.NewPath("tls"), input.protocol)
+		if len(allErrs) > 0 {
+			t.Errorf("validateUpstreamTLS(%+v, %q) returned errors %v for valid input", input.tls, input.protocol, allErrs)
+		}
+	}
+}
+
+func TestValidateUpstreamTLSFails(t *testing.T) {
+	invalidInput := []struct {
+		tls      *v1.TransportServerUpstreamTLS
+		protocol string
+		msg      string
+	}{
+		{
+			tls: &v1.TransportServerUpstreamTLS{
+				Enable: true,
+			},
+			protocol: "UDP",
+			msg:      "udp listener",
+		},
+		{
+			tls: &v1.TransportServerUpstreamTLS{
+				Enable:       true,
+				VerifyServer: true,
+			},
+			protocol: "TCP",
+			msg:      "verifyServer without trustedCertSecret",
+		},
+		{
+			tls: &v1.TransportServerUpstreamTLS{
+				Enable:      true,
+				VerifyDepth: createPointerFromInt(-1),
+			},
+			protocol: "TCP",
+			msg:      "invalid verifyDepth",
+		},
+		{
+			tls: &v1.TransportServerUpstreamTLS{
+				Enable:  true,
+				SSLName: "$host",
+			},
+			protocol: "TCP",
+			msg:      "invalid sslName",
+		},
+	}
+
+	for _, input := range invalidInput {
+		allErrs := validateUpstreamTLS(input.tls, field.NewPath("tls"), input.protocol)
+		if len(allErrs) == 0 {
+			t.Errorf("validateUpstreamTLS() returned no errors for invalid input for the case of %s", input.msg)
+		}
+	}
+}
+
 func TestValidateTransportServerAction(t *testing.T) {
 	upstreamNames := map[string]sets.Empty{
-		"test": {},
+		"test":    {},
+		"test-v2": {},
 	}
 
-	action := &v1alpha1.Action{
-		Pass: "test",
+	actions := []*v1.TransportServerAction{
+		{
+			Pass: "test",
+		},
+		{
+			Splits: []v1.TransportServerSplit{
+				{
+					Weight: 90,
+					Pass:   "test",
+				},
+				{
+					Weight: 10,
+					Pass:   "test-v2",
+				},
+			},
+		},
+		{
+			Pass: "test",
+			Matches: []v1.SNIMatch{
+				{
+					SNI:  "v2.example.com",
+					Pass: "test-v2",
+				},
+			},
+		},
 	}
 
-	allErrs := validateTransportServerAction(action, field.NewPath("action"), upstreamNames)
-	if len(allErrs) > 0 {
-		t.Errorf("validateTransportServerAction() returned errors %v for valid input", allErrs)
+	for _, action := range actions {
+		allErrs := validateTransportServerAction(action, field.NewPath("action"), upstreamNames, "TCP")
+		if len(allErrs) > 0 {
+			t.Errorf("validateTransportServerAction() returned errors %v for valid input %+v", allErrs, action)
+		}
 	}
 }
 
 func TestValidateTransportServerActionFails(t *testing.T) {
-	upstreamNames := map[string]sets.Empty{}
+	upstreamNames := map[string]sets.Empty{
+		"test":    {},
+		"test-v2": {},
+	}
 
 	tests := []struct {
-		action *v1alpha1.Action
-		msg    string
+		action   *v1.TransportServerAction
+		protocol string
+		msg      string
 	}{
 		{
-			action: &v1alpha1.Action{
+			action: &v1.TransportServerAction{
 				Pass: "",
 			},
-			msg: "missing pass field",
+			protocol: "TCP",
+			msg:      "missing pass field",
 		},
 		{
-			action: &v1alpha1.Action{
+			action: &v1.TransportServerAction{
 				Pass: "non-existing",
 			},
-			msg: "pass references a non-existing upstream",
+			protocol: "TCP",
+			msg:      "pass references a non-existing upstream",
+		},
+		{
+			action: &v1.TransportServerAction{
+				Pass: "test",
+				Splits: []v1.TransportServerSplit{
+					{
+						Weight: 50,
+						Pass:   "test",
+					},
+					{
+						Weight: 50,
+						Pass:   "test-v2",
+					},
+				},
+			},
+			protocol: "TCP",
+			msg:      "both pass and splits",
+		},
+		{
+			action: &v1.TransportServerAction{
+				Splits: []v1.TransportServerSplit{
+					{
+						Weight: 100,
+						Pass:   "test",
+					},
+				},
+			},
+			protocol: "TCP",
+			msg:      "only one split",
+		},
+		{
+			action: &v1.TransportServerAction{
+				Splits: []v1.TransportServerSplit{
+					{
+						Weight: 50,
+						Pass:   "test",
+					},
+					{
+						Weight: 40,
+						Pass:   "test-v2",
+					},
+				},
+			},
+			protocol: "TCP",
+			msg:      "sum of weights is not 100",
+		},
+		{
+			action: &v1.TransportServerAction{
+				Splits: []v1.TransportServerSplit{
+					{
+						Weight: 50,
+						Pass:   "test",
+					},
+					{
+						Weight: 50,
+						Pass:   "non-existing",
+					},
+				},
+			},
+			protocol: "TCP",
+			msg:      "split references a non-existing upstream",
+		},
+		{
+			action: &v1.TransportServerAction{
+				Pass: "test",
+				Matches: []v1.SNIMatch{
+					{
+						SNI:  "v2.example.com",
+						Pass: "test-v2",
+					},
+				},
+			},
+			protocol: "UDP",
+			msg:      "matches for UDP",
+		},
+		{
+			action: &v1.TransportServerAction{
+				Pass: "test",
+				Matches: []v1.SNIMatch{
+					{
+						SNI:  "v2.example.com",
+						Pass: "test-v2",
+					},
+					{
+						SNI:  "v2.example.com",
+						Pass: "test",
+					},
+				},
+			},
+			protocol: "TCP",
+			msg:      "duplicated sni",
+		},
+		{
+			action: &v1.TransportServerAction{
+				Pass: "test",
+				Matches: []v1.SNIMatch{
+					{
+						SNI:  "",
+						Pass: "test-v2",
+					},
+				},
+			},
+			protocol: "TCP",
+			msg:      "missing sni",
+		},
+		{
+			action: &v1.TransportServerAction{
+				Pass: "test",
+				Matches: []v1.SNIMatch{
+					{
+						SNI:  "v2.example.com",
+						Pass: "",
+					},
+				},
+			},
+			protocol: "TCP",
+			msg:      "missing pass of a match",
 		},
 	}
 
 	for _, test := range tests {
-		allErrs := validateTransportServerAction(test.action, field.NewPath("action"), upstreamNames)
+		allErrs := validateTransportServerAction(test.action, field.NewPath("action"), upstreamNames, test.protocol)
 		if len(allErrs) == 0 {
 			t.Errorf("validateTransportServerAction() returned no errors for invalid input for the case of %s", test.msg)
 		}
 	}
 }
 
+func TestValidateTransportServerActionUpstreams(t *testing.T) {
+	action := &v1.TransportServerAction{
+		Splits: []v1.TransportServerSplit{
+			{
+				Weight: 90,
+				Pass:   "test",
+			},
+			{
+				Weight: 10,
+				Pass:   "test-v2",
+			},
+		},
+	}
+
+	validUpstreams := [][]v1.TransportServerUpstream{
+		{
+			{Name: "test"},
+			{Name: "test-v2"},
+		},
+		{
+			{Name: "test", TLS: &v1.TransportServerUpstreamTLS{Enable: true}},
+			{Name: "test-v2", TLS: &v1.TransportServerUpstreamTLS{Enable: true}},
+			{Name: "unused", HealthCheck: &v1.TransportServerHealthCheck{Enabled: true}},
+		},
+		{
+			{Name: "test", TLS: &v1.TransportServerUpstreamTLS{Enable: false}},
+			{Name: "test-v2"},
+		},
+	}
+
+	for _, upstreams := range validUpstreams {
+		allErrs := validateTransportServerActionUpstreams(action, upstreams, field.NewPath("action"))
+		if len(allErrs) > 0 {
+			t.Errorf("validateTransportServerActionUpstreams() returned errors %v for valid input %+v", allErrs, upstreams)
+		}
+	}
+
+	invalidUpstreams := []struct {
+		upstreams []v1.TransportServerUpstream
+		msg       string
+	}{
+		{
+			upstreams: []v1.TransportServerUpstream{
+				{Name: "test", HealthCheck: &v1.TransportServerHealthCheck{Enabled: true}},
+				{Name: "test-v2"},
+			},
+			msg: "health checks are enabled",
+		},
+		{
+			upstreams: []v1.TransportServerUpstream{
+				{Name: "test", TLS: &v1.TransportServerUpstreamTLS{Enable: true}},
+				{Name: "test-v2"},
+			},
+			msg: "different tls",
+		},
+	}
+
+	for _, test := range invalidUpstreams {
+		allErrs := validateTransportServerActionUpstreams(action, test.upstreams, field.NewPath("action"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateTransportServerActionUpstreams() returned no errors for invalid input for the case of %s", test.msg)
+		}
+	}
+}
+
 func TestValidateMatchSend(t *testing.T) {
 	validInput := []string{
 		"",
@@ -15,15 +15,17 @@ import (
 
 // VirtualServerValidator validates a VirtualServer/VirtualServerRoute resource.
 type VirtualServerValidator struct {
-	isPlus       bool
-	isDosEnabled bool
+	isPlus                  bool
+	isDosEnabled            bool
+	isFaultInjectionEnabled bool
 }
 
 // NewVirtualServerValidator creates a new VirtualServerValidator.
-func NewVirtualServerValidator(isPlus bool, isDosEnabled bool) *VirtualServerValidator {
+func NewVirtualServerValidator(isPlus bool, isDosEnabled bool, isFaultInjectionEnabled bool) *VirtualServerValidator {
 	return &VirtualServerValidator{
-		isPlus:       isPlus,
-		isDosEnabled: isDosEnabled,
+		isPlus:                  isPlus,
+		isDosEnabled:            isDosEnabled,
+		isFaultInjectionEnabled: isFaultInjectionEnabled,
 	}
 }
 
@@ -45,6 +47,7 @@ func (vsv *VirtualServerValidator) validateVirtualServerSpec(spec *v1.VirtualSer
 	allErrs = append(allErrs, upstreamErrs...)
 
 	allErrs = append(allErrs, vsv.validateVirtualServerRoutes(spec.Routes, fieldPath.Child("routes"), upstreamNames, namespace)...)
+	allErrs = append(allErrs, validateMirrorUpstreams(spec.Routes, spec.Upstreams, fieldPath.Child("routes"))...)
 
 	allErrs = append(allErrs, validateDos(vsv.isDosEnabled, spec.Dos, fieldPath.Child("dos"))...)
 
@@ -644,6 +647,10 @@ func (vsv *VirtualServerValidator) validateRoute(route v1.Route, fieldPath *fiel
 		fieldCount++
 	}
 
+	if route.Canary != nil {
+		allErrs = append(allErrs, validateCanary(route.Canary, route.Splits, fieldPath.Child("canary"))...)
+	}
+
 	// Matches are optional. that's why we don't do fieldCount++
 	if len(route.Matches) > 0 {
 		for i, m := range route.Matches {
@@ -847,6 +854,59 @@ func (vsv *VirtualServerValidator) validateAction(action *v1.Action, fieldPath *
 		allErrs = append(allErrs, vsv.validateActionProxy(action.Proxy, fieldPath.Child("proxy"), upstreamNames, path, internal)...)
 	}
 
+	if action.Fault != nil {
+		allErrs = append(allErrs, vsv.validateActionFault(action, fieldPath.Child("fault"), internal)...)
+	}
+
+	return allErrs
+}
+
+func (vsv *VirtualServerValidator) validateActionFault(action *v1.Action, fieldPath *field.Path, internal bool) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if !vsv.isFaultInjectionEnabled {
+		return append(allErrs, field.Forbidden(fieldPath, "fault injection must be enabled via cli argument -enable-fault-injection"))
+	}
+
+	if internal {
+		return append(allErrs, field.Forbidden(fieldPath, "is not supported in the actions of splits and matches"))
+	}
+
+	if action.Pass == "" && action.Proxy == nil {
+		return append(allErrs, field.Forbidden(fieldPath, "is only supported with the `pass` or `proxy` action"))
+	}
+
+	fault := action.Fault
+
+	if fault.Delay == "" && fault.Abort == nil {
+		allErrs = append(allErrs, field.Required(fieldPath, "must specify at least one of `delay` or `abort`"))
+	}
+
+	if fault.Delay != "" {
+		allErrs = append(allErrs, validateTime(fault.Delay, fieldPath.Child("delay"))...)
+	}
+
+	if fault.Abort != nil {
+		code := fault.Abort.Code
+		if code < 400 || code > 599 {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("abort").Child("code"), code, "must be within the range [400-599]"))
+		}
+	}
+
+	if fault.Percentage != nil && (*fault.Percentage < 1 || *fault.Percentage > 100) {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("percentage"), *fault.Percentage, "must be within the range [1-100]"))
+	}
+
+	if fault.Header != nil {
+		headerPath := fieldPath.Child("header")
+		for _, msg := range validation.IsHTTPHeaderName(fault.Header.Name) {
+			allErrs = append(allErrs, field.Invalid(headerPath.Child("name"), fault.Header.Name, msg))
+		}
+		for _, msg := range isValidMatchValue(fault.Header.Value) {
+			allErrs = append(allErrs, field.Invalid(headerPath.Child("value"), fault.Header.Value, msg))
+		}
+	}
+
 	return allErrs
 }
 
@@ -987,6 +1047,10 @@ func (vsv *VirtualServerValidator) validateActionProxy(p *v1.ActionProxy, fieldP
 	allErrs = append(allErrs, vsv.validateActionProxyRequestHeaders(p.RequestHeaders, fieldPath.Child("requestHeaders"))...)
 	allErrs = append(allErrs, vsv.validateActionProxyResponseHeaders(p.ResponseHeaders, fieldPath.Child("responseHeaders"))...)
 
+	if p.Mirror != nil {
+		allErrs = append(allErrs, validateActionProxyMirror(p.Mirror, p.Upstream, fieldPath.Child("mirror"), upstreamNames)...)
+	}
+
 	if strings.HasPrefix(path, "~") || internal {
 		allErrs = append(allErrs, validateActionProxyRewritePathForRegexp(p.RewritePath, fieldPath.Child("rewritePath"))...)
 	} else {
@@ -996,6 +1060,68 @@ func (vsv *VirtualServerValidator) validateActionProxy(p *v1.ActionProxy, fieldP
 	return allErrs
 }
 
+func validateActionProxyMirror(mirror *v1.ProxyMirror, proxyUpstream string, fieldPath *field.Path, upstreamNames sets.String) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, validateReferencedUpstream(mirror.Upstream, fieldPath.Child("upstream"), upstreamNames)...)
+	if mirror.Upstream == proxyUpstream {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("upstream"), mirror.Upstream, "must be different from the upstream of the proxy action"))
+	}
+
+	if mirror.Percentage != nil && (*mirror.Percentage < 1 || *mirror.Percentage > 100) {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("percentage"), *mirror.Percentage, "must be within the range [1-100]"))
+	}
+
+	return allErrs
+}
+
+// validateMirrorUpstreams validates that the proxy actions of the routes don't mirror requests to gRPC upstreams,
+// because NGINX can only mirror requests to HTTP upstreams.
+func validateMirrorUpstreams(routes []v1.Route, upstreams []v1.Upstream, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	grpcUpstreams := sets.NewString()
+	for _, u := range upstreams {
+		if u.Type == "grpc" {
+			grpcUpstreams.Insert(u.Name)
+		}
+	}
+
+	if grpcUpstreams.Len() == 0 {
+		return allErrs
+	}
+
+	validateAction := func(action *v1.Action, actionPath *field.Path) {
+		if action == nil || action.Proxy == nil || action.Proxy.Mirror == nil {
+			return
+		}
+		if grpcUpstreams.Has(action.Proxy.Mirror.Upstream) {
+			allErrs = append(allErrs, field.Invalid(actionPath.Child("proxy", "mirror", "upstream"), action.Proxy.Mirror.Upstream, "must not be a gRPC upstream"))
+		}
+	}
+
+	validateSplits := func(splits []v1.Split, splitsPath *field.Path) {
+		for i, s := range splits {
+			validateAction(s.Action, splitsPath.Index(i).Child("action"))
+		}
+	}
+
+	for i, r := range routes {
+		idxPath := fieldPath.Index(i)
+
+		validateAction(r.Action, idxPath.Child("action"))
+		validateSplits(r.Splits, idxPath.Child("splits"))
+
+		for j, m := range r.Matches {
+			matchPath := idxPath.Child("matches").Index(j)
+			validateAction(m.Action, matchPath.Child("action"))
+			validateSplits(m.Splits, matchPath.Child("splits"))
+		}
+	}
+
+	return allErrs
+}
+
 func validateStringNoVariables(s string, fieldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -1207,6 +1333,47 @@ func (vsv *VirtualServerValidator) validateSplits(splits []v1.Split, fieldPath *
 	return allErrs
 }
 
+func validateCanary(canary *v1.Canary, splits []v1.Split, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(splits) != 2 {
+		allErrs = append(allErrs, field.Forbidden(fieldPath, "requires the route to have exactly 2 splits"))
+	} else if action := splits[1].Action; action != nil && action.Pass == "" && action.Proxy == nil {
+		allErrs = append(allErrs, field.Forbidden(fieldPath, "requires the action of the second split to be `pass` or `proxy`"))
+	}
+
+	if len(canary.Steps) == 0 {
+		allErrs = append(allErrs, field.Required(fieldPath.Child("steps"), ""))
+	}
+
+	for i, step := range canary.Steps {
+		idxPath := fieldPath.Child("steps").Index(i)
+		for _, msg := range validation.IsInRange(step, 1, 99) {
+			allErrs = append(allErrs, field.Invalid(idxPath, step, msg))
+		}
+		if i > 0 && step <= canary.Steps[i-1] {
+			allErrs = append(allErrs, field.Invalid(idxPath, step, "must be greater than the previous step"))
+		}
+	}
+
+	if canary.Interval == "" {
+		allErrs = append(allErrs, field.Required(fieldPath.Child("interval"), ""))
+	} else {
+		allErrs = append(allErrs, validateTime(canary.Interval, fieldPath.Child("interval"))...)
+	}
+
+	if canary.MaxErrorRate != nil {
+		for _, msg := range validation.IsInRange(*canary.MaxErrorRate, 0, 100) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("maxErrorRate"), *canary.MaxErrorRate, msg))
+		}
+	}
+
+	allErrs = append(allErrs, validateTime(canary.MaxLatencyP99, fieldPath.Child("maxLatencyP99"))...)
+	allErrs = append(allErrs, validatePositiveIntOrZero(canary.MinRequests, fieldPath.Child("minRequests"))...)
+
+	return allErrs
+}
+
 // We support prefix-based NGINX locations, positive case-sensitive/insensitive regular expressions matches and exact matches.
 // More info http://nginx.org/en/docs/http/ngx_http_core_module.html#location
 func validateRoutePath(path string, fieldPath *field.Path) field.ErrorList {
@@ -1290,7 +1457,7 @@ func validateGrpcService(service string, fieldPath *field.Path) field.ErrorList
 func (vsv *VirtualServerValidator) validateMatch(match v1.Match, fieldPath *field.Path, upstreamNames sets.String, path string) field.ErrorList {
 	allErrs := field.ErrorList{}
 
-	if len(match.Conditions) == 0 {
+	if len(match.Conditions) == 0 && len(match.AnyOf) == 0 {
 		allErrs = append(allErrs, field.Required(fieldPath.Child("conditions"), "must specify at least one condition"))
 	} else {
 		for i, c := range match.Conditions {
@@ -1298,6 +1465,16 @@ func (vsv *VirtualServerValidator) validateMatch(match v1.Match, fieldPath *fiel
 		}
 	}
 
+	for i, g := range match.AnyOf {
+		groupPath := fieldPath.Child("anyOf").Index(i)
+		if len(g.Conditions) == 0 {
+			allErrs = append(allErrs, field.Required(groupPath.Child("conditions"), "must specify at least one condition"))
+		}
+		for j, c := range g.Conditions {
+			allErrs = append(allErrs, validateCondition(c, groupPath.Child("conditions").Index(j))...)
+		}
+	}
+
 	fieldCount := 0
 
 	if match.Action != nil {
@@ -1348,14 +1525,106 @@ func validateCondition(condition v1.Condition, fieldPath *field.Path) field.Erro
 		fieldCount++
 	}
 
+	if len(condition.Method) > 0 {
+		allErrs = append(allErrs, validateConditionMethod(condition, fieldPath)...)
+		fieldCount++
+	}
+
 	if fieldCount != 1 {
-		allErrs = append(allErrs, field.Invalid(fieldPath, "", "must specify exactly one of: `header`, `cookie`, `argument` or `variable`"))
+		allErrs = append(allErrs, field.Invalid(fieldPath, "", "must specify exactly one of: `header`, `cookie`, `argument`, `variable` or `method`"))
+	}
+
+	if len(condition.Method) == 0 {
+		allErrs = append(allErrs, validateConditionValue(condition, fieldPath)...)
+	}
+
+	return allErrs
+}
+
+var conditionOperators = map[string]bool{
+	"exact":   true,
+	"prefix":  true,
+	"suffix":  true,
+	"regex":   true,
+	"present": true,
+	"absent":  true,
+}
+
+func validateConditionValue(condition v1.Condition, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	operator := condition.Operator
+	if operator == "" {
+		operator = "exact"
+	}
+
+	if !conditionOperators[operator] {
+		return append(allErrs, field.Invalid(fieldPath.Child("operator"), condition.Operator, fmt.Sprintf("Accepted values: %s", mapToPrettyString(conditionOperators))))
+	}
+
+	if operator == "present" || operator == "absent" {
+		if condition.Value != "" {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("value"), fmt.Sprintf("is not supported with the `%s` operator", operator)))
+		}
+		if condition.IgnoreCase {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("ignoreCase"), fmt.Sprintf("is not supported with the `%s` operator", operator)))
+		}
+		return allErrs
 	}
 
 	for _, msg := range isValidMatchValue(condition.Value) {
 		allErrs = append(allErrs, field.Invalid(fieldPath.Child("value"), condition.Value, msg))
 	}
 
+	if operator == "exact" {
+		// the strings of NGINX maps are always compared case-insensitively
+		if condition.IgnoreCase {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("ignoreCase"), "is not supported with the `exact` operator"))
+		}
+		return allErrs
+	}
+
+	value := strings.TrimPrefix(condition.Value, "!")
+	if value == "" {
+		return append(allErrs, field.Required(fieldPath.Child("value"), fmt.Sprintf("is required with the `%s` operator", operator)))
+	}
+
+	if operator == "regex" {
+		if _, err := regexp.Compile(value); err != nil {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("value"), condition.Value, fmt.Sprintf("must be a valid regular expression: %v", err)))
+		}
+	}
+
+	return allErrs
+}
+
+const (
+	methodFmt    = "[A-Z]+"
+	methodErrMsg = "a valid method must consist of uppercase letters"
+)
+
+var methodRegexp = regexp.MustCompile("^" + methodFmt + "$")
+
+func validateConditionMethod(condition v1.Condition, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i, m := range condition.Method {
+		if !methodRegexp.MatchString(m) {
+			msg := validation.RegexError(methodErrMsg, methodFmt, "GET", "POST")
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("method").Index(i), m, msg))
+		}
+	}
+
+	if condition.Value != "" {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("value"), "is not supported with `method`"))
+	}
+	if condition.Operator != "" {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("operator"), "is not supported with `method`"))
+	}
+	if condition.IgnoreCase {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("ignoreCase"), "is not supported with `method`"))
+	}
+
 	return allErrs
 }
 
@@ -1447,6 +1716,7 @@ func (vsv *VirtualServerValidator) validateVirtualServerRouteSpec(spec *v1.Virtu
 	allErrs = append(allErrs, upstreamErrs...)
 
 	allErrs = append(allErrs, vsv.validateVirtualServerRouteSubroutes(spec.Subroutes, fieldPath.Child("subroutes"), upstreamNames, vsPath, namespace)...)
+	allErrs = append(allErrs, validateMirrorUpstreams(spec.Subroutes, spec.Upstreams, fieldPath.Child("subroutes"))...)
 
 	return allErrs
 }
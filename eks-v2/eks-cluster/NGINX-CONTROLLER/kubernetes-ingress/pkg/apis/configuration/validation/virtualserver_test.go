@@ -1522,6 +1522,173 @@ func TestValidateSplits(t *testing.T) {
 	}
 }
 
+func TestValidateCanary(t *testing.T) {
+	splits := []v1.Split{
+		{
+			Weight: 90,
+			Action: &v1.Action{
+				Pass: "test-1",
+			},
+		},
+		{
+			Weight: 10,
+			Action: &v1.Action{
+				Pass: "test-2",
+			},
+		},
+	}
+	tests := []struct {
+		canary *v1.Canary
+		msg    string
+	}{
+		{
+			canary: &v1.Canary{
+				Steps:    []int{10, 50},
+				Interval: "1m",
+			},
+			msg: "only required fields",
+		},
+		{
+			canary: &v1.Canary{
+				Steps:         []int{5, 25, 50, 99},
+				Interval:      "30s",
+				MaxErrorRate:  createPointerFromInt(0),
+				MaxLatencyP99: "500ms",
+				MinRequests:   100,
+			},
+			msg: "all fields",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateCanary(test.canary, splits, field.NewPath("canary"))
+		if len(allErrs) > 0 {
+			t.Errorf("validateCanary() returned errors %v for valid input for the case of %s", allErrs, test.msg)
+		}
+	}
+}
+
+func TestValidateCanaryFails(t *testing.T) {
+	splits := []v1.Split{
+		{
+			Weight: 90,
+			Action: &v1.Action{
+				Pass: "test-1",
+			},
+		},
+		{
+			Weight: 10,
+			Action: &v1.Action{
+				Pass: "test-2",
+			},
+		},
+	}
+	tests := []struct {
+		canary *v1.Canary
+		splits []v1.Split
+		msg    string
+	}{
+		{
+			canary: &v1.Canary{
+				Steps:    []int{10, 50},
+				Interval: "1m",
+			},
+			splits: nil,
+			msg:    "no splits",
+		},
+		{
+			canary: &v1.Canary{
+				Steps:    []int{10, 50},
+				Interval: "1m",
+			},
+			splits: []v1.Split{
+				splits[0],
+				{
+					Weight: 10,
+					Action: &v1.Action{
+						Return: &v1.ActionReturn{
+							Body: "canary",
+						},
+					},
+				},
+			},
+			msg: "second split does not pass to an upstream",
+		},
+		{
+			canary: &v1.Canary{
+				Interval: "1m",
+			},
+			splits: splits,
+			msg:    "no steps",
+		},
+		{
+			canary: &v1.Canary{
+				Steps:    []int{0, 100},
+				Interval: "1m",
+			},
+			splits: splits,
+			msg:    "steps out of range",
+		},
+		{
+			canary: &v1.Canary{
+				Steps:    []int{50, 10},
+				Interval: "1m",
+			},
+			splits: splits,
+			msg:    "steps not increasing",
+		},
+		{
+			canary: &v1.Canary{
+				Steps: []int{10, 50},
+			},
+			splits: splits,
+			msg:    "no interval",
+		},
+		{
+			canary: &v1.Canary{
+				Steps:    []int{10, 50},
+				Interval: "1x",
+			},
+			splits: splits,
+			msg:    "invalid interval",
+		},
+		{
+			canary: &v1.Canary{
+				Steps:        []int{10, 50},
+				Interval:     "1m",
+				MaxErrorRate: createPointerFromInt(101),
+			},
+			splits: splits,
+			msg:    "invalid maxErrorRate",
+		},
+		{
+			canary: &v1.Canary{
+				Steps:         []int{10, 50},
+				Interval:      "1m",
+				MaxLatencyP99: "fast",
+			},
+			splits: splits,
+			msg:    "invalid maxLatencyP99",
+		},
+		{
+			canary: &v1.Canary{
+				Steps:       []int{10, 50},
+				Interval:    "1m",
+				MinRequests: -1,
+			},
+			splits: splits,
+			msg:    "invalid minRequests",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateCanary(test.canary, test.splits, field.NewPath("canary"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateCanary() returned no errors for invalid input for the case of %s", test.msg)
+		}
+	}
+}
+
 func TestValidateSplitsFails(t *testing.T) {
 	tests := []struct {
 		splits        []v1.Split
@@ -1671,6 +1838,36 @@ func TestValidateCondition(t *testing.T) {
 			},
 			msg: "valid variable",
 		},
+		{
+			condition: v1.Condition{
+				Method: []string{"GET", "HEAD"},
+			},
+			msg: "valid method",
+		},
+		{
+			condition: v1.Condition{
+				Header:   "x-version",
+				Value:    "!v1",
+				Operator: "prefix",
+			},
+			msg: "valid negated prefix",
+		},
+		{
+			condition: v1.Condition{
+				Header:     "user-agent",
+				Value:      ".*(Android|iPhone).*",
+				Operator:   "regex",
+				IgnoreCase: true,
+			},
+			msg: "valid case-insensitive regex",
+		},
+		{
+			condition: v1.Condition{
+				Cookie:   "session",
+				Operator: "present",
+			},
+			msg: "valid presence",
+		},
 	}
 
 	for _, test := range tests {
@@ -1724,6 +1921,69 @@ func TestValidateConditionFails(t *testing.T) {
 			},
 			msg: "invalid variable",
 		},
+		{
+			condition: v1.Condition{
+				Method: []string{"get"},
+			},
+			msg: "invalid method",
+		},
+		{
+			condition: v1.Condition{
+				Method:   []string{"GET"},
+				Value:    "POST",
+				Operator: "exact",
+			},
+			msg: "method with value and operator",
+		},
+		{
+			condition: v1.Condition{
+				Header:   "x-version",
+				Value:    "v1",
+				Operator: "contains",
+			},
+			msg: "invalid operator",
+		},
+		{
+			condition: v1.Condition{
+				Header:   "x-version",
+				Value:    "v1",
+				Operator: "absent",
+			},
+			msg: "absence with value",
+		},
+		{
+			condition: v1.Condition{
+				Argument:   "lang",
+				Value:      "en",
+				IgnoreCase: true,
+			},
+			msg: "exact value with ignoreCase",
+		},
+		{
+			condition: v1.Condition{
+				Header:     "x-version",
+				Value:      "v1",
+				Operator:   "exact",
+				IgnoreCase: true,
+			},
+			msg: "exact operator with ignoreCase",
+		},
+		{
+			condition: v1.Condition{
+				Header:   "x-version",
+				Value:    "!",
+				Operator: "suffix",
+			},
+			msg: "suffix without value",
+		},
+		{
+			condition: v1.Condition{
+				Header:   "x-version",
+				Value:    "v(1",
+				Operator: "regex",
+			},
+			msg: "invalid regex",
+		},
 	}
 
 	for _, test := range tests {
@@ -1865,6 +2125,30 @@ func TestValidateMatch(t *testing.T) {
 			},
 			msg: "valid match with splits",
 		},
+		{
+			match: v1.Match{
+				AnyOf: []v1.ConditionGroup{
+					{
+						Conditions: []v1.Condition{
+							{
+								Method: []string{"POST"},
+							},
+							{
+								Header:   "x-debug",
+								Operator: "present",
+							},
+						},
+					},
+				},
+				Action: &v1.Action{
+					Pass: "test",
+				},
+			},
+			upstreamNames: map[string]sets.Empty{
+				"test": {},
+			},
+			msg: "valid match with anyOf",
+		},
 	}
 
 	vsv := &VirtualServerValidator{isPlus: false}
@@ -1912,6 +2196,42 @@ func TestValidateMatchFails(t *testing.T) {
 			},
 			msg: "invalid condition",
 		},
+		{
+			match: v1.Match{
+				AnyOf: []v1.ConditionGroup{
+					{
+						Conditions: []v1.Condition{},
+					},
+				},
+				Action: &v1.Action{
+					Pass: "test",
+				},
+			},
+			upstreamNames: map[string]sets.Empty{
+				"test": {},
+			},
+			msg: "empty anyOf group",
+		},
+		{
+			match: v1.Match{
+				AnyOf: []v1.ConditionGroup{
+					{
+						Conditions: []v1.Condition{
+							{
+								Method: []string{"post"},
+							},
+						},
+					},
+				},
+				Action: &v1.Action{
+					Pass: "test",
+				},
+			},
+			upstreamNames: map[string]sets.Empty{
+				"test": {},
+			},
+			msg: "invalid condition in anyOf group",
+		},
 		{
 			match: v1.Match{
 				Conditions: []v1.Condition{
@@ -3183,6 +3503,396 @@ func TestValidateActionProxyFails(t *testing.T) {
 	}
 }
 
+func TestValidateActionProxyMirror(t *testing.T) {
+	upstreamNames := map[string]sets.Empty{
+		"upstream1": {},
+		"upstream2": {},
+	}
+	tests := []*v1.ProxyMirror{
+		{
+			Upstream: "upstream2",
+		},
+		{
+			Upstream:   "upstream2",
+			Percentage: createPointerFromInt(10),
+		},
+		{
+			Upstream:   "upstream2",
+			Percentage: createPointerFromInt(100),
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateActionProxyMirror(test, "upstream1", field.NewPath("mirror"), upstreamNames)
+		if len(allErrs) != 0 {
+			t.Errorf("validateActionProxyMirror(%+v) returned errors for valid input: %v", test, allErrs)
+		}
+	}
+}
+
+func TestValidateActionProxyMirrorFails(t *testing.T) {
+	upstreamNames := map[string]sets.Empty{
+		"upstream1": {},
+		"upstream2": {},
+	}
+	tests := []struct {
+		mirror *v1.ProxyMirror
+		msg    string
+	}{
+		{
+			mirror: &v1.ProxyMirror{
+				Upstream: "",
+			},
+			msg: "missing upstream",
+		},
+		{
+			mirror: &v1.ProxyMirror{
+				Upstream: "upstream3",
+			},
+			msg: "non-existing upstream",
+		},
+		{
+			mirror: &v1.ProxyMirror{
+				Upstream: "upstream1",
+			},
+			msg: "upstream of the proxy action",
+		},
+		{
+			mirror: &v1.ProxyMirror{
+				Upstream:   "upstream2",
+				Percentage: createPointerFromInt(0),
+			},
+			msg: "zero percentage",
+		},
+		{
+			mirror: &v1.ProxyMirror{
+				Upstream:   "upstream2",
+				Percentage: createPointerFromInt(101),
+			},
+			msg: "percentage above 100",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateActionProxyMirror(test.mirror, "upstream1", field.NewPath("mirror"), upstreamNames)
+		if len(allErrs) == 0 {
+			t.Errorf("validateActionProxyMirror() returned no errors for the case of %s", test.msg)
+		}
+	}
+}
+
+func TestValidateMirrorUpstreams(t *testing.T) {
+	upstreams := []v1.Upstream{
+		{
+			Name: "http",
+		},
+		{
+			Name: "grpc",
+			Type: "grpc",
+		},
+	}
+	mirrorAction := func(upstream string) *v1.Action {
+		return &v1.Action{
+			Proxy: &v1.ActionProxy{
+				Upstream: "other",
+				Mirror: &v1.ProxyMirror{
+					Upstream: upstream,
+				},
+			},
+		}
+	}
+
+	routes := []v1.Route{
+		{
+			Path:   "/",
+			Action: mirrorAction("http"),
+		},
+		{
+			Path: "/split",
+			Splits: []v1.Split{
+				{
+					Weight: 100,
+					Action: mirrorAction("http"),
+				},
+			},
+		},
+	}
+
+	allErrs := validateMirrorUpstreams(routes, upstreams, field.NewPath("routes"))
+	if len(allErrs) != 0 {
+		t.Errorf("validateMirrorUpstreams() returned errors for valid input: %v", allErrs)
+	}
+}
+
+func TestValidateMirrorUpstreamsFails(t *testing.T) {
+	upstreams := []v1.Upstream{
+		{
+			Name: "http",
+		},
+		{
+			Name: "grpc",
+			Type: "grpc",
+		},
+	}
+	mirrorAction := func(upstream string) *v1.Action {
+		return &v1.Action{
+			Proxy: &v1.ActionProxy{
+				Upstream: "other",
+				Mirror: &v1.ProxyMirror{
+					Upstream: upstream,
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		route         v1.Route
+		expectedField string
+		msg           string
+	}{
+		{
+			route: v1.Route{
+				Path:   "/",
+				Action: mirrorAction("grpc"),
+			},
+			expectedField: "routes[0].action.proxy.mirror.upstream",
+			msg:           "gRPC mirror upstream in action",
+		},
+		{
+			route: v1.Route{
+				Path: "/",
+				Splits: []v1.Split{
+					{
+						Weight: 100,
+						Action: mirrorAction("grpc"),
+					},
+				},
+			},
+			expectedField: "routes[0].splits[0].action.proxy.mirror.upstream",
+			msg:           "gRPC mirror upstream in split",
+		},
+		{
+			route: v1.Route{
+				Path: "/",
+				Matches: []v1.Match{
+					{
+						Action: mirrorAction("grpc"),
+					},
+				},
+				Action: mirrorAction("http"),
+			},
+			expectedField: "routes[0].matches[0].action.proxy.mirror.upstream",
+			msg:           "gRPC mirror upstream in match",
+		},
+		{
+			route: v1.Route{
+				Path: "/",
+				Matches: []v1.Match{
+					{
+						Splits: []v1.Split{
+							{
+								Weight: 100,
+								Action: mirrorAction("grpc"),
+							},
+						},
+					},
+				},
+				Action: mirrorAction("http"),
+			},
+			expectedField: "routes[0].matches[0].splits[0].action.proxy.mirror.upstream",
+			msg:           "gRPC mirror upstream in split of match",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateMirrorUpstreams([]v1.Route{test.route}, upstreams, field.NewPath("routes"))
+		if len(allErrs) != 1 {
+			t.Errorf("validateMirrorUpstreams() returned %d errors for the case of %s, expected 1", len(allErrs), test.msg)
+			continue
+		}
+		if allErrs[0].Field != test.expectedField {
+			t.Errorf("validateMirrorUpstreams() returned error for field %q for the case of %s, expected %q", allErrs[0].Field, test.msg, test.expectedField)
+		}
+	}
+}
+
+func TestValidateActionFault(t *testing.T) {
+	tests := []struct {
+		action *v1.Action
+		msg    string
+	}{
+		{
+			action: &v1.Action{
+				Pass: "test",
+				Fault: &v1.ActionFault{
+					Delay: "2s",
+				},
+			},
+			msg: "delay with pass action",
+		},
+		{
+			action: &v1.Action{
+				Proxy: &v1.ActionProxy{
+					Upstream: "test",
+				},
+				Fault: &v1.ActionFault{
+					Abort: &v1.FaultAbort{
+						Code: 503,
+					},
+					Percentage: createPointerFromInt(10),
+				},
+			},
+			msg: "abort with proxy action",
+		},
+		{
+			action: &v1.Action{
+				Pass: "test",
+				Fault: &v1.ActionFault{
+					Delay: "500ms",
+					Abort: &v1.FaultAbort{
+						Code: 500,
+					},
+					Percentage: createPointerFromInt(100),
+					Header: &v1.FaultHeader{
+						Name:  "X-Fault",
+						Value: "on",
+					},
+				},
+			},
+			msg: "all fields",
+		},
+	}
+
+	vsv := &VirtualServerValidator{isFaultInjectionEnabled: true}
+
+	for _, test := range tests {
+		allErrs := vsv.validateActionFault(test.action, field.NewPath("fault"), false)
+		if len(allErrs) != 0 {
+			t.Errorf("validateActionFault() returned errors %v for valid input for the case of %s", allErrs, test.msg)
+		}
+	}
+}
+
+func TestValidateActionFaultFails(t *testing.T) {
+	tests := []struct {
+		action                  *v1.Action
+		internal                bool
+		isFaultInjectionEnabled bool
+		msg                     string
+	}{
+		{
+			action: &v1.Action{
+				Pass: "test",
+				Fault: &v1.ActionFault{
+					Delay: "2s",
+				},
+			},
+			isFaultInjectionEnabled: false,
+			msg:                     "fault injection disabled",
+		},
+		{
+			action: &v1.Action{
+				Pass: "test",
+				Fault: &v1.ActionFault{
+					Delay: "2s",
+				},
+			},
+			internal:                true,
+			isFaultInjectionEnabled: true,
+			msg:                     "action of a split",
+		},
+		{
+			action: &v1.Action{
+				Return: &v1.ActionReturn{
+					Body: "hello",
+				},
+				Fault: &v1.ActionFault{
+					Delay: "2s",
+				},
+			},
+			isFaultInjectionEnabled: true,
+			msg:                     "return action",
+		},
+		{
+			action: &v1.Action{
+				Pass:  "test",
+				Fault: &v1.ActionFault{},
+			},
+			isFaultInjectionEnabled: true,
+			msg:                     "no delay and abort",
+		},
+		{
+			action: &v1.Action{
+				Pass: "test",
+				Fault: &v1.ActionFault{
+					Delay: "2 seconds",
+				},
+			},
+			isFaultInjectionEnabled: true,
+			msg:                     "invalid delay",
+		},
+		{
+			action: &v1.Action{
+				Pass: "test",
+				Fault: &v1.ActionFault{
+					Abort: &v1.FaultAbort{
+						Code: 200,
+					},
+				},
+			},
+			isFaultInjectionEnabled: true,
+			msg:                     "invalid abort code",
+		},
+		{
+			action: &v1.Action{
+				Pass: "test",
+				Fault: &v1.ActionFault{
+					Delay:      "2s",
+					Percentage: createPointerFromInt(0),
+				},
+			},
+			isFaultInjectionEnabled: true,
+			msg:                     "zero percentage",
+		},
+		{
+			action: &v1.Action{
+				Pass: "test",
+				Fault: &v1.ActionFault{
+					Delay: "2s",
+					Header: &v1.FaultHeader{
+						Name:  "X Fault",
+						Value: "on",
+					},
+				},
+			},
+			isFaultInjectionEnabled: true,
+			msg:                     "invalid header name",
+		},
+		{
+			action: &v1.Action{
+				Pass: "test",
+				Fault: &v1.ActionFault{
+					Delay: "2s",
+					Header: &v1.FaultHeader{
+						Name:  "X-Fault",
+						Value: `on"`,
+					},
+				},
+			},
+			isFaultInjectionEnabled: true,
+			msg:                     "invalid header value",
+		},
+	}
+
+	for _, test := range tests {
+		vsv := &VirtualServerValidator{isFaultInjectionEnabled: test.isFaultInjectionEnabled}
+		allErrs := vsv.validateActionFault(test.action, field.NewPath("fault"), test.internal)
+		if len(allErrs) == 0 {
+			t.Errorf("validateActionFault() returned no errors for the case of %s", test.msg)
+		}
+	}
+}
+
 func TestValidateActionProxyRewritePath(t *testing.T) {
 	tests := []string{"/rewrite", "/rewrite", `/$2`}
 	for _, test := range tests {
@@ -12,7 +12,9 @@ import (
 
 type K8sV1Interface interface {
 	RESTClient() rest.Interface
+	GlobalConfigurationsGetter
 	PoliciesGetter
+	TransportServersGetter
 	VirtualServersGetter
 	VirtualServerRoutesGetter
 }
@@ -22,10 +24,18 @@ type K8sV1Client struct {
 	restClient rest.Interface
 }
 
+func (c *K8sV1Client) GlobalConfigurations(namespace string) GlobalConfigurationInterface {
+	return newGlobalConfigurations(c, namespace)
+}
+
 func (c *K8sV1Client) Policies(namespace string) PolicyInterface {
 	return newPolicies(c, namespace)
 }
 
+func (c *K8sV1Client) TransportServers(namespace string) TransportServerInterface {
+	return newTransportServers(c, namespace)
+}
+
 func (c *K8sV1Client) VirtualServers(namespace string) VirtualServerInterface {
 	return newVirtualServers(c, namespace)
 }
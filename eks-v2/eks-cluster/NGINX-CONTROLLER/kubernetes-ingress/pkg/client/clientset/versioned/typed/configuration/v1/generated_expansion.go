@@ -2,8 +2,12 @@
 
 package v1
 
+type GlobalConfigurationExpansion interface{}
+
 type PolicyExpansion interface{}
 
+type TransportServerExpansion interface{}
+
 type VirtualServerExpansion interface{}
 
 type VirtualServerRouteExpansion interface{}
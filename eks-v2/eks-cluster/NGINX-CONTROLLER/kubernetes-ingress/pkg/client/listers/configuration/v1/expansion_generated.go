@@ -2,6 +2,14 @@
 
 package v1
 
+// GlobalConfigurationListerExpansion allows custom methods to be added to
+// GlobalConfigurationLister.
+type GlobalConfigurationListerExpansion interface{}
+
+// GlobalConfigurationNamespaceListerExpansion allows custom methods to be added to
+// GlobalConfigurationNamespaceLister.
+type GlobalConfigurationNamespaceListerExpansion interface{}
+
 // PolicyListerExpansion allows custom methods to be added to
 // PolicyLister.
 type PolicyListerExpansion interface{}
@@ -10,6 +18,14 @@ type PolicyListerExpansion interface{}
 // PolicyNamespaceLister.
 type PolicyNamespaceListerExpansion interface{}
 
+// TransportServerListerExpansion allows custom methods to be added to
+// TransportServerLister.
+type TransportServerListerExpansion interface{}
+
+// TransportServerNamespaceListerExpansion allows custom methods to be added to
+// TransportServerNamespaceLister.
+type TransportServerNamespaceListerExpansion interface{}
+
 // VirtualServerListerExpansion allows custom methods to be added to
 // VirtualServerLister.
 type VirtualServerListerExpansion interface{}
@@ -113,7 +113,7 @@ def read_ts(custom_objects: CustomObjectsApi, namespace, name) -> object:
     """
     Read TransportService resource.
     """
-    return read_custom_resource_v1alpha1(custom_objects, namespace, "transportservers", name)
+    return read_custom_resource(custom_objects, namespace, "transportservers", name)
 
 
 def create_ts_from_yaml(custom_objects: CustomObjectsApi, yaml_manifest, namespace) -> dict:
@@ -359,7 +359,17 @@ def patch_ts_from_yaml(
     """
     Patch a TransportServer based on yaml manifest
     """
-    return patch_custom_resource_v1alpha1(custom_objects, name, yaml_manifest, namespace, "transportservers")
+    print(f"Update a Resource: {name}")
+    with open(yaml_manifest) as f:
+        dep = yaml.safe_load(f)
+
+    try:
+        custom_objects.patch_namespaced_custom_object(
+            "k8s.nginx.org", "v1", namespace, "transportservers", name, dep
+        )
+    except ApiException:
+        logging.exception(f"Failed with exception while patching custom resource: {name}")
+        raise
 
 
 def patch_custom_resource_v1alpha1(custom_objects: CustomObjectsApi, name, yaml_manifest, namespace, plural) -> None:
@@ -388,7 +398,7 @@ def patch_ts(custom_objects: CustomObjectsApi, namespace, body) -> None:
 
     try:
         custom_objects.patch_namespaced_custom_object(
-            "k8s.nginx.org", "v1alpha1", namespace, "transportservers", name, body
+            "k8s.nginx.org", "v1", namespace, "transportservers", name, body
         )
     except ApiException:
         logging.exception(f"Failed with exception while patching custom resource: {name}")